@@ -0,0 +1,430 @@
+package gobot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNoKeyframes is the error resulting when an animation has no keyframes
+	ErrNoKeyframes = errors.New("Animation has no keyframes")
+	// ErrKeyframeOrder is the error resulting when the keyframes of an
+	// animation are not in time order
+	ErrKeyframeOrder = errors.New("Animation keyframes are not in time order")
+)
+
+// Easing maps the progress between two keyframes, from 0.0 to 1.0, to the
+// fraction of the change between their values.
+type Easing func(t float64) float64
+
+var (
+	// Linear changes the values at a constant rate
+	Linear Easing = func(t float64) float64 { return t }
+	// EaseIn starts slowly and speeds up
+	EaseIn Easing = func(t float64) float64 { return t * t }
+	// EaseOut starts quickly and slows down
+	EaseOut Easing = func(t float64) float64 { return t * (2 - t) }
+	// EaseInOut starts and ends slowly, following half a sine wave
+	EaseInOut Easing = func(t float64) float64 { return (1 - math.Cos(math.Pi*t)) / 2 }
+	// Step keeps the previous values until the keyframe is reached
+	Step Easing = func(t float64) float64 {
+		if t < 1 {
+			return 0
+		}
+		return 1
+	}
+)
+
+// Easings are the easing curves by the names used by API commands
+var Easings = map[string]Easing{
+	"linear":      Linear,
+	"ease-in":     EaseIn,
+	"ease-out":    EaseOut,
+	"ease-in-out": EaseInOut,
+	"step":        Step,
+}
+
+// Keyframe is the values of the channels of an animation at a point in time.
+// Values go from 0 to 255, e.g. one brightness for an LED or red, green and
+// blue for an RGB LED.
+type Keyframe struct {
+	// At is the time of the keyframe from the start of the animation
+	At time.Duration
+	// Values are the values of the channels at the keyframe
+	Values []float64
+	// Easing is the curve from the previous keyframe to this one, Linear if nil
+	Easing Easing
+}
+
+// Animation is a timeline of keyframes, played once or looped.
+type Animation struct {
+	Keyframes []Keyframe
+	Loop      bool
+}
+
+// NewAnimation returns a new Animation of the keyframes
+func NewAnimation(keyframes ...Keyframe) *Animation {
+	return &Animation{Keyframes: keyframes}
+}
+
+// NewFade returns an Animation which fades from one set of values to
+// another in d
+func NewFade(from []float64, to []float64, d time.Duration, easing Easing) *Animation {
+	return NewAnimation(
+		Keyframe{At: 0, Values: from},
+		Keyframe{At: d, Values: to, Easing: easing},
+	)
+}
+
+// NewBreathing returns a looped Animation which slowly fades the values in
+// and out again every period
+func NewBreathing(values []float64, period time.Duration) *Animation {
+	a := NewAnimation(
+		Keyframe{At: 0, Values: make([]float64, len(values))},
+		Keyframe{At: period / 2, Values: values, Easing: EaseInOut},
+		Keyframe{At: period, Values: make([]float64, len(values)), Easing: EaseInOut},
+	)
+	a.Loop = true
+	return a
+}
+
+// NewBlinkCode returns a looped Animation which blinks the values in groups,
+// e.g. a code of 3, 2 blinks three times, pauses and blinks twice before
+// pausing and starting over. Each blink is on for on and off for off.
+func NewBlinkCode(values []float64, code []int, on time.Duration, off time.Duration, pause time.Duration) *Animation {
+	dark := make([]float64, len(values))
+	a := NewAnimation(Keyframe{At: 0, Values: dark})
+	t := time.Duration(0)
+	for i, blinks := range code {
+		if i > 0 {
+			t += pause
+		}
+		for j := 0; j < blinks; j++ {
+			if j > 0 {
+				t += off
+			}
+			a.Keyframes = append(a.Keyframes,
+				Keyframe{At: t, Values: values, Easing: Step},
+				Keyframe{At: t + on, Values: dark, Easing: Step},
+			)
+			t += on
+		}
+	}
+	a.Keyframes = append(a.Keyframes, Keyframe{At: t + pause, Values: dark, Easing: Step})
+	a.Loop = true
+	return a
+}
+
+// Duration returns the time of the last keyframe
+func (a *Animation) Duration() time.Duration {
+	if len(a.Keyframes) == 0 {
+		return 0
+	}
+	return a.Keyframes[len(a.Keyframes)-1].At
+}
+
+// Validate checks that the animation has keyframes in time order, with
+// values from 0 to 255 for the given number of channels
+func (a *Animation) Validate(channels int) error {
+	if len(a.Keyframes) == 0 {
+		return ErrNoKeyframes
+	}
+	for i, k := range a.Keyframes {
+		if len(k.Values) != channels {
+			return fmt.Errorf("Animation keyframe %d has %d values instead of %d", i, len(k.Values), channels)
+		}
+		for _, v := range k.Values {
+			if v < 0 || v > 255 {
+				return fmt.Errorf("Animation keyframe %d has value %v out of range 0-255", i, v)
+			}
+		}
+		if i > 0 && k.At < a.Keyframes[i-1].At {
+			return ErrKeyframeOrder
+		}
+	}
+	return nil
+}
+
+// ValuesAt returns the values of the channels at time t of the animation.
+// A looped animation starts over after its duration, otherwise the values
+// of the last keyframe are kept.
+func (a *Animation) ValuesAt(t time.Duration) []float64 {
+	d := a.Duration()
+	if a.Loop && d > 0 {
+		t = t % d
+	}
+
+	next := 0
+	for next < len(a.Keyframes) && a.Keyframes[next].At <= t {
+		next++
+	}
+	if next == 0 {
+		return a.Keyframes[0].Values
+	}
+	prev := a.Keyframes[next-1]
+	if next == len(a.Keyframes) {
+		return prev.Values
+	}
+
+	k := a.Keyframes[next]
+	easing := k.Easing
+	if easing == nil {
+		easing = Linear
+	}
+	f := easing(float64(t-prev.At) / float64(k.At-prev.At))
+	values := make([]float64, len(k.Values))
+	for i := range values {
+		values[i] = prev.Values[i] + (k.Values[i]-prev.Values[i])*f
+	}
+	return values
+}
+
+// Animator plays animations by rendering their values at a frame rate.
+// Playing an animation replaces the one which is running, so only a single
+// animation drives the outputs at a time.
+type Animator struct {
+	// FrameRate is the number of frames rendered per second
+	FrameRate int
+
+	channels int
+	render   func(values []float64) error
+	mutex    *sync.Mutex
+	stop     chan bool
+	done     chan bool
+	err      error
+}
+
+// NewAnimator returns a new Animator with a frame rate of 50 frames per
+// second, which renders animations of the given number of channels.
+func NewAnimator(channels int, render func(values []float64) error) *Animator {
+	return &Animator{
+		FrameRate: 50,
+		channels:  channels,
+		render:    render,
+		mutex:     &sync.Mutex{},
+	}
+}
+
+// Play stops the running animation and starts playing a. An animation which
+// is not looped stops after rendering its last keyframe. If rendering a frame
+// fails the animation is stopped, and the error is returned by Err.
+func (a *Animator) Play(animation *Animation) (err error) {
+	if err = animation.Validate(a.channels); err != nil {
+		return
+	}
+
+	stop, done := make(chan bool), make(chan bool)
+	a.mutex.Lock()
+	oldStop, oldDone := a.stop, a.done
+	a.stop, a.done, a.err = stop, done, nil
+	frame := time.Second / 50
+	if a.FrameRate > 0 {
+		frame = time.Second / time.Duration(a.FrameRate)
+	}
+	a.mutex.Unlock()
+
+	if oldStop != nil {
+		close(oldStop)
+		<-oldDone
+	}
+
+	go func() {
+		defer close(done)
+
+		// a concurrent Play or Stop may have stopped it already
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		ticker := time.NewTicker(frame)
+		defer ticker.Stop()
+
+		start := time.Now()
+		for {
+			t := time.Since(start)
+			last := !animation.Loop && t >= animation.Duration()
+			if err := a.render(animation.ValuesAt(t)); err != nil {
+				a.mutex.Lock()
+				a.err = err
+				a.mutex.Unlock()
+				return
+			}
+			if last {
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return
+}
+
+// Stop stops the running animation and waits for it to render its last frame
+func (a *Animator) Stop() {
+	a.mutex.Lock()
+	stop, done := a.stop, a.done
+	a.stop, a.done = nil, nil
+	a.mutex.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// Running returns true while an animation is playing
+func (a *Animator) Running() bool {
+	a.mutex.Lock()
+	done := a.done
+	a.mutex.Unlock()
+
+	if done == nil {
+		return false
+	}
+	select {
+	case <-done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Wait blocks until the running animation has finished, and returns the
+// error which stopped it, if any
+func (a *Animator) Wait() error {
+	a.mutex.Lock()
+	done := a.done
+	a.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+	return a.Err()
+}
+
+// Err returns the error which stopped the last animation
+func (a *Animator) Err() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.err
+}
+
+// AnimationByte rounds an animation value to a byte
+func AnimationByte(v float64) byte {
+	return byte(math.Min(math.Max(v, 0), 255) + 0.5)
+}
+
+// ParseAnimation builds an animation from the params of an API command.
+// Values are arrays of numbers from 0 to 255 and times are in milliseconds.
+//
+// The "type" param selects the animation:
+//	"fade" - "from", "to", "duration" and optional "easing"
+//	"breathe" - "values" and "period"
+//	"blink" - "values", "code", and optional "on", "off" and "pause", which default to 200, 200 and 1000
+//	"keyframes" - "keyframes", an array of objects with "at", "values" and optional "easing", and optional "loop"
+func ParseAnimation(params map[string]interface{}) (a *Animation, err error) {
+	p := animationParams{params: params}
+	switch params["type"] {
+	case "fade":
+		a = NewFade(p.values("from"), p.values("to"), p.duration("duration", 0), p.easing("easing"))
+	case "breathe":
+		a = NewBreathing(p.values("values"), p.duration("period", 0))
+	case "blink":
+		code := []int{}
+		for _, v := range p.values("code") {
+			code = append(code, int(v))
+		}
+		a = NewBlinkCode(p.values("values"), code,
+			p.duration("on", 200*time.Millisecond), p.duration("off", 200*time.Millisecond),
+			p.duration("pause", 1000*time.Millisecond))
+	case "keyframes":
+		a = NewAnimation()
+		list, _ := params["keyframes"].([]interface{})
+		for _, item := range list {
+			k, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("Invalid animation keyframe")
+			}
+			kp := animationParams{params: k}
+			a.Keyframes = append(a.Keyframes, Keyframe{
+				At:     kp.duration("at", 0),
+				Values: kp.values("values"),
+				Easing: kp.easing("easing"),
+			})
+			if kp.err != nil {
+				return nil, kp.err
+			}
+		}
+		a.Loop, _ = params["loop"].(bool)
+	default:
+		return nil, fmt.Errorf("Unknown animation type %v", params["type"])
+	}
+	return a, p.err
+}
+
+// animationParams reads the params of an animation, keeping the first error
+type animationParams struct {
+	params map[string]interface{}
+	err    error
+}
+
+func (p *animationParams) values(name string) []float64 {
+	list, ok := p.params[name].([]interface{})
+	if !ok {
+		p.fail(fmt.Errorf("Animation param %q must be an array of numbers", name))
+		return nil
+	}
+	values := []float64{}
+	for _, v := range list {
+		f, ok := v.(float64)
+		if !ok {
+			p.fail(fmt.Errorf("Animation param %q must be an array of numbers", name))
+			return nil
+		}
+		values = append(values, f)
+	}
+	return values
+}
+
+func (p *animationParams) duration(name string, def time.Duration) time.Duration {
+	v, ok := p.params[name]
+	if !ok {
+		return def
+	}
+	ms, ok := v.(float64)
+	if !ok {
+		p.fail(fmt.Errorf("Animation param %q must be a number of milliseconds", name))
+		return def
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+func (p *animationParams) easing(name string) Easing {
+	v, ok := p.params[name]
+	if !ok {
+		return nil
+	}
+	s, _ := v.(string)
+	e, ok := Easings[s]
+	if !ok {
+		p.fail(fmt.Errorf("Unknown animation easing %v", v))
+	}
+	return e
+}
+
+func (p *animationParams) fail(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+}
@@ -0,0 +1,216 @@
+package gobot
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestEasings(t *testing.T) {
+	for name, e := range Easings {
+		gobottest.Assert(t, e(0), 0.0)
+		if e(1) != 1.0 {
+			t.Errorf("easing %v does not end at 1", name)
+		}
+	}
+	gobottest.Assert(t, Linear(0.25), 0.25)
+	gobottest.Assert(t, EaseIn(0.5), 0.25)
+	gobottest.Assert(t, EaseOut(0.5), 0.75)
+	gobottest.Assert(t, Step(0.99), 0.0)
+}
+
+func TestAnimationValuesAt(t *testing.T) {
+	a := NewFade([]float64{0, 100}, []float64{100, 0}, 100*time.Millisecond, nil)
+	gobottest.Assert(t, a.Duration(), 100*time.Millisecond)
+	gobottest.Assert(t, a.ValuesAt(0), []float64{0, 100})
+	gobottest.Assert(t, a.ValuesAt(25*time.Millisecond), []float64{25, 75})
+	gobottest.Assert(t, a.ValuesAt(200*time.Millisecond), []float64{100, 0})
+
+	a.Loop = true
+	gobottest.Assert(t, a.ValuesAt(125*time.Millisecond), []float64{25, 75})
+}
+
+func TestAnimationValidate(t *testing.T) {
+	gobottest.Assert(t, NewAnimation().Validate(1), ErrNoKeyframes)
+	gobottest.Assert(t, NewFade([]float64{0}, []float64{255}, time.Second, nil).Validate(1), nil)
+	gobottest.Assert(t, NewFade([]float64{0}, []float64{255}, time.Second, nil).Validate(3),
+		errors.New("Animation keyframe 0 has 1 values instead of 3"))
+	gobottest.Assert(t, NewFade([]float64{0}, []float64{256}, time.Second, nil).Validate(1),
+		errors.New("Animation keyframe 1 has value 256 out of range 0-255"))
+	gobottest.Assert(t, NewAnimation(
+		Keyframe{At: time.Second, Values: []float64{0}},
+		Keyframe{At: 0, Values: []float64{0}},
+	).Validate(1), ErrKeyframeOrder)
+}
+
+func TestNewBlinkCode(t *testing.T) {
+	ms := time.Millisecond
+	a := NewBlinkCode([]float64{255}, []int{2, 1}, 10*ms, 20*ms, 100*ms)
+	gobottest.Assert(t, a.Loop, true)
+	// on 0-10, off 10-30, on 30-40, pause 40-140, on 140-150, pause 150-250
+	gobottest.Assert(t, a.Duration(), 250*ms)
+	for at, v := range map[time.Duration]float64{
+		5 * ms: 255, 15 * ms: 0, 35 * ms: 255, 100 * ms: 0, 145 * ms: 255, 200 * ms: 0, 255 * ms: 255,
+	} {
+		gobottest.Assert(t, a.ValuesAt(at), []float64{v})
+	}
+}
+
+func TestNewBreathing(t *testing.T) {
+	a := NewBreathing([]float64{200}, time.Second)
+	gobottest.Assert(t, a.Loop, true)
+	gobottest.Assert(t, a.ValuesAt(0), []float64{0})
+	gobottest.Assert(t, a.ValuesAt(500*time.Millisecond), []float64{200})
+	gobottest.Assert(t, a.ValuesAt(1250*time.Millisecond)[0] > 0, true)
+}
+
+func TestAnimationByte(t *testing.T) {
+	gobottest.Assert(t, AnimationByte(-1), byte(0))
+	gobottest.Assert(t, AnimationByte(127.6), byte(128))
+	gobottest.Assert(t, AnimationByte(300), byte(255))
+}
+
+func TestAnimatorPlay(t *testing.T) {
+	mutex := &sync.Mutex{}
+	frames := [][]float64{}
+	a := NewAnimator(1, func(values []float64) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		frames = append(frames, values)
+		return nil
+	})
+	a.FrameRate = 100
+
+	gobottest.Assert(t, a.Play(NewFade([]float64{0}, []float64{255}, 50*time.Millisecond, nil)), nil)
+	gobottest.Assert(t, a.Wait(), nil)
+	gobottest.Assert(t, a.Running(), false)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	gobottest.Assert(t, len(frames) > 2, true)
+	gobottest.Assert(t, frames[len(frames)-1], []float64{255})
+}
+
+func TestAnimatorStop(t *testing.T) {
+	a := NewAnimator(1, func(values []float64) error { return nil })
+	gobottest.Assert(t, a.Play(NewBreathing([]float64{255}, time.Second)), nil)
+	gobottest.Assert(t, a.Running(), true)
+
+	// playing another animation replaces the running one
+	gobottest.Assert(t, a.Play(NewBreathing([]float64{128}, time.Second)), nil)
+	gobottest.Assert(t, a.Running(), true)
+
+	a.Stop()
+	gobottest.Assert(t, a.Running(), false)
+	a.Stop()
+}
+
+func TestAnimatorConcurrentPlay(t *testing.T) {
+	mutex := &sync.Mutex{}
+	rendering, most := 0, 0
+	a := NewAnimator(1, func(values []float64) error {
+		mutex.Lock()
+		rendering++
+		if rendering > most {
+			most = rendering
+		}
+		mutex.Unlock()
+		time.Sleep(time.Millisecond)
+		mutex.Lock()
+		rendering--
+		mutex.Unlock()
+		return nil
+	})
+	a.FrameRate = 500
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a.Play(NewBreathing([]float64{float64(i)}, time.Second))
+		}(i)
+	}
+	wg.Wait()
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, a.Running(), true)
+	a.Stop()
+	gobottest.Assert(t, a.Running(), false)
+
+	// only a single animation drives the outputs at a time
+	mutex.Lock()
+	defer mutex.Unlock()
+	gobottest.Assert(t, most, 1)
+	gobottest.Assert(t, rendering, 0)
+}
+
+func TestAnimatorRenderError(t *testing.T) {
+	a := NewAnimator(1, func(values []float64) error { return errors.New("write error") })
+	gobottest.Assert(t, a.Play(NewBreathing([]float64{255}, time.Second)), nil)
+	gobottest.Assert(t, a.Wait(), errors.New("write error"))
+	gobottest.Assert(t, a.Running(), false)
+}
+
+func TestAnimatorPlayInvalid(t *testing.T) {
+	a := NewAnimator(3, func(values []float64) error { return nil })
+	gobottest.Refute(t, a.Play(NewBreathing([]float64{255}, time.Second)), nil)
+	gobottest.Assert(t, a.Running(), false)
+}
+
+func TestParseAnimation(t *testing.T) {
+	a, err := ParseAnimation(map[string]interface{}{
+		"type":     "fade",
+		"from":     []interface{}{0.0},
+		"to":       []interface{}{255.0},
+		"duration": 1000.0,
+		"easing":   "ease-in",
+	})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.Duration(), time.Second)
+	gobottest.Assert(t, a.ValuesAt(500*time.Millisecond), []float64{63.75})
+
+	a, err = ParseAnimation(map[string]interface{}{
+		"type":   "blink",
+		"values": []interface{}{255.0, 0.0, 0.0},
+		"code":   []interface{}{3.0},
+	})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.Validate(3), nil)
+	gobottest.Assert(t, a.Duration(), 2000*time.Millisecond)
+
+	a, err = ParseAnimation(map[string]interface{}{
+		"type":   "breathe",
+		"values": []interface{}{255.0},
+		"period": 2000.0,
+	})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.Duration(), 2*time.Second)
+
+	a, err = ParseAnimation(map[string]interface{}{
+		"type": "keyframes",
+		"loop": true,
+		"keyframes": []interface{}{
+			map[string]interface{}{"at": 0.0, "values": []interface{}{0.0}},
+			map[string]interface{}{"at": 100.0, "values": []interface{}{255.0}, "easing": "step"},
+		},
+	})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.Loop, true)
+	gobottest.Assert(t, len(a.Keyframes), 2)
+}
+
+func TestParseAnimationError(t *testing.T) {
+	_, err := ParseAnimation(map[string]interface{}{"type": "spin"})
+	gobottest.Assert(t, err, errors.New("Unknown animation type spin"))
+
+	_, err = ParseAnimation(map[string]interface{}{"type": "breathe", "values": 1.0, "period": 10.0})
+	gobottest.Assert(t, err, errors.New("Animation param \"values\" must be an array of numbers"))
+
+	_, err = ParseAnimation(map[string]interface{}{
+		"type": "fade", "from": []interface{}{0.0}, "to": []interface{}{1.0}, "easing": "bounce",
+	})
+	gobottest.Assert(t, err, errors.New("Unknown animation easing bounce"))
+}
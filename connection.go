@@ -9,8 +9,9 @@ import (
 
 // JSONConnection is a JSON representation of a Connection.
 type JSONConnection struct {
-	Name    string `json:"name"`
-	Adaptor string `json:"adaptor"`
+	Name    string          `json:"name"`
+	Adaptor string          `json:"adaptor"`
+	Claims  []ResourceClaim `json:"claims"`
 }
 
 // NewJSONConnection returns a JSONConnection given a Connection.
@@ -18,6 +19,7 @@ func NewJSONConnection(connection Connection) *JSONConnection {
 	return &JSONConnection{
 		Name:    connection.Name(),
 		Adaptor: reflect.TypeOf(connection).String(),
+		Claims:  ResourceClaims(connection),
 	}
 }
 
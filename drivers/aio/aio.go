@@ -8,6 +8,15 @@ var (
 	// ErrAnalogReadUnsupported is error resulting when a driver attempts to use
 	// hardware capabilities which a connection does not support
 	ErrAnalogReadUnsupported = errors.New("AnalogRead is not supported by this platform")
+	// ErrAnalogWriteUnsupported is error resulting when a driver attempts to use
+	// hardware capabilities which a connection does not support
+	ErrAnalogWriteUnsupported = errors.New("AnalogWrite is not supported by this platform")
+	// ErrUnknownFilter is the error resulting when an analog sensor is set to
+	// a filter it does not know
+	ErrUnknownFilter = errors.New("Analog sensor filter is unknown")
+	// ErrUnknownThreshold is the error resulting when a threshold is added for
+	// an event which is not a threshold event
+	ErrUnknownThreshold = errors.New("Analog sensor threshold event is unknown")
 )
 
 const (
@@ -17,6 +26,14 @@ const (
 	Data = "data"
 	// Vibration event
 	Vibration = "vibration"
+	// Value event
+	Value = "value"
+	// ThresholdAbove event
+	ThresholdAbove = "above"
+	// ThresholdBelow event
+	ThresholdBelow = "below"
+	// ThresholdChangedBy event
+	ThresholdChangedBy = "changed-by"
 )
 
 // AnalogReader interface represents an Adaptor which has Analog capabilities
@@ -24,3 +41,35 @@ type AnalogReader interface {
 	//gobot.Adaptor
 	AnalogRead(string) (val int, err error)
 }
+
+// AnalogWriter interface represents an Adaptor which can write analog values,
+// in the counts of its digital to analog converter
+type AnalogWriter interface {
+	//gobot.Adaptor
+	AnalogWrite(string, int) (err error)
+}
+
+// ADC describes the analog to digital converter which reads an analog
+// sensor, e.g. ADC{Bits: 10, Reference: 5} for the inputs of an Arduino over
+// firmata and of an Edison, or ADC{Bits: 12, Reference: 1.8} for the inputs
+// of a BeagleBone
+type ADC struct {
+	// Bits is the resolution of the readings
+	Bits uint
+	// Reference is the reference voltage, which reads as the highest count
+	Reference float64
+}
+
+// DefaultADC is the ADC of the sensor drivers unless another ADC is passed to
+// their constructors, a 10 bit ADC with a reference of 5 volts
+var DefaultADC = ADC{Bits: 10, Reference: 5}
+
+// Max returns the highest count of the ADC
+func (a ADC) Max() float64 {
+	return float64(uint64(1)<<a.Bits - 1)
+}
+
+// Volts returns the voltage of a raw reading
+func (a ADC) Volts(raw float64) float64 {
+	return raw / a.Max() * a.Reference
+}
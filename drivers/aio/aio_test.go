@@ -0,0 +1,35 @@
+package aio
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestADC(t *testing.T) {
+	gobottest.Assert(t, DefaultADC.Max(), 1023.0)
+	gobottest.Assert(t, DefaultADC.Volts(1023), 5.0)
+	adc := ADC{Bits: 12, Reference: 1.8}
+	gobottest.Assert(t, adc.Max(), 4095.0)
+	gobottest.Assert(t, adc.Volts(0), 0.0)
+	gobottest.Assert(t, adc.Volts(4095), 1.8)
+}
+
+func TestSensorOptions(t *testing.T) {
+	adc, opts := sensorOptions(nil)
+	gobottest.Assert(t, adc, DefaultADC)
+	gobottest.Assert(t, len(opts), 0)
+	want := ADC{Bits: 12, Reference: 3.3}
+	adc, opts = sensorOptions([]interface{}{WithChangedBy(1), want, 20 * time.Millisecond})
+	gobottest.Assert(t, adc, want)
+	gobottest.Assert(t, len(opts), 2)
+}
+
+func TestSensorOptionsUnknown(t *testing.T) {
+	defer func() {
+		gobottest.Assert(t, recover() != nil, true)
+	}()
+	sensorOptions([]interface{}{100})
+	t.Errorf("sensorOptions did not panic on an unknown option")
+}
@@ -0,0 +1,116 @@
+package aio
+
+import (
+	"math"
+
+	"gobot.io/x/gobot"
+)
+
+// AnalogActuatorDriver represents an analog output, such as a channel of a
+// DAC, which is written values in engineering units, e.g. volts
+type AnalogActuatorDriver struct {
+	name       string
+	pin        string
+	connection AnalogWriter
+	// Scaler converts a value in engineering units to the counts written to
+	// the output
+	Scaler   func(value float64) (counts int)
+	value    float64
+	rawValue int
+	gobot.Commander
+}
+
+// NewAnalogActuatorDriver returns a new AnalogActuatorDriver given an
+// AnalogWriter and pin. Its values are written unscaled, rounded to counts.
+//
+// Adds the following API Commands:
+// 	"Write" - See AnalogActuatorDriver.Write, with the "val" param
+// 	"RawWrite" - See AnalogActuatorDriver.RawWrite, with the "val" param
+// 	"Value" - See AnalogActuatorDriver.Value
+func NewAnalogActuatorDriver(a AnalogWriter, pin string) *AnalogActuatorDriver {
+	d := &AnalogActuatorDriver{
+		name:       gobot.DefaultName("AnalogActuator"),
+		connection: a,
+		pin:        pin,
+		Scaler: func(value float64) int {
+			return int(math.Floor(value + 0.5))
+		},
+		Commander: gobot.NewCommander(),
+	}
+
+	d.AddCommand("Write", func(params map[string]interface{}) interface{} {
+		val, _ := params["val"].(float64)
+		return d.Write(val)
+	})
+	d.AddCommand("RawWrite", func(params map[string]interface{}) interface{} {
+		val, _ := params["val"].(float64)
+		return d.RawWrite(int(val))
+	})
+	d.AddCommand("Value", func(params map[string]interface{}) interface{} {
+		return d.Value()
+	})
+
+	return d
+}
+
+// AnalogActuatorLinearScaler returns a Scaler which maps the values from
+// fromMin to fromMax linearly to the counts from toMin to toMax. Values
+// outside the range are limited to it.
+func AnalogActuatorLinearScaler(fromMin float64, fromMax float64, toMin int, toMax int) func(float64) int {
+	return func(value float64) int {
+		f := (value - fromMin) / (fromMax - fromMin)
+		f = math.Min(math.Max(f, 0), 1)
+		return int(math.Floor(float64(toMin) + f*float64(toMax-toMin) + 0.5))
+	}
+}
+
+// Name returns the AnalogActuatorDrivers name
+func (a *AnalogActuatorDriver) Name() string { return a.name }
+
+// SetName sets the AnalogActuatorDrivers name
+func (a *AnalogActuatorDriver) SetName(n string) { a.name = n }
+
+// Pin returns the AnalogActuatorDrivers pin
+func (a *AnalogActuatorDriver) Pin() string { return a.pin }
+
+// Connection returns the AnalogActuatorDrivers Connection
+func (a *AnalogActuatorDriver) Connection() gobot.Connection {
+	return a.connection.(gobot.Connection)
+}
+
+// Start implements the Driver interface and claims the pin
+func (a *AnalogActuatorDriver) Start() (err error) {
+	return gobot.ClaimResources(a.Connection(), a, gobot.PinResource, a.pin)
+}
+
+// Halt implements the Driver interface and releases the pin
+func (a *AnalogActuatorDriver) Halt() (err error) {
+	gobot.ReleaseResources(a.Connection(), a)
+	return
+}
+
+// Write scales the value in engineering units and writes it to the output
+func (a *AnalogActuatorDriver) Write(value float64) (err error) {
+	if err = a.RawWrite(a.Scaler(value)); err != nil {
+		return
+	}
+	a.value = value
+	return
+}
+
+// RawWrite writes the counts to the output
+func (a *AnalogActuatorDriver) RawWrite(counts int) (err error) {
+	if err = a.connection.AnalogWrite(a.Pin(), counts); err != nil {
+		return
+	}
+	a.value = float64(counts)
+	a.rawValue = counts
+	return
+}
+
+// Value returns the value last written in engineering units, or the counts
+// if they were written by RawWrite
+func (a *AnalogActuatorDriver) Value() float64 { return a.value }
+
+// RawValue returns the counts last written to the output
+func (a *AnalogActuatorDriver) RawValue() int { return a.rawValue }
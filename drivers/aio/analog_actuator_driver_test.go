@@ -0,0 +1,61 @@
+package aio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*AnalogActuatorDriver)(nil)
+
+func TestAnalogActuatorDriver(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewAnalogActuatorDriver(a, "47")
+	gobottest.Assert(t, d.Connection(), a)
+	gobottest.Assert(t, d.Pin(), "47")
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "AnalogActuator"), true)
+	d.SetName("mydac")
+	gobottest.Assert(t, d.Name(), "mydac")
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestAnalogActuatorDriverWrite(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewAnalogActuatorDriver(a, "47")
+	gobottest.Assert(t, d.Write(99.6), nil)
+	gobottest.Assert(t, a.written["47"], 100)
+	gobottest.Assert(t, d.Value(), 99.6)
+	gobottest.Assert(t, d.RawValue(), 100)
+
+	// 0-5V on a 12 bit DAC
+	d.Scaler = AnalogActuatorLinearScaler(0, 5, 0, 4095)
+	gobottest.Assert(t, d.Write(2.5), nil)
+	gobottest.Assert(t, a.written["47"], 2048)
+	gobottest.Assert(t, d.Write(7), nil)
+	gobottest.Assert(t, a.written["47"], 4095)
+	gobottest.Assert(t, d.Write(-1), nil)
+	gobottest.Assert(t, a.written["47"], 0)
+
+	gobottest.Assert(t, d.RawWrite(1000), nil)
+	gobottest.Assert(t, a.written["47"], 1000)
+	gobottest.Assert(t, d.Value(), 1000.0)
+
+	a.analogWriteErr = errors.New("write error")
+	gobottest.Assert(t, d.Write(1), errors.New("write error"))
+	gobottest.Assert(t, d.RawValue(), 1000)
+}
+
+func TestAnalogActuatorDriverCommands(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewAnalogActuatorDriver(a, "47")
+	d.Scaler = AnalogActuatorLinearScaler(-10, 10, 0, 255)
+	gobottest.Assert(t, d.Command("Write")(map[string]interface{}{"val": 0.0}), nil)
+	gobottest.Assert(t, a.written["47"], 128)
+	gobottest.Assert(t, d.Command("RawWrite")(map[string]interface{}{"val": 12.0}), nil)
+	gobottest.Assert(t, a.written["47"], 12)
+	gobottest.Assert(t, d.Command("Value")(nil), 12.0)
+}
@@ -1,6 +1,8 @@
 package aio
 
 import (
+	"math"
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -8,15 +10,74 @@ import (
 
 // AnalogSensorDriver represents an Analog Sensor
 type AnalogSensorDriver struct {
-	name       string
-	pin        string
-	halt       chan bool
-	interval   time.Duration
-	connection AnalogReader
+	name        string
+	pin         string
+	halt        chan bool
+	interval    time.Duration
+	connection  AnalogReader
+	mutex       *sync.Mutex
+	filter      Filter
+	calibration Calibration
+	thresholds  []*threshold
+	value       float64
 	gobot.Eventer
 	gobot.Commander
 }
 
+// AnalogSensorOption is an option of NewAnalogSensorDriverWithOptions
+type AnalogSensorOption func(*AnalogSensorDriver)
+
+// ThresholdCrossing is the data of the threshold events, the Level of the
+// threshold and the Value which crossed it
+type ThresholdCrossing struct {
+	Level float64
+	Value float64
+}
+
+type threshold struct {
+	event      string
+	level      float64
+	hysteresis float64
+	armed      bool
+	reference  float64
+}
+
+// WithInterval sets the interval at which the AnalogSensor is polled
+func WithInterval(interval time.Duration) AnalogSensorOption {
+	return func(d *AnalogSensorDriver) { d.interval = interval }
+}
+
+// WithFilter sets the Filter which smooths the readings
+func WithFilter(f Filter) AnalogSensorOption {
+	return func(d *AnalogSensorDriver) { d.filter = f }
+}
+
+// WithCalibration sets the Calibration which converts the filtered readings
+// to engineering units
+func WithCalibration(c Calibration) AnalogSensorOption {
+	return func(d *AnalogSensorDriver) { d.calibration = c }
+}
+
+// WithAbove adds a threshold which emits the ThresholdAbove event when the
+// value rises above level. It is emitted again once the value has fallen
+// below level - hysteresis and rises above level once more.
+func WithAbove(level float64, hysteresis float64) AnalogSensorOption {
+	return func(d *AnalogSensorDriver) { d.AddThreshold(ThresholdAbove, level, hysteresis) }
+}
+
+// WithBelow adds a threshold which emits the ThresholdBelow event when the
+// value falls below level. It is emitted again once the value has risen
+// above level + hysteresis and falls below level once more.
+func WithBelow(level float64, hysteresis float64) AnalogSensorOption {
+	return func(d *AnalogSensorDriver) { d.AddThreshold(ThresholdBelow, level, hysteresis) }
+}
+
+// WithChangedBy adds a threshold which emits the ThresholdChangedBy event
+// whenever the value has changed by delta since it was last emitted
+func WithChangedBy(delta float64) AnalogSensorOption {
+	return func(d *AnalogSensorDriver) { d.AddThreshold(ThresholdChangedBy, delta, 0) }
+}
+
 // NewAnalogSensorDriver returns a new AnalogSensorDriver with a polling interval of
 // 10 Milliseconds given an AnalogReader and pin.
 //
@@ -25,7 +86,25 @@ type AnalogSensorDriver struct {
 //
 // Adds the following API Commands:
 // 	"Read" - See AnalogSensor.Read
+// 	"Value" - See AnalogSensor.Value
+// 	"AddThreshold" - See AnalogSensor.AddThreshold, with the "event", "level" and "hysteresis" params
+// 	"ClearThresholds" - See AnalogSensor.ClearThresholds
+// 	"SetFilter" - See AnalogSensor.SetFilter, with the "type" "average" or "median" and the "size",
+// 		"exponential" and the "alpha", "kalman" and the "processNoise" and "measurementNoise", or no type
+// 	"SetCalibration" - See AnalogSensor.SetCalibration, with the "scale" and "offset", the polynomial
+// 		"coefficients" starting with the constant term, or no params
 func NewAnalogSensorDriver(a AnalogReader, pin string, v ...time.Duration) *AnalogSensorDriver {
+	d := NewAnalogSensorDriverWithOptions(a, pin)
+	if len(v) > 0 {
+		d.interval = v[0]
+	}
+	return d
+}
+
+// NewAnalogSensorDriverWithOptions returns a new AnalogSensorDriver like
+// NewAnalogSensorDriver, configured by the options WithInterval, WithFilter,
+// WithCalibration, WithAbove, WithBelow or WithChangedBy.
+func NewAnalogSensorDriverWithOptions(a AnalogReader, pin string, opts ...AnalogSensorOption) *AnalogSensorDriver {
 	d := &AnalogSensorDriver{
 		name:       gobot.DefaultName("AnalogSensor"),
 		connection: a,
@@ -34,19 +113,49 @@ func NewAnalogSensorDriver(a AnalogReader, pin string, v ...time.Duration) *Anal
 		Commander:  gobot.NewCommander(),
 		interval:   10 * time.Millisecond,
 		halt:       make(chan bool),
+		mutex:      &sync.Mutex{},
 	}
 
-	if len(v) > 0 {
-		d.interval = v[0]
+	for _, option := range opts {
+		option(d)
 	}
 
 	d.AddEvent(Data)
+	d.AddEvent(Value)
+	d.AddEvent(ThresholdAbove)
+	d.AddEvent(ThresholdBelow)
+	d.AddEvent(ThresholdChangedBy)
 	d.AddEvent(Error)
 
 	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
 		val, err := d.Read()
 		return map[string]interface{}{"val": val, "err": err}
 	})
+	d.AddCommand("Value", func(params map[string]interface{}) interface{} {
+		return d.Value()
+	})
+	d.AddCommand("AddThreshold", func(params map[string]interface{}) interface{} {
+		event, _ := params["event"].(string)
+		level, _ := params["level"].(float64)
+		hysteresis, _ := params["hysteresis"].(float64)
+		return d.AddThreshold(event, level, hysteresis)
+	})
+	d.AddCommand("ClearThresholds", func(params map[string]interface{}) interface{} {
+		d.ClearThresholds()
+		return nil
+	})
+	d.AddCommand("SetFilter", func(params map[string]interface{}) interface{} {
+		f, err := parseFilter(params)
+		if err != nil {
+			return err
+		}
+		d.SetFilter(f)
+		return nil
+	})
+	d.AddCommand("SetCalibration", func(params map[string]interface{}) interface{} {
+		d.SetCalibration(parseCalibration(params))
+		return nil
+	})
 
 	return d
 }
@@ -54,8 +163,16 @@ func NewAnalogSensorDriver(a AnalogReader, pin string, v ...time.Duration) *Anal
 // Start starts the AnalogSensorDriver and reads the Analog Sensor at the given interval.
 // Emits the Events:
 //	Data int - Event is emitted on change and represents the current reading from the sensor.
+//	Value float64 - Event is emitted on change and represents the filtered and calibrated reading.
+//	ThresholdAbove ThresholdCrossing - Event is emitted when the value rises above a threshold.
+//	ThresholdBelow ThresholdCrossing - Event is emitted when the value falls below a threshold.
+//	ThresholdChangedBy ThresholdCrossing - Event is emitted when the value has changed by a delta.
 //	Error error - Event is emitted on error reading from the sensor.
 func (a *AnalogSensorDriver) Start() (err error) {
+	if err = gobot.ClaimResources(a.Connection(), a, gobot.PinResource, a.pin); err != nil {
+		return
+	}
+
 	var value int = 0
 	go func() {
 		timer := time.NewTimer(a.interval)
@@ -64,9 +181,12 @@ func (a *AnalogSensorDriver) Start() (err error) {
 			newValue, err := a.Read()
 			if err != nil {
 				a.Publish(a.Event(Error), err)
-			} else if newValue != value && newValue != -1 {
-				value = newValue
-				a.Publish(a.Event(Data), value)
+			} else if newValue != -1 {
+				if newValue != value {
+					value = newValue
+					a.Publish(a.Event(Data), value)
+				}
+				a.condition(newValue)
 			}
 
 			timer.Reset(a.interval)
@@ -84,6 +204,7 @@ func (a *AnalogSensorDriver) Start() (err error) {
 // Halt stops polling the analog sensor for new information
 func (a *AnalogSensorDriver) Halt() (err error) {
 	a.halt <- true
+	gobot.ReleaseResources(a.Connection(), a)
 	return
 }
 
@@ -103,3 +224,161 @@ func (a *AnalogSensorDriver) Connection() gobot.Connection { return a.connection
 func (a *AnalogSensorDriver) Read() (val int, err error) {
 	return a.connection.AnalogRead(a.Pin())
 }
+
+// Value returns the last filtered and calibrated reading
+func (a *AnalogSensorDriver) Value() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.value
+}
+
+// SetFilter sets the Filter which smooths the readings, nil for none
+func (a *AnalogSensorDriver) SetFilter(f Filter) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if f != nil {
+		f.Reset()
+	}
+	a.filter = f
+}
+
+// SetCalibration sets the Calibration which converts the filtered readings
+// to engineering units, nil for none
+func (a *AnalogSensorDriver) SetCalibration(c Calibration) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.calibration = c
+}
+
+// AddThreshold adds a threshold of the value which emits the event, one of
+// ThresholdAbove, ThresholdBelow or ThresholdChangedBy. The level of
+// ThresholdChangedBy is the change since it was last emitted, and its
+// hysteresis is ignored.
+func (a *AnalogSensorDriver) AddThreshold(event string, level float64, hysteresis float64) error {
+	switch event {
+	case ThresholdAbove, ThresholdBelow, ThresholdChangedBy:
+	default:
+		return ErrUnknownThreshold
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.thresholds = append(a.thresholds, &threshold{
+		event:      event,
+		level:      level,
+		hysteresis: hysteresis,
+		armed:      true,
+		reference:  math.NaN(),
+	})
+	return nil
+}
+
+// ClearThresholds removes all thresholds
+func (a *AnalogSensorDriver) ClearThresholds() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.thresholds = nil
+}
+
+// condition filters and calibrates the raw reading, and publishes the value
+// and the thresholds it crossed
+func (a *AnalogSensorDriver) condition(raw int) {
+	a.mutex.Lock()
+	value := float64(raw)
+	if a.filter != nil {
+		value = a.filter.Filter(value)
+	}
+	if a.calibration != nil {
+		value = a.calibration.Convert(value)
+	}
+	changed := value != a.value
+	a.value = value
+
+	events := []string{}
+	crossings := []ThresholdCrossing{}
+	for _, t := range a.thresholds {
+		if t.cross(value) {
+			events = append(events, t.event)
+			crossings = append(crossings, ThresholdCrossing{Level: t.level, Value: value})
+		}
+	}
+	a.mutex.Unlock()
+
+	if changed {
+		a.Publish(a.Event(Value), value)
+	}
+	for i, event := range events {
+		a.Publish(a.Event(event), crossings[i])
+	}
+}
+
+// cross returns true if the value crosses the threshold, and re-arms it once
+// the value is back beyond the hysteresis
+func (t *threshold) cross(value float64) bool {
+	switch t.event {
+	case ThresholdAbove:
+		if t.armed && value > t.level {
+			t.armed = false
+			return true
+		}
+		if value < t.level-t.hysteresis {
+			t.armed = true
+		}
+	case ThresholdBelow:
+		if t.armed && value < t.level {
+			t.armed = false
+			return true
+		}
+		if value > t.level+t.hysteresis {
+			t.armed = true
+		}
+	case ThresholdChangedBy:
+		if math.IsNaN(t.reference) {
+			t.reference = value
+		} else if math.Abs(value-t.reference) >= t.level {
+			t.reference = value
+			return true
+		}
+	}
+	return false
+}
+
+func parseFilter(params map[string]interface{}) (Filter, error) {
+	number := func(name string) float64 {
+		v, _ := params[name].(float64)
+		return v
+	}
+	kind, _ := params["type"].(string)
+	switch kind {
+	case "":
+		return nil, nil
+	case "average":
+		return NewMovingAverageFilter(int(number("size"))), nil
+	case "median":
+		return NewMedianFilter(int(number("size"))), nil
+	case "exponential":
+		return NewExponentialFilter(number("alpha")), nil
+	case "kalman":
+		return NewKalmanFilter(number("processNoise"), number("measurementNoise")), nil
+	}
+	return nil, ErrUnknownFilter
+}
+
+func parseCalibration(params map[string]interface{}) Calibration {
+	if values, ok := params["coefficients"].([]interface{}); ok {
+		coefficients := []float64{}
+		for _, v := range values {
+			c, _ := v.(float64)
+			coefficients = append(coefficients, c)
+		}
+		return NewPolynomialCalibration(coefficients...)
+	}
+	scale, hasScale := params["scale"].(float64)
+	offset, hasOffset := params["offset"].(float64)
+	if !hasScale && !hasOffset {
+		return nil
+	}
+	if !hasScale {
+		scale = 1
+	}
+	return &LinearCalibration{Scale: scale, Offset: offset}
+}
@@ -2,7 +2,9 @@ package aio
 
 import (
 	"errors"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -122,3 +124,151 @@ func TestAnalogSensorDriverSetName(t *testing.T) {
 	d.SetName("mybot")
 	gobottest.Assert(t, d.Name(), "mybot")
 }
+
+func TestAnalogSensorDriverOptions(t *testing.T) {
+	f := NewMedianFilter(3)
+	c := NewLinearCalibration(0, 0, 1023, 5)
+	d := NewAnalogSensorDriverWithOptions(newAioTestAdaptor(), "1",
+		WithFilter(f), WithInterval(20*time.Millisecond), WithCalibration(c),
+		WithAbove(3, 0.5), WithBelow(1, 0.5), WithChangedBy(0.1))
+	gobottest.Assert(t, d.interval, 20*time.Millisecond)
+	gobottest.Assert(t, d.filter, Filter(f))
+	gobottest.Assert(t, d.calibration, Calibration(c))
+	gobottest.Assert(t, len(d.thresholds), 3)
+	gobottest.Assert(t, d.thresholds[0].event, ThresholdAbove)
+	gobottest.Assert(t, d.thresholds[1].event, ThresholdBelow)
+	gobottest.Assert(t, d.thresholds[2].event, ThresholdChangedBy)
+}
+
+func TestAnalogSensorDriverValue(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewAnalogSensorDriverWithOptions(a, "1", WithCalibration(&LinearCalibration{Scale: 0.5, Offset: 1}))
+	sem := make(chan float64, 1)
+	d.Once(d.Event(Value), func(data interface{}) {
+		sem <- data.(float64)
+	})
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		return 100, nil
+	})
+	gobottest.Assert(t, d.Start(), nil)
+
+	select {
+	case v := <-sem:
+		gobottest.Assert(t, v, 51.0)
+	case <-time.After(1 * time.Second):
+		t.Errorf("AnalogSensor Event \"Value\" was not published")
+	}
+	d.Halt()
+	gobottest.Assert(t, d.Value(), 51.0)
+	gobottest.Assert(t, d.Command("Value")(nil), 51.0)
+}
+
+func TestAnalogSensorDriverThresholds(t *testing.T) {
+	d := NewAnalogSensorDriver(newAioTestAdaptor(), "1")
+	gobottest.Assert(t, d.AddThreshold("sideways", 1, 0), ErrUnknownThreshold)
+	gobottest.Assert(t, d.AddThreshold(ThresholdAbove, 100, 10), nil)
+	gobottest.Assert(t, d.AddThreshold(ThresholdBelow, 50, 10), nil)
+	gobottest.Assert(t, d.AddThreshold(ThresholdChangedBy, 40, 0), nil)
+
+	mtx := sync.Mutex{}
+	crossings := map[string]ThresholdCrossing{}
+	for _, e := range []string{ThresholdAbove, ThresholdBelow, ThresholdChangedBy} {
+		event := e
+		d.On(d.Event(event), func(data interface{}) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			crossings[event] = data.(ThresholdCrossing)
+		})
+	}
+	publish := func(raw int) ([]string, map[string]ThresholdCrossing) {
+		mtx.Lock()
+		crossings = map[string]ThresholdCrossing{}
+		mtx.Unlock()
+		d.condition(raw)
+		// events are published asynchronously
+		time.Sleep(10 * time.Millisecond)
+		mtx.Lock()
+		defer mtx.Unlock()
+		events := []string{}
+		for event := range crossings {
+			events = append(events, event)
+		}
+		sort.Strings(events)
+		return events, crossings
+	}
+
+	e, _ := publish(75)
+	gobottest.Assert(t, e, []string{})
+	e, c := publish(101)
+	gobottest.Assert(t, e, []string{ThresholdAbove})
+	gobottest.Assert(t, c[ThresholdAbove], ThresholdCrossing{Level: 100, Value: 101})
+	// within the hysteresis
+	e, _ = publish(95)
+	gobottest.Assert(t, e, []string{})
+	e, _ = publish(102)
+	gobottest.Assert(t, e, []string{})
+	// back below level - hysteresis re-arms
+	e, _ = publish(89)
+	gobottest.Assert(t, e, []string{})
+	e, _ = publish(105)
+	gobottest.Assert(t, e, []string{ThresholdAbove})
+
+	e, c = publish(30)
+	gobottest.Assert(t, e, []string{ThresholdBelow, ThresholdChangedBy})
+	gobottest.Assert(t, c[ThresholdChangedBy], ThresholdCrossing{Level: 40, Value: 30})
+	e, _ = publish(55)
+	gobottest.Assert(t, e, []string{})
+	e, _ = publish(45)
+	gobottest.Assert(t, e, []string{})
+	e, _ = publish(61)
+	gobottest.Assert(t, e, []string{})
+	e, _ = publish(49)
+	gobottest.Assert(t, e, []string{ThresholdBelow})
+
+	d.Command("ClearThresholds")(nil)
+	e, _ = publish(200)
+	gobottest.Assert(t, e, []string{})
+	gobottest.Assert(t, d.Command("AddThreshold")(map[string]interface{}{
+		"event": "above", "level": 100.0, "hysteresis": 0.0,
+	}), nil)
+	gobottest.Assert(t, len(d.thresholds), 1)
+}
+
+func TestAnalogSensorDriverSetFilter(t *testing.T) {
+	d := NewAnalogSensorDriver(newAioTestAdaptor(), "1")
+	set := func(params map[string]interface{}) interface{} {
+		return d.Command("SetFilter")(params)
+	}
+	gobottest.Assert(t, set(map[string]interface{}{"type": "average", "size": 4.0}), nil)
+	gobottest.Assert(t, d.filter, Filter(NewMovingAverageFilter(4)))
+	gobottest.Assert(t, set(map[string]interface{}{"type": "median", "size": 3.0}), nil)
+	gobottest.Assert(t, d.filter, Filter(NewMedianFilter(3)))
+	gobottest.Assert(t, set(map[string]interface{}{"type": "exponential", "alpha": 0.2}), nil)
+	gobottest.Assert(t, d.filter, Filter(NewExponentialFilter(0.2)))
+	gobottest.Assert(t, set(map[string]interface{}{"type": "kalman", "processNoise": 0.1, "measurementNoise": 2.0}), nil)
+	gobottest.Assert(t, d.filter, Filter(NewKalmanFilter(0.1, 2)))
+	gobottest.Assert(t, set(map[string]interface{}{"type": "wobbly"}), ErrUnknownFilter)
+	gobottest.Assert(t, set(map[string]interface{}{}), nil)
+	gobottest.Assert(t, d.filter, nil)
+
+	d.SetFilter(NewMovingAverageFilter(2))
+	d.condition(10)
+	d.condition(20)
+	gobottest.Assert(t, d.Value(), 15.0)
+}
+
+func TestAnalogSensorDriverSetCalibration(t *testing.T) {
+	d := NewAnalogSensorDriver(newAioTestAdaptor(), "1")
+	set := func(params map[string]interface{}) {
+		gobottest.Assert(t, d.Command("SetCalibration")(params), nil)
+	}
+	set(map[string]interface{}{"scale": 2.0, "offset": 1.0})
+	gobottest.Assert(t, d.calibration, Calibration(&LinearCalibration{Scale: 2, Offset: 1}))
+	set(map[string]interface{}{"offset": -10.0})
+	gobottest.Assert(t, d.calibration, Calibration(&LinearCalibration{Scale: 1, Offset: -10}))
+	set(map[string]interface{}{"coefficients": []interface{}{1.0, 0.0, 2.0}})
+	d.condition(3)
+	gobottest.Assert(t, d.Value(), 19.0)
+	set(map[string]interface{}{})
+	gobottest.Assert(t, d.calibration, nil)
+}
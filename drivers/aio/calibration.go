@@ -0,0 +1,61 @@
+package aio
+
+import "math"
+
+// Calibration converts the raw reading of an analog sensor to a value in
+// engineering units
+type Calibration interface {
+	Convert(raw float64) float64
+}
+
+// CalibrationFunc is a function which converts a raw reading
+type CalibrationFunc func(raw float64) float64
+
+// Convert converts the raw reading by calling f
+func (f CalibrationFunc) Convert(raw float64) float64 { return f(raw) }
+
+// LinearCalibration converts a raw reading to raw * Scale + Offset
+type LinearCalibration struct {
+	Scale  float64
+	Offset float64
+}
+
+// NewLinearCalibration returns a new LinearCalibration through two points,
+// raw1 reads value1 and raw2 reads value2
+func NewLinearCalibration(raw1 float64, value1 float64, raw2 float64, value2 float64) *LinearCalibration {
+	scale := (value2 - value1) / (raw2 - raw1)
+	return &LinearCalibration{Scale: scale, Offset: value1 - raw1*scale}
+}
+
+// Convert converts the raw reading
+func (c *LinearCalibration) Convert(raw float64) float64 {
+	return raw*c.Scale + c.Offset
+}
+
+// PolynomialCalibration converts a raw reading by a polynomial, whose
+// Coefficients start with the constant term
+type PolynomialCalibration struct {
+	Coefficients []float64
+}
+
+// NewPolynomialCalibration returns a new PolynomialCalibration given the
+// coefficients, starting with the constant term
+func NewPolynomialCalibration(coefficients ...float64) *PolynomialCalibration {
+	return &PolynomialCalibration{Coefficients: coefficients}
+}
+
+// Convert converts the raw reading
+func (c *PolynomialCalibration) Convert(raw float64) (value float64) {
+	for i := len(c.Coefficients) - 1; i >= 0; i-- {
+		value = value*raw + c.Coefficients[i]
+	}
+	return
+}
+
+// GroveTemperatureCalibration converts the reading of a 10 bit analog input
+// of the Grove temperature sensor to degrees Celsius
+var GroveTemperatureCalibration = CalibrationFunc(func(raw float64) float64 {
+	const thermistor = 3975.0
+	resistance := (1023.0 - raw) * 10000 / raw
+	return 1/(math.Log(resistance/10000.0)/thermistor+1/298.15) - 273.15
+})
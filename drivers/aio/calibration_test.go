@@ -0,0 +1,32 @@
+package aio
+
+import (
+	"fmt"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ Calibration = (*LinearCalibration)(nil)
+var _ Calibration = (*PolynomialCalibration)(nil)
+var _ Calibration = CalibrationFunc(nil)
+
+func TestLinearCalibration(t *testing.T) {
+	c := NewLinearCalibration(0, -40, 1023, 125)
+	gobottest.Assert(t, c.Convert(0), -40.0)
+	gobottest.Assert(t, c.Convert(1023), 125.0)
+	gobottest.Assert(t, (&LinearCalibration{Scale: 2, Offset: 1}).Convert(3), 7.0)
+}
+
+func TestPolynomialCalibration(t *testing.T) {
+	c := NewPolynomialCalibration(1, 2, 3)
+	gobottest.Assert(t, c.Convert(0), 1.0)
+	gobottest.Assert(t, c.Convert(2), 17.0)
+	gobottest.Assert(t, NewPolynomialCalibration().Convert(5), 0.0)
+}
+
+func TestCalibrationFunc(t *testing.T) {
+	c := CalibrationFunc(func(raw float64) float64 { return raw / 2 })
+	gobottest.Assert(t, c.Convert(5), 2.5)
+	gobottest.Assert(t, fmt.Sprintf("%.2f", GroveTemperatureCalibration.Convert(585)), "31.62")
+}
@@ -0,0 +1,130 @@
+package aio
+
+// The sensitivities of the ACS712 hall effect current sensors in volts per
+// ampere, at a supply of 5 volts
+const (
+	ACS712Sensitivity5A  = 0.185
+	ACS712Sensitivity20A = 0.100
+	ACS712Sensitivity30A = 0.066
+)
+
+// CurrentSensorDriver represents a hall effect current sensor such as the
+// ACS712, whose output voltage rises from its zero current voltage with the
+// current. Its value is the current in amperes.
+type CurrentSensorDriver struct {
+	*AnalogSensorDriver
+	ADC ADC
+	// Sensitivity is the change of the output in volts per ampere
+	Sensitivity float64
+	// Zero is the output in volts without current
+	Zero float64
+}
+
+// NewCurrentSensorDriver returns a new CurrentSensorDriver with a polling
+// interval of 10 Milliseconds given an AnalogReader, pin and the sensitivity
+// of the sensor in volts per ampere. Its zero current output is half the
+// reference voltage of the ADC.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the current is polled for new information
+// 	ADC: ADC of the AnalogReader, DefaultADC otherwise
+// 	AnalogSensorOption: See NewAnalogSensorDriverWithOptions
+//
+// Adds the following API Commands:
+// 	"Read" - See AnalogSensor.Read
+// 	"Current" - See CurrentSensorDriver.Current
+func NewCurrentSensorDriver(a AnalogReader, pin string, sensitivity float64, v ...interface{}) *CurrentSensorDriver {
+	adc, opts := sensorOptions(v)
+	d := &CurrentSensorDriver{
+		ADC:         adc,
+		Sensitivity: sensitivity,
+		Zero:        adc.Reference / 2,
+	}
+	d.AnalogSensorDriver = NewAnalogSensorDriverWithOptions(a, pin, append([]AnalogSensorOption{WithCalibration(CalibrationFunc(d.Amperes))}, opts...)...)
+
+	d.AddCommand("Current", func(params map[string]interface{}) interface{} {
+		current, err := d.Current()
+		return map[string]interface{}{"val": current, "err": err}
+	})
+
+	return d
+}
+
+// Amperes returns the current in amperes given a raw reading
+func (d *CurrentSensorDriver) Amperes(raw float64) float64 {
+	return (d.ADC.Volts(raw) - d.Zero) / d.Sensitivity
+}
+
+// Current reads the sensor and returns the current in amperes
+func (d *CurrentSensorDriver) Current() (current float64, err error) {
+	raw, err := d.Read()
+	if err != nil {
+		return
+	}
+	return d.Amperes(float64(raw)), nil
+}
+
+// CurrentLoopDriver represents a 4-20mA current loop, which is measured by
+// the voltage across a shunt resistor. 4mA is the lowest value of the
+// transmitter in the loop and 20mA the highest, its value is in between.
+type CurrentLoopDriver struct {
+	*AnalogSensorDriver
+	ADC ADC
+	// Shunt is the resistance of the shunt resistor in Ohms
+	Shunt float64
+	// Min is the value at 4mA
+	Min float64
+	// Max is the value at 20mA
+	Max float64
+}
+
+// NewCurrentLoopDriver returns a new CurrentLoopDriver with a polling
+// interval of 10 Milliseconds given an AnalogReader, pin, the resistance of
+// the shunt resistor in Ohms, e.g. 250 for 1 to 5 volts, and the values at 4
+// and 20mA.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the loop is polled for new information
+// 	ADC: ADC of the AnalogReader, DefaultADC otherwise
+// 	AnalogSensorOption: See NewAnalogSensorDriverWithOptions
+//
+// Adds the following API Commands:
+// 	"Read" - See AnalogSensor.Read
+// 	"Current" - See CurrentLoopDriver.Current
+func NewCurrentLoopDriver(a AnalogReader, pin string, shunt float64, min float64, max float64, v ...interface{}) *CurrentLoopDriver {
+	adc, opts := sensorOptions(v)
+	d := &CurrentLoopDriver{
+		ADC:   adc,
+		Shunt: shunt,
+		Min:   min,
+		Max:   max,
+	}
+	d.AnalogSensorDriver = NewAnalogSensorDriverWithOptions(a, pin, append([]AnalogSensorOption{WithCalibration(CalibrationFunc(d.Scale))}, opts...)...)
+
+	d.AddCommand("Current", func(params map[string]interface{}) interface{} {
+		current, err := d.Current()
+		return map[string]interface{}{"val": current, "err": err}
+	})
+
+	return d
+}
+
+// Milliamperes returns the loop current in milliamperes given a raw reading
+func (d *CurrentLoopDriver) Milliamperes(raw float64) float64 {
+	return d.ADC.Volts(raw) / d.Shunt * 1000
+}
+
+// Scale returns the value given a raw reading. Currents below 4mA, e.g. of a
+// broken loop, return values below Min.
+func (d *CurrentLoopDriver) Scale(raw float64) float64 {
+	return d.Min + (d.Milliamperes(raw)-4)/16*(d.Max-d.Min)
+}
+
+// Current reads the loop and returns the current in milliamperes
+func (d *CurrentLoopDriver) Current() (current float64, err error) {
+	raw, err := d.Read()
+	if err != nil {
+		return
+	}
+	return d.Milliamperes(float64(raw)), nil
+}
@@ -0,0 +1,72 @@
+package aio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*CurrentSensorDriver)(nil)
+var _ gobot.Driver = (*CurrentLoopDriver)(nil)
+
+func TestCurrentSensorDriver(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewCurrentSensorDriver(a, "1", ACS712Sensitivity20A)
+	gobottest.Assert(t, d.Zero, 2.5)
+	gobottest.Assert(t, d.Sensitivity, 0.1)
+	gobottest.Assert(t, fmt.Sprintf("%.2f", d.Amperes(511.5)), "0.00")
+	gobottest.Assert(t, fmt.Sprintf("%.2f", d.Amperes(1023)), "25.00")
+	gobottest.Assert(t, fmt.Sprintf("%.2f", d.Amperes(0)), "-25.00")
+
+	d = NewCurrentSensorDriver(a, "1", 0.4, ADC{Bits: 12, Reference: 3.3})
+	gobottest.Assert(t, d.Zero, 1.65)
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		return 4095, nil
+	})
+	current, err := d.Current()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, fmt.Sprintf("%.3f", current), "4.125")
+	ret := d.Command("Current")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["val"], current)
+	d.condition(4095)
+	gobottest.Assert(t, d.Value(), current)
+
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		return 0, errors.New("read error")
+	})
+	_, err = d.Current()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestCurrentLoopDriver(t *testing.T) {
+	a := newAioTestAdaptor()
+	// 0-10 bar transmitter on a 250 Ohm shunt, 1-5 volts
+	d := NewCurrentLoopDriver(a, "1", 250, 0, 10)
+	gobottest.Assert(t, d.Milliamperes(1023), 20.0)
+	gobottest.Assert(t, d.Scale(1023), 10.0)
+	gobottest.Assert(t, math.Abs(d.Scale(204.6)) < 1e-9, true)
+	gobottest.Assert(t, fmt.Sprintf("%.2f", d.Scale(613.8)), "5.00")
+	// a broken loop reads below the range
+	gobottest.Assert(t, d.Scale(0), -2.5)
+
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		return 1023, nil
+	})
+	current, err := d.Current()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, current, 20.0)
+	ret := d.Command("Current")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["val"], 20.0)
+	d.condition(1023)
+	gobottest.Assert(t, d.Value(), 10.0)
+
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		return 0, errors.New("read error")
+	})
+	_, err = d.Current()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
@@ -0,0 +1,145 @@
+package aio
+
+import "sort"
+
+// Filter smooths the readings of an analog sensor
+type Filter interface {
+	// Filter adds a reading and returns the filtered value
+	Filter(value float64) float64
+	// Reset forgets the readings added so far
+	Reset()
+}
+
+// MovingAverageFilter returns the mean of the last Size readings, Size below
+// one is taken as one
+type MovingAverageFilter struct {
+	Size   int
+	values []float64
+	sum    float64
+}
+
+// NewMovingAverageFilter returns a new MovingAverageFilter of the last size
+// readings
+func NewMovingAverageFilter(size int) *MovingAverageFilter {
+	return &MovingAverageFilter{Size: size}
+}
+
+// Filter adds a reading and returns the mean of the last Size readings
+func (f *MovingAverageFilter) Filter(value float64) float64 {
+	f.values = append(f.values, value)
+	f.sum += value
+	for len(f.values) > windowSize(f.Size) {
+		f.sum -= f.values[0]
+		f.values = f.values[1:]
+	}
+	return f.sum / float64(len(f.values))
+}
+
+// Reset forgets the readings added so far
+func (f *MovingAverageFilter) Reset() {
+	f.values, f.sum = nil, 0
+}
+
+// MedianFilter returns the median of the last Size readings, which removes
+// single spikes. Size below one is taken as one.
+type MedianFilter struct {
+	Size   int
+	values []float64
+}
+
+// NewMedianFilter returns a new MedianFilter of the last size readings
+func NewMedianFilter(size int) *MedianFilter {
+	return &MedianFilter{Size: size}
+}
+
+// Filter adds a reading and returns the median of the last Size readings
+func (f *MedianFilter) Filter(value float64) float64 {
+	f.values = append(f.values, value)
+	if size := windowSize(f.Size); len(f.values) > size {
+		f.values = f.values[len(f.values)-size:]
+	}
+	sorted := append([]float64{}, f.values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 0 {
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return sorted[n/2]
+}
+
+// Reset forgets the readings added so far
+func (f *MedianFilter) Reset() {
+	f.values = nil
+}
+
+// windowSize returns the number of readings of a window of size, at least one
+func windowSize(size int) int {
+	if size < 1 {
+		return 1
+	}
+	return size
+}
+
+// ExponentialFilter is a low pass filter which moves its value by Alpha,
+// from 0 to 1, towards every reading
+type ExponentialFilter struct {
+	Alpha float64
+	value float64
+	init  bool
+}
+
+// NewExponentialFilter returns a new ExponentialFilter with the smoothing
+// factor alpha, smaller ones smooth more
+func NewExponentialFilter(alpha float64) *ExponentialFilter {
+	return &ExponentialFilter{Alpha: alpha}
+}
+
+// Filter adds a reading and returns the filtered value
+func (f *ExponentialFilter) Filter(value float64) float64 {
+	if !f.init {
+		f.value, f.init = value, true
+		return value
+	}
+	f.value += f.Alpha * (value - f.value)
+	return f.value
+}
+
+// Reset forgets the readings added so far
+func (f *ExponentialFilter) Reset() {
+	f.value, f.init = 0, false
+}
+
+// KalmanFilter is a one dimensional Kalman filter of a value which changes
+// slowly, with the variance ProcessNoise per reading, and is measured with
+// the variance MeasurementNoise
+type KalmanFilter struct {
+	ProcessNoise     float64
+	MeasurementNoise float64
+	value            float64
+	variance         float64
+	init             bool
+}
+
+// NewKalmanFilter returns a new KalmanFilter given the variances of the
+// process and of the measurements
+func NewKalmanFilter(processNoise float64, measurementNoise float64) *KalmanFilter {
+	return &KalmanFilter{ProcessNoise: processNoise, MeasurementNoise: measurementNoise}
+}
+
+// Filter adds a reading and returns the estimated value
+func (f *KalmanFilter) Filter(value float64) float64 {
+	if !f.init {
+		f.value, f.variance, f.init = value, f.MeasurementNoise, true
+		return value
+	}
+	f.variance += f.ProcessNoise
+	gain := f.variance / (f.variance + f.MeasurementNoise)
+	f.value += gain * (value - f.value)
+	f.variance *= 1 - gain
+	return f.value
+}
+
+// Reset forgets the readings added so far
+func (f *KalmanFilter) Reset() {
+	f.value, f.variance, f.init = 0, 0, false
+}
@@ -0,0 +1,63 @@
+package aio
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ Filter = (*MovingAverageFilter)(nil)
+var _ Filter = (*MedianFilter)(nil)
+var _ Filter = (*ExponentialFilter)(nil)
+var _ Filter = (*KalmanFilter)(nil)
+
+func filterAll(f Filter, values ...float64) (out []float64) {
+	for _, v := range values {
+		out = append(out, f.Filter(v))
+	}
+	return
+}
+
+func TestMovingAverageFilter(t *testing.T) {
+	f := NewMovingAverageFilter(3)
+	gobottest.Assert(t, filterAll(f, 3, 6, 9, 12), []float64{3, 4.5, 6, 9})
+	f.Reset()
+	gobottest.Assert(t, f.Filter(1), 1.0)
+}
+
+func TestMedianFilter(t *testing.T) {
+	f := NewMedianFilter(3)
+	gobottest.Assert(t, filterAll(f, 10, 20, 500, 12, 11), []float64{10, 15, 20, 20, 12})
+	f.Reset()
+	gobottest.Assert(t, f.Filter(1), 1.0)
+}
+
+func TestFilterSizeBelowOne(t *testing.T) {
+	gobottest.Assert(t, filterAll(NewMovingAverageFilter(0), 3, 6, 9), []float64{3, 6, 9})
+	gobottest.Assert(t, filterAll(NewMedianFilter(0), 3, 6, 9), []float64{3, 6, 9})
+	gobottest.Assert(t, filterAll(NewMedianFilter(-2), 3, 6), []float64{3, 6})
+}
+
+func TestExponentialFilter(t *testing.T) {
+	f := NewExponentialFilter(0.5)
+	gobottest.Assert(t, filterAll(f, 10, 20, 20), []float64{10, 15, 17.5})
+	f.Reset()
+	gobottest.Assert(t, f.Filter(1), 1.0)
+}
+
+func TestKalmanFilter(t *testing.T) {
+	f := NewKalmanFilter(0, 1)
+	// without process noise the estimate is the mean of the readings
+	out := filterAll(f, 10, 20, 30)
+	gobottest.Assert(t, out[0], 10.0)
+	gobottest.Assert(t, out[1], 15.0)
+	gobottest.Assert(t, out[2] > 19.99 && out[2] < 20.01, true)
+
+	f = NewKalmanFilter(0.01, 4)
+	out = filterAll(f, 100, 140, 60, 100, 100)
+	for _, v := range out[1:] {
+		gobottest.Assert(t, v > 90 && v < 125, true)
+	}
+	f.Reset()
+	gobottest.Assert(t, f.Filter(1), 1.0)
+}
@@ -1,7 +1,6 @@
 package aio
 
 import (
-	"math"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -54,15 +53,16 @@ func NewGroveTemperatureSensorDriver(a AnalogReader, pin string, v ...time.Durat
 //	Data int - Event is emitted on change and represents the current temperature in celsius from the sensor.
 //	Error error - Event is emitted on error reading from the sensor.
 func (a *GroveTemperatureSensorDriver) Start() (err error) {
-	thermistor := 3975.0
+	if err = gobot.ClaimResources(a.Connection(), a, gobot.PinResource, a.pin); err != nil {
+		return
+	}
+
 	a.temperature = 0
 
 	go func() {
 		for {
 			rawValue, err := a.Read()
-
-			resistance := float64(1023.0-rawValue) * 10000 / float64(rawValue)
-			newValue := 1/(math.Log(resistance/10000.0)/thermistor+1/298.15) - 273.15
+			newValue := GroveTemperatureCalibration.Convert(float64(rawValue))
 
 			if err != nil {
 				a.Publish(Error, err)
@@ -83,6 +83,7 @@ func (a *GroveTemperatureSensorDriver) Start() (err error) {
 // Halt stops polling the analog sensor for new information
 func (a *GroveTemperatureSensorDriver) Halt() (err error) {
 	a.halt <- true
+	gobot.ReleaseResources(a.Connection(), a)
 	return
 }
 
@@ -105,6 +106,16 @@ func (a *GroveTemperatureSensorDriver) Temperature() (val float64) {
 	return a.temperature
 }
 
+// ReadTemperature reads the Sensor and returns the temperature in degrees
+// Celsius. It implements environment.Thermometer.
+func (a *GroveTemperatureSensorDriver) ReadTemperature() (val float64, err error) {
+	rawValue, err := a.Read()
+	if err != nil {
+		return
+	}
+	return GroveTemperatureCalibration.Convert(float64(rawValue)), nil
+}
+
 // Read returns the raw reading from the Sensor
 func (a *GroveTemperatureSensorDriver) Read() (val int, err error) {
 	return a.connection.AnalogRead(a.Pin())
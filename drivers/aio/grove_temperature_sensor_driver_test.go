@@ -8,10 +8,12 @@ import (
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*GroveTemperatureSensorDriver)(nil)
+var _ environment.Thermometer = (*GroveTemperatureSensorDriver)(nil)
 
 func TestGroveTemperatureSensorDriver(t *testing.T) {
 	testAdaptor := newAioTestAdaptor()
@@ -86,6 +88,25 @@ func TestGroveTempSensorHalt(t *testing.T) {
 	}
 }
 
+func TestGroveTempSensorReadTemperature(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewGroveTemperatureSensorDriver(a, "1")
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		val = 585
+		return
+	})
+	temperature, err := d.ReadTemperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temperature, 31.61532462352477)
+
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		err = errors.New("read error")
+		return
+	})
+	_, err = d.ReadTemperature()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
 func TestGroveTempDriverDefaultName(t *testing.T) {
 	d := NewGroveTemperatureSensorDriver(newAioTestAdaptor(), "1")
 	gobottest.Assert(t, strings.HasPrefix(d.Name(), "GroveTemperatureSensor"), true)
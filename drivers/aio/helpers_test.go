@@ -14,6 +14,8 @@ type aioTestAdaptor struct {
 	port                  string
 	mtx                   sync.Mutex
 	testAdaptorAnalogRead func() (val int, err error)
+	written               map[string]int
+	analogWriteErr        error
 }
 
 func (t *aioTestAdaptor) TestAdaptorAnalogRead(f func() (val int, err error)) {
@@ -27,6 +29,17 @@ func (t *aioTestAdaptor) AnalogRead(string) (val int, err error) {
 	defer t.mtx.Unlock()
 	return t.testAdaptorAnalogRead()
 }
+
+func (t *aioTestAdaptor) AnalogWrite(pin string, val int) (err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.analogWriteErr != nil {
+		return t.analogWriteErr
+	}
+	t.written[pin] = val
+	return
+}
+
 func (t *aioTestAdaptor) Connect() (err error)  { return }
 func (t *aioTestAdaptor) Finalize() (err error) { return }
 func (t *aioTestAdaptor) Name() string          { return t.name }
@@ -35,7 +48,8 @@ func (t *aioTestAdaptor) Port() string          { return t.port }
 
 func newAioTestAdaptor() *aioTestAdaptor {
 	return &aioTestAdaptor{
-		port: "/dev/null",
+		port:    "/dev/null",
+		written: map[string]int{},
 		testAdaptorAnalogRead: func() (val int, err error) {
 			return 99, nil
 		},
@@ -0,0 +1,138 @@
+package aio
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// zeroCelsius is 0 degrees Celsius in Kelvin
+const zeroCelsius = 273.15
+
+// ThermistorModel returns the temperature of a thermistor in Kelvin given its
+// resistance in Ohms
+type ThermistorModel interface {
+	Kelvin(resistance float64) float64
+}
+
+// BetaModel models a NTC thermistor by its Beta coefficient and its
+// resistance R0 at the temperature T0 in degrees Celsius, usually 25
+type BetaModel struct {
+	Beta float64
+	R0   float64
+	T0   float64
+}
+
+// Kelvin returns the temperature in Kelvin
+func (m BetaModel) Kelvin(resistance float64) float64 {
+	return 1 / (1/(m.T0+zeroCelsius) + math.Log(resistance/m.R0)/m.Beta)
+}
+
+// SteinhartHartModel models a NTC thermistor by the coefficients of the
+// Steinhart-Hart equation, 1/T = A + B ln(R) + C ln(R)^3
+type SteinhartHartModel struct {
+	A float64
+	B float64
+	C float64
+}
+
+// Kelvin returns the temperature in Kelvin
+func (m SteinhartHartModel) Kelvin(resistance float64) float64 {
+	l := math.Log(resistance)
+	return 1 / (m.A + m.B*l + m.C*l*l*l)
+}
+
+// ThermistorDriver represents a NTC thermistor in a voltage divider with a
+// fixed resistor, which is supplied with the reference voltage of the ADC.
+// Its value is the temperature in degrees Celsius.
+type ThermistorDriver struct {
+	*AnalogSensorDriver
+	ADC   ADC
+	Model ThermistorModel
+	// SeriesResistance is the resistance of the fixed resistor in Ohms
+	SeriesResistance float64
+	// HighSide is true if the thermistor is connected between the reference
+	// voltage and the pin, and the fixed resistor between the pin and ground
+	HighSide bool
+}
+
+// NewThermistorDriver returns a new ThermistorDriver with a polling interval
+// of 10 Milliseconds given an AnalogReader, pin, the model of the thermistor
+// and the resistance of the fixed resistor in Ohms. The thermistor is
+// connected between the pin and ground.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the thermistor is polled for new information
+// 	ADC: ADC of the AnalogReader, DefaultADC otherwise
+// 	AnalogSensorOption: See NewAnalogSensorDriverWithOptions
+//
+// Adds the following API Commands:
+// 	"Read" - See AnalogSensor.Read
+// 	"Temperature" - See ThermistorDriver.Temperature
+func NewThermistorDriver(a AnalogReader, pin string, model ThermistorModel, seriesResistance float64, v ...interface{}) *ThermistorDriver {
+	adc, opts := sensorOptions(v)
+	d := &ThermistorDriver{
+		ADC:              adc,
+		Model:            model,
+		SeriesResistance: seriesResistance,
+	}
+	d.AnalogSensorDriver = NewAnalogSensorDriverWithOptions(a, pin, append([]AnalogSensorOption{WithCalibration(CalibrationFunc(d.Celsius))}, opts...)...)
+
+	d.AddCommand("Temperature", func(params map[string]interface{}) interface{} {
+		temperature, err := d.Temperature()
+		return map[string]interface{}{"val": temperature, "err": err}
+	})
+
+	return d
+}
+
+// Resistance returns the resistance of the thermistor in Ohms given a raw
+// reading
+func (t *ThermistorDriver) Resistance(raw float64) float64 {
+	max := t.ADC.Max()
+	if t.HighSide {
+		return t.SeriesResistance * (max - raw) / raw
+	}
+	return t.SeriesResistance * raw / (max - raw)
+}
+
+// Celsius returns the temperature in degrees Celsius given a raw reading
+func (t *ThermistorDriver) Celsius(raw float64) float64 {
+	return t.Model.Kelvin(t.Resistance(raw)) - zeroCelsius
+}
+
+// Temperature reads the thermistor and returns the temperature in degrees
+// Celsius
+func (t *ThermistorDriver) Temperature() (temperature float64, err error) {
+	raw, err := t.Read()
+	if err != nil {
+		return
+	}
+	return t.Celsius(float64(raw)), nil
+}
+
+// ReadTemperature reads the thermistor and returns the temperature in degrees
+// Celsius. It implements environment.Thermometer.
+func (t *ThermistorDriver) ReadTemperature() (float64, error) {
+	return t.Temperature()
+}
+
+// sensorOptions returns the ADC among the options of a constructor, or the
+// DefaultADC, and the other options as AnalogSensorOptions. It panics on an
+// option of another type.
+func sensorOptions(v []interface{}) (adc ADC, opts []AnalogSensorOption) {
+	adc = DefaultADC
+	for _, option := range v {
+		switch o := option.(type) {
+		case ADC:
+			adc = o
+		case time.Duration:
+			opts = append(opts, WithInterval(o))
+		case AnalogSensorOption:
+			opts = append(opts, o)
+		default:
+			panic(fmt.Sprintf("aio: unknown option %T of an analog sensor", option))
+		}
+	}
+	return
+}
@@ -0,0 +1,73 @@
+package aio
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/environment"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*ThermistorDriver)(nil)
+var _ environment.Thermometer = (*ThermistorDriver)(nil)
+var _ ThermistorModel = BetaModel{}
+var _ ThermistorModel = SteinhartHartModel{}
+
+var testBetaModel = BetaModel{Beta: 3950, R0: 10000, T0: 25}
+
+func TestBetaModel(t *testing.T) {
+	gobottest.Assert(t, fmt.Sprintf("%.2f", testBetaModel.Kelvin(10000)), "298.15")
+	// colder with a higher resistance
+	gobottest.Assert(t, fmt.Sprintf("%.1f", testBetaModel.Kelvin(32650)-zeroCelsius), "0.6")
+}
+
+func TestSteinhartHartModel(t *testing.T) {
+	m := SteinhartHartModel{A: 1.009249522e-03, B: 2.378405444e-04, C: 2.019202697e-07}
+	gobottest.Assert(t, fmt.Sprintf("%.1f", m.Kelvin(10000)-zeroCelsius), "24.7")
+}
+
+func TestThermistorDriver(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewThermistorDriver(a, "1", testBetaModel, 10000, 30*time.Millisecond)
+	gobottest.Assert(t, d.Pin(), "1")
+	gobottest.Assert(t, d.interval, 30*time.Millisecond)
+	gobottest.Assert(t, d.ADC, DefaultADC)
+	gobottest.Assert(t, d.SeriesResistance, 10000.0)
+
+	gobottest.Assert(t, d.Resistance(341), 5000.0)
+	d.HighSide = true
+	gobottest.Assert(t, d.Resistance(341), 20000.0)
+
+	d = NewThermistorDriver(a, "1", testBetaModel, 10000, ADC{Bits: 12, Reference: 3.3})
+	gobottest.Assert(t, d.ADC.Max(), 4095.0)
+	gobottest.Assert(t, d.Resistance(1365), 5000.0)
+}
+
+func TestThermistorDriverTemperature(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewThermistorDriver(a, "1", testBetaModel, 10000)
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		return 512, nil
+	})
+	temperature, err := d.Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, fmt.Sprintf("%.1f", temperature), "25.0")
+	celsius, err := d.ReadTemperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, celsius, temperature)
+	ret := d.Command("Temperature")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["val"], temperature)
+
+	// the value of the sensor is the temperature
+	d.condition(512)
+	gobottest.Assert(t, d.Value(), temperature)
+
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		return 0, errors.New("read error")
+	})
+	_, err = d.Temperature()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
@@ -0,0 +1,56 @@
+package aio
+
+// VoltageDividerDriver represents a voltage which is measured through a
+// resistive divider, R1 between the voltage and the pin and R2 between the
+// pin and ground. Its value is the voltage in volts.
+type VoltageDividerDriver struct {
+	*AnalogSensorDriver
+	ADC ADC
+	// R1 is the resistance between the measured voltage and the pin in Ohms
+	R1 float64
+	// R2 is the resistance between the pin and ground in Ohms
+	R2 float64
+}
+
+// NewVoltageDividerDriver returns a new VoltageDividerDriver with a polling
+// interval of 10 Milliseconds given an AnalogReader, pin and the resistances
+// of the divider in Ohms.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the voltage is polled for new information
+// 	ADC: ADC of the AnalogReader, DefaultADC otherwise
+// 	AnalogSensorOption: See NewAnalogSensorDriverWithOptions
+//
+// Adds the following API Commands:
+// 	"Read" - See AnalogSensor.Read
+// 	"Voltage" - See VoltageDividerDriver.Voltage
+func NewVoltageDividerDriver(a AnalogReader, pin string, r1 float64, r2 float64, v ...interface{}) *VoltageDividerDriver {
+	adc, opts := sensorOptions(v)
+	d := &VoltageDividerDriver{
+		ADC: adc,
+		R1:  r1,
+		R2:  r2,
+	}
+	d.AnalogSensorDriver = NewAnalogSensorDriverWithOptions(a, pin, append([]AnalogSensorOption{WithCalibration(CalibrationFunc(d.Volts))}, opts...)...)
+
+	d.AddCommand("Voltage", func(params map[string]interface{}) interface{} {
+		voltage, err := d.Voltage()
+		return map[string]interface{}{"val": voltage, "err": err}
+	})
+
+	return d
+}
+
+// Volts returns the measured voltage given a raw reading
+func (d *VoltageDividerDriver) Volts(raw float64) float64 {
+	return d.ADC.Volts(raw) * (d.R1 + d.R2) / d.R2
+}
+
+// Voltage reads the divider and returns the measured voltage
+func (d *VoltageDividerDriver) Voltage() (voltage float64, err error) {
+	raw, err := d.Read()
+	if err != nil {
+		return
+	}
+	return d.Volts(float64(raw)), nil
+}
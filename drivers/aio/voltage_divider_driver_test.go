@@ -0,0 +1,37 @@
+package aio
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*VoltageDividerDriver)(nil)
+
+func TestVoltageDividerDriver(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewVoltageDividerDriver(a, "1", 30000, 10000, ADC{Bits: 12, Reference: 3.3})
+	gobottest.Assert(t, d.R1, 30000.0)
+	gobottest.Assert(t, d.R2, 10000.0)
+	gobottest.Assert(t, d.Volts(4095), 13.2)
+	gobottest.Assert(t, d.Volts(0), 0.0)
+
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		return 4095, nil
+	})
+	voltage, err := d.Voltage()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, voltage, 13.2)
+	ret := d.Command("Voltage")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["val"], 13.2)
+	d.condition(4095)
+	gobottest.Assert(t, d.Value(), 13.2)
+
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		return 0, errors.New("read error")
+	})
+	_, err = d.Voltage()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
@@ -0,0 +1,324 @@
+package arm
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+// pathFrame is the time between two points of a path of MoveTo, which is the
+// period of a standard 50Hz servo signal
+const pathFrame = 20 * time.Millisecond
+
+var (
+	// ErrJointLimit is the error resulting when a move would take a joint
+	// beyond its limits or its servo beyond 0-180 degrees
+	ErrJointLimit = errors.New("Arm joint angle is out of its limits")
+	// ErrUnknownPose is the error resulting when a pose which was not saved
+	// is played
+	ErrUnknownPose = errors.New("Arm pose is unknown")
+)
+
+// Joint maps a joint of an arm to the servo which turns it
+type Joint struct {
+	Servo *gpio.ServoDriver
+	// Offset is the angle of the servo in degrees when the joint is at 0
+	Offset float64
+	// Reversed is true if the servo turns the joint clockwise
+	Reversed bool
+	// Min and Max limit the angle of the joint in degrees, unless both are 0
+	Min float64
+	Max float64
+}
+
+// servoAngle returns the angle of the servo for the angle of the joint in
+// degrees
+func (j *Joint) servoAngle(angle float64) float64 {
+	if j.Reversed {
+		return j.Offset - angle
+	}
+	return j.Offset + angle
+}
+
+// angle returns the angle of the joint in degrees
+func (j *Joint) angle() float64 {
+	if j.Reversed {
+		return j.Offset - j.Servo.Angle()
+	}
+	return j.Servo.Angle() - j.Offset
+}
+
+// Driver represents a robotic arm whose joints are turned by servos, such as
+// the servos of a PCA9685. Joint angles are in degrees, positions in the
+// units of the lengths of the kinematics.
+type Driver struct {
+	name       string
+	Kinematics Kinematics
+	Joints     []Joint
+
+	mutex *sync.Mutex
+	poses map[string][]float64
+	gobot.Commander
+}
+
+// NewDriver returns a new Driver of the kinematics, with a joint for every
+// joint angle of the kinematics
+//
+// Adds the following API Commands:
+//	"MoveTo" - See Driver.MoveTo, with the "x", "y" and "z" params and the "duration" in milliseconds
+//	"MoveJoints" - See Driver.MoveJoints, with the "angles" and the "duration" in milliseconds
+//	"Position" - See Driver.Position
+//	"Angles" - See Driver.Angles
+//	"SavePose" - See Driver.SavePose, with the "name" param
+//	"PlayPoses" - See Driver.PlayPoses, with the "names" and the "duration" in milliseconds
+//	"Poses" - See Driver.Poses
+func NewDriver(kinematics Kinematics, joints ...Joint) *Driver {
+	d := &Driver{
+		name:       gobot.DefaultName("Arm"),
+		Kinematics: kinematics,
+		Joints:     joints,
+		mutex:      &sync.Mutex{},
+		poses:      map[string][]float64{},
+		Commander:  gobot.NewCommander(),
+	}
+
+	d.AddCommand("MoveTo", func(params map[string]interface{}) interface{} {
+		x, _ := params["x"].(float64)
+		y, _ := params["y"].(float64)
+		z, _ := params["z"].(float64)
+		return d.MoveTo(Point{X: x, Y: y, Z: z}, milliseconds(params["duration"]))
+	})
+	d.AddCommand("MoveJoints", func(params map[string]interface{}) interface{} {
+		angles := []float64{}
+		values, _ := params["angles"].([]interface{})
+		for _, v := range values {
+			angle, _ := v.(float64)
+			angles = append(angles, angle)
+		}
+		return d.MoveJoints(angles, milliseconds(params["duration"]))
+	})
+	d.AddCommand("Position", func(params map[string]interface{}) interface{} {
+		p, err := d.Position()
+		if err != nil {
+			return err
+		}
+		return p
+	})
+	d.AddCommand("Angles", func(params map[string]interface{}) interface{} {
+		return d.Angles()
+	})
+	d.AddCommand("SavePose", func(params map[string]interface{}) interface{} {
+		name, _ := params["name"].(string)
+		d.SavePose(name)
+		return nil
+	})
+	d.AddCommand("PlayPoses", func(params map[string]interface{}) interface{} {
+		names := []string{}
+		values, _ := params["names"].([]interface{})
+		for _, v := range values {
+			name, _ := v.(string)
+			names = append(names, name)
+		}
+		return d.PlayPoses(milliseconds(params["duration"]), names...)
+	})
+	d.AddCommand("Poses", func(params map[string]interface{}) interface{} {
+		return d.Poses()
+	})
+
+	return d
+}
+
+func milliseconds(v interface{}) time.Duration {
+	ms, _ := v.(float64)
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// Name returns the Drivers name
+func (d *Driver) Name() string { return d.name }
+
+// SetName sets the Drivers name
+func (d *Driver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the servo of the first joint
+func (d *Driver) Connection() gobot.Connection {
+	if len(d.Joints) == 0 {
+		return nil
+	}
+	return d.Joints[0].Servo.Connection()
+}
+
+// Start implements the Driver interface, the servos are drivers of their own
+func (d *Driver) Start() (err error) { return }
+
+// Halt implements the Driver interface
+func (d *Driver) Halt() (err error) { return }
+
+// Angles returns the angles of the joints in degrees
+func (d *Driver) Angles() []float64 {
+	angles := make([]float64, len(d.Joints))
+	for i := range d.Joints {
+		angles[i] = d.Joints[i].angle()
+	}
+	return angles
+}
+
+// Position returns the position of the tool
+func (d *Driver) Position() (Point, error) {
+	return d.Kinematics.Forward(radians(d.Angles()))
+}
+
+// MoveJoints moves the joints to the angles in degrees in duration, and
+// returns when they have arrived. The servos follow their Easing curves.
+func (d *Driver) MoveJoints(angles []float64, duration time.Duration) error {
+	moves, err := d.moves(angles)
+	if err != nil {
+		return err
+	}
+	return gpio.SyncMove(duration, moves...)
+}
+
+// MoveTo moves the tool along a straight line to the target in duration, and
+// returns when it has arrived. The whole path is solved and checked against
+// the limits of the joints before the arm moves.
+func (d *Driver) MoveTo(target Point, duration time.Duration) error {
+	start := d.Angles()
+	from, err := d.Kinematics.Forward(radians(start))
+	if err != nil {
+		return err
+	}
+
+	frames := int(duration / pathFrame)
+	if frames < 1 {
+		frames = 1
+	}
+	path := make([][]gpio.ServoMove, frames)
+	seed := radians(start)
+	for i := 1; i <= frames; i++ {
+		f := float64(i) / float64(frames)
+		p := Point{
+			X: from.X + (target.X-from.X)*f,
+			Y: from.Y + (target.Y-from.Y)*f,
+			Z: from.Z + (target.Z-from.Z)*f,
+		}
+		if seed, err = d.Kinematics.Inverse(p, seed); err != nil {
+			return err
+		}
+		if path[i-1], err = d.moves(degrees(seed)); err != nil {
+			return err
+		}
+	}
+
+	next := time.Now()
+	for _, moves := range path {
+		if err = gpio.SyncMove(0, moves...); err != nil {
+			return err
+		}
+		next = next.Add(duration / time.Duration(frames))
+		time.Sleep(time.Until(next))
+	}
+	return nil
+}
+
+// SavePose saves the current angles of the joints as a pose with the name
+func (d *Driver) SavePose(name string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.poses[name] = d.Angles()
+}
+
+// Poses returns the names of the saved poses in alphabetical order
+func (d *Driver) Poses() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	names := []string{}
+	for name := range d.poses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PlayPoses moves the joints through the poses with the names one after the
+// other, taking duration for every move
+func (d *Driver) PlayPoses(duration time.Duration, names ...string) error {
+	for _, name := range names {
+		d.mutex.Lock()
+		angles, ok := d.poses[name]
+		d.mutex.Unlock()
+		if !ok {
+			return ErrUnknownPose
+		}
+		if err := d.MoveJoints(angles, duration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WritePoses writes the saved poses as JSON, an object of the joint angles
+// of every pose by name
+func (d *Driver) WritePoses(w io.Writer) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return json.NewEncoder(w).Encode(d.poses)
+}
+
+// ReadPoses reads poses written by WritePoses and adds them to the saved
+// poses
+func (d *Driver) ReadPoses(r io.Reader) error {
+	poses := map[string][]float64{}
+	if err := json.NewDecoder(r).Decode(&poses); err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for name, angles := range poses {
+		if len(angles) != len(d.Joints) {
+			return ErrJointCount
+		}
+		d.poses[name] = angles
+	}
+	return nil
+}
+
+// moves returns the moves of the servos to the joint angles in degrees,
+// checking them against the limits
+func (d *Driver) moves(angles []float64) ([]gpio.ServoMove, error) {
+	if len(angles) != len(d.Joints) {
+		return nil, ErrJointCount
+	}
+	moves := make([]gpio.ServoMove, len(angles))
+	for i, angle := range angles {
+		j := &d.Joints[i]
+		limited := j.Min != 0 || j.Max != 0
+		servo := j.servoAngle(angle)
+		if limited && (angle < j.Min || angle > j.Max) || servo < 0 || servo > 180 {
+			return nil, ErrJointLimit
+		}
+		moves[i] = gpio.ServoMove{Servo: j.Servo, Angle: servo}
+	}
+	return moves, nil
+}
+
+func radians(angles []float64) []float64 {
+	r := make([]float64, len(angles))
+	for i, a := range angles {
+		r[i] = a * math.Pi / 180
+	}
+	return r
+}
+
+func degrees(angles []float64) []float64 {
+	r := make([]float64, len(angles))
+	for i, a := range angles {
+		r[i] = a * 180 / math.Pi
+	}
+	return r
+}
@@ -0,0 +1,149 @@
+package arm
+
+import (
+	"bytes"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*Driver)(nil)
+
+type armTestAdaptor struct {
+	mtx    sync.Mutex
+	writes map[string]int
+}
+
+func (t *armTestAdaptor) ServoWrite(pin string, angle byte) (err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.writes[pin]++
+	return
+}
+func (t *armTestAdaptor) Connect() (err error)  { return }
+func (t *armTestAdaptor) Finalize() (err error) { return }
+func (t *armTestAdaptor) Name() string          { return "armTestAdaptor" }
+func (t *armTestAdaptor) SetName(n string)      {}
+
+func newArmTestAdaptor() *armTestAdaptor {
+	return &armTestAdaptor{writes: map[string]int{}}
+}
+
+// initTestDriver returns a planar arm of two links with the shoulder servo at
+// 0 degrees and the elbow servo, which is reversed, at 90 degrees
+func initTestDriver() (*Driver, *armTestAdaptor) {
+	a := newArmTestAdaptor()
+	shoulder := gpio.NewServoDriver(a, "1")
+	elbow := gpio.NewServoDriver(a, "2")
+	d := NewDriver(NewPlanarArm(2, 1),
+		Joint{Servo: shoulder},
+		Joint{Servo: elbow, Offset: 90, Reversed: true, Min: -90, Max: 60},
+	)
+	d.MoveJoints([]float64{0, 0}, 0)
+	return d, a
+}
+
+func TestDriver(t *testing.T) {
+	d, a := initTestDriver()
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.Connection().(*armTestAdaptor), a)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Refute(t, d.Command("MoveTo"), nil)
+	gobottest.Refute(t, d.Command("MoveJoints"), nil)
+	gobottest.Refute(t, d.Command("Position"), nil)
+	gobottest.Refute(t, d.Command("Angles"), nil)
+	gobottest.Refute(t, d.Command("SavePose"), nil)
+	gobottest.Refute(t, d.Command("PlayPoses"), nil)
+	gobottest.Refute(t, d.Command("Poses"), nil)
+
+	d.SetName("mybot")
+	gobottest.Assert(t, d.Name(), "mybot")
+	gobottest.Assert(t, NewDriver(NewPlanarArm(2, 1)).Connection(), nil)
+}
+
+func TestDriverMoveJoints(t *testing.T) {
+	d, _ := initTestDriver()
+	gobottest.Assert(t, d.MoveJoints([]float64{30, -45}, 0), nil)
+	gobottest.Assert(t, d.Angles(), []float64{30, -45})
+	gobottest.Assert(t, d.Joints[0].Servo.Angle(), 30.0)
+	gobottest.Assert(t, d.Joints[1].Servo.Angle(), 135.0)
+
+	p, err := d.Position()
+	gobottest.Assert(t, err, nil)
+	assertNear(t, p.X, 2*math.Cos(math.Pi/6)+math.Cos(-math.Pi/12))
+	assertNear(t, p.Y, 2*math.Sin(math.Pi/6)+math.Sin(-math.Pi/12))
+
+	gobottest.Assert(t, d.MoveJoints([]float64{30, 70}, 0), ErrJointLimit)
+	gobottest.Assert(t, d.MoveJoints([]float64{-10, 0}, 0), ErrJointLimit)
+	gobottest.Assert(t, d.MoveJoints([]float64{30}, 0), ErrJointCount)
+	gobottest.Assert(t, d.Angles(), []float64{30, -45})
+
+	gobottest.Assert(t, d.Command("MoveJoints")(map[string]interface{}{"angles": []interface{}{10.0, 20.0}}), nil)
+	gobottest.Assert(t, d.Command("Angles")(nil), []float64{10, 20})
+}
+
+func TestDriverMoveTo(t *testing.T) {
+	d, a := initTestDriver()
+	d.MoveJoints([]float64{45, -90}, 0)
+	from, _ := d.Position()
+
+	target := Point{X: 2, Y: 1.2}
+	start := time.Now()
+	gobottest.Assert(t, d.MoveTo(target, 100*time.Millisecond), nil)
+	gobottest.Assert(t, time.Since(start) >= 80*time.Millisecond, true)
+	gobottest.Assert(t, a.writes["1"] > 4, true)
+
+	p, _ := d.Position()
+	assertNear(t, p.X, target.X)
+	assertNear(t, p.Y, target.Y)
+	gobottest.Refute(t, from, p)
+
+	gobottest.Assert(t, d.Command("MoveTo")(map[string]interface{}{"x": 2.5, "y": 0.5}), nil)
+	p = d.Command("Position")(nil).(Point)
+	assertNear(t, p.X, 2.5)
+	assertNear(t, p.Y, 0.5)
+}
+
+func TestDriverMoveToLimits(t *testing.T) {
+	d, a := initTestDriver()
+	// reaching back needs the shoulder servo below 0 degrees
+	gobottest.Assert(t, d.MoveTo(Point{X: 1, Y: -1}, 100*time.Millisecond), ErrJointLimit)
+	gobottest.Assert(t, d.MoveTo(Point{X: 4}, 100*time.Millisecond), ErrUnreachable)
+	// the path is checked before the arm moves
+	gobottest.Assert(t, a.writes["1"], 1)
+	gobottest.Assert(t, d.Angles(), []float64{0, 0})
+}
+
+func TestDriverPoses(t *testing.T) {
+	d, _ := initTestDriver()
+	d.MoveJoints([]float64{20, -30}, 0)
+	d.SavePose("b")
+	gobottest.Assert(t, d.Command("SavePose")(map[string]interface{}{"name": "a"}), nil)
+	d.MoveJoints([]float64{60, 10}, 0)
+	d.SavePose("c")
+	gobottest.Assert(t, d.Poses(), []string{"a", "b", "c"})
+
+	gobottest.Assert(t, d.PlayPoses(0, "b"), nil)
+	gobottest.Assert(t, d.Angles(), []float64{20, -30})
+	gobottest.Assert(t, d.Command("PlayPoses")(map[string]interface{}{"names": []interface{}{"a", "c"}}), nil)
+	gobottest.Assert(t, d.Angles(), []float64{60, 10})
+	gobottest.Assert(t, d.PlayPoses(0, "b", "d"), ErrUnknownPose)
+	gobottest.Assert(t, d.Angles(), []float64{20, -30})
+
+	var buf bytes.Buffer
+	gobottest.Assert(t, d.WritePoses(&buf), nil)
+	other, _ := initTestDriver()
+	gobottest.Assert(t, other.ReadPoses(&buf), nil)
+	gobottest.Assert(t, other.Command("Poses")(nil), []string{"a", "b", "c"})
+	gobottest.Assert(t, other.PlayPoses(0, "c"), nil)
+	gobottest.Assert(t, other.Angles(), []float64{60, 10})
+
+	gobottest.Assert(t, other.ReadPoses(bytes.NewBufferString(`{"e": [1, 2, 3]}`)), ErrJointCount)
+	gobottest.Refute(t, other.ReadPoses(bytes.NewBufferString(`{`)), nil)
+}
@@ -0,0 +1,50 @@
+/*
+Package arm provides a Gobot driver for robotic arms whose joints are turned
+by servos, with forward and inverse kinematics.
+
+Installing:
+
+	go get -d -u gobot.io/x/gobot
+
+Example:
+
+	package main
+
+	import (
+		"time"
+
+		"gobot.io/x/gobot"
+		"gobot.io/x/gobot/drivers/arm"
+		"gobot.io/x/gobot/drivers/gpio"
+		"gobot.io/x/gobot/platforms/firmata"
+	)
+
+	func main() {
+		firmataAdaptor := firmata.NewAdaptor("/dev/ttyACM0")
+		shoulder := gpio.NewServoDriver(firmataAdaptor, "3")
+		elbow := gpio.NewServoDriver(firmataAdaptor, "5")
+
+		kinematics := arm.NewPlanarArm(0.1, 0.08)
+		robotArm := arm.NewDriver(kinematics,
+			arm.Joint{Servo: shoulder, Offset: 0, Min: 0, Max: 180},
+			arm.Joint{Servo: elbow, Offset: 180, Min: -170, Max: -10},
+		)
+
+		work := func() {
+			robotArm.MoveTo(arm.Point{X: 0.12, Y: 0.05}, time.Second)
+			robotArm.SavePose("reach")
+		}
+
+		robot := gobot.NewRobot("armBot",
+			[]gobot.Connection{firmataAdaptor},
+			[]gobot.Device{shoulder, elbow, robotArm},
+			work,
+		)
+
+		robot.Start()
+	}
+
+For further information refer to arm README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/arm/README.md
+*/
+package arm // import "gobot.io/x/gobot/drivers/arm"
@@ -0,0 +1,267 @@
+package arm
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrJointCount is the error resulting when the number of joint angles
+	// does not match the joints of an arm
+	ErrJointCount = errors.New("Arm joint count mismatch")
+	// ErrUnreachable is the error resulting when the tool of an arm can not
+	// reach a position
+	ErrUnreachable = errors.New("Arm can not reach the position")
+)
+
+// Point is a position of the tool of an arm. The z axis points up, and the x
+// axis forward from the base.
+type Point struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// Kinematics maps the joint angles of an arm in radians to the position of
+// its tool, and back
+type Kinematics interface {
+	// Forward returns the position of the tool at the joint angles
+	Forward(joints []float64) (Point, error)
+	// Inverse returns joint angles which bring the tool to the target. The
+	// seed angles are the current ones, which iterative solvers start from
+	// and which choose between several solutions.
+	Inverse(target Point, seed []float64) ([]float64, error)
+}
+
+// PlanarArm is an arm of 2 or 3 links which move in a plane, solved
+// analytically. Without a base the links move in the xy plane. With a base
+// the first joint turns the plane around the z axis, and the links move
+// in the vertical plane, reaching out horizontally and up along z.
+//
+// The angle of the first link is measured from the x axis, or from the
+// horizontal, and the angle of every following link relative to the one
+// before it, counterclockwise.
+type PlanarArm struct {
+	// Lengths are the lengths of the links from the shoulder to the tool
+	Lengths []float64
+	// Base is true if the first joint turns the arm around the z axis
+	Base bool
+	// ToolAngle is the angle of the last of 3 links to the x axis, or to the
+	// horizontal, which the inverse kinematics keeps
+	ToolAngle float64
+	// ElbowUp chooses the solution with the elbow counterclockwise of, or
+	// above, the line from the shoulder to the wrist
+	ElbowUp bool
+}
+
+// NewPlanarArm returns a new PlanarArm of the lengths of 2 or 3 links
+func NewPlanarArm(lengths ...float64) *PlanarArm {
+	return &PlanarArm{Lengths: lengths, ElbowUp: true}
+}
+
+// Forward returns the position of the tool at the joint angles
+func (p *PlanarArm) Forward(joints []float64) (Point, error) {
+	base := 0.0
+	if p.Base {
+		if len(joints) == 0 {
+			return Point{}, ErrJointCount
+		}
+		base, joints = joints[0], joints[1:]
+	}
+	if len(joints) != len(p.Lengths) {
+		return Point{}, ErrJointCount
+	}
+
+	u, v, angle := 0.0, 0.0, 0.0
+	for i, length := range p.Lengths {
+		angle += joints[i]
+		u += length * math.Cos(angle)
+		v += length * math.Sin(angle)
+	}
+	if p.Base {
+		return Point{X: u * math.Cos(base), Y: u * math.Sin(base), Z: v}, nil
+	}
+	return Point{X: u, Y: v}, nil
+}
+
+// Inverse returns the joint angles which bring the tool to the target. The
+// seed is not used.
+func (p *PlanarArm) Inverse(target Point, seed []float64) ([]float64, error) {
+	if len(p.Lengths) < 2 || len(p.Lengths) > 3 {
+		return nil, ErrJointCount
+	}
+
+	joints := []float64{}
+	u, v := target.X, target.Y
+	if p.Base {
+		base := math.Atan2(target.Y, target.X)
+		joints = append(joints, base)
+		u, v = math.Hypot(target.X, target.Y), target.Z
+	}
+
+	// solve the first two links for the wrist, which is the tool itself
+	// unless there is a third link
+	l1, l2 := p.Lengths[0], p.Lengths[1]
+	if len(p.Lengths) == 3 {
+		u -= p.Lengths[2] * math.Cos(p.ToolAngle)
+		v -= p.Lengths[2] * math.Sin(p.ToolAngle)
+	}
+	cos := (u*u + v*v - l1*l1 - l2*l2) / (2 * l1 * l2)
+	if cos < -1-1e-9 || cos > 1+1e-9 {
+		return nil, ErrUnreachable
+	}
+	elbow := math.Acos(math.Max(-1, math.Min(1, cos)))
+	if p.ElbowUp {
+		elbow = -elbow
+	}
+	shoulder := math.Atan2(v, u) - math.Atan2(l2*math.Sin(elbow), l1+l2*math.Cos(elbow))
+	joints = append(joints, shoulder, elbow)
+
+	if len(p.Lengths) == 3 {
+		joints = append(joints, p.ToolAngle-shoulder-elbow)
+	}
+	return joints, nil
+}
+
+// DHLink is a link of a DHChain in Denavit-Hartenberg parameters, with a
+// revolute joint turning around its z axis
+type DHLink struct {
+	// D is the offset along the previous z axis and Theta the angle around
+	// it which is added to the joint angle
+	D     float64
+	Theta float64
+	// A is the length along the common normal and Alpha the angle around it
+	A     float64
+	Alpha float64
+}
+
+// DHChain is a general chain of links described by Denavit-Hartenberg
+// parameters, whose inverse kinematics is solved numerically by damped least
+// squares
+type DHChain struct {
+	Links []DHLink
+	// Iterations is the maximum number of iterations of the inverse
+	// kinematics, and Tolerance the distance to the target at which it stops
+	Iterations int
+	Tolerance  float64
+}
+
+// NewDHChain returns a new DHChain of the links
+func NewDHChain(links ...DHLink) *DHChain {
+	reach := 0.0
+	for _, l := range links {
+		reach += math.Abs(l.A) + math.Abs(l.D)
+	}
+	return &DHChain{Links: links, Iterations: 500, Tolerance: reach * 1e-6}
+}
+
+// Forward returns the position of the tool at the joint angles
+func (c *DHChain) Forward(joints []float64) (Point, error) {
+	if len(joints) != len(c.Links) {
+		return Point{}, ErrJointCount
+	}
+
+	// m is the transformation of the current link, as rows of a 4x4 matrix
+	// without the last one
+	m := [3][4]float64{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}}
+	for i, l := range c.Links {
+		ct, st := math.Cos(joints[i]+l.Theta), math.Sin(joints[i]+l.Theta)
+		ca, sa := math.Cos(l.Alpha), math.Sin(l.Alpha)
+		t := [3][4]float64{
+			{ct, -st * ca, st * sa, l.A * ct},
+			{st, ct * ca, -ct * sa, l.A * st},
+			{0, sa, ca, l.D},
+		}
+		var n [3][4]float64
+		for r := 0; r < 3; r++ {
+			for k := 0; k < 4; k++ {
+				for j := 0; j < 3; j++ {
+					n[r][k] += m[r][j] * t[j][k]
+				}
+			}
+			n[r][3] += m[r][3]
+		}
+		m = n
+	}
+	return Point{X: m[0][3], Y: m[1][3], Z: m[2][3]}, nil
+}
+
+// Inverse returns joint angles which bring the tool to the target, starting
+// from the seed, or from all angles at 0 if it can not be reached from there
+func (c *DHChain) Inverse(target Point, seed []float64) ([]float64, error) {
+	if len(seed) != len(c.Links) {
+		return nil, ErrJointCount
+	}
+	if joints, ok := c.solve(target, seed); ok {
+		return joints, nil
+	}
+	if joints, ok := c.solve(target, make([]float64, len(c.Links))); ok {
+		return joints, nil
+	}
+	return nil, ErrUnreachable
+}
+
+// solve moves the joints towards the target by damped least squares, with
+// the jacobian of the position computed by finite differences
+func (c *DHChain) solve(target Point, seed []float64) ([]float64, bool) {
+	const h = 1e-6
+	lambda := c.Tolerance * 1e4
+	n := len(seed)
+	joints := append([]float64{}, seed...)
+	for i := 0; i < c.Iterations; i++ {
+		p, _ := c.Forward(joints)
+		e := [3]float64{target.X - p.X, target.Y - p.Y, target.Z - p.Z}
+		if math.Sqrt(e[0]*e[0]+e[1]*e[1]+e[2]*e[2]) <= c.Tolerance {
+			return joints, true
+		}
+
+		jac := make([][3]float64, n)
+		for k := range joints {
+			joints[k] += h
+			q, _ := c.Forward(joints)
+			joints[k] -= h
+			jac[k] = [3]float64{(q.X - p.X) / h, (q.Y - p.Y) / h, (q.Z - p.Z) / h}
+		}
+
+		// the step is J^T (J J^T + lambda^2 I)^-1 e
+		var a [3][3]float64
+		for r := 0; r < 3; r++ {
+			for s := 0; s < 3; s++ {
+				for k := 0; k < n; k++ {
+					a[r][s] += jac[k][r] * jac[k][s]
+				}
+			}
+			a[r][r] += lambda * lambda
+		}
+		f, ok := solve3(a, e)
+		if !ok {
+			return nil, false
+		}
+		for k := range joints {
+			joints[k] += jac[k][0]*f[0] + jac[k][1]*f[1] + jac[k][2]*f[2]
+		}
+	}
+	return nil, false
+}
+
+// solve3 solves the linear system a x = b of 3 equations by Cramer's rule
+func solve3(a [3][3]float64, b [3]float64) ([3]float64, bool) {
+	det := func(m [3][3]float64) float64 {
+		return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+			m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+			m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	}
+	d := det(a)
+	if d == 0 {
+		return [3]float64{}, false
+	}
+	var x [3]float64
+	for c := 0; c < 3; c++ {
+		m := a
+		for r := 0; r < 3; r++ {
+			m[r][c] = b[r]
+		}
+		x[c] = det(m) / d
+	}
+	return x, true
+}
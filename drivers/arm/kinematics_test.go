@@ -0,0 +1,104 @@
+package arm
+
+import (
+	"math"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ Kinematics = (*PlanarArm)(nil)
+var _ Kinematics = (*DHChain)(nil)
+
+func assertNear(t *testing.T, a float64, b float64) {
+	if math.Abs(a-b) > 1e-6 {
+		t.Errorf("%v is not near %v", a, b)
+	}
+}
+
+func assertPoint(t *testing.T, a Point, b Point) {
+	assertNear(t, a.X, b.X)
+	assertNear(t, a.Y, b.Y)
+	assertNear(t, a.Z, b.Z)
+}
+
+func TestPlanarArmForward(t *testing.T) {
+	p := NewPlanarArm(2, 1)
+	pos, err := p.Forward([]float64{0, math.Pi / 2})
+	gobottest.Assert(t, err, nil)
+	assertPoint(t, pos, Point{X: 2, Y: 1})
+
+	_, err = p.Forward([]float64{0})
+	gobottest.Assert(t, err, ErrJointCount)
+
+	p.Base = true
+	pos, err = p.Forward([]float64{math.Pi / 2, math.Pi / 2, -math.Pi / 2})
+	gobottest.Assert(t, err, nil)
+	assertPoint(t, pos, Point{X: 0, Y: 1, Z: 2})
+}
+
+func TestPlanarArmInverse(t *testing.T) {
+	p := NewPlanarArm(2, 1)
+	for _, elbowUp := range []bool{true, false} {
+		p.ElbowUp = elbowUp
+		target := Point{X: 1.5, Y: 1.2}
+		joints, err := p.Inverse(target, nil)
+		gobottest.Assert(t, err, nil)
+		gobottest.Assert(t, joints[1] < 0, elbowUp)
+		pos, _ := p.Forward(joints)
+		assertPoint(t, pos, target)
+	}
+
+	_, err := p.Inverse(Point{X: 4}, nil)
+	gobottest.Assert(t, err, ErrUnreachable)
+}
+
+func TestPlanarArmInverseThreeLinksWithBase(t *testing.T) {
+	p := NewPlanarArm(2, 1.5, 0.5)
+	p.Base = true
+	p.ToolAngle = -math.Pi / 2
+	target := Point{X: 1, Y: 1.5, Z: 0.5}
+	joints, err := p.Inverse(target, nil)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(joints), 4)
+	assertNear(t, joints[1]+joints[2]+joints[3], p.ToolAngle)
+	pos, _ := p.Forward(joints)
+	assertPoint(t, pos, target)
+
+	_, err = NewPlanarArm(1).Inverse(target, nil)
+	gobottest.Assert(t, err, ErrJointCount)
+}
+
+func TestDHChainForward(t *testing.T) {
+	// a planar arm of two links in DH parameters
+	c := NewDHChain(DHLink{A: 2}, DHLink{A: 1})
+	pos, err := c.Forward([]float64{0, math.Pi / 2})
+	gobottest.Assert(t, err, nil)
+	assertPoint(t, pos, Point{X: 2, Y: 1})
+
+	// a base turning around z with an arm in the vertical plane
+	c = NewDHChain(DHLink{D: 1, Alpha: math.Pi / 2}, DHLink{A: 2}, DHLink{A: 1})
+	pos, err = c.Forward([]float64{math.Pi / 2, math.Pi / 2, -math.Pi / 2})
+	gobottest.Assert(t, err, nil)
+	assertPoint(t, pos, Point{X: 0, Y: 1, Z: 3})
+
+	_, err = c.Forward([]float64{0})
+	gobottest.Assert(t, err, ErrJointCount)
+}
+
+func TestDHChainInverse(t *testing.T) {
+	c := NewDHChain(DHLink{D: 1, Alpha: math.Pi / 2}, DHLink{A: 2}, DHLink{A: 1})
+	target := Point{X: 1, Y: 1, Z: 2}
+	joints, err := c.Inverse(target, []float64{0, 0.5, -0.5})
+	gobottest.Assert(t, err, nil)
+	pos, _ := c.Forward(joints)
+	gobottest.Assert(t, math.Abs(pos.X-target.X) < 1e-4, true)
+	gobottest.Assert(t, math.Abs(pos.Y-target.Y) < 1e-4, true)
+	gobottest.Assert(t, math.Abs(pos.Z-target.Z) < 1e-4, true)
+
+	_, err = c.Inverse(Point{X: 5, Z: 1}, []float64{0, 0, 0})
+	gobottest.Assert(t, err, ErrUnreachable)
+
+	_, err = c.Inverse(target, nil)
+	gobottest.Assert(t, err, ErrJointCount)
+}
@@ -0,0 +1,48 @@
+/*
+Package environment provides the interfaces shared by Gobot drivers for
+thermometers, barometers and hygrometers, and an environment driver which
+polls them and derives the dew point, heat index, sea level pressure and
+altitude from their readings.
+
+Installing:
+
+	go get -d -u gobot.io/x/gobot
+
+Example:
+
+	package main
+
+	import (
+		"fmt"
+		"time"
+
+		"gobot.io/x/gobot"
+		"gobot.io/x/gobot/drivers/environment"
+		"gobot.io/x/gobot/drivers/i2c"
+		"gobot.io/x/gobot/platforms/raspi"
+	)
+
+	func main() {
+		r := raspi.NewAdaptor()
+		bme280 := i2c.NewBME280Driver(r)
+		env := environment.NewEnvironmentDriver(bme280, 5*time.Second)
+
+		work := func() {
+			env.On(environment.Data, func(data interface{}) {
+				fmt.Println("reading", data)
+			})
+		}
+
+		robot := gobot.NewRobot("weatherBot",
+			[]gobot.Connection{r},
+			[]gobot.Device{bme280, env},
+			work,
+		)
+
+		robot.Start()
+	}
+
+For further information refer to environment README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/environment/README.md
+*/
+package environment // import "gobot.io/x/gobot/drivers/environment"
@@ -0,0 +1,79 @@
+package environment
+
+import (
+	"errors"
+	"math"
+)
+
+// StandardPressure is the mean pressure at sea level in pascals
+const StandardPressure = 101325.0
+
+const (
+	// Error event
+	Error = "error"
+	// Data event
+	Data = "data"
+)
+
+// ErrNoSensors is the error resulting when an EnvironmentDriver without
+// sensors is started
+var ErrNoSensors = errors.New("Environment driver has no sensors")
+
+// Thermometer is a sensor of the temperature in degrees Celsius
+type Thermometer interface {
+	ReadTemperature() (float64, error)
+}
+
+// Barometer is a sensor of the air pressure in pascals
+type Barometer interface {
+	ReadPressure() (float64, error)
+}
+
+// Hygrometer is a sensor of the relative humidity in percent
+type Hygrometer interface {
+	ReadHumidity() (float64, error)
+}
+
+// DewPoint returns the temperature in degrees Celsius to which air of the
+// temperature and relative humidity has to be cooled to become saturated,
+// after the Magnus formula
+func DewPoint(celsius float64, humidity float64) float64 {
+	const a, b = 17.62, 243.12
+	gamma := math.Log(humidity/100) + a*celsius/(b+celsius)
+	return b * gamma / (a - gamma)
+}
+
+// HeatIndex returns the temperature in degrees Celsius which is felt in air
+// of the temperature and relative humidity, after the regression of the US
+// National Weather Service
+func HeatIndex(celsius float64, humidity float64) float64 {
+	t := celsius*1.8 + 32
+	rh := humidity
+
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*rh -
+			0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+			0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+		switch {
+		case rh < 13 && t >= 80 && t <= 112:
+			hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		case rh > 85 && t >= 80 && t <= 87:
+			hi += (rh - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return (hi - 32) / 1.8
+}
+
+// Altitude returns the altitude in meters at which the pressure is measured,
+// given the reference pressure at sea level, both in pascals, after the
+// barometric formula of the standard atmosphere
+func Altitude(pressure float64, reference float64) float64 {
+	return 44330 * (1 - math.Pow(pressure/reference, 1/5.255))
+}
+
+// SeaLevelPressure returns the pressure at sea level in pascals given the
+// pressure measured at the altitude in meters, the inverse of Altitude
+func SeaLevelPressure(pressure float64, altitude float64) float64 {
+	return pressure / math.Pow(1-altitude/44330, 5.255)
+}
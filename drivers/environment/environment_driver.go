@@ -0,0 +1,250 @@
+package environment
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// Reading is a reading of the sensors of an EnvironmentDriver with the
+// quantities derived from it. Quantities without the sensors they are read
+// or derived from are 0.
+type Reading struct {
+	// Temperature in degrees Celsius
+	Temperature float64
+	// Pressure in pascals
+	Pressure float64
+	// Humidity in percent relative humidity
+	Humidity float64
+	// DewPoint in degrees Celsius, see DewPoint
+	DewPoint float64
+	// HeatIndex in degrees Celsius, see HeatIndex
+	HeatIndex float64
+	// SeaLevelPressure in pascals at the elevation, see SeaLevelPressure
+	SeaLevelPressure float64
+	// Altitude in meters at the reference pressure, see Altitude
+	Altitude float64
+}
+
+// EnvironmentDriver polls a thermometer, a barometer and a hygrometer, e.g.
+// a BME280 which is all of them, and derives the dew point, the heat index,
+// the pressure at sea level and the altitude from their readings
+type EnvironmentDriver struct {
+	name              string
+	Thermometer       Thermometer
+	Barometer         Barometer
+	Hygrometer        Hygrometer
+	referencePressure float64
+	elevation         float64
+	reading           Reading
+	interval          time.Duration
+	mutex             *sync.Mutex
+	halt              chan bool // nil while the loop is not running
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewEnvironmentDriver returns a new EnvironmentDriver with a polling
+// interval of 1 Second and the StandardPressure as reference pressure given
+// its sensors. The first of them which is a Thermometer, Barometer or
+// Hygrometer is used as such, e.g. NewEnvironmentDriver(sht3x, bmp180) reads
+// the temperature of the SHT3x.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the sensors are polled for new information
+//
+// Adds the following API Commands:
+// 	"Read" - See EnvironmentDriver.Read
+// 	"Reading" - See EnvironmentDriver.Reading
+// 	"SetReferencePressure" - See EnvironmentDriver.SetReferencePressure, with the "pressure" in pascals
+// 	"SetElevation" - See EnvironmentDriver.SetElevation, with the "elevation" in meters
+func NewEnvironmentDriver(v ...interface{}) *EnvironmentDriver {
+	d := &EnvironmentDriver{
+		name:              gobot.DefaultName("Environment"),
+		referencePressure: StandardPressure,
+		interval:          time.Second,
+		mutex:             &sync.Mutex{},
+		Eventer:           gobot.NewEventer(),
+		Commander:         gobot.NewCommander(),
+	}
+
+	for _, option := range v {
+		if interval, ok := option.(time.Duration); ok {
+			d.interval = interval
+		}
+		if t, ok := option.(Thermometer); ok && d.Thermometer == nil {
+			d.Thermometer = t
+		}
+		if b, ok := option.(Barometer); ok && d.Barometer == nil {
+			d.Barometer = b
+		}
+		if h, ok := option.(Hygrometer); ok && d.Hygrometer == nil {
+			d.Hygrometer = h
+		}
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Error)
+
+	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
+		r, err := d.Read()
+		if err != nil {
+			return err
+		}
+		return r
+	})
+	d.AddCommand("Reading", func(params map[string]interface{}) interface{} {
+		return d.Reading()
+	})
+	d.AddCommand("SetReferencePressure", func(params map[string]interface{}) interface{} {
+		pressure, _ := params["pressure"].(float64)
+		d.SetReferencePressure(pressure)
+		return nil
+	})
+	d.AddCommand("SetElevation", func(params map[string]interface{}) interface{} {
+		elevation, _ := params["elevation"].(float64)
+		d.SetElevation(elevation)
+		return nil
+	})
+
+	return d
+}
+
+// Name returns the EnvironmentDrivers name
+func (d *EnvironmentDriver) Name() string { return d.name }
+
+// SetName sets the EnvironmentDrivers name
+func (d *EnvironmentDriver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the first sensor which is a driver
+func (d *EnvironmentDriver) Connection() gobot.Connection {
+	for _, sensor := range []interface{}{d.Thermometer, d.Barometer, d.Hygrometer} {
+		if driver, ok := sensor.(gobot.Driver); ok {
+			return driver.Connection()
+		}
+	}
+	return nil
+}
+
+// Start starts polling the sensors at the interval. The sensors are drivers
+// of their own.
+// Emits the Events:
+//	Data Reading - Event is emitted on change and represents the current reading of the sensors.
+//	Error error - Event is emitted on error reading the sensors.
+func (d *EnvironmentDriver) Start() (err error) {
+	if d.Thermometer == nil && d.Barometer == nil && d.Hygrometer == nil {
+		return ErrNoSensors
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.halt != nil {
+		return
+	}
+	d.halt = make(chan bool)
+	go d.loop(d.halt)
+	return
+}
+
+// Halt stops polling the sensors
+func (d *EnvironmentDriver) Halt() (err error) {
+	d.mutex.Lock()
+	halt := d.halt
+	d.halt = nil
+	d.mutex.Unlock()
+	if halt != nil {
+		halt <- true
+	}
+	return
+}
+
+func (d *EnvironmentDriver) loop(halt chan bool) {
+	var last Reading
+	for {
+		r, err := d.Read()
+		if err != nil {
+			d.Publish(Error, err)
+		} else if r != last {
+			last = r
+			d.Publish(Data, r)
+		}
+		select {
+		case <-time.After(d.interval):
+		case <-halt:
+			return
+		}
+	}
+}
+
+// Read reads the sensors once, derives the other quantities and returns the
+// reading, which becomes the current one
+func (d *EnvironmentDriver) Read() (r Reading, err error) {
+	if d.Thermometer != nil {
+		if r.Temperature, err = d.Thermometer.ReadTemperature(); err != nil {
+			return Reading{}, err
+		}
+	}
+	if d.Barometer != nil {
+		if r.Pressure, err = d.Barometer.ReadPressure(); err != nil {
+			return Reading{}, err
+		}
+	}
+	if d.Hygrometer != nil {
+		if r.Humidity, err = d.Hygrometer.ReadHumidity(); err != nil {
+			return Reading{}, err
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.Thermometer != nil && d.Hygrometer != nil {
+		r.DewPoint = DewPoint(r.Temperature, r.Humidity)
+		r.HeatIndex = HeatIndex(r.Temperature, r.Humidity)
+	}
+	if d.Barometer != nil {
+		r.SeaLevelPressure = SeaLevelPressure(r.Pressure, d.elevation)
+		r.Altitude = Altitude(r.Pressure, d.referencePressure)
+	}
+	d.reading = r
+	return
+}
+
+// Reading returns the current reading, which is updated by Read and at every
+// interval after Start
+func (d *EnvironmentDriver) Reading() Reading {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.reading
+}
+
+// ReferencePressure returns the pressure at sea level in pascals of which
+// the Altitude is derived
+func (d *EnvironmentDriver) ReferencePressure() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.referencePressure
+}
+
+// SetReferencePressure sets the pressure at sea level in pascals of which the
+// Altitude is derived, e.g. the current one of a nearby weather station
+func (d *EnvironmentDriver) SetReferencePressure(pressure float64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.referencePressure = pressure
+}
+
+// Elevation returns the altitude in meters of the barometer of which the
+// SeaLevelPressure is derived
+func (d *EnvironmentDriver) Elevation() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.elevation
+}
+
+// SetElevation sets the altitude in meters of the barometer of which the
+// SeaLevelPressure is derived
+func (d *EnvironmentDriver) SetElevation(elevation float64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.elevation = elevation
+}
@@ -0,0 +1,171 @@
+package environment
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*EnvironmentDriver)(nil)
+
+type testSensor struct {
+	mutex       sync.Mutex
+	temperature float64
+	pressure    float64
+	humidity    float64
+	err         error
+}
+
+func (s *testSensor) ReadTemperature() (float64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.temperature, s.err
+}
+
+func (s *testSensor) ReadPressure() (float64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.pressure, s.err
+}
+
+func (s *testSensor) ReadHumidity() (float64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.humidity, s.err
+}
+
+type testThermometer struct{ temperature float64 }
+
+func (s *testThermometer) ReadTemperature() (float64, error) { return s.temperature, nil }
+
+func initTestEnvironmentDriver() (*EnvironmentDriver, *testSensor) {
+	s := &testSensor{temperature: 30, pressure: StandardPressure, humidity: 70}
+	return NewEnvironmentDriver(s, time.Millisecond), s
+}
+
+func TestNewEnvironmentDriver(t *testing.T) {
+	s := &testSensor{}
+	d := NewEnvironmentDriver(s)
+	gobottest.Assert(t, d.interval, time.Second)
+	gobottest.Assert(t, d.ReferencePressure(), StandardPressure)
+	gobottest.Assert(t, d.Elevation(), 0.0)
+	gobottest.Assert(t, d.Thermometer, Thermometer(s))
+	gobottest.Assert(t, d.Barometer, Barometer(s))
+	gobottest.Assert(t, d.Hygrometer, Hygrometer(s))
+	gobottest.Assert(t, d.Connection(), nil)
+	gobottest.Refute(t, d.Command("Read"), nil)
+	gobottest.Refute(t, d.Command("Reading"), nil)
+	gobottest.Refute(t, d.Command("SetReferencePressure"), nil)
+	gobottest.Refute(t, d.Command("SetElevation"), nil)
+
+	// the first thermometer is used
+	thermometer := &testThermometer{}
+	d = NewEnvironmentDriver(thermometer, s, 20*time.Millisecond)
+	gobottest.Assert(t, d.interval, 20*time.Millisecond)
+	gobottest.Assert(t, d.Thermometer, Thermometer(thermometer))
+	gobottest.Assert(t, d.Barometer, Barometer(s))
+	gobottest.Assert(t, d.Hygrometer, Hygrometer(s))
+}
+
+func TestEnvironmentDriverSetName(t *testing.T) {
+	d, _ := initTestEnvironmentDriver()
+	d.SetName("mybot")
+	gobottest.Assert(t, d.Name(), "mybot")
+}
+
+func TestEnvironmentDriverRead(t *testing.T) {
+	d, _ := initTestEnvironmentDriver()
+	d.SetElevation(1000)
+	r, err := d.Read()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, r.Temperature, 30.0)
+	gobottest.Assert(t, r.Pressure, StandardPressure)
+	gobottest.Assert(t, r.Humidity, 70.0)
+	gobottest.Assert(t, r.DewPoint, DewPoint(30, 70))
+	gobottest.Assert(t, r.HeatIndex, HeatIndex(30, 70))
+	gobottest.Assert(t, r.SeaLevelPressure, SeaLevelPressure(StandardPressure, 1000))
+	gobottest.Assert(t, r.Altitude, 0.0)
+	gobottest.Assert(t, d.Reading(), r)
+
+	d.SetReferencePressure(102000)
+	r, _ = d.Read()
+	gobottest.Assert(t, r.Altitude, Altitude(StandardPressure, 102000))
+}
+
+func TestEnvironmentDriverReadThermometer(t *testing.T) {
+	d := NewEnvironmentDriver(&testThermometer{temperature: 21.5})
+	r, err := d.Read()
+	gobottest.Assert(t, err, nil)
+	// the other quantities are 0 without their sensors
+	gobottest.Assert(t, r, Reading{Temperature: 21.5})
+}
+
+func TestEnvironmentDriverReadError(t *testing.T) {
+	d, s := initTestEnvironmentDriver()
+	d.Read()
+	s.err = errors.New("read error")
+	_, err := d.Read()
+	gobottest.Assert(t, err, errors.New("read error"))
+	// the current reading is kept
+	gobottest.Assert(t, d.Reading().Temperature, 30.0)
+}
+
+func TestEnvironmentDriverCommands(t *testing.T) {
+	d, s := initTestEnvironmentDriver()
+	gobottest.Assert(t, d.Command("SetReferencePressure")(map[string]interface{}{"pressure": 100000.0}), nil)
+	gobottest.Assert(t, d.ReferencePressure(), 100000.0)
+	gobottest.Assert(t, d.Command("SetElevation")(map[string]interface{}{"elevation": 250.0}), nil)
+	gobottest.Assert(t, d.Elevation(), 250.0)
+
+	r := d.Command("Read")(nil).(Reading)
+	gobottest.Assert(t, r.Altitude, Altitude(StandardPressure, 100000))
+	gobottest.Assert(t, d.Command("Reading")(nil), r)
+
+	s.err = errors.New("read error")
+	gobottest.Assert(t, d.Command("Read")(nil), errors.New("read error"))
+}
+
+func TestEnvironmentDriverStartHalt(t *testing.T) {
+	d, s := initTestEnvironmentDriver()
+	sem := make(chan Reading, 1)
+	d.Once(d.Event(Data), func(data interface{}) {
+		sem <- data.(Reading)
+	})
+	gobottest.Assert(t, d.Start(), nil)
+	// starting twice does not start another loop
+	gobottest.Assert(t, d.Start(), nil)
+
+	select {
+	case r := <-sem:
+		gobottest.Assert(t, r.Temperature, 30.0)
+	case <-time.After(time.Second):
+		t.Errorf("Environment Event \"Data\" was not published")
+	}
+
+	errs := make(chan error, 1)
+	d.Once(d.Event(Error), func(data interface{}) {
+		errs <- data.(error)
+	})
+	s.mutex.Lock()
+	s.err = errors.New("read error")
+	s.mutex.Unlock()
+
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err, errors.New("read error"))
+	case <-time.After(time.Second):
+		t.Errorf("Environment Event \"Error\" was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestEnvironmentDriverStartNoSensors(t *testing.T) {
+	d := NewEnvironmentDriver()
+	gobottest.Assert(t, d.Start(), ErrNoSensors)
+}
@@ -0,0 +1,40 @@
+package environment
+
+import (
+	"fmt"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestDewPoint(t *testing.T) {
+	gobottest.Assert(t, fmt.Sprintf("%.2f", DewPoint(20, 50)), "9.26")
+	gobottest.Assert(t, fmt.Sprintf("%.2f", DewPoint(10, 80)), "6.71")
+	// saturated air is at its dew point
+	gobottest.Assert(t, fmt.Sprintf("%.2f", DewPoint(30, 100)), "30.00")
+}
+
+func TestHeatIndex(t *testing.T) {
+	// the simple formula below 80F
+	gobottest.Assert(t, fmt.Sprintf("%.1f", HeatIndex(20, 50)), "19.4")
+	// the regression, 95F at 86F and 70%
+	gobottest.Assert(t, fmt.Sprintf("%.1f", HeatIndex(30, 70)), "35.0")
+	gobottest.Assert(t, fmt.Sprintf("%.1f", HeatIndex(40, 40)), "48.3")
+	// the adjustments for low and high humidity
+	gobottest.Assert(t, fmt.Sprintf("%.1f", HeatIndex(35, 10)), "31.9")
+	gobottest.Assert(t, fmt.Sprintf("%.1f", HeatIndex(28, 90)), "34.0")
+}
+
+func TestAltitude(t *testing.T) {
+	gobottest.Assert(t, Altitude(StandardPressure, StandardPressure), 0.0)
+	gobottest.Assert(t, fmt.Sprintf("%.1f", Altitude(89874.6, StandardPressure)), "1000.1")
+	// higher at a higher reference pressure
+	gobottest.Assert(t, Altitude(89874.6, 102000) > Altitude(89874.6, StandardPressure), true)
+}
+
+func TestSeaLevelPressure(t *testing.T) {
+	gobottest.Assert(t, SeaLevelPressure(StandardPressure, 0), StandardPressure)
+	gobottest.Assert(t, fmt.Sprintf("%.1f", SeaLevelPressure(89874.6, 1000)), "101323.2")
+	// the inverse of Altitude
+	gobottest.Assert(t, fmt.Sprintf("%.3f", Altitude(90000, SeaLevelPressure(90000, 500))), "500.000")
+}
@@ -14,6 +14,8 @@ type ButtonDriver struct {
 	halt       chan bool
 	interval   time.Duration
 	connection DigitalReader
+	// Gestures holds the debounce time and the thresholds of the gestures
+	Gestures *ButtonGestures
 	gobot.Eventer
 }
 
@@ -37,8 +39,14 @@ func NewButtonDriver(a DigitalReader, pin string, v ...time.Duration) *ButtonDri
 		b.interval = v[0]
 	}
 
+	b.Gestures = NewButtonGestures(b.gesture)
+
 	b.AddEvent(ButtonPush)
 	b.AddEvent(ButtonRelease)
+	b.AddEvent(ButtonClick)
+	b.AddEvent(ButtonDoubleClick)
+	b.AddEvent(ButtonLongPress)
+	b.AddEvent(ButtonHold)
 	b.AddEvent(Error)
 
 	return b
@@ -49,8 +57,16 @@ func NewButtonDriver(a DigitalReader, pin string, v ...time.Duration) *ButtonDri
 // Emits the Events:
 // 	Push int - On button push
 //	Release int - On button release
+//	Click - On button release, when it is not followed by another push
+//	DoubleClick - On the second button release within Gestures.DoubleClickTime
+//	LongPress - When the button is held down for Gestures.LongPressTime
+//	Hold int - Repeatedly while the button is held down, with the number of repeats
 //	Error error - On button error
 func (b *ButtonDriver) Start() (err error) {
+	if err = gobot.ClaimResources(b.Connection(), b, gobot.PinResource, b.pin); err != nil {
+		return
+	}
+
 	state := 0
 	go func() {
 		for {
@@ -74,6 +90,8 @@ func (b *ButtonDriver) Start() (err error) {
 // Halt stops polling the button for new information
 func (b *ButtonDriver) Halt() (err error) {
 	b.halt <- true
+	b.Gestures.Reset()
+	gobot.ReleaseResources(b.Connection(), b)
 	return
 }
 
@@ -90,11 +108,15 @@ func (b *ButtonDriver) Pin() string { return b.pin }
 func (b *ButtonDriver) Connection() gobot.Connection { return b.connection.(gobot.Connection) }
 
 func (b *ButtonDriver) update(newValue int) {
-	if newValue == 1 {
+	b.Gestures.Update(newValue == 1, newValue)
+}
+
+func (b *ButtonDriver) gesture(event string, data interface{}) {
+	switch event {
+	case ButtonPush:
 		b.Active = true
-		b.Publish(ButtonPush, newValue)
-	} else {
+	case ButtonRelease:
 		b.Active = false
-		b.Publish(ButtonRelease, newValue)
 	}
+	b.Publish(event, data)
 }
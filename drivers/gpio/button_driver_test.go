@@ -106,6 +106,37 @@ func TestButtonDriverStart(t *testing.T) {
 	}
 }
 
+func TestButtonDriverClick(t *testing.T) {
+	sem := make(chan bool, 0)
+	a := newGpioTestAdaptor()
+	d := NewButtonDriver(a, "1")
+	d.Gestures.DoubleClickTime = 0
+
+	d.Once(ButtonClick, func(data interface{}) {
+		sem <- true
+	})
+
+	a.TestAdaptorDigitalRead(func() (val int, err error) {
+		val = 1
+		return
+	})
+
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(50 * time.Millisecond)
+
+	a.TestAdaptorDigitalRead(func() (val int, err error) {
+		val = 0
+		return
+	})
+
+	select {
+	case <-sem:
+	case <-time.After(buttonTestDelay * time.Millisecond):
+		t.Errorf("Button Event \"Click\" was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
 func TestButtonDriverDefaultName(t *testing.T) {
 	g := initTestButtonDriver()
 	gobottest.Assert(t, strings.HasPrefix(g.Name(), "Button"), true)
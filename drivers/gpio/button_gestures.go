@@ -0,0 +1,210 @@
+package gpio
+
+import (
+	"sync"
+	"time"
+)
+
+// ButtonGestures turns the raw pressed and released states of a button into
+// debounced push and release events, and recognizes click, double-click,
+// long-press and hold gestures. It is shared by the button drivers, which feed
+// it with Update and publish the events it passes to their handler.
+//
+// The thresholds can be changed before the driver is started:
+//	DebounceTime - time a new state has to be stable before it is accepted, 0 disables debouncing
+//	LongPressTime - time a button has to be held down to emit long-press, 0 disables it
+//	DoubleClickTime - time after a click within which a second click is a double-click, 0 disables it
+//	HoldTime - time a button has to be held down before hold is emitted, 0 disables it
+//	HoldInterval - interval at which hold is emitted again while the button is held down
+type ButtonGestures struct {
+	DebounceTime    time.Duration
+	LongPressTime   time.Duration
+	DoubleClickTime time.Duration
+	HoldTime        time.Duration
+	HoldInterval    time.Duration
+
+	handler func(event string, data interface{})
+	mutex   *sync.Mutex
+
+	raw       bool
+	rawData   interface{}
+	pressed   bool
+	debounce  *time.Timer
+	longPress *time.Timer
+	hold      *time.Timer
+	holds     int
+	click     *time.Timer
+	clicks    int
+	gesture   bool
+	// changes and updates count accepted and raw states, so that timers
+	// which fire while being stopped can tell they are stale
+	changes int
+	updates int
+}
+
+// NewButtonGestures returns a new ButtonGestures which calls handler with
+// the name and data of each event. Debouncing is disabled, a long-press is
+// 1 second, a double-click has to follow within 250 Milliseconds and hold is
+// emitted every 250 Milliseconds after the button was held down for 1 second.
+func NewButtonGestures(handler func(event string, data interface{})) *ButtonGestures {
+	return &ButtonGestures{
+		LongPressTime:   1 * time.Second,
+		DoubleClickTime: 250 * time.Millisecond,
+		HoldTime:        1 * time.Second,
+		HoldInterval:    250 * time.Millisecond,
+		handler:         handler,
+		mutex:           &sync.Mutex{},
+	}
+}
+
+// Update passes the current state of the button. The data is published
+// along with the push and release events of the state.
+//
+// Emits the Events:
+//	Push - data of the state, when the button is pushed
+//	Release - data of the state, when the button is released
+//	Click nil - when the button was released, after DoubleClickTime has passed without another push
+//	DoubleClick nil - when the button was released for the second time within DoubleClickTime
+//	LongPress nil - when the button has been held down for LongPressTime
+//	Hold int - number of times, every HoldInterval after the button has been held down for HoldTime
+func (g *ButtonGestures) Update(pressed bool, data interface{}) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.raw, g.rawData = pressed, data
+	g.updates++
+	if g.debounce != nil {
+		g.debounce.Stop()
+		g.debounce = nil
+	}
+	if pressed == g.pressed {
+		return
+	}
+	if g.DebounceTime <= 0 {
+		g.change()
+		return
+	}
+	updates := g.updates
+	g.debounce = time.AfterFunc(g.DebounceTime, func() {
+		g.mutex.Lock()
+		defer g.mutex.Unlock()
+
+		if updates != g.updates {
+			return
+		}
+		g.debounce = nil
+		if g.raw != g.pressed {
+			g.change()
+		}
+	})
+}
+
+// Reset stops all pending gestures and forgets the state of the button
+func (g *ButtonGestures) Reset() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, t := range []*time.Timer{g.debounce, g.longPress, g.hold, g.click} {
+		if t != nil {
+			t.Stop()
+		}
+	}
+	g.debounce, g.longPress, g.hold, g.click = nil, nil, nil, nil
+	g.raw, g.pressed, g.clicks, g.holds, g.gesture = false, false, 0, 0, false
+	g.changes++
+	g.updates++
+}
+
+// change accepts the raw state of the button, it has to be called with the
+// mutex held
+func (g *ButtonGestures) change() {
+	g.changes++
+	g.pressed = g.raw
+	if g.pressed {
+		g.push()
+	} else {
+		g.release()
+	}
+}
+
+func (g *ButtonGestures) push() {
+	g.handler(ButtonPush, g.rawData)
+
+	g.gesture = false
+	if g.click != nil {
+		g.click.Stop()
+		g.click = nil
+	}
+	if g.LongPressTime > 0 {
+		g.longPress = time.AfterFunc(g.LongPressTime, g.timeout(func() {
+			g.longPress = nil
+			g.gesture = true
+			g.handler(ButtonLongPress, nil)
+		}))
+	}
+	if g.HoldTime > 0 {
+		g.holds = 0
+		g.hold = time.AfterFunc(g.HoldTime, g.timeout(g.repeatHold))
+	}
+}
+
+func (g *ButtonGestures) repeatHold() {
+	g.holds++
+	g.gesture = true
+	g.handler(ButtonHold, g.holds)
+	if g.HoldInterval > 0 {
+		g.hold = time.AfterFunc(g.HoldInterval, g.timeout(g.repeatHold))
+	} else {
+		g.hold = nil
+	}
+}
+
+func (g *ButtonGestures) release() {
+	g.handler(ButtonRelease, g.rawData)
+
+	if g.longPress != nil {
+		g.longPress.Stop()
+		g.longPress = nil
+	}
+	if g.hold != nil {
+		g.hold.Stop()
+		g.hold = nil
+	}
+
+	// a long-press or hold is not a click, and ends a pending double-click
+	if g.gesture {
+		g.clicks = 0
+		return
+	}
+
+	g.clicks++
+	if g.clicks == 2 {
+		g.clicks = 0
+		g.handler(ButtonDoubleClick, nil)
+		return
+	}
+	if g.DoubleClickTime <= 0 {
+		g.clicks = 0
+		g.handler(ButtonClick, nil)
+		return
+	}
+	g.click = time.AfterFunc(g.DoubleClickTime, g.timeout(func() {
+		g.click = nil
+		g.clicks = 0
+		g.handler(ButtonClick, nil)
+	}))
+}
+
+// timeout wraps f to be run by a timer with the mutex held, unless the
+// state of the button has changed since the timer was started
+func (g *ButtonGestures) timeout(f func()) func() {
+	changes := g.changes
+	return func() {
+		g.mutex.Lock()
+		defer g.mutex.Unlock()
+
+		if changes == g.changes {
+			f()
+		}
+	}
+}
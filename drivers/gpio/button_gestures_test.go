@@ -0,0 +1,151 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type gestureEvent struct {
+	name string
+	data interface{}
+}
+
+func initTestButtonGestures() (*ButtonGestures, chan gestureEvent) {
+	events := make(chan gestureEvent, 100)
+	g := NewButtonGestures(func(event string, data interface{}) {
+		events <- gestureEvent{event, data}
+	})
+	g.LongPressTime = 0
+	g.DoubleClickTime = 0
+	g.HoldTime = 0
+	return g, events
+}
+
+func nextGesture(t *testing.T, events chan gestureEvent) gestureEvent {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(buttonTestDelay * time.Millisecond):
+		t.Errorf("no gesture event was published")
+		return gestureEvent{}
+	}
+}
+
+func noGesture(t *testing.T, events chan gestureEvent, wait time.Duration) {
+	select {
+	case e := <-events:
+		t.Errorf("unexpected gesture event %v", e.name)
+	case <-time.After(wait):
+	}
+}
+
+func TestButtonGesturesPushRelease(t *testing.T) {
+	g, events := initTestButtonGestures()
+	g.Update(true, 1)
+	gobottest.Assert(t, nextGesture(t, events), gestureEvent{ButtonPush, 1})
+	g.Update(true, 1)
+	g.Update(false, 0)
+	gobottest.Assert(t, nextGesture(t, events), gestureEvent{ButtonRelease, 0})
+	gobottest.Assert(t, nextGesture(t, events), gestureEvent{ButtonClick, nil})
+	noGesture(t, events, 10*time.Millisecond)
+}
+
+func TestButtonGesturesDebounce(t *testing.T) {
+	g, events := initTestButtonGestures()
+	g.DebounceTime = 20 * time.Millisecond
+
+	// bounces shorter than the debounce time are ignored
+	g.Update(true, 1)
+	time.Sleep(5 * time.Millisecond)
+	g.Update(false, 0)
+	noGesture(t, events, 40*time.Millisecond)
+
+	g.Update(true, 1)
+	time.Sleep(5 * time.Millisecond)
+	g.Update(false, 0)
+	g.Update(true, 1)
+	noGesture(t, events, 10*time.Millisecond)
+	gobottest.Assert(t, nextGesture(t, events), gestureEvent{ButtonPush, 1})
+}
+
+func TestButtonGesturesClick(t *testing.T) {
+	g, events := initTestButtonGestures()
+	g.DoubleClickTime = 30 * time.Millisecond
+
+	g.Update(true, 1)
+	g.Update(false, 0)
+	nextGesture(t, events)
+	nextGesture(t, events)
+	noGesture(t, events, 10*time.Millisecond)
+	gobottest.Assert(t, nextGesture(t, events), gestureEvent{ButtonClick, nil})
+}
+
+func TestButtonGesturesDoubleClick(t *testing.T) {
+	g, events := initTestButtonGestures()
+	g.DoubleClickTime = 30 * time.Millisecond
+
+	g.Update(true, 1)
+	g.Update(false, 0)
+	g.Update(true, 1)
+	g.Update(false, 0)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonPush)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonRelease)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonPush)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonRelease)
+	gobottest.Assert(t, nextGesture(t, events), gestureEvent{ButtonDoubleClick, nil})
+	noGesture(t, events, 50*time.Millisecond)
+}
+
+func TestButtonGesturesLongPress(t *testing.T) {
+	g, events := initTestButtonGestures()
+	g.LongPressTime = 20 * time.Millisecond
+	g.DoubleClickTime = 30 * time.Millisecond
+
+	g.Update(true, 1)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonPush)
+	gobottest.Assert(t, nextGesture(t, events), gestureEvent{ButtonLongPress, nil})
+	g.Update(false, 0)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonRelease)
+	noGesture(t, events, 50*time.Millisecond)
+
+	// released before the long-press time
+	g.Update(true, 1)
+	g.Update(false, 0)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonPush)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonRelease)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonClick)
+	noGesture(t, events, 30*time.Millisecond)
+}
+
+func TestButtonGesturesHold(t *testing.T) {
+	g, events := initTestButtonGestures()
+	g.HoldTime = 20 * time.Millisecond
+	g.HoldInterval = 10 * time.Millisecond
+
+	g.Update(true, 1)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonPush)
+	gobottest.Assert(t, nextGesture(t, events), gestureEvent{ButtonHold, 1})
+	gobottest.Assert(t, nextGesture(t, events), gestureEvent{ButtonHold, 2})
+	g.Update(false, 0)
+
+	// holds which were already queued can still be received
+	for e := nextGesture(t, events); e.name != ButtonRelease && e.name != ""; e = nextGesture(t, events) {
+		gobottest.Assert(t, e.name, ButtonHold)
+	}
+	noGesture(t, events, 30*time.Millisecond)
+}
+
+func TestButtonGesturesReset(t *testing.T) {
+	g, events := initTestButtonGestures()
+	g.LongPressTime = 20 * time.Millisecond
+
+	g.Update(true, 1)
+	nextGesture(t, events)
+	g.Reset()
+	noGesture(t, events, 40*time.Millisecond)
+
+	g.Update(true, 1)
+	gobottest.Assert(t, nextGesture(t, events).name, ButtonPush)
+}
@@ -149,11 +149,16 @@ func NewBuzzerDriver(a DigitalWriter, pin string) *BuzzerDriver {
 	return l
 }
 
-// Start implements the Driver interface
-func (l *BuzzerDriver) Start() (err error) { return }
+// Start implements the Driver interface and claims the pin
+func (l *BuzzerDriver) Start() (err error) {
+	return gobot.ClaimResources(l.Connection(), l, gobot.PinResource, l.pin)
+}
 
-// Halt implements the Driver interface
-func (l *BuzzerDriver) Halt() (err error) { return }
+// Halt implements the Driver interface and releases the pin
+func (l *BuzzerDriver) Halt() (err error) {
+	gobot.ReleaseResources(l.Connection(), l)
+	return
+}
 
 // Name returns the BuzzerDrivers name
 func (l *BuzzerDriver) Name() string { return l.name }
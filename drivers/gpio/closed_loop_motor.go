@@ -0,0 +1,228 @@
+package gpio
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// ClosedLoopOff is the mode of a ClosedLoopMotor which is not controlled
+	ClosedLoopOff = "off"
+	// ClosedLoopSpeed is the mode of a ClosedLoopMotor which holds an RPM
+	ClosedLoopSpeed = "speed"
+	// ClosedLoopPosition is the mode of a ClosedLoopMotor which holds a
+	// number of revolutions
+	ClosedLoopPosition = "position"
+)
+
+// ClosedLoopMotor controls the power of a DC motor so that it holds a target
+// speed or position measured by an encoder on its shaft. Speeds are
+// controlled by SpeedPID with errors in revolutions per minute, positions
+// by PositionPID with errors in revolutions.
+//
+// The speed is measured from the ticks counted in every control interval,
+// which should therefore span several ticks at the lowest speed it holds.
+//
+// The encoder is a driver of its own and must be started before the motor.
+type ClosedLoopMotor struct {
+	name    string
+	Motor   DCMotor
+	Encoder *EncoderDriver
+	// SpeedPID and PositionPID compute the power of the motor
+	SpeedPID    *PIDController
+	PositionPID *PIDController
+
+	interval time.Duration
+	halt     chan bool
+	mutex    *sync.Mutex
+	mode     string
+	target   float64
+	last     float64 // revolutions at the last update
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewClosedLoopMotor returns a new ClosedLoopMotor with a control interval
+// of 10 Milliseconds given a DCMotor and an EncoderDriver.
+//
+// Optionally accepts:
+//  time.Duration: Interval at which the power of the motor is updated
+//
+// Adds the following API Commands:
+//	"SetRPM" - See ClosedLoopMotor.SetRPM, with the "rpm" param
+//	"SetPosition" - See ClosedLoopMotor.SetPosition, with the "revolutions" param
+//	"Stop" - See ClosedLoopMotor.Stop
+//	"SetGains" - Sets the gains of the "speed" or "position" controller given
+//	by the "mode" param to the "kp", "ki" and "kd" params
+//	"Gains" - Returns the gains of both controllers
+//	"State" - Returns the mode, target, RPM and revolutions
+func NewClosedLoopMotor(motor DCMotor, encoder *EncoderDriver, v ...time.Duration) *ClosedLoopMotor {
+	m := &ClosedLoopMotor{
+		name:        gobot.DefaultName("ClosedLoopMotor"),
+		Motor:       motor,
+		Encoder:     encoder,
+		SpeedPID:    NewPIDController(0.002, 0.02, 0),
+		PositionPID: NewPIDController(2, 0, 0.05),
+		interval:    10 * time.Millisecond,
+		halt:        make(chan bool),
+		mutex:       &sync.Mutex{},
+		mode:        ClosedLoopOff,
+		Eventer:     gobot.NewEventer(),
+		Commander:   gobot.NewCommander(),
+	}
+
+	if len(v) > 0 {
+		m.interval = v[0]
+	}
+
+	m.AddEvent(Error)
+
+	m.AddCommand("SetRPM", func(params map[string]interface{}) interface{} {
+		rpm, _ := params["rpm"].(float64)
+		m.SetRPM(rpm)
+		return nil
+	})
+	m.AddCommand("SetPosition", func(params map[string]interface{}) interface{} {
+		revolutions, _ := params["revolutions"].(float64)
+		m.SetPosition(revolutions)
+		return nil
+	})
+	m.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		return m.Stop()
+	})
+	m.AddCommand("SetGains", func(params map[string]interface{}) interface{} {
+		pid := m.SpeedPID
+		if params["mode"] == ClosedLoopPosition {
+			pid = m.PositionPID
+		}
+		kp, ki, kd := pid.Gains()
+		if v, ok := params["kp"].(float64); ok {
+			kp = v
+		}
+		if v, ok := params["ki"].(float64); ok {
+			ki = v
+		}
+		if v, ok := params["kd"].(float64); ok {
+			kd = v
+		}
+		pid.SetGains(kp, ki, kd)
+		return nil
+	})
+	m.AddCommand("Gains", func(params map[string]interface{}) interface{} {
+		gains := map[string]interface{}{}
+		for mode, pid := range map[string]*PIDController{ClosedLoopSpeed: m.SpeedPID, ClosedLoopPosition: m.PositionPID} {
+			kp, ki, kd := pid.Gains()
+			gains[mode] = map[string]float64{"kp": kp, "ki": ki, "kd": kd}
+		}
+		return gains
+	})
+	m.AddCommand("State", func(params map[string]interface{}) interface{} {
+		mode, target := m.Target()
+		return map[string]interface{}{
+			"mode":        mode,
+			"target":      target,
+			"rpm":         m.Encoder.RPM(),
+			"revolutions": m.Encoder.Revolutions(),
+		}
+	})
+
+	return m
+}
+
+// Name returns the ClosedLoopMotors name
+func (m *ClosedLoopMotor) Name() string { return m.name }
+
+// SetName sets the ClosedLoopMotors name
+func (m *ClosedLoopMotor) SetName(n string) { m.name = n }
+
+// Connection returns the Connection of the encoder
+func (m *ClosedLoopMotor) Connection() gobot.Connection { return m.Encoder.Connection() }
+
+// Start starts updating the power of the motor at the control interval.
+//
+// Emits the Events:
+//	Error error - When the power of the motor can not be set
+func (m *ClosedLoopMotor) Start() (err error) {
+	m.mutex.Lock()
+	m.last = m.Encoder.Revolutions()
+	m.mutex.Unlock()
+
+	go func() {
+		last := time.Now()
+		for {
+			select {
+			case <-time.After(m.interval):
+			case <-m.halt:
+				return
+			}
+			now := time.Now()
+			if err := m.update(now.Sub(last)); err != nil {
+				m.Publish(Error, err)
+			}
+			last = now
+		}
+	}()
+	return
+}
+
+// Halt stops controlling the motor and stops it
+func (m *ClosedLoopMotor) Halt() (err error) {
+	m.halt <- true
+	return m.Stop()
+}
+
+// SetRPM makes the motor hold the revolutions per minute, negative ones
+// turn it backward
+func (m *ClosedLoopMotor) SetRPM(rpm float64) {
+	m.setTarget(ClosedLoopSpeed, rpm, m.SpeedPID)
+}
+
+// SetPosition makes the motor turn to and hold the number of revolutions
+// counted by the encoder
+func (m *ClosedLoopMotor) SetPosition(revolutions float64) {
+	m.setTarget(ClosedLoopPosition, revolutions, m.PositionPID)
+}
+
+// Stop stops controlling the motor and sets its power to 0
+func (m *ClosedLoopMotor) Stop() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.mode = ClosedLoopOff
+	return m.Motor.SetPower(0)
+}
+
+// Target returns the mode and the target RPM or revolutions
+func (m *ClosedLoopMotor) Target() (mode string, target float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.mode, m.target
+}
+
+func (m *ClosedLoopMotor) setTarget(mode string, target float64, pid *PIDController) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.mode != mode {
+		pid.Reset()
+	}
+	m.mode, m.target = mode, target
+}
+
+// update sets the power of the motor computed by the controller of the mode
+// from the revolutions turned since the last update dt ago
+func (m *ClosedLoopMotor) update(dt time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	revolutions := m.Encoder.Revolutions()
+	rpm := (revolutions - m.last) / dt.Minutes()
+	m.last = revolutions
+
+	switch m.mode {
+	case ClosedLoopSpeed:
+		return m.Motor.SetPower(m.SpeedPID.Update(m.target, rpm, dt))
+	case ClosedLoopPosition:
+		return m.Motor.SetPower(m.PositionPID.Update(m.target, revolutions, dt))
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*ClosedLoopMotor)(nil)
+var _ DCMotor = (*MotorDriver)(nil)
+
+// simulatedMotor turns the encoder it drives at 10000 ticks per second at
+// full power
+type simulatedMotor struct {
+	mtx     sync.Mutex
+	encoder *countingEncoder
+	power   float64
+	err     error
+	done    chan bool
+}
+
+func newSimulatedMotor(encoder *countingEncoder) *simulatedMotor {
+	m := &simulatedMotor{encoder: encoder, done: make(chan bool)}
+	go func() {
+		ticks, last := 0.0, time.Now()
+		for {
+			select {
+			case <-time.After(time.Millisecond):
+			case <-m.done:
+				return
+			}
+			m.mtx.Lock()
+			ticks += m.power * 10000 * time.Since(last).Seconds()
+			last = time.Now()
+			m.mtx.Unlock()
+			encoder.mtx.Lock()
+			encoder.ticks = int(ticks)
+			encoder.mtx.Unlock()
+		}
+	}()
+	return m
+}
+
+func (m *simulatedMotor) SetPower(power float64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.power = power
+	return m.err
+}
+
+func (m *simulatedMotor) Power() float64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.power
+}
+
+func initTestClosedLoopMotor() (*ClosedLoopMotor, *simulatedMotor) {
+	c := &countingEncoder{gpioTestAdaptor: *newGpioTestAdaptor()}
+	e := NewEncoderDriver(c, "1", "2")
+	e.TicksPerRevolution = 1000
+	e.VelocityWindow = 20 * time.Millisecond
+	motor := newSimulatedMotor(c)
+	return NewClosedLoopMotor(motor, e), motor
+}
+
+func TestClosedLoopMotor(t *testing.T) {
+	m, _ := initTestClosedLoopMotor()
+	gobottest.Assert(t, strings.HasPrefix(m.Name(), "ClosedLoopMotor"), true)
+	gobottest.Refute(t, m.Connection(), nil)
+	mode, _ := m.Target()
+	gobottest.Assert(t, mode, ClosedLoopOff)
+
+	m.Command("SetGains")(map[string]interface{}{"mode": "position", "kp": 3.0})
+	gains := m.Command("Gains")(nil).(map[string]interface{})
+	gobottest.Assert(t, gains["position"], map[string]float64{"kp": 3, "ki": 0, "kd": 0.05})
+
+	m.Command("SetRPM")(map[string]interface{}{"rpm": 120.0})
+	state := m.Command("State")(nil).(map[string]interface{})
+	gobottest.Assert(t, state["mode"], ClosedLoopSpeed)
+	gobottest.Assert(t, state["target"], 120.0)
+}
+
+func TestClosedLoopMotorPosition(t *testing.T) {
+	m, motor := initTestClosedLoopMotor()
+	defer close(motor.done)
+	gobottest.Assert(t, m.Encoder.Start(), nil)
+	defer m.Encoder.Halt()
+	gobottest.Assert(t, m.Start(), nil)
+
+	m.Command("SetPosition")(map[string]interface{}{"revolutions": 1.0})
+	time.Sleep(400 * time.Millisecond)
+	revolutions := m.Encoder.Revolutions()
+	gobottest.Assert(t, revolutions > 0.9 && revolutions < 1.1, true)
+
+	gobottest.Assert(t, m.Halt(), nil)
+	gobottest.Assert(t, motor.Power(), 0.0)
+}
+
+// runClosedLoopMotor updates the motor every 10ms for d in virtual time,
+// with its encoder turned at 10000 ticks per second at full power
+func runClosedLoopMotor(m *ClosedLoopMotor, motor *simulatedMotor, d time.Duration) {
+	dt := 10 * time.Millisecond
+	ticks := float64(m.Encoder.Ticks())
+	for t := time.Duration(0); t < d; t += dt {
+		ticks += motor.Power() * 10000 * dt.Seconds()
+		m.Encoder.update(int(ticks))
+		m.update(dt)
+	}
+}
+
+func TestClosedLoopMotorSpeed(t *testing.T) {
+	m, motor := initTestClosedLoopMotor()
+	defer close(motor.done)
+
+	// half power turns 5 revolutions per second
+	m.SpeedPID.SetGains(0.001, 0.03, 0)
+	m.SetRPM(300)
+	runClosedLoopMotor(m, motor, 2*time.Second)
+	gobottest.Assert(t, motor.Power() > 0.49 && motor.Power() < 0.51, true)
+	before := m.Encoder.Revolutions()
+	runClosedLoopMotor(m, motor, time.Second)
+	rpm := (m.Encoder.Revolutions() - before) * 60
+	gobottest.Assert(t, rpm > 295 && rpm < 305, true)
+
+	gobottest.Assert(t, m.Command("Stop")(nil), nil)
+	gobottest.Assert(t, motor.Power(), 0.0)
+}
+
+func TestClosedLoopMotorSpeedPerInterval(t *testing.T) {
+	m, motor := initTestClosedLoopMotor()
+	defer close(motor.done)
+	m.SpeedPID.SetGains(0.01, 0, 0)
+	m.SetRPM(60)
+
+	// 0.1 revolutions in 100ms are 60 RPM
+	m.Encoder.update(100)
+	gobottest.Assert(t, m.update(100*time.Millisecond), nil)
+	gobottest.Assert(t, motor.Power(), 0.0)
+	// the speed is not the one of the velocity window of the encoder
+	gobottest.Assert(t, m.update(10*time.Millisecond), nil)
+	gobottest.Assert(t, motor.Power() > 0.59 && motor.Power() < 0.61, true)
+}
+
+func TestClosedLoopMotorError(t *testing.T) {
+	m, motor := initTestClosedLoopMotor()
+	defer close(motor.done)
+	motor.err = errors.New("motor error")
+	gobottest.Assert(t, m.Start(), nil)
+
+	sem := make(chan bool, 1)
+	m.Once(Error, func(data interface{}) {
+		sem <- true
+	})
+	m.SetRPM(10)
+	select {
+	case <-sem:
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Error was not published")
+	}
+	gobottest.Assert(t, m.Halt(), errors.New("motor error"))
+}
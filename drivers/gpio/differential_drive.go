@@ -0,0 +1,289 @@
+package gpio
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// YawSensor is a sensor which measures the heading of a robot, e.g. an IMU
+type YawSensor interface {
+	// Yaw returns the heading in radians, counterclockwise
+	Yaw() (float64, error)
+}
+
+// Odometry is the pose of a robot relative to where its odometry started,
+// in meters along and across its initial heading and in radians
+// counterclockwise
+type Odometry struct {
+	X       float64
+	Y       float64
+	Heading float64
+}
+
+// DifferentialDrive drives a robot with two wheels, or tracks, on a common
+// axle which are turned by their own motors, and keeps track of its pose.
+//
+// The pose is measured by encoders on the wheels when they are set, and
+// estimated from the commanded speeds otherwise. A yaw sensor, when set,
+// measures the heading instead of the wheels.
+type DifferentialDrive struct {
+	name  string
+	Left  DCMotor
+	Right DCMotor
+	// LeftEncoder and RightEncoder count the turns of the wheels, they are
+	// drivers of their own and must be started before the drive
+	LeftEncoder  *EncoderDriver
+	RightEncoder *EncoderDriver
+	// Yaw measures the heading
+	Yaw YawSensor
+	// WheelDiameter and TrackWidth, the distance between the wheels, are in
+	// meters
+	WheelDiameter float64
+	TrackWidth    float64
+	// MaxSpeed is the speed of a wheel at full power in meters per second
+	MaxSpeed float64
+	// Timeout stops the robot when no command was given within it, 0 never
+	// stops it
+	Timeout time.Duration
+
+	interval    time.Duration
+	halt        chan bool
+	mutex       *sync.Mutex
+	linear      float64
+	angular     float64
+	lastCommand time.Time
+	odometry    Odometry
+	lastLeft    float64 // revolutions of the wheels at the last update
+	lastRight   float64
+	yawOffset   float64
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewDifferentialDrive returns a new DifferentialDrive with an odometry
+// interval of 20 Milliseconds and a timeout of 1 Second given the DCMotors of
+// the left and right wheels, which drive the robot forward at positive
+// powers.
+//
+// Optionally accepts:
+//  time.Duration: Interval at which the odometry is updated
+//
+// Adds the following API Commands:
+//	"Drive" - See DifferentialDrive.Drive, with the "linear" and "angular" params
+//	"Rotate" - See DifferentialDrive.Rotate, with the "angular" param
+//	"Stop" - See DifferentialDrive.Stop
+//	"Odometry" - See DifferentialDrive.Odometry
+//	"ResetOdometry" - See DifferentialDrive.ResetOdometry
+func NewDifferentialDrive(left DCMotor, right DCMotor, v ...time.Duration) *DifferentialDrive {
+	d := &DifferentialDrive{
+		name:          gobot.DefaultName("DifferentialDrive"),
+		Left:          left,
+		Right:         right,
+		WheelDiameter: 0.065,
+		TrackWidth:    0.15,
+		MaxSpeed:      0.5,
+		Timeout:       time.Second,
+		interval:      20 * time.Millisecond,
+		halt:          make(chan bool),
+		mutex:         &sync.Mutex{},
+		Eventer:       gobot.NewEventer(),
+		Commander:     gobot.NewCommander(),
+	}
+
+	if len(v) > 0 {
+		d.interval = v[0]
+	}
+
+	d.AddEvent(DriveOdometry)
+	d.AddEvent(DriveTimeout)
+	d.AddEvent(Error)
+
+	d.AddCommand("Drive", func(params map[string]interface{}) interface{} {
+		linear, _ := params["linear"].(float64)
+		angular, _ := params["angular"].(float64)
+		return d.Drive(linear, angular)
+	})
+	d.AddCommand("Rotate", func(params map[string]interface{}) interface{} {
+		angular, _ := params["angular"].(float64)
+		return d.Rotate(angular)
+	})
+	d.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		return d.Stop()
+	})
+	d.AddCommand("Odometry", func(params map[string]interface{}) interface{} {
+		return d.Odometry()
+	})
+	d.AddCommand("ResetOdometry", func(params map[string]interface{}) interface{} {
+		return d.ResetOdometry()
+	})
+
+	return d
+}
+
+// Name returns the DifferentialDrives name
+func (d *DifferentialDrive) Name() string { return d.name }
+
+// SetName sets the DifferentialDrives name
+func (d *DifferentialDrive) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the left motor, if it is a driver
+func (d *DifferentialDrive) Connection() gobot.Connection {
+	if driver, ok := d.Left.(gobot.Driver); ok {
+		return driver.Connection()
+	}
+	return nil
+}
+
+// Start starts updating the odometry at the interval and stopping the
+// robot after the timeout.
+//
+// Emits the Events:
+//	Odometry Odometry - At every update of the odometry
+//	Timeout - When the robot is stopped because no command was given within the timeout
+//	Error error - When the odometry can not be updated
+func (d *DifferentialDrive) Start() (err error) {
+	if err = d.ResetOdometry(); err != nil {
+		return
+	}
+
+	go func() {
+		last := time.Now()
+		for {
+			select {
+			case <-time.After(d.interval):
+			case <-d.halt:
+				return
+			}
+
+			if d.timedOut() {
+				if err := d.Stop(); err != nil {
+					d.Publish(Error, err)
+				}
+				d.Publish(DriveTimeout, nil)
+			}
+
+			now := time.Now()
+			odometry, err := d.update(now.Sub(last))
+			last = now
+			if err != nil {
+				d.Publish(Error, err)
+				continue
+			}
+			d.Publish(DriveOdometry, odometry)
+		}
+	}()
+	return
+}
+
+// Halt stops updating the odometry and stops the robot
+func (d *DifferentialDrive) Halt() (err error) {
+	d.halt <- true
+	return d.Stop()
+}
+
+// Drive moves the robot at the linear speed in meters per second, forward
+// when positive, while turning at the angular speed in radians per second,
+// counterclockwise when positive. When a wheel would have to turn faster
+// than MaxSpeed, both slow down so that the robot follows the same curve.
+func (d *DifferentialDrive) Drive(linear float64, angular float64) (err error) {
+	left := linear - angular*d.TrackWidth/2
+	right := linear + angular*d.TrackWidth/2
+	if fastest := math.Max(math.Abs(left), math.Abs(right)); fastest > d.MaxSpeed {
+		left *= d.MaxSpeed / fastest
+		right *= d.MaxSpeed / fastest
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.lastCommand = time.Now()
+	d.linear = (left + right) / 2
+	d.angular = (right - left) / d.TrackWidth
+	if err = d.Left.SetPower(left / d.MaxSpeed); err != nil {
+		return
+	}
+	return d.Right.SetPower(right / d.MaxSpeed)
+}
+
+// Rotate turns the robot on the spot at the angular speed in radians per
+// second, counterclockwise when positive
+func (d *DifferentialDrive) Rotate(angular float64) error {
+	return d.Drive(0, angular)
+}
+
+// Stop stops both motors
+func (d *DifferentialDrive) Stop() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.linear, d.angular = 0, 0
+	err = d.Left.SetPower(0)
+	if e := d.Right.SetPower(0); err == nil {
+		err = e
+	}
+	return
+}
+
+// Odometry returns the pose of the robot
+func (d *DifferentialDrive) Odometry() Odometry {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.odometry
+}
+
+// ResetOdometry makes the current pose the origin of the odometry
+func (d *DifferentialDrive) ResetOdometry() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.odometry = Odometry{}
+	if d.LeftEncoder != nil && d.RightEncoder != nil {
+		d.lastLeft, d.lastRight = d.LeftEncoder.Revolutions(), d.RightEncoder.Revolutions()
+	}
+	if d.Yaw != nil {
+		d.yawOffset, err = d.Yaw.Yaw()
+	}
+	return
+}
+
+// timedOut returns true if the robot moves and no command was given within
+// the timeout
+func (d *DifferentialDrive) timedOut() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	moving := d.linear != 0 || d.angular != 0
+	return moving && d.Timeout > 0 && time.Since(d.lastCommand) > d.Timeout
+}
+
+// update advances the odometry by the distance travelled within dt
+func (d *DifferentialDrive) update(dt time.Duration) (Odometry, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	distance := d.linear * dt.Seconds()
+	turn := d.angular * dt.Seconds()
+	if d.LeftEncoder != nil && d.RightEncoder != nil {
+		left, right := d.LeftEncoder.Revolutions(), d.RightEncoder.Revolutions()
+		l := (left - d.lastLeft) * math.Pi * d.WheelDiameter
+		r := (right - d.lastRight) * math.Pi * d.WheelDiameter
+		d.lastLeft, d.lastRight = left, right
+		distance, turn = (l+r)/2, (r-l)/d.TrackWidth
+	}
+
+	heading := d.odometry.Heading + turn
+	if d.Yaw != nil {
+		yaw, err := d.Yaw.Yaw()
+		if err != nil {
+			return d.odometry, err
+		}
+		heading = yaw - d.yawOffset
+	}
+	heading = math.Remainder(heading, 2*math.Pi)
+
+	// move along the average heading of the interval
+	mean := d.odometry.Heading + math.Remainder(heading-d.odometry.Heading, 2*math.Pi)/2
+	d.odometry.X += distance * math.Cos(mean)
+	d.odometry.Y += distance * math.Sin(mean)
+	d.odometry.Heading = heading
+	return d.odometry, nil
+}
@@ -0,0 +1,180 @@
+package gpio
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*DifferentialDrive)(nil)
+
+type powerMotor struct {
+	mtx   sync.Mutex
+	power float64
+	err   error
+}
+
+func (m *powerMotor) SetPower(power float64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.power = power
+	return m.err
+}
+
+func (m *powerMotor) Power() float64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.power
+}
+
+type testYawSensor struct {
+	yaw float64
+	err error
+}
+
+func (s *testYawSensor) Yaw() (float64, error) { return s.yaw, s.err }
+
+func initTestDifferentialDrive() (*DifferentialDrive, *powerMotor, *powerMotor) {
+	left, right := &powerMotor{}, &powerMotor{}
+	d := NewDifferentialDrive(left, right)
+	d.TrackWidth = 0.5
+	d.MaxSpeed = 1
+	return d, left, right
+}
+
+func assertOdometry(t *testing.T, d *DifferentialDrive, x float64, y float64, heading float64) {
+	o := d.Odometry()
+	if math.Abs(o.X-x) > 1e-6 || math.Abs(o.Y-y) > 1e-6 || math.Abs(o.Heading-heading) > 1e-6 {
+		t.Errorf("odometry %+v should be X %v Y %v heading %v", o, x, y, heading)
+	}
+}
+
+func TestDifferentialDrive(t *testing.T) {
+	d, _, _ := initTestDifferentialDrive()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "DifferentialDrive"), true)
+	gobottest.Assert(t, d.Connection(), nil)
+	gobottest.Assert(t, d.interval, 20*time.Millisecond)
+
+	d = NewDifferentialDrive(initTestMotorDriver(), initTestMotorDriver(), time.Second)
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.interval, time.Second)
+}
+
+func TestDifferentialDriveDrive(t *testing.T) {
+	d, left, right := initTestDifferentialDrive()
+
+	gobottest.Assert(t, d.Command("Drive")(map[string]interface{}{"linear": 0.5, "angular": 1.0}), nil)
+	gobottest.Assert(t, left.Power(), 0.25)
+	gobottest.Assert(t, right.Power(), 0.75)
+
+	gobottest.Assert(t, d.Command("Rotate")(map[string]interface{}{"angular": -2.0}), nil)
+	gobottest.Assert(t, left.Power(), 0.5)
+	gobottest.Assert(t, right.Power(), -0.5)
+
+	// the outer wheel is limited and the inner one slows down with it
+	gobottest.Assert(t, d.Drive(2, 4), nil)
+	gobottest.Assert(t, left.Power(), 1.0/3)
+	gobottest.Assert(t, right.Power(), 1.0)
+
+	gobottest.Assert(t, d.Command("Stop")(nil), nil)
+	gobottest.Assert(t, left.Power(), 0.0)
+	gobottest.Assert(t, right.Power(), 0.0)
+
+	right.err = errors.New("motor error")
+	gobottest.Assert(t, d.Drive(1, 0), errors.New("motor error"))
+	gobottest.Assert(t, d.Stop(), errors.New("motor error"))
+}
+
+func TestDifferentialDriveOdometry(t *testing.T) {
+	d, _, _ := initTestDifferentialDrive()
+
+	// estimated from the commanded speeds
+	d.Drive(0.1, 0)
+	d.update(time.Second)
+	assertOdometry(t, d, 0.1, 0, 0)
+	d.Rotate(math.Pi / 2)
+	d.update(time.Second)
+	assertOdometry(t, d, 0.1, 0, math.Pi/2)
+	d.Drive(0.1, 0)
+	d.update(time.Second)
+	assertOdometry(t, d, 0.1, 0.1, math.Pi/2)
+
+	gobottest.Assert(t, d.Command("ResetOdometry")(nil), nil)
+	gobottest.Assert(t, d.Command("Odometry")(nil), Odometry{})
+}
+
+func TestDifferentialDriveEncoders(t *testing.T) {
+	d, _, _ := initTestDifferentialDrive()
+	l := &countingEncoder{gpioTestAdaptor: *newGpioTestAdaptor()}
+	r := &countingEncoder{gpioTestAdaptor: *newGpioTestAdaptor()}
+	d.LeftEncoder, d.RightEncoder = NewEncoderDriver(l, "1", "2"), NewEncoderDriver(r, "3", "4")
+	d.LeftEncoder.TicksPerRevolution, d.RightEncoder.TicksPerRevolution = 1000, 1000
+	d.WheelDiameter = 1 / math.Pi
+	d.TrackWidth = 2 / math.Pi
+
+	// one revolution moves a wheel by one meter
+	d.LeftEncoder.update(1000)
+	d.RightEncoder.update(1000)
+	d.update(time.Second)
+	assertOdometry(t, d, 1, 0, 0)
+
+	// the left wheel stands while the right one turns the robot by 90 degrees
+	d.RightEncoder.update(2000)
+	d.update(time.Second)
+	assertOdometry(t, d, 1+0.5*math.Cos(math.Pi/4), 0.5*math.Sin(math.Pi/4), math.Pi/2)
+}
+
+func TestDifferentialDriveYaw(t *testing.T) {
+	d, _, _ := initTestDifferentialDrive()
+	yaw := &testYawSensor{yaw: 1}
+	d.Yaw = yaw
+	gobottest.Assert(t, d.ResetOdometry(), nil)
+
+	// the heading is measured, the distance is estimated
+	yaw.yaw = 1 + math.Pi/2
+	d.Drive(0.1, 0)
+	d.update(time.Second)
+	o := d.Odometry()
+	gobottest.Assert(t, math.Abs(o.Heading-math.Pi/2) < 1e-6, true)
+	gobottest.Assert(t, math.Abs(o.X-o.Y) < 1e-6, true)
+
+	yaw.err = errors.New("yaw error")
+	_, err := d.update(time.Second)
+	gobottest.Assert(t, err, errors.New("yaw error"))
+	gobottest.Assert(t, d.Start(), errors.New("yaw error"))
+}
+
+func TestDifferentialDriveTimeout(t *testing.T) {
+	d, left, _ := initTestDifferentialDrive()
+	d.interval = 5 * time.Millisecond
+	d.Timeout = 30 * time.Millisecond
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	sem := make(chan bool, 1)
+	d.Once(DriveTimeout, func(data interface{}) {
+		sem <- true
+	})
+	odometry := make(chan Odometry, 100)
+	d.On(DriveOdometry, func(data interface{}) {
+		odometry <- data.(Odometry)
+	})
+
+	gobottest.Assert(t, d.Drive(0.5, 0), nil)
+	time.Sleep(10 * time.Millisecond)
+	gobottest.Assert(t, left.Power(), 0.5)
+
+	select {
+	case <-sem:
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Timeout was not published")
+	}
+	gobottest.Assert(t, left.Power(), 0.0)
+	gobottest.Assert(t, len(odometry) > 0, true)
+}
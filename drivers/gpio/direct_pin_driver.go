@@ -62,11 +62,17 @@ func (d *DirectPinDriver) Pin() string { return d.pin }
 // Connection returns the DirectPinDrivers Connection
 func (d *DirectPinDriver) Connection() gobot.Connection { return d.connection }
 
-// Start implements the Driver interface
-func (d *DirectPinDriver) Start() (err error) { return }
+// Start implements the Driver interface and claims the pin
+func (d *DirectPinDriver) Start() (err error) {
+	return gobot.ClaimResources(d.Connection(), d, gobot.PinResource, d.pin)
+}
 
-// Halt implements the Driver interface
-func (d *DirectPinDriver) Halt() (err error) { return }
+// Halt implements the Driver interface and releases the pin and its PWM
+// output
+func (d *DirectPinDriver) Halt() (err error) {
+	gobot.ReleaseResources(d.Connection(), d)
+	return
+}
 
 // Turn Off pin
 func (d *DirectPinDriver) Off() (err error) {
@@ -104,18 +110,26 @@ func (d *DirectPinDriver) DigitalWrite(level byte) (err error) {
 	return
 }
 
-// PwmWrite writes the 0-254 value to the specified pin
+// PwmWrite writes the 0-254 value to the specified pin. The PWM output of
+// the pin is claimed until Halt.
 func (d *DirectPinDriver) PwmWrite(level byte) (err error) {
 	if writer, ok := d.Connection().(PwmWriter); ok {
+		if err = gobot.ClaimResources(d.Connection(), d, gobot.PwmResource, d.pin); err != nil {
+			return
+		}
 		return writer.PwmWrite(d.Pin(), level)
 	}
 	err = ErrPwmWriteUnsupported
 	return
 }
 
-// ServoWrite writes value to the specified pin
+// ServoWrite writes value to the specified pin. The PWM output of the pin
+// is claimed until Halt.
 func (d *DirectPinDriver) ServoWrite(level byte) (err error) {
 	if writer, ok := d.Connection().(ServoWriter); ok {
+		if err = gobot.ClaimResources(d.Connection(), d, gobot.PwmResource, d.pin); err != nil {
+			return
+		}
 		return writer.ServoWrite(d.Pin(), level)
 	}
 	err = ErrServoWriteUnsupported
@@ -0,0 +1,332 @@
+package gpio
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// quadratureSteps holds the change of the count for a transition from the
+// previous to the current state of the A and B channels, indexed by
+// previous<<2 | current with the state A<<1 | B. Transitions skipping a
+// state can not tell the direction and are not counted.
+var quadratureSteps = [16]int{0, 1, -1, 0, -1, 0, 0, 1, 1, 0, 0, -1, 0, -1, 1, 0}
+
+// EncoderDriver represents a quadrature encoder, e.g. on the shaft of a DC
+// motor. It counts a tick on every edge of its A and B channels, so an
+// encoder with N pulses per revolution makes 4N ticks per revolution.
+//
+// On adaptors which count the ticks themselves, such as Firmata with the
+// encoder feature, the count is read from the adaptor. On adaptors which
+// report the changes of their pins, such as Firmata without it, the edges are counted
+// as they are reported. Otherwise the edges are detected by polling both
+// pins, which misses edges less than the polling interval apart, so at the
+// default interval of 1 Millisecond the encoder must not make more than
+// 1000 ticks per second.
+type EncoderDriver struct {
+	name       string
+	pinA       string
+	pinB       string
+	connection DigitalReader
+	interval   time.Duration
+	halt       chan bool
+	unwatch    func()
+	// TicksPerRevolution is the number of ticks of a turn of the shaft
+	TicksPerRevolution float64
+	// VelocityWindow is the time over which the velocity is measured
+	VelocityWindow time.Duration
+
+	mutex    *sync.Mutex
+	ticks    int
+	offset   int
+	velocity float64 // in ticks per second
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewEncoderDriver returns a new EncoderDriver with a polling interval of
+// 1 Millisecond given a DigitalReader and the pins of the A and B channels.
+//
+// Optionally accepts:
+//  time.Duration: Interval at which the EncoderDriver is polled for new information,
+//  and at which the ticks counted from the reported edges are published
+//
+// Adds the following API Commands:
+//	"Ticks" - See EncoderDriver.Ticks
+//	"Velocity" - See EncoderDriver.Velocity
+//	"RPM" - See EncoderDriver.RPM
+//	"Reset" - See EncoderDriver.Reset
+func NewEncoderDriver(a DigitalReader, pinA string, pinB string, v ...time.Duration) *EncoderDriver {
+	e := &EncoderDriver{
+		name:               gobot.DefaultName("Encoder"),
+		connection:         a,
+		pinA:               pinA,
+		pinB:               pinB,
+		interval:           1 * time.Millisecond,
+		halt:               make(chan bool),
+		TicksPerRevolution: 4,
+		VelocityWindow:     100 * time.Millisecond,
+		mutex:              &sync.Mutex{},
+		Eventer:            gobot.NewEventer(),
+		Commander:          gobot.NewCommander(),
+	}
+
+	if len(v) > 0 {
+		e.interval = v[0]
+	}
+
+	e.AddEvent(EncoderTicks)
+	e.AddEvent(EncoderVelocity)
+	e.AddEvent(Error)
+
+	e.AddCommand("Ticks", func(params map[string]interface{}) interface{} {
+		return e.Ticks()
+	})
+	e.AddCommand("Velocity", func(params map[string]interface{}) interface{} {
+		return e.Velocity()
+	})
+	e.AddCommand("RPM", func(params map[string]interface{}) interface{} {
+		return e.RPM()
+	})
+	e.AddCommand("Reset", func(params map[string]interface{}) interface{} {
+		e.Reset()
+		return nil
+	})
+
+	return e
+}
+
+// Start starts the EncoderDriver and counts the ticks of the encoder.
+//
+// Emits the Events:
+//	Ticks int - When the count changes, with the count
+//	Velocity float64 - Every VelocityWindow, with the ticks per second
+//	Error error - On encoder error
+func (e *EncoderDriver) Start() (err error) {
+	if err = gobot.ClaimResources(e.Connection(), e, gobot.PinResource, e.pinA, e.pinB); err != nil {
+		return
+	}
+
+	var read func() (int, error)
+	count := 0
+	if reader, ok := e.connection.(EncoderReader); ok {
+		read = func() (int, error) { return reader.EncoderRead(e.pinA, e.pinB) }
+		if count, err = read(); err == ErrEncoderReadUnsupported {
+			read, err = nil, nil
+		}
+	}
+	if read == nil {
+		if watcher, ok := e.connection.(DigitalPinWatcher); ok {
+			read, err = e.watch(watcher)
+		} else {
+			read = e.decode()
+		}
+		if err == nil {
+			count, err = read()
+		}
+	}
+	if err != nil {
+		e.stopWatching()
+		gobot.ReleaseResources(e.Connection(), e)
+		return
+	}
+	e.mutex.Lock()
+	e.offset = count - e.ticks
+	e.mutex.Unlock()
+
+	go func() {
+		windowTicks, windowStart := e.Ticks(), time.Now()
+		for {
+			select {
+			case <-time.After(e.interval):
+			case <-e.halt:
+				return
+			}
+
+			if count, err := read(); err != nil {
+				e.Publish(Error, err)
+			} else {
+				e.update(count)
+			}
+
+			if elapsed := time.Since(windowStart); elapsed >= e.VelocityWindow {
+				ticks := e.Ticks()
+				velocity := float64(ticks-windowTicks) / elapsed.Seconds()
+				e.mutex.Lock()
+				e.velocity = velocity
+				e.mutex.Unlock()
+				e.Publish(EncoderVelocity, velocity)
+				windowTicks, windowStart = ticks, time.Now()
+			}
+		}
+	}()
+	return
+}
+
+// Halt stops counting the ticks of the encoder
+func (e *EncoderDriver) Halt() (err error) {
+	e.halt <- true
+	e.stopWatching()
+	gobot.ReleaseResources(e.Connection(), e)
+	return
+}
+
+// Name returns the EncoderDrivers name
+func (e *EncoderDriver) Name() string { return e.name }
+
+// SetName sets the EncoderDrivers name
+func (e *EncoderDriver) SetName(n string) { e.name = n }
+
+// Pins returns the pins of the A and B channels
+func (e *EncoderDriver) Pins() (string, string) { return e.pinA, e.pinB }
+
+// Connection returns the EncoderDrivers Connection
+func (e *EncoderDriver) Connection() gobot.Connection { return e.connection.(gobot.Connection) }
+
+// Ticks returns the number of ticks counted since the start or the last reset
+func (e *EncoderDriver) Ticks() int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.ticks
+}
+
+// Revolutions returns the number of turns of the shaft since the start or
+// the last reset
+func (e *EncoderDriver) Revolutions() float64 {
+	return float64(e.Ticks()) / e.TicksPerRevolution
+}
+
+// Velocity returns the ticks per second measured over the last VelocityWindow
+func (e *EncoderDriver) Velocity() float64 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.velocity
+}
+
+// RPM returns the revolutions per minute measured over the last
+// VelocityWindow
+func (e *EncoderDriver) RPM() float64 {
+	return e.Velocity() * 60 / e.TicksPerRevolution
+}
+
+// Reset sets the count of ticks to 0
+func (e *EncoderDriver) Reset() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.offset += e.ticks
+	e.ticks = 0
+}
+
+// update sets the ticks from the count of the encoder and publishes them
+// when they changed
+func (e *EncoderDriver) update(count int) {
+	e.mutex.Lock()
+	ticks := count - e.offset
+	changed := ticks != e.ticks
+	e.ticks = ticks
+	e.mutex.Unlock()
+
+	if changed {
+		e.Publish(EncoderTicks, ticks)
+	}
+}
+
+// quadrature counts the edges of the A and B channels
+type quadrature struct {
+	state int // A<<1 | B, or -1 before the first levels
+	count int
+}
+
+// step moves to the levels of the channels and returns the count
+func (q *quadrature) step(a int, b int) int {
+	current := a&1<<1 | b&1
+	if q.state >= 0 {
+		q.count += quadratureSteps[q.state<<2|current]
+	}
+	q.state = current
+	return q.count
+}
+
+// decode returns a function which reads both channels and returns the count
+// of the edges between its calls
+func (e *EncoderDriver) decode() func() (int, error) {
+	q := &quadrature{state: -1}
+	return func() (int, error) {
+		a, err := e.connection.DigitalRead(e.pinA)
+		if err != nil {
+			return q.count, err
+		}
+		b, err := e.connection.DigitalRead(e.pinB)
+		if err != nil {
+			return q.count, err
+		}
+		if a < 0 || b < 0 {
+			// no value is available yet
+			return q.count, nil
+		}
+		return q.step(a, b), nil
+	}
+}
+
+// watch counts the edges of both channels reported by the watcher, and
+// returns a function which returns the count, which is published at the
+// polling interval
+func (e *EncoderDriver) watch(watcher DigitalPinWatcher) (func() (int, error), error) {
+	q := &quadrature{state: -1}
+	mutex := &sync.Mutex{}
+	a, b := -1, -1
+	handler := func(pin string, level int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if pin == e.pinA {
+			a = level
+		} else {
+			b = level
+		}
+		if a >= 0 && b >= 0 {
+			q.step(a, b)
+		}
+	}
+
+	unwatch, err := watcher.WatchDigitalPins(handler, e.pinA, e.pinB)
+	if err != nil {
+		return nil, err
+	}
+	e.unwatch = unwatch
+
+	// the levels before the first change
+	levelA, err := e.connection.DigitalRead(e.pinA)
+	if err != nil {
+		return nil, err
+	}
+	levelB, err := e.connection.DigitalRead(e.pinB)
+	if err != nil {
+		return nil, err
+	}
+	mutex.Lock()
+	if a < 0 {
+		a = levelA
+	}
+	if b < 0 {
+		b = levelB
+	}
+	if a >= 0 && b >= 0 && q.state < 0 {
+		q.step(a, b)
+	}
+	mutex.Unlock()
+
+	return func() (int, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return q.count, nil
+	}, nil
+}
+
+// stopWatching stops the watch of the channels, if they are watched
+func (e *EncoderDriver) stopWatching() {
+	if e.unwatch != nil {
+		e.unwatch()
+		e.unwatch = nil
+	}
+}
@@ -0,0 +1,265 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*EncoderDriver)(nil)
+
+// quadratureEncoder simulates the channels of an encoder at a position
+type quadratureEncoder struct {
+	gpioTestBareAdaptor
+	mtx      sync.Mutex
+	position int
+	reads    int
+	err      error
+}
+
+func (q *quadratureEncoder) turn(ticks int) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.position += ticks
+}
+
+// turnPolled turns the encoder one tick at a time, each once the last one
+// was polled and counted
+func (q *quadratureEncoder) turnPolled(ticks int) {
+	step := 1
+	if ticks < 0 {
+		step = -1
+	}
+	for i := 0; i != ticks; i += step {
+		q.mtx.Lock()
+		q.position += step
+		// the next full poll reads both channels after the turn and counts
+		// it before the one after starts reading
+		polled := q.reads + 5
+		q.mtx.Unlock()
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			q.mtx.Lock()
+			reads := q.reads
+			q.mtx.Unlock()
+			if reads >= polled || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(100 * time.Microsecond)
+		}
+	}
+}
+
+func (q *quadratureEncoder) DigitalRead(pin string) (int, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.reads++
+	// the channels go through the states 00, 01, 11, 10
+	state := []int{0, 1, 3, 2}[(q.position%4+4)%4]
+	if pin == "1" {
+		return state >> 1, q.err
+	}
+	return state & 1, q.err
+}
+
+// countingEncoder is an adaptor counting the ticks of an encoder itself
+type countingEncoder struct {
+	gpioTestAdaptor
+	ticks int
+}
+
+func (c *countingEncoder) EncoderRead(pinA string, pinB string) (int, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.ticks, nil
+}
+
+// watchedEncoder is an adaptor reporting the changes of the channels of an
+// encoder
+type watchedEncoder struct {
+	quadratureEncoder
+	handler func(pin string, level int)
+	stopped bool
+}
+
+func (w *watchedEncoder) WatchDigitalPins(handler func(pin string, level int), pins ...string) (func(), error) {
+	w.handler = handler
+	return func() { w.stopped = true }, w.err
+}
+
+// turn moves the encoder by the ticks, faster than it could be polled
+func (w *watchedEncoder) turn(ticks int) {
+	for i := 0; i != ticks; {
+		step := 1
+		if ticks < 0 {
+			step = -1
+		}
+		upper := w.position + (step+1)/2
+		w.quadratureEncoder.turn(step)
+		i += step
+		a, _ := w.DigitalRead("1")
+		b, _ := w.DigitalRead("2")
+		if (upper%2+2)%2 == 1 {
+			// the B channel changes between the states 00 and 01, and 11 and 10
+			w.handler("2", b)
+		} else {
+			w.handler("1", a)
+		}
+	}
+}
+
+func TestEncoderDriver(t *testing.T) {
+	d := NewEncoderDriver(&quadratureEncoder{}, "1", "2", 5*time.Millisecond)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "Encoder"), true)
+	a, b := d.Pins()
+	gobottest.Assert(t, a, "1")
+	gobottest.Assert(t, b, "2")
+	gobottest.Assert(t, d.interval, 5*time.Millisecond)
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.Command("Ticks")(nil), 0)
+	gobottest.Assert(t, d.Command("RPM")(nil), 0.0)
+}
+
+func TestEncoderDriverCount(t *testing.T) {
+	q := &quadratureEncoder{}
+	d := NewEncoderDriver(q, "1", "2")
+	d.TicksPerRevolution = 8
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	ticks := make(chan int, 100)
+	d.On(EncoderTicks, func(data interface{}) {
+		ticks <- data.(int)
+	})
+
+	// one edge at a time in both directions
+	q.turnPolled(6)
+	q.turnPolled(-2)
+	gobottest.Assert(t, d.Ticks(), 4)
+	gobottest.Assert(t, d.Revolutions(), 0.5)
+
+	select {
+	case n := <-ticks:
+		gobottest.Assert(t, n, 1)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Ticks was not published")
+	}
+
+	d.Command("Reset")(nil)
+	gobottest.Assert(t, d.Ticks(), 0)
+	q.turnPolled(-1)
+	gobottest.Assert(t, d.Ticks(), -1)
+}
+
+func TestEncoderDriverWatch(t *testing.T) {
+	w := &watchedEncoder{}
+	d := NewEncoderDriver(w, "1", "2", 5*time.Millisecond)
+	gobottest.Assert(t, d.Start(), nil)
+
+	ticks := make(chan int, 100)
+	d.On(EncoderTicks, func(data interface{}) {
+		ticks <- data.(int)
+	})
+
+	w.turn(1000)
+	w.turn(-3)
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, d.Ticks(), 997)
+
+	select {
+	case n := <-ticks:
+		gobottest.Assert(t, n, 997)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Ticks was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, w.stopped, true)
+}
+
+func TestEncoderDriverWatchError(t *testing.T) {
+	w := &watchedEncoder{}
+	w.err = errors.New("watch error")
+	d := NewEncoderDriver(w, "1", "2")
+	gobottest.Assert(t, d.Start(), errors.New("watch error"))
+	gobottest.Assert(t, w.stopped, false)
+}
+
+// unsupportedEncoder is an adaptor which can not count the ticks of an
+// encoder at its pins
+type unsupportedEncoder struct {
+	quadratureEncoder
+}
+
+func (u *unsupportedEncoder) EncoderRead(pinA string, pinB string) (int, error) {
+	return 0, ErrEncoderReadUnsupported
+}
+
+func TestEncoderDriverEncoderReadUnsupported(t *testing.T) {
+	u := &unsupportedEncoder{}
+	d := NewEncoderDriver(u, "1", "2")
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	u.turnPolled(1)
+	gobottest.Assert(t, d.Ticks(), 1)
+}
+
+func TestEncoderDriverVelocity(t *testing.T) {
+	c := &countingEncoder{gpioTestAdaptor: *newGpioTestAdaptor()}
+	d := NewEncoderDriver(c, "1", "2")
+	d.VelocityWindow = 20 * time.Millisecond
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	velocity := make(chan float64, 100)
+	d.On(EncoderVelocity, func(data interface{}) {
+		velocity <- data.(float64)
+	})
+
+	// the count of the adaptor is taken as it is, from its value at the start
+	c.mtx.Lock()
+	c.ticks = 40
+	c.mtx.Unlock()
+	time.Sleep(50 * time.Millisecond)
+	gobottest.Assert(t, d.Ticks(), 40)
+
+	select {
+	case v := <-velocity:
+		gobottest.Assert(t, v > 0, true)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Velocity was not published")
+	}
+	time.Sleep(50 * time.Millisecond)
+	gobottest.Assert(t, d.RPM(), 0.0)
+}
+
+func TestEncoderDriverError(t *testing.T) {
+	q := &quadratureEncoder{err: errors.New("read error")}
+	d := NewEncoderDriver(q, "1", "2")
+	gobottest.Assert(t, d.Start(), errors.New("read error"))
+
+	q.err = nil
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	sem := make(chan bool, 1)
+	d.Once(Error, func(data interface{}) {
+		sem <- true
+	})
+	q.mtx.Lock()
+	q.err = errors.New("read error")
+	q.mtx.Unlock()
+
+	select {
+	case <-sem:
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Error was not published")
+	}
+}
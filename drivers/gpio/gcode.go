@@ -0,0 +1,110 @@
+package gpio
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrGCodeChecksum is the error resulting when the checksum of a line of
+// G-code does not match its contents
+var ErrGCodeChecksum = errors.New("G-code checksum mismatch")
+
+// GCodeBlock is a parsed line of G-code
+type GCodeBlock struct {
+	// LineNumber is the N word of the line, -1 if it has none
+	LineNumber int
+	// Codes are the G and M codes of the line in order, e.g. "G1" or "M3"
+	Codes []string
+	// Params are the other words of the line by letter, e.g. "X" or "F"
+	Params map[string]float64
+}
+
+// Has returns true if the block contains the code
+func (b *GCodeBlock) Has(code string) bool {
+	for _, c := range b.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Param returns the value of the param, or def if the block does not have it
+func (b *GCodeBlock) Param(letter string, def float64) float64 {
+	if v, ok := b.Params[letter]; ok {
+		return v
+	}
+	return def
+}
+
+// ParseGCode parses a line of G-code. Comments in parentheses or after a
+// semicolon are ignored, as are the line number and the checksum after an
+// asterisk, which is checked when present. Codes are normalized, so "G01"
+// becomes "G1".
+func ParseGCode(line string) (*GCodeBlock, error) {
+	b := &GCodeBlock{LineNumber: -1, Params: map[string]float64{}}
+
+	if i := strings.IndexByte(line, ';'); i >= 0 {
+		line = line[:i]
+	}
+	if i := strings.IndexByte(line, '*'); i >= 0 {
+		checksum, err := strconv.Atoi(strings.TrimSpace(line[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid G-code checksum %q", line[i+1:])
+		}
+		sum := 0
+		for _, c := range []byte(line[:i]) {
+			sum ^= int(c)
+		}
+		if sum != checksum {
+			return nil, ErrGCodeChecksum
+		}
+		line = line[:i]
+	}
+
+	line = strings.ToUpper(line)
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '%':
+			i++
+			continue
+		case c == '(':
+			end := strings.IndexByte(line[i:], ')')
+			if end < 0 {
+				return nil, errors.New("Unterminated G-code comment")
+			}
+			i += end + 1
+			continue
+		case c < 'A' || c > 'Z':
+			return nil, fmt.Errorf("Invalid G-code character %q", c)
+		}
+
+		// the number may be separated from its letter by spaces
+		i++
+		for i < len(line) && line[i] == ' ' {
+			i++
+		}
+		start := i
+		for i < len(line) && strings.IndexByte("+-.0123456789", line[i]) >= 0 {
+			i++
+		}
+		value, err := strconv.ParseFloat(line[start:i], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid G-code word %q", line[start-1:i])
+		}
+
+		letter := string(c)
+		switch letter {
+		case "N":
+			b.LineNumber = int(value)
+		case "G", "M":
+			b.Codes = append(b.Codes, letter+strconv.FormatFloat(value, 'f', -1, 64))
+		default:
+			b.Params[letter] = value
+		}
+	}
+	return b, nil
+}
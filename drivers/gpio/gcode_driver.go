@@ -0,0 +1,785 @@
+package gpio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// gcodeLookaheadDelay is the time a move waits for following moves to be
+// queued before it is executed, unless the lookahead buffer is full
+const gcodeLookaheadDelay = 50 * time.Millisecond
+
+var (
+	// ErrGCodeNotStarted is the error resulting when G-code is executed
+	// before the driver is started
+	ErrGCodeNotStarted = errors.New("G-code driver is not started")
+	// ErrGCodeArcAxes is the error resulting when an arc is executed by a
+	// machine without X and Y axes
+	ErrGCodeArcAxes = errors.New("G-code arcs need X and Y axes")
+	// ErrGCodeArcRadius is the error resulting when the radius of an arc is
+	// too small to reach its end
+	ErrGCodeArcRadius = errors.New("G-code arc radius is too small")
+)
+
+// GCodeAxis is an axis of a machine driven by a GCodeDriver
+type GCodeAxis struct {
+	// Name is the letter of the axis in G-code, e.g. "X"
+	Name string
+	// Motor is the stepper motor which moves the axis. Its Speed and
+	// Acceleration limit the moves of the axis.
+	Motor *StepperMotorDriver
+	// UnitsPerTurn is the distance in millimeters the axis moves per turn of
+	// the motor
+	UnitsPerTurn float64
+}
+
+// GCodeTool is the tool of a machine driven by a GCodeDriver, e.g. the pen
+// of a plotter or a spindle, which is switched by M3 and M5
+type GCodeTool interface {
+	// ToolOn switches the tool on with the power of the S word
+	ToolOn(power float64) error
+	// ToolOff switches the tool off
+	ToolOff() error
+}
+
+// GCodeServoTool is a tool moved by a servo, e.g. to lower and lift the pen
+// of a plotter
+type GCodeServoTool struct {
+	Servo *ServoDriver
+	// OnAngle and OffAngle are the angles of the servo when the tool is on
+	// and off
+	OnAngle  float64
+	OffAngle float64
+	// Duration is the time the servo takes to move between the angles
+	Duration time.Duration
+}
+
+// ToolOn moves the servo to OnAngle
+func (t *GCodeServoTool) ToolOn(power float64) error {
+	return t.Servo.MoveTo(t.OnAngle, t.Duration)
+}
+
+// ToolOff moves the servo to OffAngle
+func (t *GCodeServoTool) ToolOff() error {
+	return t.Servo.MoveTo(t.OffAngle, t.Duration)
+}
+
+// GCodeRelayTool is a tool switched by a relay, e.g. a spindle or a laser
+type GCodeRelayTool struct {
+	Relay *RelayDriver
+}
+
+// ToolOn switches the relay on
+func (t *GCodeRelayTool) ToolOn(power float64) error { return t.Relay.On() }
+
+// ToolOff switches the relay off
+func (t *GCodeRelayTool) ToolOff() error { return t.Relay.Off() }
+
+// gcodeStep is a queued move or action of a GCodeDriver
+type gcodeStep struct {
+	target       []float64 // machine position in millimeters
+	unit         []float64 // direction of the move
+	length       float64   // in millimeters
+	maxSpeed     float64   // in millimeters per second
+	acceleration float64   // in millimeters per second squared, 0 for none
+	maxEntry     float64   // highest speed at the junction with the previous move
+	action       func(stop chan bool) error
+}
+
+// GCodeDriver interprets G-code and drives the stepper motors of the axes of
+// a machine, e.g. a pen plotter or a small CNC mill, and its tool. Moves are
+// queued and planned with lookahead, so that they blend into each other
+// without stopping at every junction.
+//
+// The following subset of G-code is supported:
+//	G0, G1 - rapid and linear moves
+//	G2, G3 - clockwise and counterclockwise arcs in the XY plane with I and J or R
+//	G4 - dwell for P milliseconds or S seconds
+//	G17, G94 - XY plane and feed per minute, which are the only modes
+//	G20, G21 - inches and millimeters
+//	G28 - home the given axes, or all of them, to their min endstops
+//	G90, G91 - absolute and relative positions
+//	G92 - set the current position
+//	M3, M5 - tool on with power S and tool off
+//	M2, M30 - end of program
+type GCodeDriver struct {
+	name string
+	Axes []GCodeAxis
+	// Tool is switched by M3 and M5, which are ignored if it is nil
+	Tool GCodeTool
+	// Feed is the feed rate in millimeters per minute until an F word is given
+	Feed float64
+	// RapidFeed is the feed rate of G0 in millimeters per minute, 0 moves as
+	// fast as the motors allow
+	RapidFeed float64
+	// Acceleration is the acceleration in millimeters per second squared, 0
+	// accelerates as fast as the motors allow
+	Acceleration float64
+	// JunctionDeviation in millimeters controls the speed at corners, the
+	// higher the faster
+	JunctionDeviation float64
+	// ArcSegmentLength is the length in millimeters of the lines arcs are
+	// made of
+	ArcSegmentLength float64
+	// BufferSize is the number of moves queued for the lookahead
+	BufferSize int
+
+	coordinator *StepperCoordinator
+	mutex       *sync.Mutex
+	cond        *sync.Cond
+	signal      chan bool
+	halt        chan bool
+	stop        chan bool
+	running     bool
+	queue       []*gcodeStep
+	queued      time.Time // time the last step was queued
+	pending     int       // steps queued or executing
+	err         error
+	resync      bool
+
+	// the state of the interpreter, positions are in machine millimeters
+	execMutex *sync.Mutex
+	absolute  bool
+	inches    bool
+	motion    string
+	feed      float64
+	position  []float64
+	offset    []float64
+	last      *gcodeStep // last queued move, nil after an action
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewGCodeDriver returns a new GCodeDriver of the axes
+//
+// Adds the following API Commands:
+//	"GCode" - See GCodeDriver.Execute, with the "code" param
+//	"Stop" - See GCodeDriver.Stop
+//	"Position" - See GCodeDriver.Position
+func NewGCodeDriver(axes ...GCodeAxis) *GCodeDriver {
+	d := &GCodeDriver{
+		name:              gobot.DefaultName("GCode"),
+		Axes:              axes,
+		Feed:              1000,
+		JunctionDeviation: 0.05,
+		ArcSegmentLength:  0.5,
+		BufferSize:        16,
+		mutex:             &sync.Mutex{},
+		signal:            make(chan bool, 1),
+		execMutex:         &sync.Mutex{},
+		absolute:          true,
+		motion:            "G0",
+		Eventer:           gobot.NewEventer(),
+		Commander:         gobot.NewCommander(),
+	}
+	d.cond = sync.NewCond(d.mutex)
+
+	d.AddEvent(Error)
+
+	d.AddCommand("GCode", func(params map[string]interface{}) interface{} {
+		code, _ := params["code"].(string)
+		return d.Execute(code)
+	})
+	d.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		d.Stop()
+		return nil
+	})
+	d.AddCommand("Position", func(params map[string]interface{}) interface{} {
+		return d.Position()
+	})
+
+	return d
+}
+
+// Name returns the name of the driver
+func (d *GCodeDriver) Name() string { return d.name }
+
+// SetName sets the name of the driver
+func (d *GCodeDriver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the motor of the first axis
+func (d *GCodeDriver) Connection() gobot.Connection {
+	if len(d.Axes) == 0 {
+		return nil
+	}
+	return d.Axes[0].Motor.Connection()
+}
+
+// Start starts executing queued G-code
+func (d *GCodeDriver) Start() (err error) {
+	motors := []*StepperMotorDriver{}
+	for _, axis := range d.Axes {
+		motors = append(motors, axis.Motor)
+	}
+
+	d.execMutex.Lock()
+	d.syncPosition()
+	d.execMutex.Unlock()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.coordinator = NewStepperCoordinator(motors...)
+	d.halt = make(chan bool)
+	d.stop = make(chan bool)
+	d.running = true
+	go d.run(d.halt)
+	return
+}
+
+// Halt stops the running move and drops the queued G-code
+func (d *GCodeDriver) Halt() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if !d.running {
+		return
+	}
+	d.running = false
+	close(d.halt)
+	d.stopQueue()
+	return
+}
+
+// Stop stops the running move immediately and drops the queued G-code. Homing
+// is not interrupted.
+func (d *GCodeDriver) Stop() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.stopQueue()
+}
+
+// Wait blocks until the queued G-code has been executed, and returns the
+// error which stopped it, if any
+func (d *GCodeDriver) Wait() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for d.pending > 0 && d.running {
+		d.cond.Wait()
+	}
+	err := d.err
+	d.err = nil
+	return err
+}
+
+// Position returns the current position of every axis in millimeters
+func (d *GCodeDriver) Position() map[string]float64 {
+	position := map[string]float64{}
+	for _, axis := range d.Axes {
+		position[axis.Name] = axis.Motor.Position() * axis.UnitsPerTurn / 360
+	}
+	return position
+}
+
+// Execute interprets lines of G-code and queues their moves and actions. It
+// blocks while the lookahead buffer is full. An error of G-code which was
+// executed before is returned instead of executing the lines.
+func (d *GCodeDriver) Execute(code string) error {
+	d.execMutex.Lock()
+	defer d.execMutex.Unlock()
+
+	d.mutex.Lock()
+	running, err, resync := d.running, d.err, d.resync
+	d.err, d.resync = nil, false
+	d.mutex.Unlock()
+	if !running {
+		return ErrGCodeNotStarted
+	}
+	if resync {
+		d.syncPosition()
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(code, "\n") {
+		b, err := ParseGCode(line)
+		if err != nil {
+			return err
+		}
+		if err = d.interpret(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Serve executes G-code streamed line by line, as sent to a serial port by
+// G-code senders. Every line is answered with "ok", or "error: " and the
+// error, once it is queued. A line of "?" is answered with the status and
+// the position, e.g. "<Idle|MPos:10.000,0.000>".
+func (d *GCodeDriver) Serve(rw io.ReadWriter) error {
+	scanner := bufio.NewScanner(rw)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		reply := "ok"
+		if line == "?" {
+			reply = d.status()
+		} else if err := d.Execute(line); err != nil {
+			reply = "error: " + err.Error()
+		}
+		if _, err := io.WriteString(rw, reply+"\n"); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (d *GCodeDriver) status() string {
+	d.mutex.Lock()
+	state := "Idle"
+	if d.pending > 0 {
+		state = "Run"
+	}
+	d.mutex.Unlock()
+
+	values := []string{}
+	position := d.Position()
+	for _, axis := range d.Axes {
+		values = append(values, fmt.Sprintf("%.3f", position[axis.Name]))
+	}
+	return fmt.Sprintf("<%v|MPos:%v>", state, strings.Join(values, ","))
+}
+
+// interpret queues the moves and actions of a block. Modes are set first,
+// then the tool is switched, and then the block dwells, homes, sets the
+// position or moves.
+func (d *GCodeDriver) interpret(b *GCodeBlock) (err error) {
+	for _, code := range b.Codes {
+		switch code {
+		case "G0", "G1", "G2", "G3":
+			d.motion = code
+		case "G20":
+			d.inches = true
+		case "G21":
+			d.inches = false
+		case "G90":
+			d.absolute = true
+		case "G91":
+			d.absolute = false
+		case "G4", "G17", "G28", "G92", "G94", "M2", "M3", "M5", "M30":
+		default:
+			return fmt.Errorf("Unsupported G-code %v", code)
+		}
+	}
+	if f, ok := b.Params["F"]; ok {
+		d.feed = f * d.scale()
+	}
+
+	if d.Tool != nil && b.Has("M3") {
+		power := b.Param("S", 0)
+		if err = d.queueAction(func(chan bool) error { return d.Tool.ToolOn(power) }); err != nil {
+			return
+		}
+	}
+	if d.Tool != nil && b.Has("M5") {
+		if err = d.queueAction(func(chan bool) error { return d.Tool.ToolOff() }); err != nil {
+			return
+		}
+	}
+
+	switch {
+	case b.Has("G4"):
+		return d.queueDwell(b)
+	case b.Has("G28"):
+		return d.queueHoming(b)
+	case b.Has("G92"):
+		for i, axis := range d.Axes {
+			if v, ok := b.Params[axis.Name]; ok {
+				d.offset[i] = d.position[i] - v*d.scale()
+			}
+		}
+		return
+	}
+
+	target, moved := d.target(b)
+	switch d.motion {
+	case "G0", "G1":
+		if moved {
+			return d.queueLine(target, d.motion == "G0")
+		}
+	case "G2", "G3":
+		_, i := b.Params["I"]
+		_, j := b.Params["J"]
+		_, r := b.Params["R"]
+		if moved || i || j || r {
+			return d.queueArc(b, target)
+		}
+	}
+	return
+}
+
+// target returns the machine position of the axis words of a block, and if
+// the block has any
+func (d *GCodeDriver) target(b *GCodeBlock) ([]float64, bool) {
+	target := make([]float64, len(d.Axes))
+	moved := false
+	for i, axis := range d.Axes {
+		target[i] = d.position[i]
+		if v, ok := b.Params[axis.Name]; ok {
+			moved = true
+			if d.absolute {
+				target[i] = v*d.scale() + d.offset[i]
+			} else {
+				target[i] += v * d.scale()
+			}
+		}
+	}
+	return target, moved
+}
+
+// scale returns the millimeters per unit of the G-code
+func (d *GCodeDriver) scale() float64 {
+	if d.inches {
+		return 25.4
+	}
+	return 1
+}
+
+func (d *GCodeDriver) queueDwell(b *GCodeBlock) error {
+	t := time.Duration(b.Param("P", 0)*float64(time.Millisecond)) +
+		time.Duration(b.Param("S", 0)*float64(time.Second))
+	return d.queueAction(func(stop chan bool) error {
+		select {
+		case <-time.After(t):
+		case <-stop:
+		}
+		return nil
+	})
+}
+
+func (d *GCodeDriver) queueHoming(b *GCodeBlock) error {
+	axes := []int{}
+	for i, axis := range d.Axes {
+		if _, ok := b.Params[axis.Name]; ok {
+			axes = append(axes, i)
+		}
+	}
+	if len(axes) == 0 {
+		for i := range d.Axes {
+			axes = append(axes, i)
+		}
+	}
+	for _, i := range axes {
+		d.position[i] = 0
+	}
+	return d.queueAction(func(chan bool) error {
+		for _, i := range axes {
+			if err := d.Axes[i].Motor.Min(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// queueArc queues the lines an arc from the current position to the target
+// is made of, around the center given by I and J or by the radius R
+func (d *GCodeDriver) queueArc(b *GCodeBlock, target []float64) error {
+	x, y := d.axis("X"), d.axis("Y")
+	if x < 0 || y < 0 {
+		return ErrGCodeArcAxes
+	}
+	start := d.position
+	clockwise := d.motion == "G2"
+
+	var cx, cy float64
+	if r, ok := b.Params["R"]; ok {
+		r *= d.scale()
+		dx, dy := target[x]-start[x], target[y]-start[y]
+		h := 4*r*r - dx*dx - dy*dy
+		if h < 0 || (dx == 0 && dy == 0) {
+			return ErrGCodeArcRadius
+		}
+		// the center is on the bisector of the chord, on the side given by
+		// the direction and the sign of the radius
+		h = -math.Sqrt(h) / math.Hypot(dx, dy)
+		if !clockwise {
+			h = -h
+		}
+		if r < 0 {
+			h = -h
+		}
+		cx, cy = start[x]+(dx-dy*h)/2, start[y]+(dy+dx*h)/2
+	} else {
+		cx = start[x] + b.Param("I", 0)*d.scale()
+		cy = start[y] + b.Param("J", 0)*d.scale()
+	}
+
+	radius := math.Hypot(start[x]-cx, start[y]-cy)
+	from := math.Atan2(start[y]-cy, start[x]-cx)
+	sweep := math.Atan2(target[y]-cy, target[x]-cx) - from
+	if clockwise && sweep >= 0 {
+		sweep -= 2 * math.Pi
+	} else if !clockwise && sweep <= 0 {
+		sweep += 2 * math.Pi
+	}
+
+	segments := int(math.Ceil(math.Abs(sweep) * radius / d.ArcSegmentLength))
+	if segments < 1 {
+		segments = 1
+	}
+	origin := append([]float64{}, start...)
+	for k := 1; k < segments; k++ {
+		f := float64(k) / float64(segments)
+		point := make([]float64, len(target))
+		for i := range point {
+			point[i] = origin[i] + (target[i]-origin[i])*f
+		}
+		point[x] = cx + radius*math.Cos(from+sweep*f)
+		point[y] = cy + radius*math.Sin(from+sweep*f)
+		if err := d.queueLine(point, false); err != nil {
+			return err
+		}
+	}
+	return d.queueLine(target, false)
+}
+
+func (d *GCodeDriver) axis(name string) int {
+	for i, axis := range d.Axes {
+		if axis.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// queueLine queues a straight move to the target, limited by the feed rate
+// and the speeds and accelerations of the motors
+func (d *GCodeDriver) queueLine(target []float64, rapid bool) error {
+	delta := make([]float64, len(target))
+	length := 0.0
+	for i := range target {
+		delta[i] = target[i] - d.position[i]
+		length += delta[i] * delta[i]
+	}
+	length = math.Sqrt(length)
+	d.position = append([]float64{}, target...)
+	if length == 0 {
+		return nil
+	}
+
+	feed := d.feed
+	if feed == 0 {
+		feed = d.Feed
+	}
+	if rapid {
+		feed = d.RapidFeed
+	}
+	s := &gcodeStep{target: target, unit: delta, length: length, maxSpeed: math.Inf(1)}
+	if feed > 0 {
+		s.maxSpeed = feed / 60
+	}
+	acceleration := math.Inf(1)
+	if d.Acceleration > 0 {
+		acceleration = d.Acceleration
+	}
+	for i, axis := range d.Axes {
+		s.unit[i] /= length
+		if delta[i] == 0 {
+			continue
+		}
+		// the axis moves |delta|/length times as fast as the tool
+		f := length / math.Abs(delta[i])
+		s.maxSpeed = math.Min(s.maxSpeed, axis.Motor.Speed/60*axis.UnitsPerTurn*f)
+		if axis.Motor.Acceleration > 0 {
+			acceleration = math.Min(acceleration, axis.Motor.Acceleration/60*axis.UnitsPerTurn*f)
+		}
+	}
+	if !math.IsInf(acceleration, 1) {
+		s.acceleration = acceleration
+	}
+
+	if d.last != nil {
+		s.maxEntry = math.Min(d.junctionSpeed(d.last, s), math.Min(d.last.maxSpeed, s.maxSpeed))
+	}
+	d.last = s
+	return d.enqueue(s)
+}
+
+// junctionSpeed returns the highest speed at the junction of two moves,
+// which follows a circle deviating JunctionDeviation from the corner
+func (d *GCodeDriver) junctionSpeed(prev *gcodeStep, next *gcodeStep) float64 {
+	cos := 0.0
+	for i := range prev.unit {
+		cos -= prev.unit[i] * next.unit[i]
+	}
+	acceleration := math.Min(prev.acceleration, next.acceleration)
+	switch {
+	case cos > 0.999999:
+		// the direction reverses
+		return 0
+	case cos < -0.999999 || acceleration == 0:
+		return math.Inf(1)
+	}
+	sin := math.Sqrt((1 - cos) / 2)
+	return math.Sqrt(acceleration * d.JunctionDeviation * sin / (1 - sin))
+}
+
+func (d *GCodeDriver) queueAction(action func(stop chan bool) error) error {
+	d.last = nil
+	return d.enqueue(&gcodeStep{action: action})
+}
+
+// enqueue queues a step, waiting while the buffer is full
+func (d *GCodeDriver) enqueue(s *gcodeStep) error {
+	d.mutex.Lock()
+	for d.running && len(d.queue) >= d.BufferSize {
+		d.cond.Wait()
+	}
+	if !d.running {
+		d.mutex.Unlock()
+		return ErrGCodeNotStarted
+	}
+	d.queue = append(d.queue, s)
+	d.pending++
+	d.queued = time.Now()
+	d.mutex.Unlock()
+
+	select {
+	case d.signal <- true:
+	default:
+	}
+	return nil
+}
+
+// run executes the queued steps until the driver is halted
+func (d *GCodeDriver) run(halt chan bool) {
+	speed := 0.0 // the speed at the end of the last move
+	for {
+		d.mutex.Lock()
+		n := len(d.queue)
+		wait := time.Duration(-1)
+		if n > 0 && n < d.BufferSize && d.queue[0].action == nil {
+			// give the sender time to queue the following moves
+			if idle := time.Since(d.queued); idle < gcodeLookaheadDelay {
+				wait = gcodeLookaheadDelay - idle
+			}
+		}
+		if n == 0 || wait >= 0 {
+			d.mutex.Unlock()
+			var timeout <-chan time.Time
+			if wait >= 0 {
+				timeout = time.After(wait)
+			}
+			select {
+			case <-d.signal:
+			case <-timeout:
+			case <-halt:
+				return
+			}
+			continue
+		}
+
+		s := d.queue[0]
+		d.queue = d.queue[1:]
+		stop := d.stop
+		var plan *MotionPlan
+		var err error
+		if s.action == nil {
+			plan, err = d.startMove(s, math.Min(speed, s.maxEntry), d.exitSpeed(s, speed))
+		}
+		d.cond.Broadcast()
+		d.mutex.Unlock()
+
+		speed = 0
+		if err == nil && s.action != nil {
+			err = s.action(stop)
+		} else if err == nil {
+			err = d.coordinator.Wait()
+			if plan != nil && plan.Steps > 0 {
+				speed = plan.ExitSpeed * s.length / float64(plan.Steps)
+			}
+		}
+
+		d.mutex.Lock()
+		d.pending--
+		if err != nil {
+			d.err = err
+			d.pending -= len(d.queue)
+			d.queue = nil
+			d.resync = true
+			speed = 0
+		}
+		d.cond.Broadcast()
+		d.mutex.Unlock()
+
+		if err != nil {
+			d.Publish(Error, err)
+		}
+	}
+}
+
+// exitSpeed returns the highest speed at the end of a move from which the
+// queued moves can still slow down to stop after the last of them
+func (d *GCodeDriver) exitSpeed(s *gcodeStep, entry float64) float64 {
+	end := 0
+	for end < len(d.queue) && d.queue[end].action == nil {
+		end++
+	}
+	next := 0.0
+	for i := end - 1; i >= 0; i-- {
+		next = math.Min(d.queue[i].maxEntry, reachableSpeed(d.queue[i], next))
+	}
+	return math.Min(next, reachableSpeed(s, entry))
+}
+
+// reachableSpeed returns the speed reached by accelerating over a move from v
+func reachableSpeed(s *gcodeStep, v float64) float64 {
+	if s.acceleration <= 0 {
+		return math.Inf(1)
+	}
+	return math.Sqrt(v*v + 2*s.acceleration*s.length)
+}
+
+// startMove starts the coordinated move of the motors to the target of a
+// step, converting its speeds in millimeters to steps of the longest move
+func (d *GCodeDriver) startMove(s *gcodeStep, entry float64, exit float64) (plan *MotionPlan, err error) {
+	angles := make([]float64, len(d.Axes))
+	for i, axis := range d.Axes {
+		angles[i] = s.target[i]*360/axis.UnitsPerTurn - axis.Motor.Position()
+	}
+	err = d.coordinator.startMove(angles, func(steps int, speed float64, acceleration float64, profile MotionProfile) *MotionPlan {
+		k := float64(steps) / s.length
+		a := s.acceleration * k
+		if acceleration > 0 && (a == 0 || acceleration < a) {
+			a = acceleration
+		}
+		plan = NewBlendedMotionPlan(steps, entry*k, math.Min(speed, s.maxSpeed*k), exit*k, a, profile)
+		return plan
+	})
+	return
+}
+
+// stopQueue drops the queued steps and stops the running one
+func (d *GCodeDriver) stopQueue() {
+	d.pending -= len(d.queue)
+	d.queue = nil
+	d.resync = true
+	if d.stop != nil {
+		close(d.stop)
+		d.stop = make(chan bool)
+	}
+	if d.coordinator != nil {
+		d.coordinator.Stop()
+	}
+	d.cond.Broadcast()
+}
+
+// syncPosition sets the position of the interpreter to the one of the motors
+func (d *GCodeDriver) syncPosition() {
+	d.position = make([]float64, len(d.Axes))
+	if len(d.offset) != len(d.Axes) {
+		d.offset = make([]float64, len(d.Axes))
+	}
+	for i, axis := range d.Axes {
+		d.position[i] = axis.Motor.Position() * axis.UnitsPerTurn / 360
+	}
+	d.last = nil
+}
@@ -0,0 +1,198 @@
+package gpio
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+// initTestGCodeDriver returns a plotter with X and Y axes moving 5 steps per
+// millimeter at up to 4000 millimeters per second
+func initTestGCodeDriver() (*GCodeDriver, *stepCounter) {
+	a := &stepCounter{steps: map[string]int{}}
+	x := NewStepperMotorDriver(a, "1", "2", "3")
+	y := NewStepperMotorDriver(a, "4", "5", "6")
+	x.Speed, y.Speed = 6000, 6000
+	d := NewGCodeDriver(
+		GCodeAxis{Name: "X", Motor: x, UnitsPerTurn: 40},
+		GCodeAxis{Name: "Y", Motor: y, UnitsPerTurn: 40},
+	)
+	d.Feed = 60000
+	return d, a
+}
+
+func assertGCodePosition(t *testing.T, d *GCodeDriver, x float64, y float64) {
+	p := d.Position()
+	if math.Abs(p["X"]-x) > 0.2 || math.Abs(p["Y"]-y) > 0.2 {
+		t.Errorf("position %v should be X %v Y %v", p, x, y)
+	}
+}
+
+func TestGCodeDriver(t *testing.T) {
+	d, _ := initTestGCodeDriver()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "GCode"), true)
+	gobottest.Refute(t, d.Command("GCode"), nil)
+	gobottest.Refute(t, d.Command("Stop"), nil)
+	gobottest.Refute(t, d.Command("Position"), nil)
+
+	gobottest.Assert(t, d.Execute("G1 X1"), ErrGCodeNotStarted)
+}
+
+func TestGCodeDriverMove(t *testing.T) {
+	d, a := initTestGCodeDriver()
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	gobottest.Assert(t, d.Execute("G1 X10 Y5\nG0 X20"), nil)
+	gobottest.Assert(t, d.Wait(), nil)
+	assertGCodePosition(t, d, 20, 5)
+	gobottest.Assert(t, a.steps["1"], 100)
+	gobottest.Assert(t, a.steps["4"], 25)
+
+	// relative moves in inches
+	gobottest.Assert(t, d.Command("GCode")(map[string]interface{}{"code": "G91 G20\nX-0.5 Y1"}), nil)
+	gobottest.Assert(t, d.Wait(), nil)
+	assertGCodePosition(t, d, 7.3, 30.4)
+
+	// offsets set by G92
+	gobottest.Assert(t, d.Execute("G90 G21 G92 X0 Y0\nG1 X1 Y1"), nil)
+	gobottest.Assert(t, d.Wait(), nil)
+	assertGCodePosition(t, d, 8.3, 31.4)
+}
+
+func TestGCodeDriverArc(t *testing.T) {
+	d, _ := initTestGCodeDriver()
+	d.BufferSize = 1000
+	d.syncPosition()
+	d.running = true
+
+	// half circle through X5 Y5 and back below by the radius
+	gobottest.Assert(t, d.Execute("G2 X10 Y0 I5 J0"), nil)
+	n := len(d.queue)
+	gobottest.Assert(t, n > 20, true)
+	gobottest.Assert(t, d.queue[n/2].target[1] > 4.9, true)
+	gobottest.Assert(t, d.Execute("G2 X0 Y0 R5"), nil)
+	gobottest.Assert(t, d.queue[n+n/2].target[1] < -4.9, true)
+	gobottest.Assert(t, d.Execute("G2 X20 R5"), ErrGCodeArcRadius)
+
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+	gobottest.Assert(t, d.Wait(), nil)
+	assertGCodePosition(t, d, 0, 0)
+}
+
+func TestGCodeDriverLookahead(t *testing.T) {
+	d, _ := initTestGCodeDriver()
+	d.Acceleration = 1000
+	d.syncPosition()
+	d.running = true
+
+	gobottest.Assert(t, d.Execute("G1 X10 F6000\nX20\nY10\nX10"), nil)
+	gobottest.Assert(t, len(d.queue), 4)
+	gobottest.Assert(t, d.queue[0].maxEntry, 0.0)
+	// straight on at the feed rate of 100mm/s
+	gobottest.Assert(t, d.queue[1].maxEntry, 100.0)
+	// slower at the corner and stopping at the reversal
+	gobottest.Assert(t, d.queue[2].maxEntry > 0 && d.queue[2].maxEntry < 100, true)
+	gobottest.Assert(t, d.queue[3].maxEntry > 0 && d.queue[3].maxEntry < 100, true)
+
+	first := d.queue[0]
+	d.queue = d.queue[1:]
+	exit := d.exitSpeed(first, 0)
+	gobottest.Assert(t, exit > 0 && exit <= 100, true)
+
+	// without further moves the move stops at its end
+	d.queue = nil
+	gobottest.Assert(t, d.exitSpeed(first, 0), 0.0)
+}
+
+func TestGCodeDriverTool(t *testing.T) {
+	d, a := initTestGCodeDriver()
+	relay := NewRelayDriver(a, "7")
+	d.Tool = &GCodeRelayTool{Relay: relay}
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	gobottest.Assert(t, d.Execute("M3 S1000\nG4 P10"), nil)
+	gobottest.Assert(t, d.Wait(), nil)
+	gobottest.Assert(t, relay.State(), true)
+
+	gobottest.Assert(t, d.Execute("M5"), nil)
+	gobottest.Assert(t, d.Wait(), nil)
+	gobottest.Assert(t, relay.State(), false)
+}
+
+func TestGCodeDriverHoming(t *testing.T) {
+	d, _ := initTestGCodeDriver()
+	x := d.Axes[0].Motor
+	x.ConfigureEndDetection(&mockedEndstop{}, nil, 100)
+	x.CurrentPosition = 90
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	gobottest.Assert(t, d.Execute("G28 X0"), nil)
+	gobottest.Assert(t, d.Wait(), nil)
+	gobottest.Assert(t, x.CurrentPosition, 0.0)
+
+	// the y axis has no endstops
+	gobottest.Assert(t, d.Execute("G28 Y0"), nil)
+	gobottest.Assert(t, d.Wait(), ErrStepperMotorEndstopUnsupported)
+}
+
+func TestGCodeDriverStop(t *testing.T) {
+	d, _ := initTestGCodeDriver()
+	d.Axes[0].Motor.Speed = 60
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	gobottest.Assert(t, d.Execute("G1 X100\nG1 X0"), nil)
+	time.Sleep(100 * time.Millisecond)
+	d.Stop()
+	gobottest.Assert(t, d.Wait(), nil)
+	x := d.Position()["X"]
+	gobottest.Assert(t, x > 0 && x < 100, true)
+
+	// the position is taken from the motors after stopping
+	gobottest.Assert(t, d.Execute("G91 G1 X1"), nil)
+	d.Stop()
+	gobottest.Assert(t, d.position[0], x+1)
+}
+
+func TestGCodeDriverUnsupported(t *testing.T) {
+	d, _ := initTestGCodeDriver()
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	gobottest.Assert(t, d.Execute("G38.2 X1"), errors.New("Unsupported G-code G38.2"))
+}
+
+// gcodeStream is a serial port streaming G-code
+type gcodeStream struct {
+	*strings.Reader
+	bytes.Buffer
+}
+
+func (s *gcodeStream) Read(p []byte) (int, error) { return s.Reader.Read(p) }
+
+func (s *gcodeStream) Write(p []byte) (int, error) { return s.Buffer.Write(p) }
+
+func TestGCodeDriverServe(t *testing.T) {
+	d, _ := initTestGCodeDriver()
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	s := &gcodeStream{Reader: strings.NewReader("G1 X1\nN2 G1 Y1*99\nG5\n")}
+	gobottest.Assert(t, d.Serve(s), nil)
+	gobottest.Assert(t, d.Wait(), nil)
+	assertGCodePosition(t, d, 1, 0)
+	gobottest.Assert(t, s.String(), "ok\nerror: G-code checksum mismatch\nerror: Unsupported G-code G5\n")
+
+	s = &gcodeStream{Reader: strings.NewReader("?\n")}
+	gobottest.Assert(t, d.Serve(s), nil)
+	gobottest.Assert(t, s.String(), "<Idle|MPos:1.000,0.000>\n")
+}
@@ -0,0 +1,47 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestParseGCode(t *testing.T) {
+	b, err := ParseGCode("N10 g01 X1.5 Y-2 f 300 (move) ; to the corner")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, b.LineNumber, 10)
+	gobottest.Assert(t, b.Codes, []string{"G1"})
+	gobottest.Assert(t, b.Params, map[string]float64{"X": 1.5, "Y": -2, "F": 300})
+	gobottest.Assert(t, b.Has("G1"), true)
+	gobottest.Assert(t, b.Param("Z", 7), 7.0)
+
+	b, err = ParseGCode("G90 G28.1 M3")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, b.Codes, []string{"G90", "G28.1", "M3"})
+
+	b, err = ParseGCode("")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, b.LineNumber, -1)
+	gobottest.Assert(t, len(b.Codes), 0)
+}
+
+func TestParseGCodeChecksum(t *testing.T) {
+	b, err := ParseGCode("N1 G1 X1*96")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, b.Params["X"], 1.0)
+
+	_, err = ParseGCode("N1 G1 X2*96")
+	gobottest.Assert(t, err, ErrGCodeChecksum)
+}
+
+func TestParseGCodeError(t *testing.T) {
+	_, err := ParseGCode("G1 X")
+	gobottest.Assert(t, err, errors.New("Invalid G-code word \"X\""))
+
+	_, err = ParseGCode("G1 (unterminated")
+	gobottest.Assert(t, err, errors.New("Unterminated G-code comment"))
+
+	_, err = ParseGCode("G1 #1")
+	gobottest.Assert(t, err, errors.New("Invalid G-code character '#'"))
+}
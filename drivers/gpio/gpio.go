@@ -20,6 +20,9 @@ var (
 	// ErrDigitalReadUnsupported is the error resulting when a driver attempts to use
 	// hardware capabilities which a connection does not support
 	ErrDigitalReadUnsupported = errors.New("DigitalRead is not supported by this platform")
+	// ErrEncoderReadUnsupported is the error resulting when a driver attempts to use
+	// hardware capabilities which a connection does not support
+	ErrEncoderReadUnsupported = errors.New("EncoderRead is not supported by this platform")
 	// ErrServoOutOfRange is the error resulting when a driver attempts to use
 	// hardware capabilities which a connection does not support
 	ErrServoOutOfRange = errors.New("servo angle must be between 0-180")
@@ -29,6 +32,12 @@ var (
 	// ErrStepperMotorEndstopUnsupported is the error resulting when a driver attempts to use
 	// hardware capabilities which a connection does not support
 	ErrStepperMotorEndstopUnsupported = errors.New("Endstops were not correctly defined for stepper motor")
+	// ErrInvalidPin is the error resulting when a pin is not a pin of a
+	// shift register or DAC
+	ErrInvalidPin = errors.New("Invalid pin for this device")
+	// ErrDACOutOfRange is the error resulting when a value beyond the
+	// resolution of a DAC is written
+	ErrDACOutOfRange = errors.New("DAC value is out of range")
 )
 
 const (
@@ -38,6 +47,14 @@ const (
 	ButtonRelease = "release"
 	// ButtonPush event
 	ButtonPush = "push"
+	// ButtonClick event
+	ButtonClick = "click"
+	// ButtonDoubleClick event
+	ButtonDoubleClick = "double-click"
+	// ButtonLongPress event
+	ButtonLongPress = "long-press"
+	// ButtonHold event
+	ButtonHold = "hold"
 	// Data event
 	Data = "data"
 	// Vibration event
@@ -48,6 +65,14 @@ const (
 	MotionStopped = "motion-stopped"
 	// Limit Switch end detected
 	EndDetected = "end-detected"
+	// EncoderTicks event
+	EncoderTicks = "ticks"
+	// EncoderVelocity event
+	EncoderVelocity = "velocity"
+	// DriveOdometry event
+	DriveOdometry = "odometry"
+	// DriveTimeout event
+	DriveTimeout = "timeout"
 )
 
 // PwmWriter interface represents an Adaptor which has Pwm capabilities
@@ -60,6 +85,12 @@ type ServoWriter interface {
 	ServoWrite(string, byte) (err error)
 }
 
+// ServoPulseWriter interface represents an Adaptor which can write the
+// pulse width of a servo signal in microseconds
+type ServoPulseWriter interface {
+	ServoPulseWrite(string, uint16) (err error)
+}
+
 // DigitalWriter interface represents an Adaptor which has DigitalWrite capabilities
 type DigitalWriter interface {
 	DigitalWrite(string, byte) (err error)
@@ -69,3 +100,25 @@ type DigitalWriter interface {
 type DigitalReader interface {
 	DigitalRead(string) (val int, err error)
 }
+
+// DigitalReadWriter interface represents an Adaptor which has DigitalRead and
+// DigitalWrite capabilities
+type DigitalReadWriter interface {
+	DigitalReader
+	DigitalWriter
+}
+
+// EncoderReader interface represents an Adaptor which counts the ticks of a
+// quadrature encoder itself
+type EncoderReader interface {
+	EncoderRead(pinA string, pinB string) (ticks int, err error)
+}
+
+// DigitalPinWatcher interface represents an Adaptor which calls a handler
+// whenever the level of an input pin changes, e.g. from an interrupt or a
+// report of the board, instead of being polled. The changes of the pins of
+// one watch are handled one at a time in the order they happened, until
+// stop is called.
+type DigitalPinWatcher interface {
+	WatchDigitalPins(handler func(pin string, level int), pins ...string) (stop func(), err error)
+}
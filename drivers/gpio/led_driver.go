@@ -8,6 +8,8 @@ type LedDriver struct {
 	name       string
 	connection DigitalWriter
 	high       bool
+	// Animator plays the animations of the LED brightness
+	Animator *gobot.Animator
 	gobot.Commander
 }
 
@@ -18,6 +20,8 @@ type LedDriver struct {
 //	"Toggle" - See LedDriver.Toggle
 //	"On" - See LedDriver.On
 //	"Off" - See LedDriver.Off
+//	"Animate" - See LedDriver.Animate and gobot.ParseAnimation
+//	"StopAnimation" - See LedDriver.StopAnimation
 func NewLedDriver(a DigitalWriter, pin string) *LedDriver {
 	l := &LedDriver{
 		name:       gobot.DefaultName("LED"),
@@ -27,6 +31,10 @@ func NewLedDriver(a DigitalWriter, pin string) *LedDriver {
 		Commander:  gobot.NewCommander(),
 	}
 
+	l.Animator = gobot.NewAnimator(1, func(values []float64) error {
+		return l.Brightness(gobot.AnimationByte(values[0]))
+	})
+
 	l.AddCommand("Brightness", func(params map[string]interface{}) interface{} {
 		level := byte(params["level"].(float64))
 		return l.Brightness(level)
@@ -44,14 +52,34 @@ func NewLedDriver(a DigitalWriter, pin string) *LedDriver {
 		return l.Off()
 	})
 
+	l.AddCommand("Animate", func(params map[string]interface{}) interface{} {
+		a, err := gobot.ParseAnimation(params)
+		if err != nil {
+			return err
+		}
+		return l.Animate(a)
+	})
+
+	l.AddCommand("StopAnimation", func(params map[string]interface{}) interface{} {
+		l.StopAnimation()
+		return nil
+	})
+
 	return l
 }
 
-// Start implements the Driver interface
-func (l *LedDriver) Start() (err error) { return }
+// Start implements the Driver interface and claims the pin
+func (l *LedDriver) Start() (err error) {
+	return gobot.ClaimResources(l.Connection(), l, gobot.PinResource, l.pin)
+}
 
-// Halt implements the Driver interface
-func (l *LedDriver) Halt() (err error) { return }
+// Halt implements the Driver interface and releases the pin and its PWM
+// output
+func (l *LedDriver) Halt() (err error) {
+	l.StopAnimation()
+	gobot.ReleaseResources(l.Connection(), l)
+	return
+}
 
 // Name returns the LedDrivers name
 func (l *LedDriver) Name() string { return l.name }
@@ -100,10 +128,25 @@ func (l *LedDriver) Toggle() (err error) {
 	return
 }
 
-// Brightness sets the led to the specified level of brightness
+// Brightness sets the led to the specified level of brightness. The PWM
+// output of the pin is claimed until Halt.
 func (l *LedDriver) Brightness(level byte) (err error) {
 	if writer, ok := l.connection.(PwmWriter); ok {
+		if err = gobot.ClaimResources(l.Connection(), l, gobot.PwmResource, l.pin); err != nil {
+			return
+		}
 		return writer.PwmWrite(l.Pin(), level)
 	}
 	return ErrPwmWriteUnsupported
 }
+
+// Animate plays an animation of the brightness of the led, with one value
+// per keyframe, replacing the animation which is running.
+func (l *LedDriver) Animate(a *gobot.Animation) error {
+	return l.Animator.Play(a)
+}
+
+// StopAnimation stops the animation which is running
+func (l *LedDriver) StopAnimation() {
+	l.Animator.Stop()
+}
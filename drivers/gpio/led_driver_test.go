@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -56,6 +57,55 @@ func TestLedDriverStart(t *testing.T) {
 	gobottest.Assert(t, d.Start(), nil)
 }
 
+func TestLedDriverStartPinInUse(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d1 := NewLedDriver(a, "1")
+	d2 := NewLedDriver(a, "1")
+	gobottest.Assert(t, d1.Start(), nil)
+	gobottest.Refute(t, d2.Start(), nil)
+	gobottest.Assert(t, d1.Halt(), nil)
+	gobottest.Assert(t, d2.Start(), nil)
+}
+
+func TestLedDriverAnimate(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewLedDriver(a, "1")
+	levels := make(chan byte, 100)
+	a.testAdaptorPwmWrite = func() (err error) {
+		levels <- 0
+		return nil
+	}
+
+	gobottest.Assert(t, d.Animate(gobot.NewBreathing([]float64{255}, time.Second)), nil)
+	<-levels
+	gobottest.Assert(t, d.Animator.Running(), true)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Animator.Running(), false)
+
+	gobottest.Refute(t, d.Animate(gobot.NewBreathing([]float64{255, 0, 0}, time.Second)), nil)
+}
+
+func TestLedDriverHaltRenamed(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d1 := NewLedDriver(a, "1")
+	d2 := NewLedDriver(a, "1")
+	gobottest.Assert(t, d1.Start(), nil)
+	d1.SetName("renamed")
+	gobottest.Assert(t, d1.Halt(), nil)
+	gobottest.Assert(t, d2.Start(), nil)
+}
+
+func TestLedDriverBrightnessPwmInUse(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewLedDriver(a, "1")
+	pin := NewDirectPinDriver(a, "1")
+	gobottest.Assert(t, d.Brightness(100), nil)
+	gobottest.Assert(t, pin.PwmWrite(100), errors.New("pwm 1 is already in use by "+d.Name()))
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, pin.PwmWrite(100), nil)
+	gobottest.Assert(t, pin.Halt(), nil)
+}
+
 func TestLedDriverHalt(t *testing.T) {
 	d := initTestLedDriver()
 	gobottest.Assert(t, d.Halt(), nil)
@@ -14,6 +14,8 @@ type MakeyButtonDriver struct {
 	connection DigitalReader
 	Active     bool
 	interval   time.Duration
+	// Gestures holds the debounce time and the thresholds of the gestures
+	Gestures *ButtonGestures
 	gobot.Eventer
 }
 
@@ -37,9 +39,15 @@ func NewMakeyButtonDriver(a DigitalReader, pin string, v ...time.Duration) *Make
 		m.interval = v[0]
 	}
 
+	m.Gestures = NewButtonGestures(m.gesture)
+
 	m.AddEvent(Error)
 	m.AddEvent(ButtonPush)
 	m.AddEvent(ButtonRelease)
+	m.AddEvent(ButtonClick)
+	m.AddEvent(ButtonDoubleClick)
+	m.AddEvent(ButtonLongPress)
+	m.AddEvent(ButtonHold)
 
 	return m
 }
@@ -61,8 +69,16 @@ func (b *MakeyButtonDriver) Connection() gobot.Connection { return b.connection.
 // Emits the Events:
 // 	Push int - On button push
 //	Release int - On button release
+//	Click - On button release, when it is not followed by another push
+//	DoubleClick - On the second button release within Gestures.DoubleClickTime
+//	LongPress - When the button is held down for Gestures.LongPressTime
+//	Hold int - Repeatedly while the button is held down, with the number of repeats
 //	Error error - On button error
 func (b *MakeyButtonDriver) Start() (err error) {
+	if err = gobot.ClaimResources(b.Connection(), b, gobot.PinResource, b.pin); err != nil {
+		return
+	}
+
 	state := 1
 	go func() {
 		timer := time.NewTimer(b.interval)
@@ -73,13 +89,7 @@ func (b *MakeyButtonDriver) Start() (err error) {
 				b.Publish(Error, err)
 			} else if newValue != state && newValue != -1 {
 				state = newValue
-				if newValue == 0 {
-					b.Active = true
-					b.Publish(ButtonPush, newValue)
-				} else {
-					b.Active = false
-					b.Publish(ButtonRelease, newValue)
-				}
+				b.Gestures.Update(newValue == 0, newValue)
 			}
 			timer.Reset(b.interval)
 			select {
@@ -95,5 +105,17 @@ func (b *MakeyButtonDriver) Start() (err error) {
 // Halt stops polling the makey button for new information
 func (b *MakeyButtonDriver) Halt() (err error) {
 	b.halt <- true
+	b.Gestures.Reset()
+	gobot.ReleaseResources(b.Connection(), b)
 	return
 }
+
+func (b *MakeyButtonDriver) gesture(event string, data interface{}) {
+	switch event {
+	case ButtonPush:
+		b.Active = true
+	case ButtonRelease:
+		b.Active = false
+	}
+	b.Publish(event, data)
+}
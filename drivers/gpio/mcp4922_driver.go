@@ -0,0 +1,214 @@
+package gpio
+
+import (
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+// MCP4922Max is the highest value of the 12 bit MCP4922
+const MCP4922Max = 4095
+
+const (
+	mcp4922ChannelB = 0x8000
+	mcp4922Buffered = 0x4000
+	mcp4922Gain1x   = 0x2000
+	mcp4922Active   = 0x1000
+)
+
+// MCP4922Driver is a driver for the MCP4922 dual 12 bit digital to analog
+// converter, whose SPI bus is driven by pins of an adaptor.
+//
+// It implements aio.AnalogWriter for the outputs "A" and "B", also named
+// "0" and "1", so that it can be the connection of an
+// aio.AnalogActuatorDriver.
+type MCP4922Driver struct {
+	name       string
+	connection DigitalWriter
+	CSPin      string
+	ClockPin   string
+	DataPin    string
+	// LDACPin latches the outputs, if it is empty the LDAC input must be
+	// tied low and every output is updated as soon as it is written
+	LDACPin string
+	// Buffered buffers the reference inputs
+	Buffered bool
+	// Gain2x doubles the output, up to the supply voltage
+	Gain2x bool
+	mutex  *sync.Mutex
+	values [2]uint16
+	gobot.Commander
+}
+
+// NewMCP4922Driver returns a new MCP4922Driver given a DigitalWriter and the
+// pins connected to its CS, SCK and SDI inputs.
+//
+// Optionally accepts:
+//  string: Pin connected to the LDAC input
+//
+// Adds the following API Commands:
+//	"Write" - See MCP4922Driver.Write, with the "a" and "b" values
+//	"Values" - See MCP4922Driver.Values
+func NewMCP4922Driver(a DigitalWriter, csPin string, clockPin string, dataPin string, v ...string) *MCP4922Driver {
+	d := &MCP4922Driver{
+		name:       gobot.DefaultName("MCP4922"),
+		connection: a,
+		CSPin:      csPin,
+		ClockPin:   clockPin,
+		DataPin:    dataPin,
+		mutex:      &sync.Mutex{},
+		Commander:  gobot.NewCommander(),
+	}
+
+	if len(v) > 0 {
+		d.LDACPin = v[0]
+	}
+
+	d.AddCommand("Write", func(params map[string]interface{}) interface{} {
+		a, _ := params["a"].(float64)
+		b, _ := params["b"].(float64)
+		return d.Write(uint16(a), uint16(b))
+	})
+	d.AddCommand("Values", func(params map[string]interface{}) interface{} {
+		return d.Values()
+	})
+
+	return d
+}
+
+// Name returns the MCP4922Drivers name
+func (d *MCP4922Driver) Name() string { return d.name }
+
+// SetName sets the MCP4922Drivers name
+func (d *MCP4922Driver) SetName(n string) { d.name = n }
+
+// Connection returns the MCP4922Drivers Connection
+func (d *MCP4922Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Connect implements the Connection interface, so that aio drivers can
+// claim the outputs. The DAC itself is started as a driver.
+func (d *MCP4922Driver) Connect() (err error) { return }
+
+// Finalize implements the Connection interface
+func (d *MCP4922Driver) Finalize() (err error) { return }
+
+// Start claims the pins and sets both outputs to 0
+func (d *MCP4922Driver) Start() (err error) {
+	pins := []string{d.CSPin, d.ClockPin, d.DataPin}
+	if d.LDACPin != "" {
+		pins = append(pins, d.LDACPin)
+	}
+	if err = gobot.ClaimResources(d.Connection(), d, gobot.PinResource, pins...); err != nil {
+		return
+	}
+	if err = d.connection.DigitalWrite(d.CSPin, 1); err != nil {
+		return
+	}
+	if err = d.connection.DigitalWrite(d.ClockPin, 0); err != nil {
+		return
+	}
+	if d.LDACPin != "" {
+		if err = d.connection.DigitalWrite(d.LDACPin, 1); err != nil {
+			return
+		}
+	}
+	return d.Write(0, 0)
+}
+
+// Halt releases the pins
+func (d *MCP4922Driver) Halt() (err error) {
+	gobot.ReleaseResources(d.Connection(), d)
+	return
+}
+
+// AnalogWrite writes the 0-4095 value to an output
+func (d *MCP4922Driver) AnalogWrite(pin string, value int) (err error) {
+	var channel int
+	switch pin {
+	case "A", "0":
+		channel = 0
+	case "B", "1":
+		channel = 1
+	default:
+		return ErrInvalidPin
+	}
+	if value < 0 || value > MCP4922Max {
+		return ErrDACOutOfRange
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err = d.shiftOut(channel, uint16(value)); err != nil {
+		return
+	}
+	return d.latch()
+}
+
+// Write writes the 0-4095 values to the outputs A and B. They change at the
+// same time if the LDAC input is connected.
+func (d *MCP4922Driver) Write(a uint16, b uint16) (err error) {
+	if a > MCP4922Max || b > MCP4922Max {
+		return ErrDACOutOfRange
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err = d.shiftOut(0, a); err != nil {
+		return
+	}
+	if err = d.shiftOut(1, b); err != nil {
+		return
+	}
+	return d.latch()
+}
+
+// Values returns the values last written to the outputs A and B
+func (d *MCP4922Driver) Values() []uint16 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return []uint16{d.values[0], d.values[1]}
+}
+
+// shiftOut shifts the command which writes the value to the channel into the
+// DAC, most significant bit first
+func (d *MCP4922Driver) shiftOut(channel int, value uint16) (err error) {
+	command := mcp4922Active | value
+	if channel == 1 {
+		command |= mcp4922ChannelB
+	}
+	if d.Buffered {
+		command |= mcp4922Buffered
+	}
+	if !d.Gain2x {
+		command |= mcp4922Gain1x
+	}
+
+	if err = d.connection.DigitalWrite(d.CSPin, 0); err != nil {
+		return
+	}
+	for bit := 15; bit >= 0; bit-- {
+		if err = d.connection.DigitalWrite(d.DataPin, byte(command>>uint(bit))&1); err != nil {
+			return
+		}
+		if err = d.connection.DigitalWrite(d.ClockPin, 1); err != nil {
+			return
+		}
+		if err = d.connection.DigitalWrite(d.ClockPin, 0); err != nil {
+			return
+		}
+	}
+	if err = d.connection.DigitalWrite(d.CSPin, 1); err != nil {
+		return
+	}
+	d.values[channel] = value
+	return
+}
+
+// latch pulses the LDAC input, which updates the outputs
+func (d *MCP4922Driver) latch() (err error) {
+	if d.LDACPin == "" {
+		return
+	}
+	if err = d.connection.DigitalWrite(d.LDACPin, 0); err != nil {
+		return
+	}
+	return d.connection.DigitalWrite(d.LDACPin, 1)
+}
@@ -0,0 +1,110 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/aio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*MCP4922Driver)(nil)
+var _ gobot.Connection = (*MCP4922Driver)(nil)
+var _ aio.AnalogWriter = (*MCP4922Driver)(nil)
+
+// mcp4922Adaptor simulates a MCP4922 on the pins "cs", "sck", "sdi" and
+// "ldac"
+type mcp4922Adaptor struct {
+	*gpioTestAdaptor
+	levels   map[string]byte
+	shifted  uint16
+	commands []uint16 // commands received, when CS rose
+	inputs   [2]uint16
+	outputs  [2]uint16
+	err      error
+}
+
+func newMCP4922Adaptor() *mcp4922Adaptor {
+	return &mcp4922Adaptor{gpioTestAdaptor: newGpioTestAdaptor(), levels: map[string]byte{"cs": 1, "ldac": 1}}
+}
+
+func (a *mcp4922Adaptor) DigitalWrite(pin string, level byte) error {
+	if a.err != nil {
+		return a.err
+	}
+	rising := a.levels[pin] == 0 && level == 1
+	falling := a.levels[pin] == 1 && level == 0
+	a.levels[pin] = level
+	switch {
+	case pin == "sck" && rising && a.levels["cs"] == 0:
+		a.shifted = a.shifted<<1 | uint16(a.levels["sdi"])
+	case pin == "cs" && rising:
+		a.commands = append(a.commands, a.shifted)
+		a.inputs[a.shifted>>15] = a.shifted & 0xfff
+		if a.levels["ldac"] == 0 {
+			a.outputs = a.inputs
+		}
+	case pin == "ldac" && falling:
+		a.outputs = a.inputs
+	}
+	return nil
+}
+
+func TestMCP4922Driver(t *testing.T) {
+	a := newMCP4922Adaptor()
+	d := NewMCP4922Driver(a, "cs", "sck", "sdi", "ldac")
+	gobottest.Assert(t, d.Connection().(*mcp4922Adaptor), a)
+	gobottest.Assert(t, d.LDACPin, "ldac")
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MCP4922"), true)
+	d.SetName("dac")
+	gobottest.Assert(t, d.Name(), "dac")
+	gobottest.Assert(t, d.Connect(), nil)
+	gobottest.Assert(t, d.Finalize(), nil)
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, a.commands, []uint16{0x3000, 0xb000})
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestMCP4922DriverAnalogWrite(t *testing.T) {
+	a := newMCP4922Adaptor()
+	d := NewMCP4922Driver(a, "cs", "sck", "sdi")
+	// without LDAC the outputs follow the inputs
+	a.levels["ldac"] = 0
+	gobottest.Assert(t, d.AnalogWrite("A", 0x123), nil)
+	gobottest.Assert(t, d.AnalogWrite("1", 0xfff), nil)
+	gobottest.Assert(t, a.outputs, [2]uint16{0x123, 0xfff})
+	gobottest.Assert(t, a.commands, []uint16{0x3123, 0xbfff})
+	gobottest.Assert(t, d.Values(), []uint16{0x123, 0xfff})
+
+	d.Buffered = true
+	d.Gain2x = true
+	gobottest.Assert(t, d.AnalogWrite("0", 1), nil)
+	gobottest.Assert(t, a.commands[2], uint16(0x5001))
+
+	gobottest.Assert(t, d.AnalogWrite("C", 1), ErrInvalidPin)
+	gobottest.Assert(t, d.AnalogWrite("B", 4096), ErrDACOutOfRange)
+	gobottest.Assert(t, d.AnalogWrite("B", -1), ErrDACOutOfRange)
+
+	a.err = errors.New("write error")
+	gobottest.Assert(t, d.AnalogWrite("B", 1), errors.New("write error"))
+	gobottest.Assert(t, d.Values(), []uint16{1, 0xfff})
+}
+
+func TestMCP4922DriverWrite(t *testing.T) {
+	a := newMCP4922Adaptor()
+	d := NewMCP4922Driver(a, "cs", "sck", "sdi", "ldac")
+	gobottest.Assert(t, d.Start(), nil)
+
+	// the outputs change together when LDAC is pulsed
+	gobottest.Assert(t, d.Write(1000, 3000), nil)
+	gobottest.Assert(t, a.commands[2:], []uint16{0x33e8, 0xbbb8})
+	gobottest.Assert(t, a.outputs, [2]uint16{1000, 3000})
+	gobottest.Assert(t, d.Write(4096, 0), ErrDACOutOfRange)
+
+	gobottest.Assert(t, d.Command("Write")(map[string]interface{}{"a": 5.0, "b": 6.0}), nil)
+	gobottest.Assert(t, a.outputs, [2]uint16{5, 6})
+	gobottest.Assert(t, d.Command("Values")(nil), []uint16{5, 6})
+}
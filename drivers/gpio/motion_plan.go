@@ -0,0 +1,192 @@
+package gpio
+
+import (
+	"math"
+	"time"
+)
+
+// MotionProfile is the shape of the speed of a move over time
+type MotionProfile int
+
+const (
+	// TrapezoidalProfile accelerates and decelerates at a constant rate
+	TrapezoidalProfile MotionProfile = iota
+	// SCurveProfile eases into and out of the acceleration, which reduces
+	// the jerk at the start and end of the ramps
+	SCurveProfile
+)
+
+// MotionPlan computes when each step of a move of a stepper motor is made.
+// The motor accelerates from its entry speed to its maximum speed, cruises
+// and decelerates to its exit speed at the end of the move. A move which is
+// too short to reach the maximum speed decelerates right after accelerating.
+type MotionPlan struct {
+	// Steps is the length of the move
+	Steps int
+	// MaxSpeed is the cruise speed in steps per second
+	MaxSpeed float64
+	// EntrySpeed and ExitSpeed are the speeds in steps per second at the
+	// start and end of the move, 0 for a move from and to standstill
+	EntrySpeed float64
+	ExitSpeed  float64
+	// Acceleration is the average acceleration of the ramps in steps per
+	// second squared, 0 moves at MaxSpeed from start to end
+	Acceleration float64
+	Profile      MotionProfile
+
+	accel  float64 // duration of the acceleration in seconds
+	cruise float64 // duration of the cruise in seconds
+	decel  float64 // duration of the deceleration in seconds
+	speed  float64 // reached speed in steps per second
+}
+
+// NewMotionPlan returns a new MotionPlan of steps from and to standstill with
+// the maximum speed and acceleration in steps per second and steps per second
+// squared
+func NewMotionPlan(steps int, maxSpeed float64, acceleration float64, profile MotionProfile) *MotionPlan {
+	return NewBlendedMotionPlan(steps, 0, maxSpeed, 0, acceleration, profile)
+}
+
+// NewBlendedMotionPlan returns a new MotionPlan of steps which enters and
+// exits at the given speeds, so that consecutive moves blend into each other
+// without stopping. An exit speed which can not be reached within the steps
+// is lowered, as is an entry speed which can not be slowed down from.
+func NewBlendedMotionPlan(steps int, entrySpeed float64, maxSpeed float64, exitSpeed float64, acceleration float64, profile MotionProfile) *MotionPlan {
+	p := &MotionPlan{
+		Steps:        steps,
+		MaxSpeed:     maxSpeed,
+		EntrySpeed:   math.Min(entrySpeed, maxSpeed),
+		ExitSpeed:    math.Min(exitSpeed, maxSpeed),
+		Acceleration: acceleration,
+		Profile:      profile,
+		speed:        maxSpeed,
+	}
+
+	n := float64(steps)
+	if acceleration <= 0 {
+		p.EntrySpeed, p.ExitSpeed = maxSpeed, maxSpeed
+	} else {
+		v0, v1, a := p.EntrySpeed, p.ExitSpeed, acceleration
+		// both ramps together cover (2v^2-v0^2-v1^2)/2a steps
+		if (2*p.speed*p.speed-v0*v0-v1*v1)/(2*a) > n {
+			p.speed = math.Sqrt((2*a*n + v0*v0 + v1*v1) / 2)
+		}
+		switch {
+		case p.speed < v0:
+			p.EntrySpeed = math.Sqrt(v1*v1 + 2*a*n)
+			p.speed = p.EntrySpeed
+		case p.speed < v1:
+			p.ExitSpeed = math.Sqrt(v0*v0 + 2*a*n)
+			p.speed = p.ExitSpeed
+		}
+		p.accel = (p.speed - p.EntrySpeed) / a
+		p.decel = (p.speed - p.ExitSpeed) / a
+	}
+	if p.speed > 0 {
+		p.cruise = math.Max(0, (n-p.accelSteps()-p.decelSteps())/p.speed)
+	}
+	return p
+}
+
+// Duration returns the time the move takes
+func (p *MotionPlan) Duration() time.Duration {
+	return seconds(p.accel + p.cruise + p.decel)
+}
+
+// Position returns the number of steps made at time t of the move, as a
+// fraction
+func (p *MotionPlan) Position(t time.Duration) float64 {
+	s := t.Seconds()
+	end := p.accel + p.cruise + p.decel
+	switch {
+	case s <= 0:
+		return 0
+	case s < p.accel:
+		return p.rampPosition(p.EntrySpeed, p.accel, s)
+	case s < p.accel+p.cruise:
+		return p.accelSteps() + p.speed*(s-p.accel)
+	case s < end:
+		return float64(p.Steps) - p.rampPosition(p.ExitSpeed, p.decel, end-s)
+	}
+	return float64(p.Steps)
+}
+
+// Velocity returns the speed in steps per second at time t of the move
+func (p *MotionPlan) Velocity(t time.Duration) float64 {
+	s := t.Seconds()
+	end := p.accel + p.cruise + p.decel
+	switch {
+	case s < 0 || s > end:
+		return 0
+	case s < p.accel:
+		return p.rampVelocity(p.EntrySpeed, p.accel, s)
+	case s < p.accel+p.cruise:
+		return p.speed
+	}
+	return p.rampVelocity(p.ExitSpeed, p.decel, end-s)
+}
+
+// StepTime returns the time of the step with the given number, from 1 to
+// Steps, from the start of the move
+func (p *MotionPlan) StepTime(step int) time.Duration {
+	x := float64(step)
+	n := float64(p.Steps)
+	switch {
+	case x <= p.accelSteps():
+		return seconds(p.rampTime(p.EntrySpeed, p.accel, x))
+	case x <= n-p.decelSteps():
+		return seconds(p.accel + (x-p.accelSteps())/p.speed)
+	}
+	return seconds(p.accel + p.cruise + p.decel - p.rampTime(p.ExitSpeed, p.decel, n-x))
+}
+
+func (p *MotionPlan) accelSteps() float64 {
+	return (p.EntrySpeed + p.speed) / 2 * p.accel
+}
+
+func (p *MotionPlan) decelSteps() float64 {
+	return (p.ExitSpeed + p.speed) / 2 * p.decel
+}
+
+// rampPosition returns the steps covered at time s of a ramp of duration d
+// from speed v0 up to the reached speed
+func (p *MotionPlan) rampPosition(v0 float64, d float64, s float64) float64 {
+	if p.Profile == SCurveProfile {
+		return v0*s + (p.speed-v0)*(s/2-d/(2*math.Pi)*math.Sin(math.Pi*s/d))
+	}
+	return v0*s + (p.speed-v0)/d*s*s/2
+}
+
+func (p *MotionPlan) rampVelocity(v0 float64, d float64, s float64) float64 {
+	if p.Profile == SCurveProfile {
+		return v0 + (p.speed-v0)*(1-math.Cos(math.Pi*s/d))/2
+	}
+	return v0 + (p.speed-v0)/d*s
+}
+
+// rampTime returns the time at which a ramp of duration d from speed v0 has
+// covered x steps
+func (p *MotionPlan) rampTime(v0 float64, d float64, x float64) float64 {
+	if d == 0 {
+		return 0
+	}
+	if p.Profile != SCurveProfile {
+		k := (p.speed - v0) / d
+		return (math.Sqrt(v0*v0+2*k*x) - v0) / k
+	}
+	// the position of the S-curve can not be inverted analytically
+	lo, hi := 0.0, d
+	for i := 0; i < 40; i++ {
+		mid := (lo + hi) / 2
+		if p.rampPosition(v0, d, mid) < x {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,76 @@
+package gpio
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestMotionPlanConstantSpeed(t *testing.T) {
+	p := NewMotionPlan(100, 100, 0, TrapezoidalProfile)
+	gobottest.Assert(t, p.Duration(), time.Second)
+	gobottest.Assert(t, p.StepTime(0), time.Duration(0))
+	gobottest.Assert(t, p.StepTime(50), 500*time.Millisecond)
+	gobottest.Assert(t, p.Velocity(300*time.Millisecond), 100.0)
+	gobottest.Assert(t, p.Position(250*time.Millisecond), 25.0)
+}
+
+func TestMotionPlanTrapezoidal(t *testing.T) {
+	// ramps of 1s covering 50 steps each and a cruise of 1s
+	p := NewMotionPlan(200, 100, 100, TrapezoidalProfile)
+	gobottest.Assert(t, p.Duration(), 3*time.Second)
+	gobottest.Assert(t, p.Velocity(500*time.Millisecond), 50.0)
+	gobottest.Assert(t, p.Velocity(1500*time.Millisecond), 100.0)
+	gobottest.Assert(t, p.Velocity(2500*time.Millisecond), 50.0)
+	gobottest.Assert(t, p.Position(time.Second), 50.0)
+	gobottest.Assert(t, p.Position(3*time.Second), 200.0)
+	gobottest.Assert(t, p.StepTime(50), time.Second)
+	gobottest.Assert(t, p.StepTime(100), 1500*time.Millisecond)
+	gobottest.Assert(t, p.StepTime(200), 3*time.Second)
+}
+
+func TestMotionPlanShortMove(t *testing.T) {
+	// too short to reach the maximum speed
+	p := NewMotionPlan(100, 1000, 100, TrapezoidalProfile)
+	gobottest.Assert(t, p.Duration(), 2*time.Second)
+	gobottest.Assert(t, p.Velocity(time.Second), 100.0)
+}
+
+func TestMotionPlanSCurve(t *testing.T) {
+	p := NewMotionPlan(200, 100, 100, SCurveProfile)
+	gobottest.Assert(t, p.Duration(), 3*time.Second)
+	gobottest.Assert(t, p.Velocity(0), 0.0)
+	gobottest.Assert(t, p.Velocity(time.Second), 100.0)
+	gobottest.Assert(t, p.Position(time.Second), 50.0)
+
+	// the step times are monotonic and match the positions
+	prev := time.Duration(0)
+	for step := 1; step <= 200; step++ {
+		st := p.StepTime(step)
+		gobottest.Assert(t, st > prev, true)
+		gobottest.Assert(t, math.Abs(p.Position(st)-float64(step)) < 1e-6, true)
+		prev = st
+	}
+}
+
+func TestMotionPlanBlended(t *testing.T) {
+	// accelerates from 50 to 100 in 0.5s over 37.5 steps, cruises and
+	// decelerates to 0 in 1s over 50 steps
+	p := NewBlendedMotionPlan(200, 50, 100, 0, 100, TrapezoidalProfile)
+	gobottest.Assert(t, p.Velocity(0), 50.0)
+	gobottest.Assert(t, p.Velocity(time.Second), 100.0)
+	gobottest.Assert(t, p.Position(500*time.Millisecond), 37.5)
+	gobottest.Assert(t, p.Duration(), 2625*time.Millisecond)
+	gobottest.Assert(t, p.StepTime(200), p.Duration())
+
+	// an exit speed which can not be reached is lowered
+	p = NewBlendedMotionPlan(10, 0, 100, 100, 100, TrapezoidalProfile)
+	gobottest.Assert(t, math.Abs(p.ExitSpeed-math.Sqrt(2000)) < 1e-9, true)
+
+	// without acceleration the speed does not change
+	p = NewBlendedMotionPlan(10, 20, 100, 20, 0, SCurveProfile)
+	gobottest.Assert(t, p.EntrySpeed, 100.0)
+	gobottest.Assert(t, p.Duration(), 100*time.Millisecond)
+}
@@ -1,9 +1,19 @@
 package gpio
 
 import (
+	"math"
+
 	"gobot.io/x/gobot"
 )
 
+// DCMotor is a motor whose power can be set in both directions, such as a
+// MotorDriver, a DC motor of an Adafruit motor hat or a MegaPi motor
+type DCMotor interface {
+	// SetPower sets the power from -1 full backward to 1 full forward, 0
+	// stops the motor
+	SetPower(power float64) error
+}
+
 // MotorDriver Represents a Motor
 type MotorDriver struct {
 	name             string
@@ -41,11 +51,22 @@ func (m *MotorDriver) SetName(n string) { m.name = n }
 // Connection returns the MotorDrivers Connection
 func (m *MotorDriver) Connection() gobot.Connection { return m.connection.(gobot.Connection) }
 
-// Start implements the Driver interface
-func (m *MotorDriver) Start() (err error) { return }
+// Start implements the Driver interface and claims the pins in use
+func (m *MotorDriver) Start() (err error) {
+	pins := []string{}
+	for _, pin := range []string{m.SpeedPin, m.SwitchPin, m.DirectionPin, m.ForwardPin, m.BackwardPin} {
+		if pin != "" {
+			pins = append(pins, pin)
+		}
+	}
+	return gobot.ClaimResources(m.Connection(), m, gobot.PinResource, pins...)
+}
 
-// Halt implements the Driver interface
-func (m *MotorDriver) Halt() (err error) { return }
+// Halt implements the Driver interface and releases the pins
+func (m *MotorDriver) Halt() (err error) {
+	gobot.ReleaseResources(m.Connection(), m)
+	return
+}
 
 // Off turns the motor off or sets the motor to a 0 speed
 func (m *MotorDriver) Off() (err error) {
@@ -175,6 +196,23 @@ func (m *MotorDriver) Direction(direction string) (err error) {
 	return
 }
 
+// SetPower sets the speed and direction of the motor from -1 full backward
+// to 1 full forward. A motor without direction pins can not run backward,
+// and stops for negative powers.
+func (m *MotorDriver) SetPower(power float64) (err error) {
+	speed := byte(math.Round(math.Min(math.Abs(power), 1) * 255))
+	switch {
+	case m.DirectionPin == "" && m.ForwardPin == "":
+		if power < 0 {
+			speed = 0
+		}
+		return m.Speed(speed)
+	case power < 0:
+		return m.Backward(speed)
+	}
+	return m.Forward(speed)
+}
+
 func (m *MotorDriver) isDigital() bool {
 	return m.CurrentMode == "digital"
 }
@@ -136,3 +136,20 @@ func TestMotorDriverSetName(t *testing.T) {
 	d.SetName("mybot")
 	gobottest.Assert(t, d.Name(), "mybot")
 }
+
+func TestMotorDriverSetPower(t *testing.T) {
+	d := initTestMotorDriver()
+	d.ForwardPin = "2"
+	d.BackwardPin = "3"
+	gobottest.Assert(t, d.SetPower(-0.5), nil)
+	gobottest.Assert(t, d.CurrentDirection, "backward")
+	gobottest.Assert(t, d.CurrentSpeed, uint8(128))
+	gobottest.Assert(t, d.SetPower(2), nil)
+	gobottest.Assert(t, d.CurrentDirection, "forward")
+	gobottest.Assert(t, d.CurrentSpeed, uint8(255))
+
+	// without direction pins the motor only runs forward
+	d = initTestMotorDriver()
+	gobottest.Assert(t, d.SetPower(-1), nil)
+	gobottest.Assert(t, d.CurrentSpeed, uint8(0))
+}
@@ -0,0 +1,85 @@
+package gpio
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// PIDController is a proportional-integral-derivative controller, which
+// computes the output that drives a measured value towards its setpoint,
+// e.g. the power of a motor to reach a speed.
+type PIDController struct {
+	// Kp, Ki and Kd are the proportional, integral and derivative gains
+	Kp float64
+	Ki float64
+	Kd float64
+	// OutputMin and OutputMax limit the output. The integral stops growing
+	// while the output is limited, so that it does not wind up.
+	OutputMin float64
+	OutputMax float64
+
+	mutex     *sync.Mutex
+	integral  float64
+	lastError float64
+	started   bool
+}
+
+// NewPIDController returns a new PIDController with the gains and an output
+// from -1 to 1
+func NewPIDController(kp float64, ki float64, kd float64) *PIDController {
+	return &PIDController{
+		Kp:        kp,
+		Ki:        ki,
+		Kd:        kd,
+		OutputMin: -1,
+		OutputMax: 1,
+		mutex:     &sync.Mutex{},
+	}
+}
+
+// SetGains sets the proportional, integral and derivative gains
+func (p *PIDController) SetGains(kp float64, ki float64, kd float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.Kp, p.Ki, p.Kd = kp, ki, kd
+}
+
+// Gains returns the proportional, integral and derivative gains
+func (p *PIDController) Gains() (kp float64, ki float64, kd float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.Kp, p.Ki, p.Kd
+}
+
+// Update returns the output for the measured value, dt after the last
+// update. The first update after a reset has no derivative term.
+func (p *PIDController) Update(setpoint float64, measured float64, dt time.Duration) float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	err := setpoint - measured
+	s := dt.Seconds()
+	derivative := 0.0
+	if p.started && s > 0 {
+		derivative = (err - p.lastError) / s
+	}
+	p.lastError, p.started = err, true
+
+	integral := p.integral + err*s
+	output := p.Kp*err + p.Ki*integral + p.Kd*derivative
+	limited := math.Max(p.OutputMin, math.Min(p.OutputMax, output))
+	// integrate only while the output is not limited, or when the error
+	// pulls it back into its range
+	if limited == output || (output > limited) != (err > 0) {
+		p.integral = integral
+	}
+	return limited
+}
+
+// Reset clears the integral and the last error
+func (p *PIDController) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.integral, p.lastError, p.started = 0, 0, false
+}
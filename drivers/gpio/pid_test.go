@@ -0,0 +1,43 @@
+package gpio
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestPIDController(t *testing.T) {
+	p := NewPIDController(0.1, 0.5, 0.01)
+	gobottest.Assert(t, p.OutputMin, -1.0)
+	gobottest.Assert(t, p.OutputMax, 1.0)
+
+	// no derivative on the first update
+	out := p.Update(3, 1, 100*time.Millisecond)
+	gobottest.Assert(t, math.Abs(out-0.3) < 1e-9, true)
+	// the error fell by 1 within 100ms
+	out = p.Update(3, 2, 100*time.Millisecond)
+	gobottest.Assert(t, math.Abs(out-0.15) < 1e-9, true)
+
+	p.Reset()
+	gobottest.Assert(t, p.Update(3, 3, time.Second), 0.0)
+}
+
+func TestPIDControllerWindup(t *testing.T) {
+	p := NewPIDController(1, 1, 0)
+	// the output is limited and the integral does not grow meanwhile
+	for i := 0; i < 10; i++ {
+		gobottest.Assert(t, p.Update(10, 0, time.Second), 1.0)
+	}
+	gobottest.Assert(t, p.integral, 0.0)
+	gobottest.Assert(t, p.Update(0, 0.5, time.Second), -1.0)
+	gobottest.Assert(t, p.integral, -0.5)
+}
+
+func TestPIDControllerGains(t *testing.T) {
+	p := NewPIDController(1, 2, 3)
+	p.SetGains(4, 5, 6)
+	kp, ki, kd := p.Gains()
+	gobottest.Assert(t, []float64{kp, ki, kd}, []float64{4, 5, 6})
+}
@@ -56,6 +56,10 @@ func NewPIRMotionDriver(a DigitalReader, pin string, v ...time.Duration) *PIRMot
 // It will only send the MotionStopped event once, however, until
 // motion starts being detected again
 func (p *PIRMotionDriver) Start() (err error) {
+	if err = gobot.ClaimResources(p.Connection(), p, gobot.PinResource, p.pin); err != nil {
+		return
+	}
+
 	go func() {
 		for {
 			newValue, err := p.connection.DigitalRead(p.Pin())
@@ -88,6 +92,7 @@ func (p *PIRMotionDriver) Start() (err error) {
 // Halt stops polling the button for new information
 func (p *PIRMotionDriver) Halt() (err error) {
 	p.halt <- true
+	gobot.ReleaseResources(p.Connection(), p)
 	return
 }
 
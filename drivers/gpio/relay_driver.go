@@ -41,11 +41,16 @@ func NewRelayDriver(a DigitalWriter, pin string) *RelayDriver {
 	return l
 }
 
-// Start implements the Driver interface
-func (l *RelayDriver) Start() (err error) { return }
+// Start implements the Driver interface and claims the pin
+func (l *RelayDriver) Start() (err error) {
+	return gobot.ClaimResources(l.Connection(), l, gobot.PinResource, l.pin)
+}
 
-// Halt implements the Driver interface
-func (l *RelayDriver) Halt() (err error) { return }
+// Halt implements the Driver interface and releases the pin
+func (l *RelayDriver) Halt() (err error) {
+	gobot.ReleaseResources(l.Connection(), l)
+	return
+}
 
 // Name returns the RelayDrivers name
 func (l *RelayDriver) Name() string { return l.name }
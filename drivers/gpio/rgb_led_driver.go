@@ -13,6 +13,8 @@ type RgbLedDriver struct {
 	name       string
 	connection DigitalWriter
 	high       bool
+	// Animator plays the animations of the LED color
+	Animator *gobot.Animator
 	gobot.Commander
 }
 
@@ -24,6 +26,8 @@ type RgbLedDriver struct {
 //	"Toggle" - See RgbLedDriver.Toggle
 //	"On" - See RgbLedDriver.On
 //	"Off" - See RgbLedDriver.Off
+//	"Animate" - See RgbLedDriver.Animate and gobot.ParseAnimation
+//	"StopAnimation" - See RgbLedDriver.StopAnimation
 func NewRgbLedDriver(a DigitalWriter, redPin string, greenPin string, bluePin string) *RgbLedDriver {
 	l := &RgbLedDriver{
 		name:       gobot.DefaultName("RGBLED"),
@@ -35,6 +39,10 @@ func NewRgbLedDriver(a DigitalWriter, redPin string, greenPin string, bluePin st
 		Commander:  gobot.NewCommander(),
 	}
 
+	l.Animator = gobot.NewAnimator(3, func(values []float64) error {
+		return l.SetRGB(gobot.AnimationByte(values[0]), gobot.AnimationByte(values[1]), gobot.AnimationByte(values[2]))
+	})
+
 	l.AddCommand("SetRGB", func(params map[string]interface{}) interface{} {
 		r := byte(params["r"].(int))
 		g := byte(params["g"].(int))
@@ -54,14 +62,33 @@ func NewRgbLedDriver(a DigitalWriter, redPin string, greenPin string, bluePin st
 		return l.Off()
 	})
 
+	l.AddCommand("Animate", func(params map[string]interface{}) interface{} {
+		a, err := gobot.ParseAnimation(params)
+		if err != nil {
+			return err
+		}
+		return l.Animate(a)
+	})
+
+	l.AddCommand("StopAnimation", func(params map[string]interface{}) interface{} {
+		l.StopAnimation()
+		return nil
+	})
+
 	return l
 }
 
-// Start implements the Driver interface
-func (l *RgbLedDriver) Start() (err error) { return }
+// Start implements the Driver interface and claims the pins
+func (l *RgbLedDriver) Start() (err error) {
+	return gobot.ClaimResources(l.Connection(), l, gobot.PinResource, l.pinRed, l.pinGreen, l.pinBlue)
+}
 
-// Halt implements the Driver interface
-func (l *RgbLedDriver) Halt() (err error) { return }
+// Halt implements the Driver interface and releases the pins
+func (l *RgbLedDriver) Halt() (err error) {
+	l.StopAnimation()
+	gobot.ReleaseResources(l.Connection(), l)
+	return
+}
 
 // Name returns the RGBLEDDrivers name
 func (l *RgbLedDriver) Name() string { return l.name }
@@ -153,3 +180,14 @@ func (l *RgbLedDriver) SetRGB(r, g, b byte) error {
 
 	return l.On()
 }
+
+// Animate plays an animation of the color of the led, with red, green and
+// blue values per keyframe, replacing the animation which is running.
+func (l *RgbLedDriver) Animate(a *gobot.Animation) error {
+	return l.Animator.Play(a)
+}
+
+// StopAnimation stops the animation which is running
+func (l *RgbLedDriver) StopAnimation() {
+	l.Animator.Stop()
+}
@@ -1,20 +1,62 @@
 package gpio
 
-import "gobot.io/x/gobot"
+import (
+	"math"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// servoFrame is the time between two positions of a servo move, which is
+// the period of a standard 50Hz servo signal
+const servoFrame = 20 * time.Millisecond
+
+// ServoCalibration describes how the angle of a servo maps to its signal.
+//
+// MinPulse and MaxPulse are the pulse widths in microseconds of 0 and 180
+// degrees, e.g. 544 and 2400. They are only used by connections which
+// implement ServoPulseWriter, and only if MaxPulse is set, otherwise the
+// angle is written with ServoWrite. Trim is added to every
+// angle in degrees, and Inverted mirrors the angles of a servo which is
+// mounted the other way around.
+type ServoCalibration struct {
+	MinPulse uint16
+	MaxPulse uint16
+	Trim     float64
+	Inverted bool
+}
 
 // ServoDriver Represents a Servo
 type ServoDriver struct {
 	name       string
 	pin        string
 	connection ServoWriter
+	angle      float64
+	mutex      *sync.Mutex
 	gobot.Commander
 	CurrentAngle byte
+	// Calibration maps the angles of the servo to its signal
+	Calibration ServoCalibration
+	// MaxSpeed limits the speed of MoveTo in degrees per second, 0 is unlimited
+	MaxSpeed float64
+	// Easing is the curve followed by MoveTo
+	Easing gobot.Easing
+}
+
+// ServoMove is the angle a servo is moved to by SyncMove
+type ServoMove struct {
+	Servo *ServoDriver
+	Angle float64
 }
 
 // NewServoDriver returns a new ServoDriver given a ServoWriter and pin.
+// The servo is written the angle until it is given a Calibration with pulse
+// widths, and MoveTo eases in and out.
 //
 // Adds the following API Commands:
 // 	"Move" - See ServoDriver.Move
+//		"MoveTo" - See ServoDriver.MoveTo, with "angle" and "duration" in milliseconds
 //		"Min" - See ServoDriver.Min
 //		"Center" - See ServoDriver.Center
 //		"Max" - See ServoDriver.Max
@@ -23,14 +65,21 @@ func NewServoDriver(a ServoWriter, pin string) *ServoDriver {
 		name:         gobot.DefaultName("Servo"),
 		connection:   a,
 		pin:          pin,
+		mutex:        &sync.Mutex{},
 		Commander:    gobot.NewCommander(),
 		CurrentAngle: 0,
+		Easing:       gobot.EaseInOut,
 	}
 
 	s.AddCommand("Move", func(params map[string]interface{}) interface{} {
 		angle := byte(params["angle"].(float64))
 		return s.Move(angle)
 	})
+	s.AddCommand("MoveTo", func(params map[string]interface{}) interface{} {
+		angle := params["angle"].(float64)
+		duration, _ := params["duration"].(float64)
+		return s.MoveTo(angle, time.Duration(duration*float64(time.Millisecond)))
+	})
 	s.AddCommand("Min", func(params map[string]interface{}) interface{} {
 		return s.Min()
 	})
@@ -57,19 +106,31 @@ func (s *ServoDriver) Pin() string { return s.pin }
 // Connection returns the ServoDrivers connection
 func (s *ServoDriver) Connection() gobot.Connection { return s.connection.(gobot.Connection) }
 
-// Start implements the Driver interface
-func (s *ServoDriver) Start() (err error) { return }
+// Start implements the Driver interface and claims the pin and its PWM
+// output
+func (s *ServoDriver) Start() (err error) {
+	if err = gobot.ClaimResources(s.Connection(), s, gobot.PinResource, s.pin); err != nil {
+		return
+	}
+	if err = gobot.ClaimResources(s.Connection(), s, gobot.PwmResource, s.pin); err != nil {
+		gobot.ReleaseResources(s.Connection(), s)
+	}
+	return
+}
 
-// Halt implements the Driver interface
-func (s *ServoDriver) Halt() (err error) { return }
+// Halt implements the Driver interface and releases the pin and its PWM
+// output
+func (s *ServoDriver) Halt() (err error) {
+	gobot.ReleaseResources(s.Connection(), s)
+	return
+}
 
 // Move sets the servo to the specified angle. Acceptable angles are 0-180
 func (s *ServoDriver) Move(angle uint8) (err error) {
 	if !(angle >= 0 && angle <= 180) {
 		return ErrServoOutOfRange
 	}
-	s.CurrentAngle = angle
-	return s.connection.ServoWrite(s.Pin(), angle)
+	return s.write(float64(angle))
 }
 
 // Min sets the servo to it's minimum position
@@ -86,3 +147,80 @@ func (s *ServoDriver) Center() (err error) {
 func (s *ServoDriver) Max() (err error) {
 	return s.Move(180)
 }
+
+// Angle returns the angle the servo was last set to
+func (s *ServoDriver) Angle() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.angle
+}
+
+// MoveTo moves the servo from its current angle to the specified angle in
+// duration, following the Easing curve, and returns when it has arrived.
+// Acceptable angles are 0-180. The move takes longer than duration if the
+// servo would otherwise be faster than MaxSpeed.
+func (s *ServoDriver) MoveTo(angle float64, duration time.Duration) (err error) {
+	return SyncMove(duration, ServoMove{Servo: s, Angle: angle})
+}
+
+// SyncMove moves several servos at once, so that they all start and arrive
+// at the same time. It returns when all of them have arrived. The move takes
+// longer than duration if any servo would be faster than its MaxSpeed.
+func SyncMove(duration time.Duration, moves ...ServoMove) (err error) {
+	from := make([]float64, len(moves))
+	for i, m := range moves {
+		if m.Angle < 0 || m.Angle > 180 {
+			return ErrServoOutOfRange
+		}
+		from[i] = m.Servo.Angle()
+		if m.Servo.MaxSpeed > 0 {
+			d := time.Duration(math.Abs(m.Angle-from[i]) / m.Servo.MaxSpeed * float64(time.Second))
+			if d > duration {
+				duration = d
+			}
+		}
+	}
+
+	start := time.Now()
+	for t := time.Duration(0); t < duration; t = time.Since(start) {
+		for i, m := range moves {
+			easing := m.Servo.Easing
+			if easing == nil {
+				easing = gobot.Linear
+			}
+			f := easing(float64(t) / float64(duration))
+			if err = m.Servo.write(from[i] + (m.Angle-from[i])*f); err != nil {
+				return
+			}
+		}
+		time.Sleep(servoFrame)
+	}
+
+	for _, m := range moves {
+		if err = m.Servo.write(m.Angle); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// write sets the servo to the calibrated angle
+func (s *ServoDriver) write(angle float64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.angle = angle
+	s.CurrentAngle = byte(angle + 0.5)
+
+	c := s.Calibration
+	if c.Inverted {
+		angle = 180 - angle
+	}
+	angle = math.Min(math.Max(angle+c.Trim, 0), 180)
+
+	if writer, ok := s.connection.(ServoPulseWriter); ok && c.MaxPulse > c.MinPulse {
+		pulse := float64(c.MinPulse) + angle/180*float64(c.MaxPulse-c.MinPulse)
+		return writer.ServoPulseWrite(s.Pin(), uint16(pulse+0.5))
+	}
+	return s.connection.ServoWrite(s.Pin(), byte(angle+0.5))
+}
@@ -3,7 +3,9 @@ package gpio
 import (
 	"errors"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -15,6 +17,23 @@ func initTestServoDriver() *ServoDriver {
 	return NewServoDriver(newGpioTestAdaptor(), "1")
 }
 
+type gpioTestServoPulseWriter struct {
+	*gpioTestAdaptor
+	mtx    sync.Mutex
+	pulses []uint16
+}
+
+func (t *gpioTestServoPulseWriter) ServoPulseWrite(pin string, us uint16) (err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.pulses = append(t.pulses, us)
+	return
+}
+
+func newGpioTestServoPulseWriter() *gpioTestServoPulseWriter {
+	return &gpioTestServoPulseWriter{gpioTestAdaptor: newGpioTestAdaptor()}
+}
+
 func TestServoDriver(t *testing.T) {
 	var err interface{}
 
@@ -39,6 +58,9 @@ func TestServoDriver(t *testing.T) {
 
 	err = d.Command("Move")(map[string]interface{}{"angle": 100.0})
 	gobottest.Assert(t, err.(error), errors.New("pwm error"))
+
+	err = d.Command("MoveTo")(map[string]interface{}{"angle": 100.0, "duration": 0.0})
+	gobottest.Assert(t, err.(error), errors.New("pwm error"))
 }
 
 func TestServoDriverStart(t *testing.T) {
@@ -46,6 +68,18 @@ func TestServoDriverStart(t *testing.T) {
 	gobottest.Assert(t, d.Start(), nil)
 }
 
+func TestServoDriverStartPwmInUse(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewServoDriver(a, "1")
+	pin := NewDirectPinDriver(a, "1")
+	gobottest.Assert(t, pin.PwmWrite(100), nil)
+	gobottest.Assert(t, d.Start(), errors.New("pwm 1 is already in use by "+pin.Name()))
+	gobottest.Assert(t, len(gobot.ResourceClaims(a)), 1)
+	gobottest.Assert(t, pin.Halt(), nil)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
 func TestServoDriverHalt(t *testing.T) {
 	d := initTestServoDriver()
 	gobottest.Assert(t, d.Halt(), nil)
@@ -77,6 +111,117 @@ func TestServoDriverCenter(t *testing.T) {
 	gobottest.Assert(t, d.CurrentAngle, uint8(90))
 }
 
+func TestServoDriverCalibration(t *testing.T) {
+	a := newGpioTestServoPulseWriter()
+	d := NewServoDriver(a, "1")
+	d.Calibration = ServoCalibration{MinPulse: 1000, MaxPulse: 2000}
+
+	gobottest.Assert(t, d.Move(90), nil)
+	gobottest.Assert(t, d.Max(), nil)
+	d.Calibration.Trim = -9
+	gobottest.Assert(t, d.Move(90), nil)
+	d.Calibration.Inverted = true
+	gobottest.Assert(t, d.Move(45), nil)
+	gobottest.Assert(t, a.pulses, []uint16{1500, 2000, 1450, 1700})
+	gobottest.Assert(t, d.CurrentAngle, uint8(45))
+}
+
+func TestServoDriverUncalibratedServoWrite(t *testing.T) {
+	a := newGpioTestServoPulseWriter()
+	d := NewServoDriver(a, "1")
+	written := false
+	a.testAdaptorServoWrite = func() (err error) {
+		written = true
+		return
+	}
+
+	gobottest.Assert(t, d.Move(90), nil)
+	gobottest.Assert(t, written, true)
+	gobottest.Assert(t, len(a.pulses), 0)
+}
+
+func TestServoDriverCalibrationServoWrite(t *testing.T) {
+	var angle byte
+	a := newGpioTestAdaptor()
+	d := NewServoDriver(&gpioTestServoWriter{a, &angle}, "1")
+	d.Calibration.Trim = 5
+	d.Calibration.Inverted = true
+
+	gobottest.Assert(t, d.Move(30), nil)
+	gobottest.Assert(t, angle, uint8(155))
+	gobottest.Assert(t, d.Move(0), nil)
+	gobottest.Assert(t, angle, uint8(180))
+}
+
+type gpioTestServoWriter struct {
+	*gpioTestAdaptor
+	angle *byte
+}
+
+func (t *gpioTestServoWriter) ServoWrite(pin string, angle byte) (err error) {
+	*t.angle = angle
+	return
+}
+
+func TestServoDriverMoveTo(t *testing.T) {
+	a := newGpioTestServoPulseWriter()
+	d := NewServoDriver(a, "1")
+	d.Calibration = ServoCalibration{MinPulse: 1000, MaxPulse: 2800}
+
+	start := time.Now()
+	gobottest.Assert(t, d.MoveTo(180, 100*time.Millisecond), nil)
+	gobottest.Assert(t, time.Since(start) >= 100*time.Millisecond, true)
+	gobottest.Assert(t, d.Angle(), 180.0)
+	gobottest.Assert(t, len(a.pulses) > 3, true)
+	gobottest.Assert(t, a.pulses[0], uint16(1000))
+	gobottest.Assert(t, a.pulses[len(a.pulses)-1], uint16(2800))
+	for i := 1; i < len(a.pulses); i++ {
+		gobottest.Assert(t, a.pulses[i] >= a.pulses[i-1], true)
+	}
+
+	gobottest.Assert(t, d.MoveTo(181, 0), ErrServoOutOfRange)
+}
+
+func TestServoDriverMoveToMaxSpeed(t *testing.T) {
+	d := initTestServoDriver()
+	d.MaxSpeed = 900
+
+	start := time.Now()
+	gobottest.Assert(t, d.MoveTo(90, 0), nil)
+	gobottest.Assert(t, time.Since(start) >= 100*time.Millisecond, true)
+	gobottest.Assert(t, d.CurrentAngle, uint8(90))
+}
+
+func TestServoDriverSyncMove(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d1 := NewServoDriver(a, "1")
+	d2 := NewServoDriver(a, "2")
+	d2.Move(180)
+
+	gobottest.Assert(t, SyncMove(50*time.Millisecond,
+		ServoMove{Servo: d1, Angle: 90}, ServoMove{Servo: d2, Angle: 90}), nil)
+	gobottest.Assert(t, d1.Angle(), 90.0)
+	gobottest.Assert(t, d2.Angle(), 90.0)
+
+	a.testAdaptorServoWrite = func() (err error) {
+		return errors.New("pwm error")
+	}
+	gobottest.Assert(t, SyncMove(50*time.Millisecond, ServoMove{Servo: d1, Angle: 0}), errors.New("pwm error"))
+}
+
+func TestServoDriverSyncMoveConcurrent(t *testing.T) {
+	d := initTestServoDriver()
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 20; i++ {
+			d.Move(uint8(i))
+		}
+		done <- true
+	}()
+	gobottest.Assert(t, SyncMove(30*time.Millisecond, ServoMove{Servo: d, Angle: 90}), nil)
+	<-done
+}
+
 func TestServoDriverDefaultName(t *testing.T) {
 	d := initTestServoDriver()
 	gobottest.Assert(t, strings.HasPrefix(d.Name(), "Servo"), true)
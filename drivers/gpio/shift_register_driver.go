@@ -0,0 +1,298 @@
+package gpio
+
+import (
+	"strconv"
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+// HC595Driver is a driver for a chain of 74HC595 shift registers, which turn
+// three pins of an adaptor into 8 outputs per register.
+//
+// It implements DigitalWriter for the outputs "0" to "7" of the first
+// register, "8" to "15" of the next one and so on, so that it can be the
+// connection of other gpio drivers.
+type HC595Driver struct {
+	name       string
+	connection DigitalWriter
+	DataPin    string
+	ClockPin   string
+	LatchPin   string
+	mutex      *sync.Mutex
+	levels     []byte
+	gobot.Commander
+}
+
+// NewHC595Driver returns a new HC595Driver for a single register given a
+// DigitalWriter and the pins connected to its SER, SRCLK and RCLK inputs.
+//
+// Optionally accepts:
+//  int: Number of chained registers
+//
+// Adds the following API Commands:
+//	"Write" - See HC595Driver.Write, with the "levels" of every register
+//	"Levels" - See HC595Driver.Levels
+func NewHC595Driver(a DigitalWriter, dataPin string, clockPin string, latchPin string, v ...int) *HC595Driver {
+	registers := 1
+	if len(v) > 0 {
+		registers = v[0]
+	}
+
+	d := &HC595Driver{
+		name:       gobot.DefaultName("HC595"),
+		connection: a,
+		DataPin:    dataPin,
+		ClockPin:   clockPin,
+		LatchPin:   latchPin,
+		mutex:      &sync.Mutex{},
+		levels:     make([]byte, registers),
+		Commander:  gobot.NewCommander(),
+	}
+
+	d.AddCommand("Write", func(params map[string]interface{}) interface{} {
+		levels := []byte{}
+		values, _ := params["levels"].([]interface{})
+		for _, v := range values {
+			level, _ := v.(float64)
+			levels = append(levels, byte(level))
+		}
+		return d.Write(levels)
+	})
+	d.AddCommand("Levels", func(params map[string]interface{}) interface{} {
+		return d.Levels()
+	})
+
+	return d
+}
+
+// Name returns the HC595Drivers name
+func (d *HC595Driver) Name() string { return d.name }
+
+// SetName sets the HC595Drivers name
+func (d *HC595Driver) SetName(n string) { d.name = n }
+
+// Connection returns the HC595Drivers Connection
+func (d *HC595Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Connect implements the Connection interface, so that gpio drivers can
+// claim the outputs. The register itself is started as a driver.
+func (d *HC595Driver) Connect() (err error) { return }
+
+// Finalize implements the Connection interface
+func (d *HC595Driver) Finalize() (err error) { return }
+
+// Start claims the pins and sets all outputs low
+func (d *HC595Driver) Start() (err error) {
+	if err = gobot.ClaimResources(d.Connection(), d, gobot.PinResource, d.DataPin, d.ClockPin, d.LatchPin); err != nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.shiftOut()
+}
+
+// Halt releases the pins
+func (d *HC595Driver) Halt() (err error) {
+	gobot.ReleaseResources(d.Connection(), d)
+	return
+}
+
+// DigitalWrite writes the level to an output
+func (d *HC595Driver) DigitalWrite(pin string, level byte) (err error) {
+	n, err := shiftRegisterPin(pin, len(d.levels))
+	if err != nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if level == 0 {
+		d.levels[n/8] &^= 1 << uint(n%8)
+	} else {
+		d.levels[n/8] |= 1 << uint(n%8)
+	}
+	return d.shiftOut()
+}
+
+// Write writes the levels of all outputs, a byte for every register with
+// output 0 as its lowest bit
+func (d *HC595Driver) Write(levels []byte) (err error) {
+	if len(levels) != len(d.levels) {
+		return ErrInvalidPin
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	copy(d.levels, levels)
+	return d.shiftOut()
+}
+
+// Levels returns the levels of the outputs, a byte for every register
+func (d *HC595Driver) Levels() []byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return append([]byte{}, d.levels...)
+}
+
+// shiftOut shifts the levels into the registers, the last register first,
+// and latches them to the outputs
+func (d *HC595Driver) shiftOut() (err error) {
+	if err = d.connection.DigitalWrite(d.LatchPin, 0); err != nil {
+		return
+	}
+	for r := len(d.levels) - 1; r >= 0; r-- {
+		for bit := 7; bit >= 0; bit-- {
+			if err = d.connection.DigitalWrite(d.DataPin, (d.levels[r]>>uint(bit))&1); err != nil {
+				return
+			}
+			if err = d.connection.DigitalWrite(d.ClockPin, 1); err != nil {
+				return
+			}
+			if err = d.connection.DigitalWrite(d.ClockPin, 0); err != nil {
+				return
+			}
+		}
+	}
+	return d.connection.DigitalWrite(d.LatchPin, 1)
+}
+
+// HC165Driver is a driver for a chain of 74HC165 shift registers, which turn
+// three pins of an adaptor into 8 inputs per register. The CLK INH input of
+// the registers must be tied low.
+//
+// It implements DigitalReader for the inputs "0" to "7" of the first
+// register, whose QH output is connected to the adaptor, "8" to "15" of the
+// next one and so on, so that it can be the connection of other gpio
+// drivers.
+type HC165Driver struct {
+	name       string
+	connection DigitalReadWriter
+	DataPin    string
+	ClockPin   string
+	LoadPin    string
+	registers  int
+	mutex      *sync.Mutex
+	gobot.Commander
+}
+
+// NewHC165Driver returns a new HC165Driver for a single register given a
+// DigitalReadWriter and the pins connected to its QH output and its CLK and
+// SH/LD inputs.
+//
+// Optionally accepts:
+//  int: Number of chained registers
+//
+// Adds the following API Commands:
+//	"Read" - See HC165Driver.Read
+func NewHC165Driver(a DigitalReadWriter, dataPin string, clockPin string, loadPin string, v ...int) *HC165Driver {
+	d := &HC165Driver{
+		name:       gobot.DefaultName("HC165"),
+		connection: a,
+		DataPin:    dataPin,
+		ClockPin:   clockPin,
+		LoadPin:    loadPin,
+		registers:  1,
+		mutex:      &sync.Mutex{},
+		Commander:  gobot.NewCommander(),
+	}
+
+	if len(v) > 0 {
+		d.registers = v[0]
+	}
+
+	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
+		levels, err := d.Read()
+		if err != nil {
+			return err
+		}
+		return levels
+	})
+
+	return d
+}
+
+// Name returns the HC165Drivers name
+func (d *HC165Driver) Name() string { return d.name }
+
+// SetName sets the HC165Drivers name
+func (d *HC165Driver) SetName(n string) { d.name = n }
+
+// Connection returns the HC165Drivers Connection
+func (d *HC165Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Connect implements the Connection interface, so that gpio drivers can
+// claim the inputs. The register itself is started as a driver.
+func (d *HC165Driver) Connect() (err error) { return }
+
+// Finalize implements the Connection interface
+func (d *HC165Driver) Finalize() (err error) { return }
+
+// Start claims the pins
+func (d *HC165Driver) Start() (err error) {
+	if err = gobot.ClaimResources(d.Connection(), d, gobot.PinResource, d.DataPin, d.ClockPin, d.LoadPin); err != nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.connection.DigitalWrite(d.ClockPin, 0)
+}
+
+// Halt releases the pins
+func (d *HC165Driver) Halt() (err error) {
+	gobot.ReleaseResources(d.Connection(), d)
+	return
+}
+
+// DigitalRead reads the level of an input
+func (d *HC165Driver) DigitalRead(pin string) (val int, err error) {
+	n, err := shiftRegisterPin(pin, d.registers)
+	if err != nil {
+		return
+	}
+	levels, err := d.Read()
+	if err != nil {
+		return
+	}
+	return int(levels[n/8]>>uint(n%8)) & 1, nil
+}
+
+// Read loads the inputs into the registers and shifts them in, returning a
+// byte for every register with input 0 as its lowest bit
+func (d *HC165Driver) Read() (levels []byte, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err = d.connection.DigitalWrite(d.LoadPin, 0); err != nil {
+		return
+	}
+	if err = d.connection.DigitalWrite(d.LoadPin, 1); err != nil {
+		return
+	}
+	levels = make([]byte, d.registers)
+	for r := range levels {
+		for bit := 7; bit >= 0; bit-- {
+			val, err := d.connection.DigitalRead(d.DataPin)
+			if err != nil {
+				return nil, err
+			}
+			if val != 0 {
+				levels[r] |= 1 << uint(bit)
+			}
+			if err = d.connection.DigitalWrite(d.ClockPin, 1); err != nil {
+				return nil, err
+			}
+			if err = d.connection.DigitalWrite(d.ClockPin, 0); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return
+}
+
+// shiftRegisterPin returns the number of a pin of a chain of registers
+func shiftRegisterPin(pin string, registers int) (int, error) {
+	n, err := strconv.Atoi(pin)
+	if err != nil || n < 0 || n >= 8*registers {
+		return 0, ErrInvalidPin
+	}
+	return n, nil
+}
@@ -0,0 +1,173 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*HC595Driver)(nil)
+var _ gobot.Connection = (*HC595Driver)(nil)
+var _ DigitalWriter = (*HC595Driver)(nil)
+var _ gobot.Driver = (*HC165Driver)(nil)
+var _ gobot.Connection = (*HC165Driver)(nil)
+var _ DigitalReader = (*HC165Driver)(nil)
+
+// shiftRegisterAdaptor simulates a chain of 74HC595 registers on the pins
+// "data", "clock" and "latch", and a chain of 74HC165 registers on the pins
+// "qh", "clk" and "load"
+type shiftRegisterAdaptor struct {
+	*gpioTestAdaptor
+	levels  map[string]byte
+	shifted []byte // bits shifted into the 74HC595 chain
+	outputs []byte // bits latched to the outputs of the 74HC595 chain
+	inputs  []byte // levels of the 74HC165 inputs, register 0 first
+	loaded  []byte // bits in the 74HC165 chain, the next at QH first
+	err     error
+}
+
+func newShiftRegisterAdaptor() *shiftRegisterAdaptor {
+	return &shiftRegisterAdaptor{gpioTestAdaptor: newGpioTestAdaptor(), levels: map[string]byte{}}
+}
+
+func (a *shiftRegisterAdaptor) DigitalWrite(pin string, level byte) error {
+	if a.err != nil {
+		return a.err
+	}
+	rising := a.levels[pin] == 0 && level == 1
+	a.levels[pin] = level
+	switch {
+	case pin == "clock" && rising:
+		a.shifted = append([]byte{a.levels["data"]}, a.shifted...)
+	case pin == "latch" && rising:
+		a.outputs = append([]byte{}, a.shifted...)
+	case pin == "clk" && rising && len(a.loaded) > 0:
+		a.loaded = a.loaded[1:]
+	case pin == "load" && level == 0:
+		a.loaded = nil
+		for _, b := range a.inputs {
+			for bit := 7; bit >= 0; bit-- {
+				a.loaded = append(a.loaded, (b>>uint(bit))&1)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *shiftRegisterAdaptor) DigitalRead(pin string) (int, error) {
+	if a.err != nil {
+		return 0, a.err
+	}
+	if pin != "qh" || len(a.loaded) == 0 {
+		return 0, nil
+	}
+	return int(a.loaded[0]), nil
+}
+
+// output returns the level of output n of the 74HC595 chain, whose first
+// register holds the last bits shifted in
+func (a *shiftRegisterAdaptor) output(n int) byte {
+	r, bit := n/8, n%8
+	return a.outputs[8*r+bit]
+}
+
+func TestHC595Driver(t *testing.T) {
+	a := newShiftRegisterAdaptor()
+	d := NewHC595Driver(a, "data", "clock", "latch", 2)
+	gobottest.Assert(t, d.Connection().(*shiftRegisterAdaptor), a)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "HC595"), true)
+	d.SetName("outputs")
+	gobottest.Assert(t, d.Name(), "outputs")
+	gobottest.Assert(t, d.Connect(), nil)
+	gobottest.Assert(t, d.Finalize(), nil)
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, len(a.outputs), 16)
+	gobottest.Refute(t, NewHC595Driver(a, "data", "x", "y").Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestHC595DriverDigitalWrite(t *testing.T) {
+	a := newShiftRegisterAdaptor()
+	d := NewHC595Driver(a, "data", "clock", "latch", 2)
+	gobottest.Assert(t, d.Start(), nil)
+
+	gobottest.Assert(t, d.DigitalWrite("2", 1), nil)
+	gobottest.Assert(t, d.DigitalWrite("9", 1), nil)
+	gobottest.Assert(t, d.Levels(), []byte{0x04, 0x02})
+	for n := 0; n < 16; n++ {
+		expected := byte(0)
+		if n == 2 || n == 9 {
+			expected = 1
+		}
+		gobottest.Assert(t, a.output(n), expected)
+	}
+
+	gobottest.Assert(t, d.DigitalWrite("16", 1), ErrInvalidPin)
+	gobottest.Assert(t, d.DigitalWrite("x", 1), ErrInvalidPin)
+
+	a.err = errors.New("write error")
+	gobottest.Assert(t, d.DigitalWrite("2", 0), errors.New("write error"))
+}
+
+func TestHC595DriverWrite(t *testing.T) {
+	a := newShiftRegisterAdaptor()
+	d := NewHC595Driver(a, "data", "clock", "latch", 2)
+	gobottest.Assert(t, d.Command("Write")(map[string]interface{}{"levels": []interface{}{1.0, 128.0}}), nil)
+	gobottest.Assert(t, d.Command("Levels")(nil), []byte{0x01, 0x80})
+	gobottest.Assert(t, a.output(0), byte(1))
+	gobottest.Assert(t, a.output(15), byte(1))
+	gobottest.Assert(t, d.Write([]byte{1}), ErrInvalidPin)
+}
+
+func TestHC595DriverLed(t *testing.T) {
+	a := newShiftRegisterAdaptor()
+	d := NewHC595Driver(a, "data", "clock", "latch")
+	led := NewLedDriver(d, "5")
+	gobottest.Assert(t, led.Start(), nil)
+	gobottest.Assert(t, led.On(), nil)
+	gobottest.Assert(t, a.output(5), byte(1))
+	gobottest.Refute(t, NewLedDriver(d, "5").Start(), nil)
+}
+
+func TestHC165Driver(t *testing.T) {
+	a := newShiftRegisterAdaptor()
+	d := NewHC165Driver(a, "qh", "clk", "load", 2)
+	gobottest.Assert(t, d.Connection().(*shiftRegisterAdaptor), a)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "HC165"), true)
+	d.SetName("inputs")
+	gobottest.Assert(t, d.Name(), "inputs")
+	gobottest.Assert(t, d.Connect(), nil)
+	gobottest.Assert(t, d.Finalize(), nil)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestHC165DriverRead(t *testing.T) {
+	a := newShiftRegisterAdaptor()
+	d := NewHC165Driver(a, "qh", "clk", "load", 2)
+	gobottest.Assert(t, d.Start(), nil)
+
+	a.inputs = []byte{0x81, 0x10}
+	levels, err := d.Read()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, levels, []byte{0x81, 0x10})
+	gobottest.Assert(t, d.Command("Read")(nil), []byte{0x81, 0x10})
+
+	val, err := d.DigitalRead("7")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+	val, _ = d.DigitalRead("12")
+	gobottest.Assert(t, val, 1)
+	val, _ = d.DigitalRead("13")
+	gobottest.Assert(t, val, 0)
+
+	_, err = d.DigitalRead("16")
+	gobottest.Assert(t, err, ErrInvalidPin)
+	a.err = errors.New("read error")
+	_, err = d.DigitalRead("1")
+	gobottest.Assert(t, err, errors.New("read error"))
+}
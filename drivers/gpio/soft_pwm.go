@@ -0,0 +1,395 @@
+package gpio
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// SoftPwm turns the pins of any DigitalWriter into PWM and servo outputs by
+// toggling them in software. The pins of all SoftPwms share a single timing
+// loop, which sleeps until shortly before every edge and then spins until it
+// is due.
+//
+// It wraps adaptors without hardware PWM, e.g. the pins of an MCP23017, so
+// that they can be used with the LedDriver, DirectPinDriver or ServoDriver.
+type SoftPwm struct {
+	name       string
+	connection DigitalWriter
+	// Frequency is the PWM frequency in Hertz of pins which were not set
+	// with SetFrequency and are not servos
+	Frequency float64
+	// Spin is how long before an edge the loop stops sleeping and spins, a
+	// longer one lowers the jitter at the cost of CPU time. The loop spins
+	// the longest Spin of the SoftPwms sharing it.
+	Spin time.Duration
+
+	mutex  *sync.Mutex
+	pins   map[string]*softPwmPin
+	loop   *softPwmLoop
+	jitter jitterStats
+	gobot.Eventer
+}
+
+type softPwmPin struct {
+	period time.Duration
+	high   time.Duration
+	level  byte
+	start  time.Time // start of the current period
+	next   time.Time // next edge, zero if the level is constant
+}
+
+// JitterStats describes how late the edges of a SoftPwm were toggled
+type JitterStats struct {
+	Edges  int
+	Mean   time.Duration
+	StdDev time.Duration
+	Max    time.Duration
+}
+
+type jitterStats struct {
+	edges int
+	mean  float64
+	m2    float64
+	max   time.Duration
+}
+
+// add adds a sample by Welford's online algorithm
+func (j *jitterStats) add(late time.Duration) {
+	j.edges++
+	delta := float64(late) - j.mean
+	j.mean += delta / float64(j.edges)
+	j.m2 += delta * (float64(late) - j.mean)
+	if late > j.max {
+		j.max = late
+	}
+}
+
+// NewSoftPwm returns a new SoftPwm with a frequency of 100 Hertz and a spin
+// of 1 Millisecond given a DigitalWriter.
+//
+// Optionally accepts:
+// 	float64: Frequency in Hertz
+func NewSoftPwm(a DigitalWriter, v ...float64) *SoftPwm {
+	p := &SoftPwm{
+		name:       gobot.DefaultName("SoftPwm"),
+		connection: a,
+		Frequency:  100,
+		Spin:       time.Millisecond,
+		mutex:      &sync.Mutex{},
+		pins:       map[string]*softPwmPin{},
+		loop:       sharedSoftPwmLoop,
+		Eventer:    gobot.NewEventer(),
+	}
+
+	if len(v) > 0 {
+		p.Frequency = v[0]
+	}
+
+	p.AddEvent(Error)
+
+	return p
+}
+
+// Name returns the SoftPwms name
+func (p *SoftPwm) Name() string { return p.name }
+
+// SetName sets the SoftPwms name
+func (p *SoftPwm) SetName(n string) { p.name = n }
+
+// Connect implements the Connection interface, the wrapped adaptor is
+// connected on its own
+func (p *SoftPwm) Connect() (err error) { return }
+
+// Finalize removes the pins from the timing loop and sets them low
+func (p *SoftPwm) Finalize() (err error) {
+	p.loop.remove(p)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for pin := range p.pins {
+		if e := p.connection.DigitalWrite(pin, 0); err == nil {
+			err = e
+		}
+	}
+	p.pins = map[string]*softPwmPin{}
+	return
+}
+
+// SetFrequency sets the PWM frequency of the pin in Hertz
+func (p *SoftPwm) SetFrequency(pin string, hz float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	s := p.pin(pin)
+	duty := 0.0
+	if s.period > 0 {
+		duty = float64(s.high) / float64(s.period)
+	}
+	s.period = time.Duration(float64(time.Second) / hz)
+	s.high = time.Duration(duty * float64(s.period))
+}
+
+// DigitalWrite stops the PWM of the pin and writes the level to it
+func (p *SoftPwm) DigitalWrite(pin string, level byte) (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.pins, pin)
+	return p.connection.DigitalWrite(pin, level)
+}
+
+// DigitalRead reads the pin, if the wrapped adaptor is a DigitalReader
+func (p *SoftPwm) DigitalRead(pin string) (val int, err error) {
+	if reader, ok := p.connection.(DigitalReader); ok {
+		return reader.DigitalRead(pin)
+	}
+	return 0, ErrDigitalReadUnsupported
+}
+
+// PwmWrite sets the duty cycle of the pin to level/255
+func (p *SoftPwm) PwmWrite(pin string, level byte) (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	s := p.pin(pin)
+	return p.setHigh(pin, s, time.Duration(float64(level)/255*float64(s.period)))
+}
+
+// ServoWrite sets the pin to the servo pulse of the angle, from 544 to 2400
+// microseconds
+func (p *SoftPwm) ServoWrite(pin string, angle byte) (err error) {
+	if angle > 180 {
+		return ErrServoOutOfRange
+	}
+	return p.ServoPulseWrite(pin, uint16(544+float64(angle)/180*(2400-544)+0.5))
+}
+
+// ServoPulseWrite sets the pin to pulses of us microseconds at 50 Hertz
+func (p *SoftPwm) ServoPulseWrite(pin string, us uint16) (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	s := p.pin(pin)
+	s.period = 20 * time.Millisecond
+	return p.setHigh(pin, s, time.Duration(us)*time.Microsecond)
+}
+
+// Jitter returns how late the edges were toggled since the SoftPwm was
+// created or the statistics were reset
+func (p *SoftPwm) Jitter() JitterStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	j := p.jitter
+	stats := JitterStats{Edges: j.edges, Mean: time.Duration(j.mean), Max: j.max}
+	if j.edges > 1 {
+		stats.StdDev = time.Duration(math.Sqrt(j.m2 / float64(j.edges-1)))
+	}
+	return stats
+}
+
+// ResetJitter resets the jitter statistics
+func (p *SoftPwm) ResetJitter() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.jitter = jitterStats{}
+}
+
+// pin returns the state of the pin, adding it at the frequency
+func (p *SoftPwm) pin(pin string) *softPwmPin {
+	s, ok := p.pins[pin]
+	if !ok {
+		s = &softPwmPin{period: time.Duration(float64(time.Second) / p.Frequency)}
+		p.pins[pin] = s
+	}
+	return s
+}
+
+// setHigh sets the time the pin is high in every period. Pins which are
+// always low or high are written once and left out of the loop.
+func (p *SoftPwm) setHigh(pin string, s *softPwmPin, high time.Duration) error {
+	s.high = high
+	if high <= 0 || high >= s.period {
+		s.next = time.Time{}
+		s.level = 0
+		if high > 0 {
+			s.level = 1
+		}
+		return p.connection.DigitalWrite(pin, s.level)
+	}
+
+	if s.next.IsZero() {
+		// start a period with the next turn of the loop
+		s.level = 0
+		s.next = p.loop.now()
+	} else if s.level == 1 {
+		s.next = s.start.Add(high)
+	}
+
+	p.loop.add(p)
+	return nil
+}
+
+// next returns the next edge of the pins, zero if there is none
+func (p *SoftPwm) next() (next time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, s := range p.pins {
+		if !s.next.IsZero() && (next.IsZero() || s.next.Before(next)) {
+			next = s.next
+		}
+	}
+	return
+}
+
+// toggle writes the edges which are due at now and schedules the following
+// ones
+func (p *SoftPwm) toggle(now time.Time) (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for pin, s := range p.pins {
+		if s.next.IsZero() || s.next.After(now) {
+			continue
+		}
+		p.jitter.add(now.Sub(s.next))
+
+		if s.level == 1 {
+			s.level = 0
+			s.next = s.start.Add(s.period)
+		} else {
+			s.start = s.next
+			if now.Sub(s.start) > s.period {
+				// skip the periods which were missed
+				s.start = now
+			}
+			s.level = 1
+			s.next = s.start.Add(s.high)
+		}
+		if e := p.connection.DigitalWrite(pin, s.level); err == nil {
+			err = e
+		}
+	}
+	return
+}
+
+// softPwmLoop is a timing loop which toggles the pins of the SoftPwms added
+// to it at their edges. Its goroutine runs while SoftPwms are added.
+type softPwmLoop struct {
+	now     func() time.Time
+	manual  bool // the edges are toggled by step instead of the goroutine
+	mutex   *sync.Mutex
+	pwms    map[*SoftPwm]bool
+	wake    chan bool
+	running bool
+}
+
+// sharedSoftPwmLoop is the timing loop of all SoftPwms
+var sharedSoftPwmLoop = newSoftPwmLoop(time.Now)
+
+func newSoftPwmLoop(now func() time.Time) *softPwmLoop {
+	return &softPwmLoop{
+		now:   now,
+		mutex: &sync.Mutex{},
+		pwms:  map[*SoftPwm]bool{},
+		wake:  make(chan bool, 1),
+	}
+}
+
+// add adds the SoftPwm, whose edges changed, and wakes the loop
+func (l *softPwmLoop) add(p *SoftPwm) {
+	l.mutex.Lock()
+	l.pwms[p] = true
+	if !l.running && !l.manual {
+		l.running = true
+		go l.run()
+	}
+	l.mutex.Unlock()
+	l.notify()
+}
+
+// remove removes the SoftPwm, the goroutine ends with the last one
+func (l *softPwmLoop) remove(p *SoftPwm) {
+	l.mutex.Lock()
+	delete(l.pwms, p)
+	l.mutex.Unlock()
+	l.notify()
+}
+
+func (l *softPwmLoop) notify() {
+	select {
+	case l.wake <- true:
+	default:
+	}
+}
+
+// next returns the next edge of the SoftPwms and the longest spin of them,
+// a zero edge if there is none. ok is false once all of them are removed.
+func (l *softPwmLoop) next() (next time.Time, spin time.Duration, ok bool) {
+	l.mutex.Lock()
+	pwms := make([]*SoftPwm, 0, len(l.pwms))
+	for p := range l.pwms {
+		pwms = append(pwms, p)
+	}
+	l.mutex.Unlock()
+
+	for _, p := range pwms {
+		edge := p.next()
+		if !edge.IsZero() && (next.IsZero() || edge.Before(next)) {
+			next = edge
+		}
+		if p.Spin > spin {
+			spin = p.Spin
+		}
+	}
+	return next, spin, len(pwms) > 0
+}
+
+// step writes the edges of the SoftPwms which are due at now
+func (l *softPwmLoop) step(now time.Time) {
+	l.mutex.Lock()
+	pwms := make([]*SoftPwm, 0, len(l.pwms))
+	for p := range l.pwms {
+		pwms = append(pwms, p)
+	}
+	l.mutex.Unlock()
+
+	for _, p := range pwms {
+		if err := p.toggle(now); err != nil {
+			p.Publish(Error, err)
+		}
+	}
+}
+
+// run toggles the pins at their edges until all SoftPwms are removed
+func (l *softPwmLoop) run() {
+	for {
+		next, spin, ok := l.next()
+		if !ok {
+			l.mutex.Lock()
+			if len(l.pwms) == 0 {
+				l.running = false
+				l.mutex.Unlock()
+				return
+			}
+			l.mutex.Unlock()
+			continue
+		}
+
+		if next.IsZero() {
+			<-l.wake
+			continue
+		}
+
+		if wait := next.Sub(l.now()) - spin; wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-l.wake:
+				timer.Stop()
+				continue
+			}
+		}
+		for l.now().Before(next) {
+		}
+
+		l.step(l.now())
+	}
+}
@@ -0,0 +1,301 @@
+package gpio
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Connection = (*SoftPwm)(nil)
+var _ PwmWriter = (*SoftPwm)(nil)
+var _ ServoWriter = (*SoftPwm)(nil)
+var _ ServoPulseWriter = (*SoftPwm)(nil)
+
+type softPwmEdge struct {
+	level byte
+	at    time.Time
+}
+
+// recordingWriter records the levels written to its pins and when
+type recordingWriter struct {
+	mtx   sync.Mutex
+	edges map[string][]softPwmEdge
+	now   func() time.Time
+	err   error
+}
+
+func (r *recordingWriter) DigitalWrite(pin string, level byte) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.edges[pin] = append(r.edges[pin], softPwmEdge{level: level, at: r.now()})
+	return r.err
+}
+
+func (r *recordingWriter) pin(pin string) []softPwmEdge {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([]softPwmEdge{}, r.edges[pin]...)
+}
+
+func newRecordingWriter() *recordingWriter {
+	return &recordingWriter{edges: map[string][]softPwmEdge{}, now: time.Now}
+}
+
+// virtualClock is the time of a timing loop which is stepped by the tests
+type virtualClock struct {
+	mtx sync.Mutex
+	t   time.Time
+}
+
+func (c *virtualClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.t
+}
+
+func (c *virtualClock) set(t time.Time) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.t = t
+}
+
+// run toggles every edge of the loop on time until d has passed
+func (c *virtualClock) run(l *softPwmLoop, d time.Duration) {
+	until := c.Now().Add(d)
+	for {
+		next, _, _ := l.next()
+		if next.IsZero() || next.After(until) {
+			break
+		}
+		c.set(next)
+		l.step(next)
+	}
+	c.set(until)
+}
+
+// initTestSoftPwm returns a SoftPwm of a writer recording the edges, on a
+// timing loop in virtual time
+func initTestSoftPwm() (*SoftPwm, *recordingWriter, *virtualClock) {
+	clock := &virtualClock{t: time.Unix(0, 0)}
+	w := newRecordingWriter()
+	w.now = clock.Now
+	p := NewSoftPwm(w)
+	p.loop = newSoftPwmLoop(clock.Now)
+	p.loop.manual = true
+	return p, w, clock
+}
+
+// highTimes returns the durations of the complete high pulses
+func highTimes(edges []softPwmEdge) (highs []time.Duration) {
+	for i := 1; i < len(edges); i++ {
+		if edges[i-1].level == 1 && edges[i].level == 0 {
+			highs = append(highs, edges[i].at.Sub(edges[i-1].at))
+		}
+	}
+	return
+}
+
+// risingEdges returns the times the pin went high
+func risingEdges(edges []softPwmEdge) (rising []time.Time) {
+	for _, e := range edges {
+		if e.level == 1 {
+			rising = append(rising, e.at)
+		}
+	}
+	return
+}
+
+func TestSoftPwm(t *testing.T) {
+	p := NewSoftPwm(newRecordingWriter(), 200)
+	gobottest.Assert(t, p.Frequency, 200.0)
+	gobottest.Assert(t, p.loop, sharedSoftPwmLoop)
+	gobottest.Assert(t, p.Connect(), nil)
+	gobottest.Assert(t, p.Finalize(), nil)
+
+	p.SetName("mypwm")
+	gobottest.Assert(t, p.Name(), "mypwm")
+	gobottest.Assert(t, NewSoftPwm(newRecordingWriter()).Frequency, 100.0)
+}
+
+func TestSoftPwmPwmWrite(t *testing.T) {
+	p, w, clock := initTestSoftPwm()
+	start := clock.Now()
+	gobottest.Assert(t, p.PwmWrite("3", 64), nil)
+	clock.run(p.loop, 105*time.Millisecond)
+
+	level := 64.0
+	high := time.Duration(level / 255 * float64(10*time.Millisecond))
+	highs := highTimes(w.pin("3"))
+	gobottest.Assert(t, len(highs), 11)
+	for _, h := range highs {
+		gobottest.Assert(t, h, high)
+	}
+	for i, at := range risingEdges(w.pin("3")) {
+		gobottest.Assert(t, at, start.Add(time.Duration(i)*10*time.Millisecond))
+	}
+	gobottest.Assert(t, p.Jitter(), JitterStats{Edges: 22})
+
+	gobottest.Assert(t, p.Finalize(), nil)
+	// the pin is left low
+	edges := w.pin("3")
+	gobottest.Assert(t, edges[len(edges)-1].level, byte(0))
+	next, _, ok := p.loop.next()
+	gobottest.Assert(t, next.IsZero(), true)
+	gobottest.Assert(t, ok, false)
+}
+
+func TestSoftPwmJitter(t *testing.T) {
+	p, _, clock := initTestSoftPwm()
+	p.PwmWrite("3", 128)
+	next, _, _ := p.loop.next()
+	p.loop.step(next.Add(2 * time.Millisecond))
+	clock.set(next.Add(2 * time.Millisecond))
+	next, _, _ = p.loop.next()
+	p.loop.step(next)
+
+	gobottest.Assert(t, p.Jitter(), JitterStats{
+		Edges:  2,
+		Mean:   time.Millisecond,
+		StdDev: time.Duration(math.Sqrt(2) * float64(time.Millisecond)),
+		Max:    2 * time.Millisecond,
+	})
+	p.ResetJitter()
+	gobottest.Assert(t, p.Jitter(), JitterStats{})
+	p.Finalize()
+}
+
+func TestSoftPwmConstantLevels(t *testing.T) {
+	p, w, clock := initTestSoftPwm()
+	gobottest.Assert(t, p.PwmWrite("1", 255), nil)
+	gobottest.Assert(t, p.PwmWrite("2", 0), nil)
+	next, _, _ := p.loop.next()
+	gobottest.Assert(t, next.IsZero(), true)
+	clock.run(p.loop, 30*time.Millisecond)
+	gobottest.Assert(t, len(w.pin("1")), 1)
+	gobottest.Assert(t, w.pin("1")[0].level, byte(1))
+	gobottest.Assert(t, len(w.pin("2")), 1)
+	gobottest.Assert(t, w.pin("2")[0].level, byte(0))
+	gobottest.Assert(t, p.Jitter().Edges, 0)
+	gobottest.Assert(t, p.Finalize(), nil)
+}
+
+func TestSoftPwmDigitalWrite(t *testing.T) {
+	p, w, clock := initTestSoftPwm()
+	p.PwmWrite("3", 128)
+	clock.run(p.loop, 25*time.Millisecond)
+	gobottest.Assert(t, p.DigitalWrite("3", 1), nil)
+	n := len(w.pin("3"))
+	clock.run(p.loop, 25*time.Millisecond)
+	gobottest.Assert(t, len(w.pin("3")), n)
+	gobottest.Assert(t, w.pin("3")[n-1].level, byte(1))
+	gobottest.Assert(t, p.Finalize(), nil)
+
+	_, err := p.DigitalRead("3")
+	gobottest.Assert(t, err, ErrDigitalReadUnsupported)
+	val, err := NewSoftPwm(newGpioTestAdaptor()).DigitalRead("3")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+}
+
+func TestSoftPwmSetFrequency(t *testing.T) {
+	p, w, clock := initTestSoftPwm()
+	p.SetFrequency("3", 50)
+	p.PwmWrite("3", 51)
+	clock.run(p.loop, 70*time.Millisecond)
+	p.Finalize()
+
+	highs := highTimes(w.pin("3"))
+	gobottest.Assert(t, len(highs), 4)
+	for _, h := range highs {
+		gobottest.Assert(t, h, 4*time.Millisecond)
+	}
+	rising := risingEdges(w.pin("3"))
+	gobottest.Assert(t, rising[1].Sub(rising[0]), 20*time.Millisecond)
+}
+
+func TestSoftPwmServo(t *testing.T) {
+	p, w, clock := initTestSoftPwm()
+	s := NewServoDriver(p, "5")
+	gobottest.Assert(t, s.Move(90), nil)
+	clock.run(p.loop, 65*time.Millisecond)
+	p.Finalize()
+
+	highs := highTimes(w.pin("5"))
+	gobottest.Assert(t, len(highs), 4)
+	for _, h := range highs {
+		gobottest.Assert(t, h, 1472*time.Microsecond)
+	}
+	rising := risingEdges(w.pin("5"))
+	gobottest.Assert(t, rising[1].Sub(rising[0]), 20*time.Millisecond)
+	gobottest.Assert(t, p.ServoWrite("5", 181), ErrServoOutOfRange)
+}
+
+func TestSoftPwmSharedLoop(t *testing.T) {
+	p1, w1, clock := initTestSoftPwm()
+	w2 := newRecordingWriter()
+	w2.now = clock.Now
+	p2 := NewSoftPwm(w2, 50)
+	p2.loop = p1.loop
+	p2.Spin = 2 * time.Millisecond
+
+	p1.PwmWrite("1", 128)
+	p2.PwmWrite("1", 128)
+	_, spin, _ := p1.loop.next()
+	gobottest.Assert(t, spin, 2*time.Millisecond)
+	clock.run(p1.loop, 45*time.Millisecond)
+	gobottest.Assert(t, len(risingEdges(w1.pin("1"))), 5)
+	gobottest.Assert(t, len(risingEdges(w2.pin("1"))), 3)
+
+	p1.Finalize()
+	n := len(w1.pin("1"))
+	clock.run(p1.loop, 45*time.Millisecond)
+	gobottest.Assert(t, len(w1.pin("1")), n)
+	gobottest.Assert(t, len(risingEdges(w2.pin("1"))), 5)
+	p2.Finalize()
+}
+
+func TestSoftPwmError(t *testing.T) {
+	w := newRecordingWriter()
+	w.err = errors.New("write error")
+	p := NewSoftPwm(w)
+	sem := make(chan bool, 1)
+	p.Once(Error, func(data interface{}) {
+		gobottest.Assert(t, data.(error).Error(), "write error")
+		sem <- true
+	})
+	gobottest.Assert(t, p.PwmWrite("3", 128), nil)
+	select {
+	case <-sem:
+	case <-time.After(time.Second):
+		t.Errorf("SoftPwm Event \"Error\" was not published")
+	}
+	p.Finalize()
+}
+
+func TestSoftPwmWithDrivers(t *testing.T) {
+	p := NewSoftPwm(newRecordingWriter())
+	gobottest.Assert(t, NewDirectPinDriver(p, "1").PwmWrite(100), nil)
+	gobottest.Assert(t, NewLedDriver(p, "2").Brightness(100), nil)
+	gobottest.Assert(t, NewDirectPinDriver(p, "3").ServoWrite(100), nil)
+	gobottest.Assert(t, p.Finalize(), nil)
+
+	// the goroutine of the loop ends with its last SoftPwm
+	deadline := time.Now().Add(time.Second)
+	for {
+		p.loop.mutex.Lock()
+		running := p.loop.running
+		p.loop.mutex.Unlock()
+		if !running {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("SoftPwm loop is still running")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
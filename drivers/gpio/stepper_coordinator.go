@@ -0,0 +1,254 @@
+package gpio
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrStepperCoordinatorAxes is the error resulting when a coordinated move
+// does not have one angle or position per axis
+var ErrStepperCoordinatorAxes = errors.New("Stepper coordinator needs one value per axis")
+
+// StepperCoordinator moves several stepper motors together, so that all axes
+// start and arrive at the same time, e.g. for straight lines of a plotter.
+// The longest move is planned with the speed, acceleration and profile of its
+// motor, limited so that no other axis moves faster than its own settings,
+// and the other axes step in proportion to it.
+type StepperCoordinator struct {
+	Axes []*StepperMotorDriver
+
+	mutex *sync.Mutex
+	stop  chan bool
+	done  chan bool
+	err   error
+}
+
+// NewStepperCoordinator returns a new StepperCoordinator of the axes
+func NewStepperCoordinator(axes ...*StepperMotorDriver) *StepperCoordinator {
+	return &StepperCoordinator{
+		Axes:  axes,
+		mutex: &sync.Mutex{},
+	}
+}
+
+// Move moves every axis by its angle in degrees and waits for the move to
+// finish
+func (c *StepperCoordinator) Move(angles ...float64) error {
+	if err := c.StartMove(angles...); err != nil {
+		return err
+	}
+	return c.Wait()
+}
+
+// MoveTo moves every axis to its position in degrees and waits for the move
+// to finish
+func (c *StepperCoordinator) MoveTo(positions ...float64) error {
+	if len(positions) != len(c.Axes) {
+		return ErrStepperCoordinatorAxes
+	}
+	angles := make([]float64, len(positions))
+	for i, axis := range c.Axes {
+		angles[i] = positions[i] - axis.Position()
+	}
+	return c.Move(angles...)
+}
+
+// StartMove starts moving every axis by its angle in degrees without waiting
+// for the move to finish. The axes are busy until the move has finished, and
+// their positions and velocities can be queried while it is running.
+func (c *StepperCoordinator) StartMove(angles ...float64) (err error) {
+	return c.startMove(angles, NewMotionPlan)
+}
+
+// startMove starts a move of the angles which is planned by newPlan, with
+// the limits of the axes in steps of the longest move
+func (c *StepperCoordinator) startMove(angles []float64, newPlan func(steps int, speed float64, acceleration float64, profile MotionProfile) *MotionPlan) (err error) {
+	if len(angles) != len(c.Axes) {
+		return ErrStepperCoordinatorAxes
+	}
+
+	for i, axis := range c.Axes {
+		if err = axis.acquire(); err != nil {
+			for _, acquired := range c.Axes[:i] {
+				acquired.release(nil)
+			}
+			return
+		}
+	}
+
+	plan, steps, err := c.plan(angles, newPlan)
+	if err != nil {
+		c.releaseAxes(err)
+		return
+	}
+
+	stop, done := make(chan bool), make(chan bool)
+	c.mutex.Lock()
+	c.stop, c.done, c.err = stop, done, nil
+	c.mutex.Unlock()
+
+	for _, axis := range c.Axes {
+		axis.mutex.Lock()
+		axis.stop = nil
+		axis.done = done
+		axis.mutex.Unlock()
+	}
+
+	go func() {
+		err := c.run(plan, steps, stop)
+		c.mutex.Lock()
+		c.err = err
+		c.mutex.Unlock()
+		close(done)
+	}()
+	return
+}
+
+// Stop stops the running move immediately and waits for it to finish
+func (c *StepperCoordinator) Stop() {
+	c.mutex.Lock()
+	stop := c.stop
+	c.stop = nil
+	c.mutex.Unlock()
+
+	if stop != nil {
+		close(stop)
+	}
+	c.Wait()
+}
+
+// Wait blocks until the running move has finished, and returns its error
+func (c *StepperCoordinator) Wait() error {
+	c.mutex.Lock()
+	done := c.done
+	c.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+// IsMoving returns true while a move is running
+func (c *StepperCoordinator) IsMoving() bool {
+	for _, axis := range c.Axes {
+		if axis.IsMoving() {
+			return true
+		}
+	}
+	return false
+}
+
+// plan sets the directions of the axes and returns the motion plan of the
+// longest move and the microsteps of every axis
+func (c *StepperCoordinator) plan(angles []float64, newPlan func(int, float64, float64, MotionProfile) *MotionPlan) (*MotionPlan, []int, error) {
+	steps := make([]int, len(c.Axes))
+	master := 0
+	for i, axis := range c.Axes {
+		if _, err := axis.calculateSleep(); err != nil {
+			return nil, nil, err
+		}
+		steps[i] = int(math.Abs(angles[i]) * float64(axis.StepsPerTurn) * float64(axis.Microstepping) / 360)
+		if steps[i] > steps[master] {
+			master = i
+		}
+	}
+	n := float64(steps[master])
+
+	speed, acceleration := math.Inf(1), math.Inf(1)
+	for i, axis := range c.Axes {
+		if steps[i] == 0 {
+			continue
+		}
+		p, err := axis.motionPlan(steps[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		// axis i moves steps[i]/n times as fast as the longest move
+		speed = math.Min(speed, p.MaxSpeed*n/float64(steps[i]))
+		if p.Acceleration > 0 {
+			acceleration = math.Min(acceleration, p.Acceleration*n/float64(steps[i]))
+		}
+
+		dir := byte(0)
+		if angles[i] > 0 {
+			dir = 1
+		}
+		if err = axis.setDirection(dir); err != nil {
+			return nil, nil, err
+		}
+		axis.mutex.Lock()
+		axis.direction = math.Copysign(1, angles[i])
+		axis.mutex.Unlock()
+	}
+	if math.IsInf(acceleration, 1) {
+		acceleration = 0
+	}
+	return newPlan(steps[master], speed, acceleration, c.Axes[master].Profile), steps, nil
+}
+
+// run steps the axes following the plan of the longest move, until the move
+// has finished or is stopped
+func (c *StepperCoordinator) run(plan *MotionPlan, steps []int, stop chan bool) (err error) {
+	made := make([]int, len(c.Axes))
+	defer func() {
+		for i, axis := range c.Axes {
+			axis.mutex.Lock()
+			axis.CurrentPosition += axis.direction * axis.microStepsToAngle(made[i])
+			axis.steps = 0
+			axis.mutex.Unlock()
+		}
+		c.releaseAxes(err)
+	}()
+
+	n := plan.Steps
+	start := time.Now()
+	for k := 1; k <= n; k++ {
+		// axis i makes a step whenever k*steps[i]/n reaches the next integer
+		stepping := []int{}
+		for i := range c.Axes {
+			if k*steps[i]/n > (k-1)*steps[i]/n {
+				stepping = append(stepping, i)
+			}
+		}
+
+		if !sleepUntil(start.Add((plan.StepTime(k-1)+plan.StepTime(k))/2), stop) {
+			return nil
+		}
+		for _, i := range stepping {
+			if err = c.Axes[i].connection.DigitalWrite(c.Axes[i].StepPin, 0); err != nil {
+				return
+			}
+		}
+		if !sleepUntil(start.Add(plan.StepTime(k)), stop) {
+			return nil
+		}
+		velocity := plan.Velocity(time.Since(start))
+		for _, i := range stepping {
+			axis := c.Axes[i]
+			if err = axis.connection.DigitalWrite(axis.StepPin, 1); err != nil {
+				return
+			}
+			if axis.endDetected(true) {
+				return ErrStepperMotorOutOfRange
+			}
+			made[i]++
+			axis.mutex.Lock()
+			axis.steps = made[i]
+			axis.velocity = velocity * float64(steps[i]) / float64(n)
+			axis.mutex.Unlock()
+		}
+	}
+	return nil
+}
+
+func (c *StepperCoordinator) releaseAxes(err error) {
+	for _, axis := range c.Axes {
+		axis.release(err)
+	}
+}
@@ -0,0 +1,95 @@
+package gpio
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func initTestStepperCoordinator() (*StepperCoordinator, *gpioTestAdaptor) {
+	a := newGpioTestAdaptor()
+	x := NewStepperMotorDriver(a, "1", "2", "3")
+	y := NewStepperMotorDriver(a, "4", "5", "6")
+	x.Speed, y.Speed = 600, 600
+	return NewStepperCoordinator(x, y), a
+}
+
+// stepCounter counts the rising edges written to each pin
+type stepCounter struct {
+	mtx   sync.Mutex
+	steps map[string]int
+}
+
+func (s *stepCounter) DigitalWrite(pin string, val byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if val == 1 {
+		s.steps[pin]++
+	}
+	return nil
+}
+
+func TestStepperCoordinatorMove(t *testing.T) {
+	a := &stepCounter{steps: map[string]int{}}
+	x := NewStepperMotorDriver(a, "1", "2", "3")
+	y := NewStepperMotorDriver(a, "4", "5", "6")
+	x.Speed, y.Speed = 600, 600
+	c := NewStepperCoordinator(x, y)
+
+	// 40 steps of x at 2000 steps/s take 20ms, y makes 10 steps meanwhile
+	start := time.Now()
+	gobottest.Assert(t, c.Move(72, -18), nil)
+	gobottest.Assert(t, time.Since(start) >= 19*time.Millisecond, true)
+	gobottest.Assert(t, a.steps["1"], 40)
+	gobottest.Assert(t, a.steps["4"], 10)
+	gobottest.Assert(t, c.Axes[0].CurrentPosition, 72.0)
+	gobottest.Assert(t, c.Axes[1].CurrentPosition, -18.0)
+	gobottest.Assert(t, c.IsMoving(), false)
+
+	gobottest.Assert(t, c.MoveTo(0, 0), nil)
+	gobottest.Assert(t, c.Axes[0].CurrentPosition, 0.0)
+	gobottest.Assert(t, c.Axes[1].CurrentPosition, 0.0)
+}
+
+func TestStepperCoordinatorSpeedLimit(t *testing.T) {
+	c, _ := initTestStepperCoordinator()
+	// y is the slower axis and limits the longer move of x
+	c.Axes[1].Speed = 60
+	plan, steps, err := c.plan([]float64{72, 36}, NewMotionPlan)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, steps, []int{40, 20})
+	gobottest.Assert(t, plan.MaxSpeed, 400.0)
+}
+
+func TestStepperCoordinatorStartMove(t *testing.T) {
+	c, _ := initTestStepperCoordinator()
+	gobottest.Assert(t, c.StartMove(1), ErrStepperCoordinatorAxes)
+
+	c.Axes[0].Speed, c.Axes[1].Speed = 60, 60
+	gobottest.Assert(t, c.StartMove(360, 360), nil)
+	gobottest.Assert(t, c.IsMoving(), true)
+	gobottest.Assert(t, c.Axes[1].StartMove(10), ErrStepperMotorBusy)
+
+	time.Sleep(50 * time.Millisecond)
+	gobottest.Assert(t, c.Axes[1].Velocity(), 60.0)
+	c.Stop()
+	gobottest.Assert(t, c.IsMoving(), false)
+	gobottest.Assert(t, c.Axes[0].CurrentPosition > 0 && c.Axes[0].CurrentPosition < 36, true)
+	gobottest.Assert(t, c.Axes[0].CurrentPosition, c.Axes[1].CurrentPosition)
+}
+
+func TestStepperCoordinatorMoveError(t *testing.T) {
+	c, a := initTestStepperCoordinator()
+	a.TestAdaptorDigitalWrite(func() (err error) {
+		return errors.New("write error")
+	})
+	gobottest.Assert(t, c.Move(10, 10), errors.New("write error"))
+	gobottest.Assert(t, c.IsMoving(), false)
+
+	a.TestAdaptorDigitalWrite(func() (err error) { return nil })
+	c.Axes[1].Microstepping = 0
+	gobottest.Assert(t, c.Move(10, 10), ErrIncorrectMicrostepping)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 	"gobot.io/x/gobot"
 	"math"
+	"sync"
 	"time"
 )
 
@@ -15,7 +16,9 @@ This driver allows you to control stepper motors using hardware driver
 This driver also support end detection using hardware endstops.
 You can define 2 hardware endstops or 1 for start and MaxPosition (software end stop)
 
-TODO: implement motor acceleration
+Moves accelerate and decelerate following Profile when Acceleration is set.
+StartMove starts a move without waiting for it, which can be queried with
+Position and Velocity and cancelled with StopMove.
 */
 
 const (
@@ -30,6 +33,7 @@ var (
 	ErrIncorrectMicrostepping = errors.New("Stepper motor: incorrect microstepping settings")
 	ErrIncorrectStepsPerTurn  = errors.New("Stepper motor: incorrect steps per turn")
 	ErrIncorrectSpeed         = errors.New("Stepper motor: incorrect speed")
+	ErrStepperMotorBusy       = errors.New("Stepper motor is already moving")
 )
 
 type StepperMotorDriver struct {
@@ -47,6 +51,8 @@ type StepperMotorDriver struct {
 	CurrentPosition    float64
 	CurrentState       int
 	CheckEndWhenMoving bool //def. true - use it to protect your hardware. Driver check if endstop is closed after every step and return error if it's true
+	Acceleration       float64       //RPM per second def. 0 - no acceleration, moves start and stop at Speed
+	Profile            MotionProfile //def. TrapezoidalProfile - shape of the acceleration
 
 	minLimitSwitch  LimitSwitchDriverInterface
 	maxLimitSwitch  LimitSwitchDriverInterface
@@ -54,6 +60,15 @@ type StepperMotorDriver struct {
 	motorCalibrated bool    //If moved to min - motor is calibrated
 
 	errorsList []error
+
+	mutex     *sync.Mutex
+	moving    bool
+	stop      chan bool
+	done      chan bool
+	moveErr   error
+	direction float64 //1 or -1 - direction of the running move
+	steps     int     //microsteps made by the running move
+	velocity  float64 //microsteps per second of the running move
 	gobot.Commander
 }
 
@@ -62,6 +77,7 @@ type StepperMotorDriver struct {
 //
 // Adds the following API Commands:
 // 	"Move" - See ServoDriver.Move
+// 	"StopMove" - See StepperMotorDriver.StopMove
 // If endstops were defined:
 //		"Min" - See ServoDriver.Min
 //		"Center" - See ServoDriver.Center
@@ -82,6 +98,8 @@ func NewStepperMotorDriver(a DigitalWriter, stepPin, dirPin, enablePin string) *
 		CurrentState:       STATE_DISABLED,
 		motorCalibrated:    false,
 		CheckEndWhenMoving: true,
+		Profile:            TrapezoidalProfile,
+		mutex:              &sync.Mutex{},
 		Commander:          gobot.NewCommander(),
 	}
 
@@ -91,6 +109,11 @@ func NewStepperMotorDriver(a DigitalWriter, stepPin, dirPin, enablePin string) *
 		return s.Move(angle)
 	})
 
+	s.AddCommand("StopMove", func(params map[string]interface{}) interface{} {
+		s.StopMove()
+		return nil
+	})
+
 	s.AddCommand("Min", func(params map[string]interface{}) interface{} {
 		return s.Min()
 	})
@@ -112,11 +135,16 @@ func (s *StepperMotorDriver) SetName(n string) { s.name = n }
 // Connection returns the StepperMotorDriver Connection
 func (s *StepperMotorDriver) Connection() gobot.Connection { return s.connection.(gobot.Connection) }
 
-// Start implements the Driver interface
-func (s *StepperMotorDriver) Start() (err error) { return }
+// Start implements the Driver interface and claims the pins
+func (s *StepperMotorDriver) Start() (err error) {
+	return gobot.ClaimResources(s.Connection(), s, gobot.PinResource, s.StepPin, s.DirectionPin, s.EnablePin)
+}
 
-// Halt implements the Driver interface
-func (s *StepperMotorDriver) Halt() (err error) { return }
+// Halt implements the Driver interface and releases the pins
+func (s *StepperMotorDriver) Halt() (err error) {
+	gobot.ReleaseResources(s.Connection(), s)
+	return
+}
 
 // Configure end detection
 func (s *StepperMotorDriver) ConfigureEndDetection(min, max LimitSwitchDriverInterface, maxPosition float64) (err error) {
@@ -160,6 +188,89 @@ func (s *StepperMotorDriver) GetLastError() (err error) {
 // Move stepper motor for an specified angle <-x, y>.
 // Example: move motor for -45 deg
 func (s *StepperMotorDriver) Move(angle float64) (err error) {
+	if err = s.StartMove(angle); err != nil {
+		return
+	}
+	return s.Wait()
+}
+
+// Start move for an specified angle without waiting for finishing.
+// Returns ErrStepperMotorBusy if the motor is already moving
+func (s *StepperMotorDriver) StartMove(angle float64) (err error) {
+	if err = s.acquire(); err != nil {
+		return
+	}
+
+	s.mutex.Lock()
+	s.stop = make(chan bool)
+	s.done = make(chan bool)
+	done := s.done
+	s.mutex.Unlock()
+
+	go func() {
+		err := s.move(angle)
+		s.release(err)
+		close(done)
+	}()
+	return
+}
+
+// Wait for the move started by StartMove to finish. Returns the error of the move
+func (s *StepperMotorDriver) Wait() (err error) {
+	s.mutex.Lock()
+	done := s.done
+	s.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.moveErr
+}
+
+// Stop the move started by StartMove immediately and wait for it to finish
+func (s *StepperMotorDriver) StopMove() {
+	s.mutex.Lock()
+	stop := s.stop
+	s.stop = nil
+	s.mutex.Unlock()
+
+	if stop != nil {
+		close(stop)
+	}
+	s.Wait()
+}
+
+// Return true while the motor is moving
+func (s *StepperMotorDriver) IsMoving() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.moving
+}
+
+// Return the current position in degrees, including the progress of the running move
+func (s *StepperMotorDriver) Position() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.moving {
+		return s.CurrentPosition
+	}
+	return s.CurrentPosition + s.direction*s.microStepsToAngle(s.steps)
+}
+
+// Return the current velocity in RPM, negative when moving backwards
+func (s *StepperMotorDriver) Velocity() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.moving || s.Microstepping < 1 || s.StepsPerTurn < 1 {
+		return 0
+	}
+	return s.direction * s.velocity * 60 / float64(s.Microstepping*s.StepsPerTurn)
+}
+
+func (s *StepperMotorDriver) move(angle float64) (err error) {
 	if angle == 0 {
 		return
 	} else if angle < 0 {
@@ -171,13 +282,20 @@ func (s *StepperMotorDriver) Move(angle float64) (err error) {
 		return
 	}
 
+	s.mutex.Lock()
+	s.direction = math.Copysign(1, angle)
+	s.mutex.Unlock()
+
 	steps := int(math.Abs(angle) * float64(s.StepsPerTurn) * float64(s.Microstepping) / 360)
 	steps, err = s.moveMicroSteps(steps)
-	moved_angle := float64(steps) * 360 / float64(s.StepsPerTurn) / float64(s.Microstepping)
+	moved_angle := s.microStepsToAngle(steps)
 	if angle < 0 {
 		moved_angle *= -1
 	}
+	s.mutex.Lock()
 	s.CurrentPosition += moved_angle
+	s.steps = 0
+	s.mutex.Unlock()
 	return
 }
 
@@ -250,22 +368,111 @@ func (s *StepperMotorDriver) Center() (err error) {
 	return
 }
 
-//Rotate motor for specified number of microsteps. Return number of steps it made and error
+//Rotate motor for specified number of microsteps following the motion plan.
+//Return number of steps it made and error. Stops early without error when the move is stopped
 func (s *StepperMotorDriver) moveMicroSteps(steps int) (int, error) {
-	sleep, err := s.calculateSleep()
+	plan, err := s.motionPlan(steps)
 	if err != nil {
 		return 0, err
 	}
 
+	s.mutex.Lock()
+	stop := s.stop
+	s.mutex.Unlock()
+
+	start := time.Now()
 	for i := 0; i < steps; i++ {
-		err = s.singleStep(*sleep, true)
-		if err != nil {
+		// the step pin is low for the first and high for the second half of each step
+		if !sleepUntil(start.Add((plan.StepTime(i)+plan.StepTime(i+1))/2), stop) {
+			return i, nil
+		}
+		if err = s.connection.DigitalWrite(s.StepPin, 0); err != nil {
 			return i, err
 		}
+		if !sleepUntil(start.Add(plan.StepTime(i+1)), stop) {
+			return i, nil
+		}
+		if err = s.connection.DigitalWrite(s.StepPin, 1); err != nil {
+			return i, err
+		}
+		if s.endDetected(true) {
+			return i, ErrStepperMotorOutOfRange
+		}
+
+		s.mutex.Lock()
+		s.steps = i + 1
+		s.velocity = plan.Velocity(time.Since(start))
+		s.mutex.Unlock()
 	}
 	return steps, nil
 }
 
+//Return the motion plan of a move of microsteps at Speed and Acceleration
+func (s *StepperMotorDriver) motionPlan(steps int) (*MotionPlan, error) {
+	if _, err := s.calculateSleep(); err != nil {
+		return nil, err
+	}
+	micro_steps_per_turn := float64(s.Microstepping * s.StepsPerTurn)
+	acceleration := 0.0
+	if s.Acceleration > 0 {
+		acceleration = micro_steps_per_turn * s.Acceleration / 60
+	}
+	return NewMotionPlan(steps, micro_steps_per_turn*s.Speed/60, acceleration, s.Profile), nil
+}
+
+func (s *StepperMotorDriver) microStepsToAngle(steps int) float64 {
+	return float64(steps) * 360 / float64(s.StepsPerTurn) / float64(s.Microstepping)
+}
+
+//Mark the motor as moving. Return ErrStepperMotorBusy if it is already moving
+func (s *StepperMotorDriver) acquire() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.moving {
+		return ErrStepperMotorBusy
+	}
+	s.moving = true
+	s.moveErr = nil
+	s.steps = 0
+	s.velocity = 0
+	return nil
+}
+
+//Mark the motor as standing still after a move which ended with err
+func (s *StepperMotorDriver) release(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.moving = false
+	s.moveErr = err
+	s.stop = nil
+	s.velocity = 0
+}
+
+//Sleep until t. Return false if the stop channel was closed before
+func sleepUntil(t time.Time, stop chan bool) bool {
+	d := t.Sub(time.Now())
+	if stop == nil {
+		time.Sleep(d)
+		return true
+	}
+	if d <= 0 {
+		select {
+		case <-stop:
+			return false
+		default:
+			return true
+		}
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
 //Rotate while endstop is open. Return number of steps it made and error
 func (s *StepperMotorDriver) moveWhileEndstopOpen(endstop LimitSwitchDriverInterface) (int, error) {
 	sleep, err := s.calculateSleep()
@@ -310,17 +517,25 @@ func (s *StepperMotorDriver) singleStep(d time.Duration, check_end bool) (err er
 	}
 	err = s.connection.DigitalWrite(s.StepPin, 1)
 	time.Sleep(d)
+	if s.endDetected(check_end) {
+		return ErrStepperMotorOutOfRange
+	}
+	return
+}
+
+//Check if any endstop is closed, if CheckEndWhenMoving and check_end
+func (s *StepperMotorDriver) endDetected(check_end bool) bool {
 	if s.CheckEndWhenMoving && check_end {
 		endstops := []LimitSwitchDriverInterface{s.minLimitSwitch, s.maxLimitSwitch}
 		for _, endstop := range endstops {
 			if endstop != nil {
 				if end, _ := endstop.EndDetected(); end {
-					return ErrStepperMotorOutOfRange
+					return true
 				}
 			}
 		}
 	}
-	return
+	return false
 }
 
 //Check if endstops were correctly defined
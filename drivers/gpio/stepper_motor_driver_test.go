@@ -301,3 +301,43 @@ func TestStepperMotorSingleStepDetectedError(t *testing.T) {
 	err := g.singleStep(time.Duration(5), true)
 	gobottest.Refute(t, err, nil)
 }
+
+func TestStepperMotorDriverMoveAcceleration(t *testing.T) {
+	g := initStepperMotorDriver()
+	g.Speed = 600
+	g.Acceleration = 6000
+
+	// 20 steps accelerating to 2000 steps/s at 20000 steps/s^2 take 63ms
+	start := time.Now()
+	gobottest.Assert(t, g.Move(36), nil)
+	gobottest.Assert(t, time.Since(start) > 50*time.Millisecond, true)
+	gobottest.Assert(t, g.CurrentPosition, 36.0)
+}
+
+func TestStepperMotorDriverStartMove(t *testing.T) {
+	g := initStepperMotorDriver()
+	gobottest.Assert(t, g.StartMove(36), nil)
+	gobottest.Assert(t, g.IsMoving(), true)
+	gobottest.Assert(t, g.StartMove(36), ErrStepperMotorBusy)
+
+	time.Sleep(55 * time.Millisecond)
+	gobottest.Assert(t, g.Velocity(), 30.0)
+	gobottest.Assert(t, g.Position() > 0 && g.Position() < 36, true)
+
+	gobottest.Assert(t, g.Wait(), nil)
+	gobottest.Assert(t, g.IsMoving(), false)
+	gobottest.Assert(t, g.Velocity(), 0.0)
+	gobottest.Assert(t, g.Position(), 36.0)
+}
+
+func TestStepperMotorDriverStopMove(t *testing.T) {
+	g := initStepperMotorDriver()
+	gobottest.Assert(t, g.StartMove(-360), nil)
+	time.Sleep(50 * time.Millisecond)
+	g.StopMove()
+	gobottest.Assert(t, g.IsMoving(), false)
+	gobottest.Assert(t, g.CurrentPosition < 0 && g.CurrentPosition > -36, true)
+
+	// stopping without a running move does nothing
+	g.StopMove()
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 )
 
 // AdafruitDirection declares a type for specification of the motor direction
@@ -23,6 +24,7 @@ type adaFruitStepperMotor struct {
 	ain1, ain2                         byte
 	bin1, bin2                         byte
 	secPerStep                         float64
+	stepsPerSecSquared                 float64
 	currentStep, stepCounter, revSteps int
 }
 
@@ -39,6 +41,8 @@ type AdafruitMotorHatDriver struct {
 	gobot.Commander
 	dcMotors      []adaFruitDCMotor
 	stepperMotors []adaFruitStepperMotor
+	now           func() time.Time
+	sleep         func(time.Duration)
 }
 
 var adafruitDebug = false // Set this to true to see debug output
@@ -130,6 +134,8 @@ func NewAdafruitMotorHatDriver(conn Connector, options ...func(Config)) *Adafrui
 		Commander:     gobot.NewCommander(),
 		dcMotors:      dc,
 		stepperMotors: st,
+		now:           time.Now,
+		sleep:         time.Sleep,
 	}
 
 	for _, option := range options {
@@ -201,7 +207,7 @@ func (a *AdafruitMotorHatDriver) startDriver(connection Connection) (err error)
 func (a *AdafruitMotorHatDriver) Start() (err error) {
 	bus := a.GetBusOrDefault(a.connector.GetDefaultBus())
 
-	if a.servoHatConnection, err = a.connector.GetConnection(servoHatAddress, bus); err != nil {
+	if a.servoHatConnection, err = claimConnection(a.connector, a, servoHatAddress, bus); err != nil {
 		return
 	}
 
@@ -209,7 +215,8 @@ func (a *AdafruitMotorHatDriver) Start() (err error) {
 		return
 	}
 
-	if a.motorHatConnection, err = a.connector.GetConnection(motorHatAddress, bus); err != nil {
+	if a.motorHatConnection, err = claimConnection(a.connector, a, motorHatAddress, bus); err != nil {
+		releaseConnections(a.connector, a)
 		return
 	}
 
@@ -221,7 +228,10 @@ func (a *AdafruitMotorHatDriver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (a *AdafruitMotorHatDriver) Halt() (err error) { return }
+func (a *AdafruitMotorHatDriver) Halt() (err error) {
+	releaseConnections(a.connector, a)
+	return
+}
 
 // setPWM sets the start (on) and end (off) of the high-segment of the PWM pulse
 // on the specific channel (pin).
@@ -369,6 +379,35 @@ func (a *AdafruitMotorHatDriver) RunDCMotor(dcMotor int, dir AdafruitDirection)
 	return
 }
 
+// AdafruitDCMotor is a DC motor of an AdafruitMotorHatDriver whose power can
+// be set in both directions, see gpio.DCMotor
+type AdafruitDCMotor struct {
+	Hat   *AdafruitMotorHatDriver
+	Motor int
+}
+
+// DCMotor returns the DC motor with the given number, from 0 to 3
+func (a *AdafruitMotorHatDriver) DCMotor(dcMotor int) *AdafruitDCMotor {
+	return &AdafruitDCMotor{Hat: a, Motor: dcMotor}
+}
+
+// SetPower runs the motor from -1 full backward to 1 full forward, 0
+// releases it
+func (m *AdafruitDCMotor) SetPower(power float64) (err error) {
+	dir := AdafruitForward
+	switch {
+	case power < 0:
+		dir = AdafruitBackward
+	case power == 0:
+		dir = AdafruitRelease
+	}
+	speed := int32(math.Round(math.Min(math.Abs(power), 1) * 255))
+	if err = m.Hat.SetDCMotorSpeed(m.Motor, speed); err != nil {
+		return
+	}
+	return m.Hat.RunDCMotor(m.Motor, dir)
+}
+
 func (a *AdafruitMotorHatDriver) oneStep(motor int, dir AdafruitDirection, style AdafruitStepStyle) (steps int, err error) {
 	pwmA := 255
 	pwmB := 255
@@ -489,6 +528,15 @@ func (a *AdafruitMotorHatDriver) oneStep(motor int, dir AdafruitDirection, style
 	return a.stepperMotors[motor].currentStep, nil
 }
 
+// SetStepperMotorAcceleration sets the acceleration in RPM per second for the
+// given Stepper Motor. Step ramps the speed up to and down from the speed set by
+// SetStepperMotorSpeed at this rate, 0 (the default) disables the ramps.
+func (a *AdafruitMotorHatDriver) SetStepperMotorAcceleration(stepperMotor int, rpmPerSec int) (err error) {
+	revSteps := a.stepperMotors[stepperMotor].revSteps
+	a.stepperMotors[stepperMotor].stepsPerSecSquared = float64(revSteps*rpmPerSec) / 60.0
+	return
+}
+
 // SetStepperMotorSpeed sets the seconds-per-step for the given Stepper Motor.
 func (a *AdafruitMotorHatDriver) SetStepperMotorSpeed(stepperMotor int, rpm int) (err error) {
 	revSteps := a.stepperMotors[stepperMotor].revSteps
@@ -498,24 +546,31 @@ func (a *AdafruitMotorHatDriver) SetStepperMotorSpeed(stepperMotor int, rpm int)
 }
 
 // Step will rotate the stepper motor the given number of steps, in the given direction and step style.
+// The steps are paced at the speed set by SetStepperMotorSpeed, ramped at the rate set by
+// SetStepperMotorAcceleration, and Step returns once the last one is due.
 func (a *AdafruitMotorHatDriver) Step(motor, steps int, dir AdafruitDirection, style AdafruitStepStyle) (err error) {
 	secPerStep := a.stepperMotors[motor].secPerStep
+	acceleration := a.stepperMotors[motor].stepsPerSecSquared
 	latestStep := 0
 	if style == AdafruitInterleave {
 		secPerStep = secPerStep / 2.0
+		acceleration *= 2
 	}
 	if style == AdafruitMicrostep {
 		secPerStep /= float64(stepperMicrosteps)
+		acceleration *= float64(stepperMicrosteps)
 		steps *= stepperMicrosteps
 	}
 	if adafruitDebug {
 		log.Printf("[adafruit_driver] %f seconds per step", secPerStep)
 	}
+	plan := gpio.NewMotionPlan(steps, 1/secPerStep, acceleration, gpio.TrapezoidalProfile)
+	start := a.now()
 	for i := 0; i < steps; i++ {
 		if latestStep, err = a.oneStep(motor, dir, style); err != nil {
 			return
 		}
-		time.Sleep(time.Duration(secPerStep) * time.Second)
+		a.sleep(start.Add(plan.StepTime(i + 1)).Sub(a.now()))
 	}
 	// As documented in the Adafruit python driver:
 	// This is an edge case, if we are in between full steps, keep going to end on a full step
@@ -524,7 +579,7 @@ func (a *AdafruitMotorHatDriver) Step(motor, steps int, dir AdafruitDirection, s
 			if latestStep, err = a.oneStep(motor, dir, style); err != nil {
 				return
 			}
-			time.Sleep(time.Duration(secPerStep) * time.Second)
+			a.sleep(time.Duration(secPerStep * float64(time.Second)))
 		}
 	}
 	return
@@ -4,12 +4,15 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*AdafruitMotorHatDriver)(nil)
+var _ gpio.DCMotor = (*AdafruitDCMotor)(nil)
 
 // --------- HELPERS
 func initTestAdafruitMotorHatDriver() (driver *AdafruitMotorHatDriver) {
@@ -172,6 +175,21 @@ func TestAdafruitMotorHatDriverRunDCMotorError(t *testing.T) {
 	gobottest.Assert(t, ada.RunDCMotor(dcMotor, AdafruitRelease), errors.New("write error"))
 }
 
+func TestAdafruitMotorHatDriverDCMotorSetPower(t *testing.T) {
+	ada, a := initTestAdafruitMotorHatDriverWithStubbedAdaptor()
+	gobottest.Assert(t, ada.Start(), nil)
+
+	m := ada.DCMotor(1)
+	gobottest.Assert(t, m.SetPower(-0.5), nil)
+	gobottest.Assert(t, m.SetPower(0), nil)
+	gobottest.Assert(t, m.SetPower(1), nil)
+
+	a.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, m.SetPower(1), errors.New("write error"))
+}
+
 func TestAdafruitMotorHatDriverSetStepperMotorSpeed(t *testing.T) {
 	ada, _ := initTestAdafruitMotorHatDriverWithStubbedAdaptor()
 
@@ -182,6 +200,61 @@ func TestAdafruitMotorHatDriverSetStepperMotorSpeed(t *testing.T) {
 	gobottest.Assert(t, ada.SetStepperMotorSpeed(stepperMotor, rpm), nil)
 }
 
+func TestAdafruitMotorHatDriverSetStepperMotorAcceleration(t *testing.T) {
+	ada, _ := initTestAdafruitMotorHatDriverWithStubbedAdaptor()
+
+	gobottest.Assert(t, ada.Start(), nil)
+
+	stepperMotor := 0
+	ada.SetStepperMotorSpeed(stepperMotor, 600)
+	gobottest.Assert(t, ada.SetStepperMotorAcceleration(stepperMotor, 6000), nil)
+
+	// 20 steps accelerating to 2000 steps/s at 20000 steps/s^2 take 63ms
+	clock := withVirtualClock(ada)
+	gobottest.Assert(t, ada.Step(stepperMotor, 20, 1, 0), nil)
+	elapsed := clock.elapsed()
+	gobottest.Assert(t, elapsed > 62*time.Millisecond && elapsed < 64*time.Millisecond, true)
+	// the steps speed up and slow down again
+	gobottest.Assert(t, clock.sleeps[0] > clock.sleeps[10], true)
+	gobottest.Assert(t, clock.sleeps[19] > clock.sleeps[10], true)
+}
+
+// virtualClock is advanced by the sleeps of a driver instead of waiting
+type virtualClock struct {
+	start  time.Time
+	t      time.Time
+	sleeps []time.Duration
+}
+
+func withVirtualClock(ada *AdafruitMotorHatDriver) *virtualClock {
+	c := &virtualClock{start: time.Unix(0, 0), t: time.Unix(0, 0)}
+	ada.now = func() time.Time { return c.t }
+	ada.sleep = func(d time.Duration) {
+		c.sleeps = append(c.sleeps, d)
+		if d > 0 {
+			c.t = c.t.Add(d)
+		}
+	}
+	return c
+}
+
+func (c *virtualClock) elapsed() time.Duration { return c.t.Sub(c.start) }
+
+func TestAdafruitMotorHatDriverStepPaced(t *testing.T) {
+	ada, _ := initTestAdafruitMotorHatDriverWithStubbedAdaptor()
+	gobottest.Assert(t, ada.Start(), nil)
+
+	// at 30 RPM every one of the 200 steps of a revolution takes 10ms, which
+	// used to be truncated to no wait at all
+	ada.SetStepperMotorSpeed(0, 30)
+	clock := withVirtualClock(ada)
+	gobottest.Assert(t, ada.Step(0, 10, 1, 0), nil)
+	gobottest.Assert(t, clock.elapsed(), 100*time.Millisecond)
+	for _, d := range clock.sleeps {
+		gobottest.Assert(t, d, 10*time.Millisecond)
+	}
+}
+
 func TestAdafruitMotorHatDriverStepperMicroStep(t *testing.T) {
 	ada, _ := initTestAdafruitMotorHatDriverWithStubbedAdaptor()
 
@@ -191,6 +264,7 @@ func TestAdafruitMotorHatDriverStepperMicroStep(t *testing.T) {
 	// the i2c package
 	stepperMotor := 0
 	steps := 50
+	ada.SetStepperMotorSpeed(stepperMotor, 3000)
 	err := ada.Step(stepperMotor, steps, 1, 3)
 	gobottest.Assert(t, err, nil)
 }
@@ -204,6 +278,7 @@ func TestAdafruitMotorHatDriverStepperSingleStep(t *testing.T) {
 	// the i2c package
 	stepperMotor := 0
 	steps := 50
+	ada.SetStepperMotorSpeed(stepperMotor, 3000)
 	err := ada.Step(stepperMotor, steps, 1, 0)
 	gobottest.Assert(t, err, nil)
 }
@@ -217,6 +292,7 @@ func TestAdafruitMotorHatDriverStepperDoubleStep(t *testing.T) {
 	// the i2c package
 	stepperMotor := 0
 	steps := 50
+	ada.SetStepperMotorSpeed(stepperMotor, 3000)
 	err := ada.Step(stepperMotor, steps, 1, 1)
 	gobottest.Assert(t, err, nil)
 }
@@ -230,6 +306,7 @@ func TestAdafruitMotorHatDriverStepperInterleaveStep(t *testing.T) {
 	// the i2c package
 	stepperMotor := 0
 	steps := 50
+	ada.SetStepperMotorSpeed(stepperMotor, 3000)
 	err := ada.Step(stepperMotor, steps, 1, 2)
 	gobottest.Assert(t, err, nil)
 }
@@ -149,7 +149,7 @@ func (d *ADS1x15Driver) Start() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
 	address := d.GetAddressOrDefault(ADS1x15DefaultAddress)
 
-	if d.connection, err = d.connector.GetConnection(address, bus); err != nil {
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
 		return err
 	}
 
@@ -166,7 +166,10 @@ func (d *ADS1x15Driver) SetName(n string) { d.name = n }
 func (d *ADS1x15Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
 
 // Halt returns true if devices is halted successfully
-func (d *ADS1x15Driver) Halt() (err error) { return }
+func (d *ADS1x15Driver) Halt() (err error) {
+	releaseConnections(d.connector, d)
+	return
+}
 
 // WithADS1x15Gain option sets the ADS1x15Driver gain option.
 // Valid gain settings are any of the ADS1x15RegConfigPga* values
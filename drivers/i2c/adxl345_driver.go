@@ -0,0 +1,295 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+)
+
+const adxl345Address = 0x53
+
+const (
+	adxl345RegisterBWRate     = 0x2C
+	adxl345RegisterPowerCtl   = 0x2D
+	adxl345RegisterIntEnable  = 0x2E
+	adxl345RegisterIntMap     = 0x2F
+	adxl345RegisterIntSource  = 0x30
+	adxl345RegisterDataFormat = 0x31
+	adxl345RegisterDataX0     = 0x32
+	adxl345RegisterFIFOCtl    = 0x38
+	adxl345RegisterFIFOStatus = 0x39
+
+	adxl345Measure    = 0x08
+	adxl345FullRes    = 0x08
+	adxl345FIFOLength = 32
+)
+
+// adxl345Scale is the g per LSB in full resolution, at any range
+const adxl345Scale = 0.0039
+
+// ADXL345Range is the measurement range of the ADXL345.
+type ADXL345Range byte
+
+const (
+	// ADXL345Range2g is the +-2g range.
+	ADXL345Range2g ADXL345Range = 0x00
+	// ADXL345Range4g is the +-4g range.
+	ADXL345Range4g ADXL345Range = 0x01
+	// ADXL345Range8g is the +-8g range.
+	ADXL345Range8g ADXL345Range = 0x02
+	// ADXL345Range16g is the +-16g range.
+	ADXL345Range16g ADXL345Range = 0x03
+)
+
+// ADXL345Rate is the output data rate of the ADXL345.
+type ADXL345Rate byte
+
+const (
+	// ADXL345Rate6_25Hz is the 6.25Hz data rate.
+	ADXL345Rate6_25Hz ADXL345Rate = 0x06
+	// ADXL345Rate12_5Hz is the 12.5Hz data rate.
+	ADXL345Rate12_5Hz ADXL345Rate = 0x07
+	// ADXL345Rate25Hz is the 25Hz data rate.
+	ADXL345Rate25Hz ADXL345Rate = 0x08
+	// ADXL345Rate50Hz is the 50Hz data rate.
+	ADXL345Rate50Hz ADXL345Rate = 0x09
+	// ADXL345Rate100Hz is the 100Hz data rate.
+	ADXL345Rate100Hz ADXL345Rate = 0x0A
+	// ADXL345Rate200Hz is the 200Hz data rate.
+	ADXL345Rate200Hz ADXL345Rate = 0x0B
+	// ADXL345Rate400Hz is the 400Hz data rate.
+	ADXL345Rate400Hz ADXL345Rate = 0x0C
+	// ADXL345Rate800Hz is the 800Hz data rate.
+	ADXL345Rate800Hz ADXL345Rate = 0x0D
+	// ADXL345Rate1600Hz is the 1600Hz data rate.
+	ADXL345Rate1600Hz ADXL345Rate = 0x0E
+	// ADXL345Rate3200Hz is the 3200Hz data rate.
+	ADXL345Rate3200Hz ADXL345Rate = 0x0F
+)
+
+// ADXL345FIFOMode is the mode of the FIFO of the ADXL345.
+type ADXL345FIFOMode byte
+
+const (
+	// ADXL345FIFOBypass bypasses the FIFO.
+	ADXL345FIFOBypass ADXL345FIFOMode = 0x00
+	// ADXL345FIFOFill collects samples until the FIFO is full.
+	ADXL345FIFOFill ADXL345FIFOMode = 0x40
+	// ADXL345FIFOStream keeps the latest samples, discarding the oldest.
+	ADXL345FIFOStream ADXL345FIFOMode = 0x80
+	// ADXL345FIFOTrigger keeps the latest samples until the trigger
+	// interrupt, then collects samples until the FIFO is full.
+	ADXL345FIFOTrigger ADXL345FIFOMode = 0xC0
+)
+
+// ADXL345 interrupts, which are or'ed together.
+const (
+	ADXL345InterruptOverrun    = 0x01
+	ADXL345InterruptWatermark  = 0x02
+	ADXL345InterruptFreeFall   = 0x04
+	ADXL345InterruptInactivity = 0x08
+	ADXL345InterruptActivity   = 0x10
+	ADXL345InterruptDoubleTap  = 0x20
+	ADXL345InterruptSingleTap  = 0x40
+	ADXL345InterruptDataReady  = 0x80
+)
+
+// ADXL345Driver is a driver for the ADXL345 3-axis accelerometer, which is
+// read at full resolution.
+// Device datasheet: http://www.analog.com/media/en/technical-documentation/data-sheets/ADXL345.pdf
+type ADXL345Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	accelRange     ADXL345Range
+	rate           ADXL345Rate
+	fifoMode       ADXL345FIFOMode
+	fifoSamples    uint8
+	interrupts     byte
+	interruptsInt2 byte
+}
+
+// NewADXL345Driver creates a new driver for the ADXL345. Its address is
+// 0x53, or 0x1D if its ALT ADDRESS pin is high.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithADXL345Range(ADXL345Range):	measurement range, +-2g by default
+//		i2c.WithADXL345Rate(ADXL345Rate):	output data rate, 100Hz by default
+//		i2c.WithADXL345FIFO(ADXL345FIFOMode, uint8):	FIFO mode and number of samples of the watermark
+//		i2c.WithADXL345Interrupts(byte, byte):	interrupts which are enabled and those of them routed to INT2
+//
+func NewADXL345Driver(a Connector, options ...func(Config)) *ADXL345Driver {
+	d := &ADXL345Driver{
+		name:       gobot.DefaultName("ADXL345"),
+		connector:  a,
+		Config:     NewConfig(),
+		accelRange: ADXL345Range2g,
+		rate:       ADXL345Rate100Hz,
+		fifoMode:   ADXL345FIFOBypass,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithADXL345Range option sets the measurement range of the ADXL345Driver.
+func WithADXL345Range(val ADXL345Range) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*ADXL345Driver); ok {
+			d.accelRange = val
+		}
+	}
+}
+
+// WithADXL345Rate option sets the output data rate of the ADXL345Driver.
+func WithADXL345Rate(val ADXL345Rate) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*ADXL345Driver); ok {
+			d.rate = val
+		}
+	}
+}
+
+// WithADXL345FIFO option sets the mode of the FIFO of the ADXL345Driver and
+// the number of samples in it, up to 31, at which the watermark interrupt
+// is triggered.
+func WithADXL345FIFO(mode ADXL345FIFOMode, samples uint8) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*ADXL345Driver); ok {
+			d.fifoMode = mode
+			d.fifoSamples = samples
+		}
+	}
+}
+
+// WithADXL345Interrupts option enables the ADXL345Interrupt* interrupts of
+// the ADXL345Driver. Those of them also in int2 are signaled on the INT2
+// pin, the others on the INT1 pin.
+func WithADXL345Interrupts(enable byte, int2 byte) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*ADXL345Driver); ok {
+			d.interrupts = enable
+			d.interruptsInt2 = int2
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *ADXL345Driver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *ADXL345Driver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the device.
+func (d *ADXL345Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start configures the ADXL345 and starts measuring.
+func (d *ADXL345Driver) Start() (err error) {
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(adxl345Address)
+
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
+		return
+	}
+
+	for _, reg := range [][2]byte{
+		{adxl345RegisterPowerCtl, 0},
+		{adxl345RegisterBWRate, byte(d.rate)},
+		{adxl345RegisterDataFormat, adxl345FullRes | byte(d.accelRange)},
+		{adxl345RegisterFIFOCtl, byte(d.fifoMode) | d.fifoSamples&0x1f},
+		{adxl345RegisterIntMap, d.interruptsInt2},
+		{adxl345RegisterIntEnable, d.interrupts},
+		{adxl345RegisterPowerCtl, adxl345Measure},
+	} {
+		if err = d.connection.WriteByteData(reg[0], reg[1]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Halt halts the device.
+func (d *ADXL345Driver) Halt() (err error) {
+	releaseConnections(d.connector, d)
+	return
+}
+
+// RawXYZ returns the raw acceleration of the 3 axis.
+func (d *ADXL345Driver) RawXYZ() (x int16, y int16, z int16, err error) {
+	if _, err = d.connection.Write([]byte{adxl345RegisterDataX0}); err != nil {
+		return
+	}
+	data := make([]byte, 6)
+	n, err := d.connection.Read(data)
+	if err != nil {
+		return
+	}
+	if n != len(data) {
+		err = ErrNotEnoughBytes
+		return
+	}
+
+	buf := bytes.NewBuffer(data)
+	binary.Read(buf, binary.LittleEndian, &x)
+	binary.Read(buf, binary.LittleEndian, &y)
+	binary.Read(buf, binary.LittleEndian, &z)
+	return
+}
+
+// XYZ returns the acceleration of the 3 axis in g.
+func (d *ADXL345Driver) XYZ() (x float64, y float64, z float64, err error) {
+	rx, ry, rz, err := d.RawXYZ()
+	return float64(rx) * adxl345Scale, float64(ry) * adxl345Scale, float64(rz) * adxl345Scale, err
+}
+
+// ReadAcceleration returns the acceleration in meters per second squared. It
+// implements imu.Accelerometer.
+func (d *ADXL345Driver) ReadAcceleration() (v imu.Vector, err error) {
+	x, y, z, err := d.XYZ()
+	if err != nil {
+		return
+	}
+	return imu.Vector{X: x, Y: y, Z: z}.Scale(imu.StandardGravity), nil
+}
+
+// FIFOEntries returns the number of samples in the FIFO.
+func (d *ADXL345Driver) FIFOEntries() (entries int, err error) {
+	status, err := d.connection.ReadByteData(adxl345RegisterFIFOStatus)
+	return int(status & 0x3f), err
+}
+
+// ReadFIFO reads all samples in the FIFO and returns their accelerations in
+// meters per second squared, the oldest first.
+func (d *ADXL345Driver) ReadFIFO() (samples []imu.Vector, err error) {
+	entries, err := d.FIFOEntries()
+	if err != nil {
+		return
+	}
+	if entries > adxl345FIFOLength {
+		entries = adxl345FIFOLength
+	}
+	for i := 0; i < entries; i++ {
+		v, err := d.ReadAcceleration()
+		if err != nil {
+			return samples, err
+		}
+		samples = append(samples, v)
+	}
+	return
+}
+
+// InterruptSource returns the ADXL345Interrupt* interrupts which have
+// occurred, and clears those of activity, inactivity, free fall and taps.
+func (d *ADXL345Driver) InterruptSource() (source byte, err error) {
+	return d.connection.ReadByteData(adxl345RegisterIntSource)
+}
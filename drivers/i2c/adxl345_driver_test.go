@@ -0,0 +1,205 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*ADXL345Driver)(nil)
+
+var _ imu.Accelerometer = (*ADXL345Driver)(nil)
+
+// --------- HELPERS
+func initTestADXL345Driver() (driver *ADXL345Driver) {
+	driver, _ = initTestADXL345DriverWithStubbedAdaptor()
+	return
+}
+
+func initTestADXL345DriverWithStubbedAdaptor() (*ADXL345Driver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	return NewADXL345Driver(adaptor), adaptor
+}
+
+// --------- TESTS
+
+func TestNewADXL345Driver(t *testing.T) {
+	var d interface{} = NewADXL345Driver(newI2cTestAdaptor())
+	_, ok := d.(*ADXL345Driver)
+	if !ok {
+		t.Errorf("NewADXL345Driver() should have returned a *ADXL345Driver")
+	}
+}
+
+func TestADXL345Driver(t *testing.T) {
+	d := initTestADXL345Driver()
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "ADXL345"), true)
+	d.SetName("mybot")
+	gobottest.Assert(t, d.Name(), "mybot")
+}
+
+func TestADXL345DriverOptions(t *testing.T) {
+	d := NewADXL345Driver(newI2cTestAdaptor(), WithBus(2),
+		WithADXL345Range(ADXL345Range8g),
+		WithADXL345Rate(ADXL345Rate400Hz),
+		WithADXL345FIFO(ADXL345FIFOStream, 16),
+		WithADXL345Interrupts(ADXL345InterruptWatermark|ADXL345InterruptDataReady, ADXL345InterruptWatermark))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.accelRange, ADXL345Range8g)
+	gobottest.Assert(t, d.rate, ADXL345Rate400Hz)
+	gobottest.Assert(t, d.fifoMode, ADXL345FIFOStream)
+	gobottest.Assert(t, d.fifoSamples, uint8(16))
+	gobottest.Assert(t, d.interrupts, byte(0x82))
+	gobottest.Assert(t, d.interruptsInt2, byte(0x02))
+}
+
+func TestADXL345DriverStart(t *testing.T) {
+	d, adaptor := initTestADXL345DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		0x2D, 0x00,
+		0x2C, 0x0A,
+		0x31, 0x08,
+		0x38, 0x00,
+		0x2F, 0x00,
+		0x2E, 0x00,
+		0x2D, 0x08,
+	})
+
+	adaptor = newI2cTestAdaptor()
+	d = NewADXL345Driver(adaptor,
+		WithADXL345Range(ADXL345Range16g),
+		WithADXL345Rate(ADXL345Rate3200Hz),
+		WithADXL345FIFO(ADXL345FIFOFill, 20),
+		WithADXL345Interrupts(ADXL345InterruptWatermark, ADXL345InterruptWatermark))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		0x2D, 0x00,
+		0x2C, 0x0F,
+		0x31, 0x0B,
+		0x38, 0x54,
+		0x2F, 0x02,
+		0x2E, 0x02,
+		0x2D, 0x08,
+	})
+}
+
+func TestADXL345DriverStartConnectError(t *testing.T) {
+	d, adaptor := initTestADXL345DriverWithStubbedAdaptor()
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestADXL345DriverStartWriteError(t *testing.T) {
+	d, adaptor := initTestADXL345DriverWithStubbedAdaptor()
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, d.Start(), errors.New("write error"))
+}
+
+func TestADXL345DriverHalt(t *testing.T) {
+	d := initTestADXL345Driver()
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestADXL345DriverXYZ(t *testing.T) {
+	d, adaptor := initTestADXL345DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		buf := new(bytes.Buffer)
+		binary.Write(buf, binary.LittleEndian, int16(100))
+		binary.Write(buf, binary.LittleEndian, int16(-200))
+		binary.Write(buf, binary.LittleEndian, int16(256))
+		copy(b, buf.Bytes())
+		return buf.Len(), nil
+	}
+
+	rx, ry, rz, err := d.RawXYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, []int16{rx, ry, rz}, []int16{100, -200, 256})
+
+	scale := adxl345Scale
+	x, y, z, err := d.XYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, x, 100*scale)
+	gobottest.Assert(t, y, -200*scale)
+	gobottest.Assert(t, z, 256*scale)
+
+	v, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v.Z, 256*scale*imu.StandardGravity)
+}
+
+func TestADXL345DriverXYZError(t *testing.T) {
+	d, adaptor := initTestADXL345DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, _, _, err := d.XYZ()
+	gobottest.Assert(t, err, errors.New("read error"))
+	_, err = d.ReadAcceleration()
+	gobottest.Assert(t, err, errors.New("read error"))
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 4, nil
+	}
+	_, _, _, err = d.RawXYZ()
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	_, _, _, err = d.RawXYZ()
+	gobottest.Assert(t, err, errors.New("write error"))
+}
+
+func TestADXL345DriverReadFIFO(t *testing.T) {
+	d, adaptor := initTestADXL345DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		if len(b) == 1 {
+			b[0] = 0x82
+			return 1, nil
+		}
+		copy(b, []byte{0x00, 0x01, 0x00, 0x00, 0x00, 0xff})
+		return 6, nil
+	}
+
+	entries, err := d.FIFOEntries()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, entries, 2)
+
+	scale := adxl345Scale
+	samples, err := d.ReadFIFO()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(samples), 2)
+	gobottest.Assert(t, samples[1].X, 256*scale*imu.StandardGravity)
+	gobottest.Assert(t, samples[1].Z, -256*scale*imu.StandardGravity)
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err = d.ReadFIFO()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestADXL345DriverInterruptSource(t *testing.T) {
+	d, adaptor := initTestADXL345DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = ADXL345InterruptSingleTap | ADXL345InterruptDataReady
+		return 1, nil
+	}
+	source, err := d.InterruptSource()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, source, byte(0xC0))
+}
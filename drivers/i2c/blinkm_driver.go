@@ -13,6 +13,8 @@ type BlinkMDriver struct {
 	name       string
 	connector  Connector
 	connection Connection
+	// Animator plays the animations of the LED color
+	Animator *gobot.Animator
 	Config
 	gobot.Commander
 }
@@ -38,6 +40,10 @@ func NewBlinkMDriver(a Connector, options ...func(Config)) *BlinkMDriver {
 		option(b)
 	}
 
+	b.Animator = gobot.NewAnimator(3, func(values []float64) error {
+		return b.Rgb(gobot.AnimationByte(values[0]), gobot.AnimationByte(values[1]), gobot.AnimationByte(values[2]))
+	})
+
 	b.AddCommand("Rgb", func(params map[string]interface{}) interface{} {
 		red := byte(params["red"].(float64))
 		green := byte(params["green"].(float64))
@@ -62,6 +68,19 @@ func NewBlinkMDriver(a Connector, options ...func(Config)) *BlinkMDriver {
 		return map[string]interface{}{"color": color, "err": err}
 	})
 
+	b.AddCommand("Animate", func(params map[string]interface{}) interface{} {
+		a, err := gobot.ParseAnimation(params)
+		if err != nil {
+			return err
+		}
+		return b.Animate(a)
+	})
+
+	b.AddCommand("StopAnimation", func(params map[string]interface{}) interface{} {
+		b.StopAnimation()
+		return nil
+	})
+
 	return b
 }
 
@@ -79,7 +98,7 @@ func (b *BlinkMDriver) Start() (err error) {
 	bus := b.GetBusOrDefault(b.connector.GetDefaultBus())
 	address := b.GetAddressOrDefault(blinkmAddress)
 
-	b.connection, err = b.connector.GetConnection(address, bus)
+	b.connection, err = claimConnection(b.connector, b, address, bus)
 	if err != nil {
 		return
 	}
@@ -91,7 +110,11 @@ func (b *BlinkMDriver) Start() (err error) {
 }
 
 // Halt returns true if device is halted successfully
-func (b *BlinkMDriver) Halt() (err error) { return }
+func (b *BlinkMDriver) Halt() (err error) {
+	b.StopAnimation()
+	releaseConnections(b.connector, b)
+	return
+}
 
 // Rgb sets color using r,g,b params
 func (b *BlinkMDriver) Rgb(red byte, green byte, blue byte) (err error) {
@@ -136,3 +159,15 @@ func (b *BlinkMDriver) Color() (color []byte, err error) {
 	}
 	return []byte{data[0], data[1], data[2]}, nil
 }
+
+// Animate plays an animation of the color, with red, green and blue values
+// per keyframe, replacing the animation which is running. Unlike Fade the
+// color is changed by the driver, one frame at a time.
+func (b *BlinkMDriver) Animate(a *gobot.Animation) error {
+	return b.Animator.Play(a)
+}
+
+// StopAnimation stops the animation which is running
+func (b *BlinkMDriver) StopAnimation() {
+	b.Animator.Stop()
+}
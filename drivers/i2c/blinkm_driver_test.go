@@ -112,6 +112,36 @@ func TestBlinkMDriverStart(t *testing.T) {
 	gobottest.Assert(t, blinkM.Start(), errors.New("write error"))
 }
 
+func TestBlinkMDriverStartAddressInUse(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	d1 := NewBlinkMDriver(adaptor)
+	d2 := NewBlinkMDriver(adaptor)
+	gobottest.Assert(t, d1.Start(), nil)
+	gobottest.Refute(t, d2.Start(), nil)
+	gobottest.Assert(t, d1.Halt(), nil)
+	gobottest.Assert(t, d2.Start(), nil)
+}
+
+func TestBlinkMDriverHaltRenamed(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	d1 := NewBlinkMDriver(adaptor)
+	d2 := NewBlinkMDriver(adaptor)
+	gobottest.Assert(t, d1.Start(), nil)
+	d1.SetName("renamed")
+	gobottest.Assert(t, d1.Halt(), nil)
+	gobottest.Assert(t, d2.Start(), nil)
+}
+
+func TestBlinkMDriverStartConnectErrorReleases(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	d1 := NewBlinkMDriver(adaptor)
+	d2 := NewBlinkMDriver(adaptor)
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Refute(t, d1.Start(), nil)
+	adaptor.Testi2cConnectErr(false)
+	gobottest.Assert(t, d2.Start(), nil)
+}
+
 func TestBlinkMDriverStartConnectError(t *testing.T) {
 	d, adaptor := initTestBlinkDriverWithStubbedAdaptor()
 	adaptor.Testi2cConnectErr(true)
@@ -58,7 +58,7 @@ func (d *BME280Driver) Start() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
 	address := d.GetAddressOrDefault(bmp180Address)
 
-	if d.connection, err = d.connector.GetConnection(address, bus); err != nil {
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
 		return err
 	}
 
@@ -83,6 +83,13 @@ func (d *BME280Driver) Humidity() (humidity float32, err error) {
 	return
 }
 
+// ReadHumidity returns the current humidity in percentage of relative
+// humidity. It implements environment.Hygrometer.
+func (d *BME280Driver) ReadHumidity() (float64, error) {
+	humidity, err := d.Humidity()
+	return float64(humidity), err
+}
+
 // read the humidity calibration coefficients.
 func (d *BME280Driver) initHumidity() (err error) {
 	var coefficients []byte
@@ -6,11 +6,18 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*BME280Driver)(nil)
 
+var _ environment.Thermometer = (*BME280Driver)(nil)
+
+var _ environment.Barometer = (*BME280Driver)(nil)
+
+var _ environment.Hygrometer = (*BME280Driver)(nil)
+
 // --------- HELPERS
 func initTestBME280Driver() (driver *BME280Driver) {
 	driver, _ = initTestBME280DriverWithStubbedAdaptor()
@@ -99,6 +106,9 @@ func TestBME280DriverMeasurements(t *testing.T) {
 	hum, err := bme280.Humidity()
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, hum, float32(51.20179))
+	percent, err := bme280.ReadHumidity()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, percent, float64(hum))
 }
 
 func TestBME280DriverInitH1Error(t *testing.T) {
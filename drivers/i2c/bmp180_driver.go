@@ -102,7 +102,7 @@ func (d *BMP180Driver) Start() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
 	address := d.GetAddressOrDefault(bmp180Address)
 
-	if d.connection, err = d.connector.GetConnection(address, bus); err != nil {
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
 		return err
 	}
 	if err := d.initialization(); err != nil {
@@ -135,6 +135,7 @@ func (d *BMP180Driver) initialization() (err error) {
 
 // Halt halts the device.
 func (d *BMP180Driver) Halt() (err error) {
+	releaseConnections(d.connector, d)
 	return nil
 }
 
@@ -160,6 +161,20 @@ func (d *BMP180Driver) Pressure() (pressure float32, err error) {
 	return d.calculatePressure(rawTemp, rawPressure, d.Mode), nil
 }
 
+// ReadTemperature returns the current temperature in degrees Celsius. It
+// implements environment.Thermometer.
+func (d *BMP180Driver) ReadTemperature() (float64, error) {
+	temp, err := d.Temperature()
+	return float64(temp), err
+}
+
+// ReadPressure returns the current pressure in pascals. It implements
+// environment.Barometer.
+func (d *BMP180Driver) ReadPressure() (float64, error) {
+	pressure, err := d.Pressure()
+	return float64(pressure), err
+}
+
 func (d *BMP180Driver) rawTemp() (int16, error) {
 	if _, err := d.connection.Write([]byte{bmp180RegisterCtl, bmp180CmdTemp}); err != nil {
 		return 0, err
@@ -8,11 +8,16 @@ import (
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*BMP180Driver)(nil)
 
+var _ environment.Thermometer = (*BMP180Driver)(nil)
+
+var _ environment.Barometer = (*BMP180Driver)(nil)
+
 // --------- HELPERS
 func initTestBMP180Driver() (driver *BMP180Driver) {
 	driver, _ = initTestBMP180DriverWithStubbedAdaptor()
@@ -99,6 +104,12 @@ func TestBMP180DriverMeasurements(t *testing.T) {
 	pressure, err := bmp180.Pressure()
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, pressure, float32(69964))
+	celsius, err := bmp180.ReadTemperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, celsius, 15.0)
+	pascal, err := bmp180.ReadPressure()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, pascal, 69964.0)
 }
 
 func TestBMP180DriverTemperatureError(t *testing.T) {
@@ -86,7 +86,7 @@ func (d *BMP280Driver) Start() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
 	address := d.GetAddressOrDefault(bmp180Address)
 
-	if d.connection, err = d.connector.GetConnection(address, bus); err != nil {
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
 		return err
 	}
 
@@ -99,6 +99,7 @@ func (d *BMP280Driver) Start() (err error) {
 
 // Halt halts the device.
 func (d *BMP280Driver) Halt() (err error) {
+	releaseConnections(d.connector, d)
 	return nil
 }
 
@@ -126,6 +127,20 @@ func (d *BMP280Driver) Pressure() (press float32, err error) {
 	return d.calculatePress(rawP, tFine), nil
 }
 
+// ReadTemperature returns the current temperature in degrees Celsius. It
+// implements environment.Thermometer.
+func (d *BMP280Driver) ReadTemperature() (float64, error) {
+	temp, err := d.Temperature()
+	return float64(temp), err
+}
+
+// ReadPressure returns the current barometric pressure in pascals. It
+// implements environment.Barometer.
+func (d *BMP280Driver) ReadPressure() (float64, error) {
+	press, err := d.Pressure()
+	return float64(press), err
+}
+
 // Altitude returns the current altitude in meters based on the
 // current barometric pressure and estimated pressure at sea level.
 // Calculation is based on code from Adafruit BME280 library
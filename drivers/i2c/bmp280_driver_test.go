@@ -6,11 +6,16 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*BMP280Driver)(nil)
 
+var _ environment.Thermometer = (*BMP280Driver)(nil)
+
+var _ environment.Barometer = (*BMP280Driver)(nil)
+
 // --------- HELPERS
 func initTestBMP280Driver() (driver *BMP280Driver) {
 	driver, _ = initTestBMP280DriverWithStubbedAdaptor()
@@ -96,6 +101,12 @@ func TestBMP280DriverMeasurements(t *testing.T) {
 	alt, err := bmp280.Altitude()
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, alt, float32(149.22713))
+	celsius, err := bmp280.ReadTemperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, celsius, float64(temp))
+	pascal, err := bmp280.ReadPressure()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, pascal, float64(pressure))
 }
 
 func TestBMP280DriverTemperatureWriteError(t *testing.T) {
@@ -139,7 +139,7 @@ func (d *DRV2605LDriver) initialize() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
 	address := d.GetAddressOrDefault(drv2605Address)
 
-	d.connection, err = d.connector.GetConnection(address, bus)
+	d.connection, err = claimConnection(d.connector, d, address, bus)
 	if err != nil {
 		return
 	}
@@ -257,6 +257,8 @@ func (d *DRV2605LDriver) Go() (err error) {
 
 // Halt halts the device.
 func (d *DRV2605LDriver) Halt() (err error) {
+	defer releaseConnections(d.connector, d)
+
 	if d.connection != nil {
 		// stop playback
 		if err = d.connection.WriteByteData(drv2605RegGo, 0); err != nil {
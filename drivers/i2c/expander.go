@@ -0,0 +1,182 @@
+package i2c
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+// ErrInvalidPin is the error resulting when a pin name is not a pin of a
+// port expander
+var ErrInvalidPin = errors.New("Invalid pin name for this expander")
+
+// ErrNoInterruptOutput is the error returned by Start of a driver which was
+// given an interrupt pin, but has no interrupt output
+var ErrNoInterruptOutput = errors.New("Trying to set interrupt pin for a driver without interrupt output")
+
+// PinLevel is the data of a PinChange event, the pin of a port expander and
+// its new level
+type PinLevel struct {
+	Pin   string
+	Level int
+}
+
+// expanderInterrupt watches the interrupt output of a port expander, which
+// is connected to a pin of another adaptor
+type expanderInterrupt struct {
+	reader  gpio.DigitalReader
+	pin     string
+	active  int
+	halt    chan bool
+	unwatch func()
+}
+
+// interruptConfig is implemented by the port expanders which have an
+// interrupt output
+type interruptConfig interface {
+	setInterrupt(reader gpio.DigitalReader, pin string)
+}
+
+// WithInterruptPin option sets the pin of another adaptor which the
+// interrupt output of a port expander is connected to. The expander then
+// reads its inputs only when they change, publishes a PinChange event for
+// every input which changed and serves DigitalRead from the levels it read.
+// Start of a driver without an interrupt output returns
+// ErrNoInterruptOutput.
+func WithInterruptPin(reader gpio.DigitalReader, pin string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(interruptConfig)
+		if ok {
+			d.setInterrupt(reader, pin)
+		} else {
+			c.setOptionError(ErrNoInterruptOutput)
+		}
+	}
+}
+
+func (e *expanderInterrupt) enabled() bool { return e.reader != nil }
+
+// watch calls update whenever the interrupt pin is at its active level,
+// until stop is called. If the adaptor of the pin is a
+// gpio.DigitalPinWatcher, update is called at the start if the pin is active
+// and then at every change to the active level, otherwise the pin is polled
+// every millisecond.
+func (e *expanderInterrupt) watch(update func() error, errored func(error)) (err error) {
+	watcher, ok := e.reader.(gpio.DigitalPinWatcher)
+	if !ok {
+		e.halt = make(chan bool)
+		go e.poll(e.halt, update, errored)
+		return
+	}
+
+	interrupt := make(chan bool, 1)
+	unwatch, err := watcher.WatchDigitalPins(func(pin string, level int) {
+		if level == e.active {
+			select {
+			case interrupt <- true:
+			default:
+			}
+		}
+	}, e.pin)
+	if err != nil {
+		return
+	}
+	e.unwatch = unwatch
+	e.halt = make(chan bool)
+
+	go func(halt chan bool) {
+		// the pin may be active before its first change
+		val, err := e.reader.DigitalRead(e.pin)
+		if err == nil && val == e.active {
+			err = update()
+		}
+		for {
+			if err != nil {
+				errored(err)
+			}
+			select {
+			case <-interrupt:
+			case <-halt:
+				return
+			}
+			err = update()
+		}
+	}(e.halt)
+	return
+}
+
+// poll calls update while the interrupt pin is at its active level, reading
+// it every millisecond
+func (e *expanderInterrupt) poll(halt chan bool, update func() error, errored func(error)) {
+	for {
+		select {
+		case <-time.After(time.Millisecond):
+		case <-halt:
+			return
+		}
+		val, err := e.reader.DigitalRead(e.pin)
+		if err == nil && val == e.active {
+			err = update()
+		}
+		if err != nil {
+			errored(err)
+		}
+	}
+}
+
+func (e *expanderInterrupt) stop() {
+	if e.unwatch != nil {
+		e.unwatch()
+		e.unwatch = nil
+	}
+	if e.halt != nil {
+		e.halt <- true
+		e.halt = nil
+	}
+}
+
+// changedPins returns the PinChange events of the inputs whose levels
+// differ between before and after
+func changedPins(before uint16, after uint16, inputs uint16, ports int) (changes []PinLevel) {
+	changed := (before ^ after) & inputs
+	for bit := uint8(0); bit < uint8(8*ports); bit++ {
+		if changed&(1<<bit) != 0 {
+			changes = append(changes, PinLevel{Pin: expanderPinName(bit, ports), Level: int(after>>bit) & 1})
+		}
+	}
+	return
+}
+
+// expanderPin returns the bit of a pin of a port expander. Pins of
+// expanders with two ports are named by the port and number, "A0" to "B7",
+// those of expanders with one port by their number, "0" to "7" or "P0" to
+// "P7".
+func expanderPin(pin string, ports int) (uint8, error) {
+	pin = strings.ToUpper(pin)
+	port := 0
+	if ports == 2 && len(pin) == 2 && (pin[0] == 'A' || pin[0] == 'B') {
+		port = int(pin[0] - 'A')
+		pin = pin[1:]
+	} else if ports == 1 {
+		pin = strings.TrimPrefix(pin, "P")
+	} else {
+		return 0, ErrInvalidPin
+	}
+
+	n, err := strconv.Atoi(pin)
+	if err != nil || n < 0 || n > 7 {
+		return 0, ErrInvalidPin
+	}
+	return uint8(port*8 + n), nil
+}
+
+// expanderPinName returns the name of the bit of a port expander
+func expanderPinName(bit uint8, ports int) string {
+	if ports == 2 {
+		return string('A'+rune(bit/8)) + strconv.Itoa(int(bit%8))
+	}
+	return strconv.Itoa(int(bit))
+}
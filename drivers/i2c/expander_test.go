@@ -0,0 +1,129 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+// testInterruptPin is the pin of an adaptor which the interrupt output of an
+// expander is connected to
+type testInterruptPin struct {
+	mtx   sync.Mutex
+	level int
+}
+
+func (p *testInterruptPin) DigitalRead(pin string) (int, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.level, nil
+}
+
+func (p *testInterruptPin) set(level int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.level = level
+}
+
+// watchedInterruptPin is the pin of an adaptor which reports the changes
+// of its level
+type watchedInterruptPin struct {
+	testInterruptPin
+	handler func(pin string, level int)
+	stopped bool
+	err     error
+}
+
+func (p *watchedInterruptPin) WatchDigitalPins(handler func(pin string, level int), pins ...string) (func(), error) {
+	p.handler = handler
+	return func() { p.stopped = true }, p.err
+}
+
+func (p *watchedInterruptPin) change(level int) {
+	p.set(level)
+	p.handler("1", level)
+}
+
+func TestExpanderPin(t *testing.T) {
+	for pin, bit := range map[string]uint8{"A0": 0, "a7": 7, "B0": 8, "B7": 15} {
+		n, err := expanderPin(pin, 2)
+		gobottest.Assert(t, err, nil)
+		gobottest.Assert(t, n, bit)
+		gobottest.Assert(t, expanderPinName(bit, 2), strings.ToUpper(pin))
+	}
+	for pin, bit := range map[string]uint8{"0": 0, "7": 7, "P3": 3} {
+		n, err := expanderPin(pin, 1)
+		gobottest.Assert(t, err, nil)
+		gobottest.Assert(t, n, bit)
+	}
+	gobottest.Assert(t, expanderPinName(3, 1), "3")
+
+	for _, pin := range []string{"C0", "A8", "B", "3", "A-1"} {
+		_, err := expanderPin(pin, 2)
+		gobottest.Assert(t, err, ErrInvalidPin)
+	}
+	for _, pin := range []string{"8", "A0", "P", "-1"} {
+		_, err := expanderPin(pin, 1)
+		gobottest.Assert(t, err, ErrInvalidPin)
+	}
+}
+
+func TestChangedPins(t *testing.T) {
+	changes := changedPins(0x0001, 0x0102, 0xff03, 2)
+	gobottest.Assert(t, changes, []PinLevel{{Pin: "A0", Level: 0}, {Pin: "A1", Level: 1}, {Pin: "B0", Level: 1}})
+	gobottest.Assert(t, len(changedPins(0x00, 0x04, 0x03, 1)), 0)
+}
+
+func TestWithInterruptPin(t *testing.T) {
+	d := NewHMC6352Driver(newI2cTestAdaptor(), WithInterruptPin(&testInterruptPin{}, "1"))
+	gobottest.Assert(t, d.Start(), ErrNoInterruptOutput)
+}
+
+func TestExpanderInterruptWatch(t *testing.T) {
+	pin := &watchedInterruptPin{}
+	e := &expanderInterrupt{reader: pin, pin: "1"}
+	updates := make(chan bool, 10)
+	update := func() error {
+		updates <- true
+		return nil
+	}
+	errored := func(err error) { t.Errorf("unexpected error %v", err) }
+
+	// the pin is active at the start
+	gobottest.Assert(t, e.watch(update, errored), nil)
+	select {
+	case <-updates:
+	case <-time.After(time.Second):
+		t.Errorf("inputs were not read at the start")
+	}
+
+	pin.change(1)
+	select {
+	case <-updates:
+		t.Errorf("inputs were read at an inactive level")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	pin.change(0)
+	select {
+	case <-updates:
+	case <-time.After(time.Second):
+		t.Errorf("inputs were not read at the interrupt")
+	}
+
+	e.stop()
+	gobottest.Assert(t, pin.stopped, true)
+}
+
+func TestExpanderInterruptWatchError(t *testing.T) {
+	pin := &watchedInterruptPin{err: errors.New("watch error")}
+	adaptor := newI2cTestAdaptor()
+	d := NewPCF8574Driver(adaptor, WithInterruptPin(pin, "1"))
+	simulatePCF857x(adaptor)
+	gobottest.Assert(t, d.Start(), errors.New("watch error"))
+	gobottest.Assert(t, d.interrupt.halt == nil, true)
+}
@@ -0,0 +1,64 @@
+package i2c
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestFonts(t *testing.T) {
+	for _, f := range []*Font{Font5x7, Font3x5} {
+		gobottest.Assert(t, len(f.Glyphs), f.Width*('~'-' '+1))
+	}
+}
+
+// renderText returns the rows of the text drawn onto an image
+func renderText(text string, f *Font) string {
+	img := image.NewGray(image.Rect(0, 0, 16, 8))
+	DrawText(img, image.Pt(1, 0), text, f, color.White)
+	rows := []string{}
+	for y := 0; y < f.Height; y++ {
+		row := ""
+		for x := 0; x < 16; x++ {
+			if img.GrayAt(x, y).Y != 0 {
+				row += "#"
+			} else {
+				row += "."
+			}
+		}
+		rows = append(rows, row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func TestDrawText(t *testing.T) {
+	gobottest.Assert(t, renderText("A1", Font5x7), strings.Join([]string{
+		"..###....#......",
+		".#...#..##......",
+		".#...#...#......",
+		".#...#...#......",
+		".#####...#......",
+		".#...#...#......",
+		".#...#..###.....",
+	}, "\n"))
+	gobottest.Assert(t, renderText("Hi!", Font3x5), strings.Join([]string{
+		".#.#..#...#.....",
+		".#.#......#.....",
+		".###..#...#.....",
+		".#.#..#.........",
+		".#.#..#...#.....",
+	}, "\n"))
+	// characters outside of the font are question marks
+	gobottest.Assert(t, renderText("\t", Font3x5), renderText("?", Font3x5))
+}
+
+func TestDrawTextLines(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 16, 16))
+	pt := DrawText(img, image.Pt(2, 1), "ab\nc", Font5x7, color.White)
+	gobottest.Assert(t, pt, image.Pt(8, 9))
+	pt = DrawText(img, image.Pt(2, 1), "ab", Font3x5, color.White)
+	gobottest.Assert(t, pt, image.Pt(10, 1))
+}
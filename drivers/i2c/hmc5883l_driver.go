@@ -0,0 +1,266 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+)
+
+const hmc5883lAddress = 0x1E
+
+const (
+	hmc5883lRegisterConfigA = 0x00
+	hmc5883lRegisterConfigB = 0x01
+	hmc5883lRegisterMode    = 0x02
+	hmc5883lRegisterDataX   = 0x03
+	hmc5883lRegisterStatus  = 0x09
+
+	hmc5883lContinuous = 0x00
+	hmc5883lReady      = 0x01
+	hmc5883lOverflow   = -4096
+)
+
+// ErrMagneticFieldOverflow is the error resulting when the magnetic field
+// exceeds the range of a magnetometer
+var ErrMagneticFieldOverflow = errors.New("Magnetic field is out of range")
+
+// HMC5883LGain is the gain of the HMC5883L, which sets its range.
+type HMC5883LGain byte
+
+const (
+	// HMC5883LGain0_88 is the +-0.88 Gauss range.
+	HMC5883LGain0_88 HMC5883LGain = 0x00
+	// HMC5883LGain1_3 is the +-1.3 Gauss range.
+	HMC5883LGain1_3 HMC5883LGain = 0x20
+	// HMC5883LGain1_9 is the +-1.9 Gauss range.
+	HMC5883LGain1_9 HMC5883LGain = 0x40
+	// HMC5883LGain2_5 is the +-2.5 Gauss range.
+	HMC5883LGain2_5 HMC5883LGain = 0x60
+	// HMC5883LGain4_0 is the +-4.0 Gauss range.
+	HMC5883LGain4_0 HMC5883LGain = 0x80
+	// HMC5883LGain4_7 is the +-4.7 Gauss range.
+	HMC5883LGain4_7 HMC5883LGain = 0xA0
+	// HMC5883LGain5_6 is the +-5.6 Gauss range.
+	HMC5883LGain5_6 HMC5883LGain = 0xC0
+	// HMC5883LGain8_1 is the +-8.1 Gauss range.
+	HMC5883LGain8_1 HMC5883LGain = 0xE0
+)
+
+// hmc5883lLSBPerGauss are the LSB per Gauss of the gains
+var hmc5883lLSBPerGauss = map[HMC5883LGain]float64{
+	HMC5883LGain0_88: 1370,
+	HMC5883LGain1_3:  1090,
+	HMC5883LGain1_9:  820,
+	HMC5883LGain2_5:  660,
+	HMC5883LGain4_0:  440,
+	HMC5883LGain4_7:  390,
+	HMC5883LGain5_6:  330,
+	HMC5883LGain8_1:  230,
+}
+
+// HMC5883LRate is the output data rate of the HMC5883L.
+type HMC5883LRate byte
+
+const (
+	// HMC5883LRate0_75Hz is the 0.75Hz data rate.
+	HMC5883LRate0_75Hz HMC5883LRate = 0x00
+	// HMC5883LRate1_5Hz is the 1.5Hz data rate.
+	HMC5883LRate1_5Hz HMC5883LRate = 0x04
+	// HMC5883LRate3Hz is the 3Hz data rate.
+	HMC5883LRate3Hz HMC5883LRate = 0x08
+	// HMC5883LRate7_5Hz is the 7.5Hz data rate.
+	HMC5883LRate7_5Hz HMC5883LRate = 0x0C
+	// HMC5883LRate15Hz is the 15Hz data rate.
+	HMC5883LRate15Hz HMC5883LRate = 0x10
+	// HMC5883LRate30Hz is the 30Hz data rate.
+	HMC5883LRate30Hz HMC5883LRate = 0x14
+	// HMC5883LRate75Hz is the 75Hz data rate.
+	HMC5883LRate75Hz HMC5883LRate = 0x18
+)
+
+// HMC5883LSamples is the number of samples the HMC5883L averages per output.
+type HMC5883LSamples byte
+
+const (
+	// HMC5883LSamples1 outputs every sample.
+	HMC5883LSamples1 HMC5883LSamples = 0x00
+	// HMC5883LSamples2 averages 2 samples.
+	HMC5883LSamples2 HMC5883LSamples = 0x20
+	// HMC5883LSamples4 averages 4 samples.
+	HMC5883LSamples4 HMC5883LSamples = 0x40
+	// HMC5883LSamples8 averages 8 samples.
+	HMC5883LSamples8 HMC5883LSamples = 0x60
+)
+
+// HMC5883LDriver is a driver for the HMC5883L 3-axis digital compass, which
+// measures continuously. Its DRDY pin signals new data.
+// Device datasheet: https://cdn-shop.adafruit.com/datasheets/HMC5883L_3-Axis_Digital_Compass_IC.pdf
+type HMC5883LDriver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	gain    HMC5883LGain
+	rate    HMC5883LRate
+	samples HMC5883LSamples
+}
+
+// NewHMC5883LDriver creates a new driver for the HMC5883L.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithHMC5883LGain(HMC5883LGain):	gain, +-1.3 Gauss by default
+//		i2c.WithHMC5883LRate(HMC5883LRate):	output data rate, 15Hz by default
+//		i2c.WithHMC5883LSamples(HMC5883LSamples):	samples averaged per output, 1 by default
+//
+func NewHMC5883LDriver(a Connector, options ...func(Config)) *HMC5883LDriver {
+	d := &HMC5883LDriver{
+		name:      gobot.DefaultName("HMC5883L"),
+		connector: a,
+		Config:    NewConfig(),
+		gain:      HMC5883LGain1_3,
+		rate:      HMC5883LRate15Hz,
+		samples:   HMC5883LSamples1,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithHMC5883LGain option sets the gain of the HMC5883LDriver.
+func WithHMC5883LGain(val HMC5883LGain) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*HMC5883LDriver); ok {
+			d.gain = val
+		}
+	}
+}
+
+// WithHMC5883LRate option sets the output data rate of the HMC5883LDriver.
+func WithHMC5883LRate(val HMC5883LRate) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*HMC5883LDriver); ok {
+			d.rate = val
+		}
+	}
+}
+
+// WithHMC5883LSamples option sets the number of samples the HMC5883LDriver
+// averages per output.
+func WithHMC5883LSamples(val HMC5883LSamples) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*HMC5883LDriver); ok {
+			d.samples = val
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *HMC5883LDriver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *HMC5883LDriver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the device.
+func (d *HMC5883LDriver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start configures the HMC5883L and starts measuring continuously.
+func (d *HMC5883LDriver) Start() (err error) {
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(hmc5883lAddress)
+
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
+		return
+	}
+
+	if err = d.connection.WriteByteData(hmc5883lRegisterConfigA, byte(d.samples)|byte(d.rate)); err != nil {
+		return
+	}
+	if err = d.connection.WriteByteData(hmc5883lRegisterConfigB, byte(d.gain)); err != nil {
+		return
+	}
+	return d.connection.WriteByteData(hmc5883lRegisterMode, hmc5883lContinuous)
+}
+
+// Halt halts the device.
+func (d *HMC5883LDriver) Halt() (err error) {
+	releaseConnections(d.connector, d)
+	return
+}
+
+// RawXYZ returns the raw magnetic field of the 3 axis, or
+// ErrMagneticFieldOverflow if an axis exceeds the range of the gain.
+func (d *HMC5883LDriver) RawXYZ() (x int16, y int16, z int16, err error) {
+	if _, err = d.connection.Write([]byte{hmc5883lRegisterDataX}); err != nil {
+		return
+	}
+	data := make([]byte, 6)
+	n, err := d.connection.Read(data)
+	if err != nil {
+		return
+	}
+	if n != len(data) {
+		err = ErrNotEnoughBytes
+		return
+	}
+
+	// the axis are in the order X, Z, Y
+	buf := bytes.NewBuffer(data)
+	binary.Read(buf, binary.BigEndian, &x)
+	binary.Read(buf, binary.BigEndian, &z)
+	binary.Read(buf, binary.BigEndian, &y)
+	if x == hmc5883lOverflow || y == hmc5883lOverflow || z == hmc5883lOverflow {
+		err = ErrMagneticFieldOverflow
+	}
+	return
+}
+
+// XYZ returns the magnetic field of the 3 axis in Gauss.
+func (d *HMC5883LDriver) XYZ() (x float64, y float64, z float64, err error) {
+	rx, ry, rz, err := d.RawXYZ()
+	if err != nil {
+		return
+	}
+	lsb := hmc5883lLSBPerGauss[d.gain]
+	return float64(rx) / lsb, float64(ry) / lsb, float64(rz) / lsb, nil
+}
+
+// ReadMagneticField returns the magnetic field in tesla. It implements
+// imu.Magnetometer.
+func (d *HMC5883LDriver) ReadMagneticField() (v imu.Vector, err error) {
+	x, y, z, err := d.XYZ()
+	if err != nil {
+		return
+	}
+	return imu.Vector{X: x, Y: y, Z: z}.Scale(1e-4), nil
+}
+
+// Heading returns the heading in radians, from 0 to 2*Pi clockwise from
+// magnetic north, of the x axis while the HMC5883L lies flat.
+func (d *HMC5883LDriver) Heading() (heading float64, err error) {
+	x, y, _, err := d.RawXYZ()
+	if err != nil {
+		return
+	}
+	heading = math.Atan2(float64(y), float64(x))
+	if heading < 0 {
+		heading += 2 * math.Pi
+	}
+	return
+}
+
+// Ready returns true when new data is available.
+func (d *HMC5883LDriver) Ready() (ready bool, err error) {
+	status, err := d.connection.ReadByteData(hmc5883lRegisterStatus)
+	return status&hmc5883lReady != 0, err
+}
@@ -0,0 +1,175 @@
+package i2c
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*HMC5883LDriver)(nil)
+
+var _ imu.Magnetometer = (*HMC5883LDriver)(nil)
+
+// --------- HELPERS
+func initTestHMC5883LDriver() (driver *HMC5883LDriver) {
+	driver, _ = initTestHMC5883LDriverWithStubbedAdaptor()
+	return
+}
+
+func initTestHMC5883LDriverWithStubbedAdaptor() (*HMC5883LDriver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	return NewHMC5883LDriver(adaptor), adaptor
+}
+
+// --------- TESTS
+
+func TestNewHMC5883LDriver(t *testing.T) {
+	var d interface{} = NewHMC5883LDriver(newI2cTestAdaptor())
+	_, ok := d.(*HMC5883LDriver)
+	if !ok {
+		t.Errorf("NewHMC5883LDriver() should have returned a *HMC5883LDriver")
+	}
+}
+
+func TestHMC5883LDriver(t *testing.T) {
+	d := initTestHMC5883LDriver()
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "HMC5883L"), true)
+	d.SetName("mybot")
+	gobottest.Assert(t, d.Name(), "mybot")
+}
+
+func TestHMC5883LDriverOptions(t *testing.T) {
+	d := NewHMC5883LDriver(newI2cTestAdaptor(), WithBus(2),
+		WithHMC5883LGain(HMC5883LGain4_7),
+		WithHMC5883LRate(HMC5883LRate75Hz),
+		WithHMC5883LSamples(HMC5883LSamples8))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.gain, HMC5883LGain4_7)
+	gobottest.Assert(t, d.rate, HMC5883LRate75Hz)
+	gobottest.Assert(t, d.samples, HMC5883LSamples8)
+}
+
+func TestHMC5883LDriverStart(t *testing.T) {
+	d, adaptor := initTestHMC5883LDriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x00, 0x10, 0x01, 0x20, 0x02, 0x00})
+
+	adaptor = newI2cTestAdaptor()
+	d = NewHMC5883LDriver(adaptor,
+		WithHMC5883LGain(HMC5883LGain8_1),
+		WithHMC5883LRate(HMC5883LRate75Hz),
+		WithHMC5883LSamples(HMC5883LSamples4))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x00, 0x58, 0x01, 0xE0, 0x02, 0x00})
+}
+
+func TestHMC5883LDriverStartConnectError(t *testing.T) {
+	d, adaptor := initTestHMC5883LDriverWithStubbedAdaptor()
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestHMC5883LDriverStartWriteError(t *testing.T) {
+	d, adaptor := initTestHMC5883LDriverWithStubbedAdaptor()
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, d.Start(), errors.New("write error"))
+}
+
+func TestHMC5883LDriverHalt(t *testing.T) {
+	d := initTestHMC5883LDriver()
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestHMC5883LDriverXYZ(t *testing.T) {
+	d, adaptor := initTestHMC5883LDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		// x 1090, z -545, y 0
+		copy(b, []byte{0x04, 0x42, 0xfd, 0xdf, 0x00, 0x00})
+		return 6, nil
+	}
+
+	rx, ry, rz, err := d.RawXYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, []int16{rx, ry, rz}, []int16{1090, 0, -545})
+
+	x, y, z, err := d.XYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, []float64{x, y, z}, []float64{1, 0, -0.5})
+
+	v, err := d.ReadMagneticField()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, imu.Vector{X: 1e-4, Y: 0, Z: -0.5e-4})
+
+	heading, err := d.Heading()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, heading, 0.0)
+}
+
+func TestHMC5883LDriverHeading(t *testing.T) {
+	d, adaptor := initTestHMC5883LDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		// x 0, z 0, y -100
+		copy(b, []byte{0x00, 0x00, 0x00, 0x00, 0xff, 0x9c})
+		return 6, nil
+	}
+	heading, err := d.Heading()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, heading, 1.5*math.Pi)
+}
+
+func TestHMC5883LDriverXYZOverflow(t *testing.T) {
+	d, adaptor := initTestHMC5883LDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0xf0, 0x00, 0x00, 0x00, 0x00, 0x00})
+		return 6, nil
+	}
+	_, _, _, err := d.XYZ()
+	gobottest.Assert(t, err, ErrMagneticFieldOverflow)
+}
+
+func TestHMC5883LDriverXYZError(t *testing.T) {
+	d, adaptor := initTestHMC5883LDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err := d.ReadMagneticField()
+	gobottest.Assert(t, err, errors.New("read error"))
+	_, err = d.Heading()
+	gobottest.Assert(t, err, errors.New("read error"))
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 2, nil
+	}
+	_, _, _, err = d.RawXYZ()
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	_, _, _, err = d.RawXYZ()
+	gobottest.Assert(t, err, errors.New("write error"))
+}
+
+func TestHMC5883LDriverReady(t *testing.T) {
+	d, adaptor := initTestHMC5883LDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x01
+		return 1, nil
+	}
+	ready, err := d.Ready()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, ready, true)
+}
@@ -48,7 +48,7 @@ func (h *HMC6352Driver) Start() (err error) {
 	bus := h.GetBusOrDefault(h.connector.GetDefaultBus())
 	address := h.GetAddressOrDefault(hmc6352Address)
 
-	h.connection, err = h.connector.GetConnection(address, bus)
+	h.connection, err = claimConnection(h.connector, h, address, bus)
 	if err != nil {
 		return err
 	}
@@ -60,7 +60,10 @@ func (h *HMC6352Driver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (h *HMC6352Driver) Halt() (err error) { return }
+func (h *HMC6352Driver) Halt() (err error) {
+	releaseConnections(h.connector, h)
+	return
+}
 
 // Heading returns the current heading
 func (h *HMC6352Driver) Heading() (heading uint16, err error) {
@@ -2,13 +2,18 @@ package i2c
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"sync"
+
+	"gobot.io/x/gobot"
 )
 
 const (
 	// Error event
 	Error = "error"
+	// PinChange event
+	PinChange = "pin-change"
 )
 
 const (
@@ -17,6 +22,10 @@ const (
 
 	// AddressNotInitialized is the initial value for an address
 	AddressNotInitialized = -1
+
+	// TenBitAddress is or'ed into the address of a device which uses
+	// 10-bit addressing, e.g. 0x1a5|TenBitAddress
+	TenBitAddress = 0x8000
 )
 
 var (
@@ -24,6 +33,7 @@ var (
 	ErrNotEnoughBytes  = errors.New("Not enough bytes read")
 	ErrNotReady        = errors.New("Device is not ready")
 	ErrInvalidPosition = errors.New("Invalid position value")
+	ErrNotSupported    = errors.New("Operation not supported by i2c bus")
 )
 
 type I2cOperations interface {
@@ -37,6 +47,22 @@ type I2cOperations interface {
 	WriteBlockData(reg uint8, b []byte) (err error)
 }
 
+// I2cExtendedOperations are the i2c operations beyond plain transfers and
+// SMBus byte and word access, which not every bus supports. Connections
+// return ErrNotSupported when their bus does not implement them.
+type I2cExtendedOperations interface {
+	// WriteRead writes w and then reads len(r) bytes into r as a single
+	// transaction, with a repeated start instead of a stop between them.
+	WriteRead(w []byte, r []byte) (err error)
+	// ReadBlockData reads an SMBus block, whose length is sent by the
+	// device, into b and returns the number of bytes read.
+	ReadBlockData(reg uint8, b []byte) (n int, err error)
+	// ReadI2cBlockData reads len(b) bytes starting at register reg.
+	ReadI2cBlockData(reg uint8, b []byte) (err error)
+	// SetPEC enables or disables SMBus packet error checking.
+	SetPEC(enable bool) (err error)
+}
+
 // I2cDevice is the interface to a specific i2c bus
 type I2cDevice interface {
 	I2cOperations
@@ -182,3 +208,119 @@ func (c *i2cConnection) WriteBlockData(reg uint8, b []byte) (err error) {
 	}
 	return c.bus.WriteBlockData(reg, b)
 }
+
+// WriteRead writes w and then reads len(r) bytes into r as a single
+// transaction on the i2c device.
+func (c *i2cConnection) WriteRead(w []byte, r []byte) (err error) {
+	bus, ok := c.bus.(I2cExtendedOperations)
+	if !ok {
+		return ErrNotSupported
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.bus.SetAddress(c.address); err != nil {
+		return err
+	}
+	return bus.WriteRead(w, r)
+}
+
+// ReadBlockData reads an SMBus block from a register on the i2c device.
+func (c *i2cConnection) ReadBlockData(reg uint8, b []byte) (n int, err error) {
+	bus, ok := c.bus.(I2cExtendedOperations)
+	if !ok {
+		return 0, ErrNotSupported
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.bus.SetAddress(c.address); err != nil {
+		return 0, err
+	}
+	return bus.ReadBlockData(reg, b)
+}
+
+// ReadI2cBlockData reads a block of bytes starting at a register on the i2c device.
+func (c *i2cConnection) ReadI2cBlockData(reg uint8, b []byte) (err error) {
+	bus, ok := c.bus.(I2cExtendedOperations)
+	if !ok {
+		return ErrNotSupported
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.bus.SetAddress(c.address); err != nil {
+		return err
+	}
+	return bus.ReadI2cBlockData(reg, b)
+}
+
+// SetPEC enables or disables packet error checking on the i2c bus.
+func (c *i2cConnection) SetPEC(enable bool) (err error) {
+	bus, ok := c.bus.(I2cExtendedOperations)
+	if !ok {
+		return ErrNotSupported
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return bus.SetPEC(enable)
+}
+
+// WriteRead writes w and then reads len(r) bytes into r. The transfers are
+// combined with a repeated start when the connection supports it, otherwise
+// they are done one after the other.
+func WriteRead(c Connection, w []byte, r []byte) (err error) {
+	if ext, ok := c.(I2cExtendedOperations); ok {
+		if err = ext.WriteRead(w, r); err != ErrNotSupported {
+			return
+		}
+	}
+
+	if _, err = c.Write(w); err != nil {
+		return
+	}
+	n, err := c.Read(r)
+	if err != nil {
+		return
+	}
+	if n != len(r) {
+		return ErrNotEnoughBytes
+	}
+	return
+}
+
+// claimConnection claims the bus and address for owner, if the connector is
+// a gobot.Connection, and returns the connection to the device. The claim is
+// released again if there is no connection. The error of an option of the
+// owner is returned instead, if there is one.
+func claimConnection(c Connector, owner gobot.Driver, address int, bus int) (Connection, error) {
+	if config, ok := owner.(Config); ok {
+		if err := config.optionError(); err != nil {
+			return nil, err
+		}
+	}
+	conn, ok := c.(gobot.Connection)
+	if ok {
+		id := fmt.Sprintf("%d:0x%02x", bus, address)
+		if err := gobot.ClaimResources(conn, owner, gobot.I2cResource, id); err != nil {
+			return nil, err
+		}
+	}
+	connection, err := c.GetConnection(address, bus)
+	if err != nil && ok {
+		gobot.ReleaseResources(conn, owner)
+	}
+	return connection, err
+}
+
+// releaseConnections releases every bus and address claimed by owner.
+func releaseConnections(c Connector, owner gobot.Driver) {
+	if conn, ok := c.(gobot.Connection); ok {
+		gobot.ReleaseResources(conn, owner)
+	}
+}
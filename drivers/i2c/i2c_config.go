@@ -3,6 +3,7 @@ package i2c
 type i2cConfig struct {
 	bus     int
 	address int
+	err     error
 }
 
 // Config is the interface which describes how a Driver can specify
@@ -19,6 +20,12 @@ type Config interface {
 
 	// GetAddressOrDefault gets which address to use
 	GetAddressOrDefault(def int) int
+
+	// setOptionError records the error of an option, which Start returns
+	setOptionError(err error)
+
+	// optionError returns the first error of an option
+	optionError() error
 }
 
 // NewConfig returns a new I2c Config.
@@ -70,3 +77,13 @@ func WithAddress(address int) func(Config) {
 		i.WithAddress(address)
 	}
 }
+
+func (i *i2cConfig) setOptionError(err error) {
+	if i.err == nil {
+		i.err = err
+	}
+}
+
+func (i *i2cConfig) optionError() error {
+	return i.err
+}
@@ -14,15 +14,20 @@ import (
 )
 
 func syscallImpl(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
-	if (trap == syscall.SYS_IOCTL) && (a2 == sysfs.I2C_FUNCS) {
-		var funcPtr *uint64 = (*uint64)(unsafe.Pointer(a3))
+	// Let all operations succeed
+	return 0, 0, 0
+}
+
+func ioctlImpl(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno) {
+	if request == sysfs.I2C_FUNCS {
+		var funcPtr *uint64 = (*uint64)(arg)
 		*funcPtr = sysfs.I2C_FUNC_SMBUS_READ_BYTE | sysfs.I2C_FUNC_SMBUS_READ_BYTE_DATA |
 			sysfs.I2C_FUNC_SMBUS_READ_WORD_DATA |
 			sysfs.I2C_FUNC_SMBUS_WRITE_BYTE | sysfs.I2C_FUNC_SMBUS_WRITE_BYTE_DATA |
 			sysfs.I2C_FUNC_SMBUS_WRITE_WORD_DATA
 	}
 	// Let all operations succeed
-	return 0, 0, 0
+	return 0
 }
 
 func syscallImplFail(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
@@ -37,7 +42,8 @@ func initI2CDevice() I2cDevice {
 	sysfs.SetFilesystem(fs)
 
 	sysfs.SetSyscall(&sysfs.MockSyscall{
-		Impl: syscallImpl,
+		Impl:      syscallImpl,
+		IoctlImpl: ioctlImpl,
 	})
 	i, _ := sysfs.NewI2cDevice("/dev/i2c-1")
 	return i
@@ -173,3 +179,59 @@ func TestI2CWriteBlockDataAddressError(t *testing.T) {
 	err := c.WriteBlockData(0x01, []byte{0x01, 0x02})
 	gobottest.Assert(t, err, errors.New("Setting address failed with syscall.Errno operation not permitted"))
 }
+
+// basicI2cDevice hides the extended operations of a device
+type basicI2cDevice struct {
+	I2cDevice
+}
+
+func TestI2CExtendedOperationsNotSupported(t *testing.T) {
+	c := NewConnection(basicI2cDevice{initI2CDevice()}, 0x06)
+	gobottest.Assert(t, c.WriteRead([]byte{0x01}, make([]byte, 2)), ErrNotSupported)
+	_, err := c.ReadBlockData(0x01, make([]byte, 2))
+	gobottest.Assert(t, err, ErrNotSupported)
+	gobottest.Assert(t, c.ReadI2cBlockData(0x01, make([]byte, 2)), ErrNotSupported)
+	gobottest.Assert(t, c.SetPEC(true), ErrNotSupported)
+}
+
+func TestI2CWriteRead(t *testing.T) {
+	sysfs.SetSyscall(&sysfs.MockSyscall{
+		IoctlImpl: func(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno) {
+			if request == sysfs.I2C_FUNCS {
+				*(*uint64)(arg) = sysfs.I2C_FUNC_I2C
+			}
+			return 0
+		},
+	})
+	fs := sysfs.NewMockFilesystem([]string{
+		"/dev/i2c-1",
+	})
+	sysfs.SetFilesystem(fs)
+	i, _ := sysfs.NewI2cDevice("/dev/i2c-1")
+
+	c := NewConnection(i, 0x06)
+	gobottest.Assert(t, c.WriteRead([]byte{0x01}, make([]byte, 2)), nil)
+	gobottest.Assert(t, WriteRead(c, []byte{0x01}, make([]byte, 2)), nil)
+}
+
+func TestI2CWriteReadAddressError(t *testing.T) {
+	c := NewConnection(initI2CDeviceAddressError(), 0x06)
+	err := c.WriteRead([]byte{0x01}, make([]byte, 2))
+	gobottest.Assert(t, err, errors.New("Setting address failed with syscall.Errno operation not permitted"))
+}
+
+func TestI2CWriteReadFallback(t *testing.T) {
+	a := newI2cTestAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x12, 0x34})
+		return 2, nil
+	}
+	c := Connection(a)
+
+	r := make([]byte, 2)
+	gobottest.Assert(t, WriteRead(c, []byte{0x01}, r), nil)
+	gobottest.Assert(t, r, []byte{0x12, 0x34})
+	gobottest.Assert(t, a.written, []byte{0x01})
+
+	gobottest.Assert(t, WriteRead(c, []byte{0x01}, make([]byte, 3)), ErrNotEnoughBytes)
+}
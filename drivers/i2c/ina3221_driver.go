@@ -94,7 +94,7 @@ func (i *INA3221Driver) Start() error {
 	bus := i.GetBusOrDefault(i.connector.GetDefaultBus())
 	address := i.GetAddressOrDefault(int(ina3221Address))
 
-	if i.connection, err = i.connector.GetConnection(address, bus); err != nil {
+	if i.connection, err = claimConnection(i.connector, i, address, bus); err != nil {
 		return err
 	}
 
@@ -107,6 +107,7 @@ func (i *INA3221Driver) Start() error {
 
 // Halt halts the device.
 func (i *INA3221Driver) Halt() error {
+	releaseConnections(i.connector, i)
 	return nil
 }
 
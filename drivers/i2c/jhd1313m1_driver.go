@@ -118,11 +118,12 @@ func (h *JHD1313M1Driver) Connection() gobot.Connection {
 func (h *JHD1313M1Driver) Start() (err error) {
 	bus := h.GetBusOrDefault(h.connector.GetDefaultBus())
 
-	if h.lcdConnection, err = h.connector.GetConnection(h.lcdAddress, bus); err != nil {
+	if h.lcdConnection, err = claimConnection(h.connector, h, h.lcdAddress, bus); err != nil {
 		return err
 	}
 
-	if h.rgbConnection, err = h.connector.GetConnection(h.rgbAddress, bus); err != nil {
+	if h.rgbConnection, err = claimConnection(h.connector, h, h.rgbAddress, bus); err != nil {
+		releaseConnections(h.connector, h)
 		return err
 	}
 
@@ -236,7 +237,10 @@ func (h *JHD1313M1Driver) Scroll(leftToRight bool) error {
 }
 
 // Halt is a noop function.
-func (h *JHD1313M1Driver) Halt() error { return nil }
+func (h *JHD1313M1Driver) Halt() error {
+	releaseConnections(h.connector, h)
+	return nil
+}
 
 // SetCustomChar sets one of the 8 CGRAM locations with a custom character.
 // The custom character can be used by writing a byte of value 0 to 7.
@@ -267,3 +271,18 @@ func (h *JHD1313M1Driver) command(buf []byte) error {
 	_, err := h.lcdConnection.Write(append([]byte{LCD_CMD}, buf...))
 	return err
 }
+
+// Init implements lcd.Bus, so that the driver can be the bus of an
+// lcd.CharacterLCDDriver. The controller is set up by Start.
+func (h *JHD1313M1Driver) Init() error { return nil }
+
+// WriteCommand writes a command to the controller, it implements lcd.Bus.
+func (h *JHD1313M1Driver) WriteCommand(command byte) error {
+	return h.command([]byte{command})
+}
+
+// WriteData writes data to the controller, it implements lcd.Bus.
+func (h *JHD1313M1Driver) WriteData(data byte) error {
+	_, err := h.lcdConnection.Write([]byte{LCD_DATA, data})
+	return err
+}
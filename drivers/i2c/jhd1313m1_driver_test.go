@@ -6,10 +6,12 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/lcd"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*JHD1313M1Driver)(nil)
+var _ lcd.Bus = (*JHD1313M1Driver)(nil)
 
 // --------- HELPERS
 func initTestJHD1313M1Driver() (driver *JHD1313M1Driver) {
@@ -202,3 +204,20 @@ func TestJHD1313MDriverSetCustomCharWriteError(t *testing.T) {
 	data := [8]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
 	gobottest.Assert(t, d.SetCustomChar(0, data), errors.New("write error"))
 }
+
+func TestJHD1313MDriverBus(t *testing.T) {
+	d, a := initTestJHD1313M1DriverWithStubbedAdaptor()
+	d.Start()
+	a.written = []byte{}
+
+	gobottest.Assert(t, d.Init(), nil)
+	gobottest.Assert(t, d.WriteCommand(LCD_CLEARDISPLAY), nil)
+	gobottest.Assert(t, d.WriteData('a'), nil)
+	gobottest.Assert(t, a.written, []byte{LCD_CMD, LCD_CLEARDISPLAY, LCD_DATA, 'a'})
+
+	a.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, d.WriteCommand(LCD_CLEARDISPLAY), errors.New("write error"))
+	gobottest.Assert(t, d.WriteData('a'), errors.New("write error"))
+}
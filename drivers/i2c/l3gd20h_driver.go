@@ -3,8 +3,10 @@ package i2c
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 )
 
 const l3gd20hAddress = 0x6B
@@ -104,6 +106,7 @@ func (d *L3GD20HDriver) Start() (err error) {
 
 // Halt halts the device.
 func (d *L3GD20HDriver) Halt() (err error) {
+	releaseConnections(d.connector, d)
 	return nil
 }
 
@@ -131,11 +134,22 @@ func (d *L3GD20HDriver) XYZ() (x float32, y float32, z float32, err error) {
 	return float32(rawX) * sensitivity, float32(rawY) * sensitivity, float32(rawZ) * sensitivity, nil
 }
 
+// ReadAngularVelocity returns the angular velocity in radians per second. It
+// implements imu.Gyroscope.
+func (d *L3GD20HDriver) ReadAngularVelocity() (v imu.Vector, err error) {
+	x, y, z, err := d.XYZ()
+	if err != nil {
+		return
+	}
+	scale := math.Pi / 180
+	return imu.Vector{X: float64(x) * scale, Y: float64(y) * scale, Z: float64(z) * scale}, nil
+}
+
 func (d *L3GD20HDriver) initialization() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
 	address := d.GetAddressOrDefault(l3gd20hAddress)
 
-	d.connection, err = d.connector.GetConnection(address, bus)
+	d.connection, err = claimConnection(d.connector, d, address, bus)
 	if err != nil {
 		return err
 	}
@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"math"
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*HMC6352Driver)(nil)
 
+var _ imu.Gyroscope = (*L3GD20HDriver)(nil)
+
 // --------- HELPERS
 func initTestL3GD20HDriver() (driver *L3GD20HDriver) {
 	driver, _ = initTestL3GD20HDriverWithStubbedAdaptor()
@@ -103,6 +107,31 @@ func TestL3GD20HDriverMeasurement(t *testing.T) {
 	gobottest.Assert(t, z, float32(rawZ)*sensitivity)
 }
 
+func TestL3GD20HDriverReadAngularVelocity(t *testing.T) {
+	d, adaptor := initTestL3GD20HDriverWithStubbedAdaptor()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		buf := new(bytes.Buffer)
+		binary.Write(buf, binary.LittleEndian, int16(8000))
+		binary.Write(buf, binary.LittleEndian, int16(0))
+		binary.Write(buf, binary.LittleEndian, int16(-8000))
+		copy(b, buf.Bytes())
+		return buf.Len(), nil
+	}
+
+	d.Start()
+	v, err := d.ReadAngularVelocity()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, math.Abs(v.X-70*math.Pi/180) < 1e-6, true)
+	gobottest.Assert(t, v.Y, 0.0)
+	gobottest.Assert(t, math.Abs(v.Z+70*math.Pi/180) < 1e-6, true)
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err = d.ReadAngularVelocity()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
 func TestL3GD20HDriverMeasurementError(t *testing.T) {
 	d, adaptor := initTestL3GD20HDriverWithStubbedAdaptor()
 	adaptor.i2cReadImpl = func(b []byte) (int, error) {
@@ -0,0 +1,117 @@
+package i2c
+
+import (
+	"sync"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/lcd"
+)
+
+const lcdBackpackAddress = 0x27
+
+// pins of the PCF8574 of the backpack
+const (
+	lcdBackpackRS        = 0x01
+	lcdBackpackRW        = 0x02
+	lcdBackpackE         = 0x04
+	lcdBackpackBacklight = 0x08
+)
+
+// LCDBackpackDriver is a driver for the common i2c backpacks of HD44780
+// character LCDs, which connect a PCF8574 to the RS, RW, E and D4 to D7
+// inputs of the controller and to the backlight. It is the lcd.Bus of an
+// lcd.CharacterLCDDriver, and has to be started before it.
+type LCDBackpackDriver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	backlight byte
+	mutex     *sync.Mutex
+}
+
+// NewLCDBackpackDriver creates a new driver for the backpack of a character
+// LCD. Backpacks with a PCF8574A have their addresses from 0x38 instead of
+// 0x20.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//
+func NewLCDBackpackDriver(a Connector, options ...func(Config)) *LCDBackpackDriver {
+	d := &LCDBackpackDriver{
+		name:      gobot.DefaultName("LCDBackpack"),
+		connector: a,
+		Config:    NewConfig(),
+		backlight: lcdBackpackBacklight,
+		mutex:     &sync.Mutex{},
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// Name returns the name of the device.
+func (d *LCDBackpackDriver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *LCDBackpackDriver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the device.
+func (d *LCDBackpackDriver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start initializes the device.
+func (d *LCDBackpackDriver) Start() (err error) {
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(lcdBackpackAddress)
+
+	d.connection, err = claimConnection(d.connector, d, address, bus)
+	return
+}
+
+// Halt stops the device.
+func (d *LCDBackpackDriver) Halt() (err error) {
+	releaseConnections(d.connector, d)
+	return
+}
+
+// Init puts the controller into the 4 bit mode.
+func (d *LCDBackpackDriver) Init() error { return lcd.InitFourBit(d) }
+
+// WriteCommand writes a command to the controller.
+func (d *LCDBackpackDriver) WriteCommand(command byte) error {
+	return lcd.WriteFourBit(d, command, false)
+}
+
+// WriteData writes data to the controller.
+func (d *LCDBackpackDriver) WriteData(data byte) error { return lcd.WriteFourBit(d, data, true) }
+
+// WriteNibble writes the nibble to the D4 to D7 inputs and pulses E.
+func (d *LCDBackpackDriver) WriteNibble(nibble byte, data bool) (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	b := nibble<<4 | d.backlight
+	if data {
+		b |= lcdBackpackRS
+	}
+	if err = d.connection.WriteByte(b | lcdBackpackE); err != nil {
+		return
+	}
+	return d.connection.WriteByte(b)
+}
+
+// SetBacklight turns the backlight on or off, it is on after Start.
+func (d *LCDBackpackDriver) SetBacklight(on bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.backlight = 0
+	if on {
+		d.backlight = lcdBackpackBacklight
+	}
+	return d.connection.WriteByte(d.backlight)
+}
@@ -0,0 +1,73 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/lcd"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*LCDBackpackDriver)(nil)
+var _ lcd.Bus = (*LCDBackpackDriver)(nil)
+var _ lcd.NibbleWriter = (*LCDBackpackDriver)(nil)
+
+func initTestLCDBackpackDriverWithStubbedAdaptor() (*LCDBackpackDriver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	d := NewLCDBackpackDriver(adaptor)
+	d.Start()
+	return d, adaptor
+}
+
+func TestNewLCDBackpackDriver(t *testing.T) {
+	d := NewLCDBackpackDriver(newI2cTestAdaptor(), WithAddress(0x3F))
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "LCDBackpack"), true)
+	gobottest.Assert(t, d.GetAddressOrDefault(lcdBackpackAddress), 0x3F)
+
+	d.SetName("lcd")
+	gobottest.Assert(t, d.Name(), "lcd")
+}
+
+func TestLCDBackpackDriverStart(t *testing.T) {
+	d, _ := initTestLCDBackpackDriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Halt(), nil)
+
+	a := newI2cTestAdaptor()
+	a.Testi2cConnectErr(true)
+	d = NewLCDBackpackDriver(a)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestLCDBackpackDriverWriteNibble(t *testing.T) {
+	d, a := initTestLCDBackpackDriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.WriteNibble(0x0A, false), nil)
+	gobottest.Assert(t, d.WriteNibble(0x05, true), nil)
+	gobottest.Assert(t, a.written, []byte{0xAC, 0xA8, 0x5D, 0x59})
+
+	a.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, d.WriteNibble(0x05, true), errors.New("write error"))
+}
+
+func TestLCDBackpackDriverBus(t *testing.T) {
+	d, a := initTestLCDBackpackDriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Init(), nil)
+	gobottest.Assert(t, a.written, []byte{0x3C, 0x38, 0x3C, 0x38, 0x3C, 0x38, 0x2C, 0x28})
+
+	a.written = []byte{}
+	gobottest.Assert(t, d.WriteCommand(0x28), nil)
+	gobottest.Assert(t, d.WriteData('A'), nil)
+	gobottest.Assert(t, a.written, []byte{0x2C, 0x28, 0x8C, 0x88, 0x4D, 0x49, 0x1D, 0x19})
+}
+
+func TestLCDBackpackDriverSetBacklight(t *testing.T) {
+	d, a := initTestLCDBackpackDriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.SetBacklight(false), nil)
+	gobottest.Assert(t, d.WriteNibble(0x0F, false), nil)
+	gobottest.Assert(t, d.SetBacklight(true), nil)
+	gobottest.Assert(t, a.written, []byte{0x00, 0xF4, 0xF0, 0x08})
+}
@@ -54,7 +54,7 @@ func (h *LIDARLiteDriver) Start() (err error) {
 	bus := h.GetBusOrDefault(h.connector.GetDefaultBus())
 	address := h.GetAddressOrDefault(lidarliteAddress)
 
-	h.connection, err = h.connector.GetConnection(address, bus)
+	h.connection, err = claimConnection(h.connector, h, address, bus)
 	if err != nil {
 		return err
 	}
@@ -62,7 +62,10 @@ func (h *LIDARLiteDriver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (h *LIDARLiteDriver) Halt() (err error) { return }
+func (h *LIDARLiteDriver) Halt() (err error) {
+	releaseConnections(h.connector, h)
+	return
+}
 
 // Distance returns the current distance in cm
 func (h *LIDARLiteDriver) Distance() (distance int, err error) {
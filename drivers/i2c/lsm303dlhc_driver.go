@@ -0,0 +1,434 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+)
+
+const (
+	lsm303dlhcAccelerometerAddress = 0x19
+	lsm303dlhcMagnetometerAddress  = 0x1E
+)
+
+const (
+	lsm303dlhcRegisterCtrl1A    = 0x20
+	lsm303dlhcRegisterCtrl3A    = 0x22
+	lsm303dlhcRegisterCtrl4A    = 0x23
+	lsm303dlhcRegisterCtrl5A    = 0x24
+	lsm303dlhcRegisterOutXLA    = 0x28 | 0x80 // set auto-increment bit.
+	lsm303dlhcRegisterFIFOCtrlA = 0x2E
+	lsm303dlhcRegisterFIFOSrcA  = 0x2F
+	lsm303dlhcRegisterInt1SrcA  = 0x31
+
+	lsm303dlhcRegisterCRAM  = 0x00
+	lsm303dlhcRegisterCRBM  = 0x01
+	lsm303dlhcRegisterMRM   = 0x02
+	lsm303dlhcRegisterOutXM = 0x03
+
+	lsm303dlhcEnableXYZ      = 0x07
+	lsm303dlhcHighResolution = 0x08
+	lsm303dlhcFIFOEnable     = 0x40
+	lsm303dlhcContinuous     = 0x00
+)
+
+// LSM303DLHCRange is the measurement range of the accelerometer of the
+// LSM303DLHC.
+type LSM303DLHCRange byte
+
+const (
+	// LSM303DLHCRange2g is the +-2g range.
+	LSM303DLHCRange2g LSM303DLHCRange = 0x00
+	// LSM303DLHCRange4g is the +-4g range.
+	LSM303DLHCRange4g LSM303DLHCRange = 0x10
+	// LSM303DLHCRange8g is the +-8g range.
+	LSM303DLHCRange8g LSM303DLHCRange = 0x20
+	// LSM303DLHCRange16g is the +-16g range.
+	LSM303DLHCRange16g LSM303DLHCRange = 0x30
+)
+
+// lsm303dlhcGPerLSB are the g per LSB of the ranges in high resolution
+var lsm303dlhcGPerLSB = map[LSM303DLHCRange]float64{
+	LSM303DLHCRange2g:  0.001,
+	LSM303DLHCRange4g:  0.002,
+	LSM303DLHCRange8g:  0.004,
+	LSM303DLHCRange16g: 0.012,
+}
+
+// LSM303DLHCAccelerometerRate is the output data rate of the accelerometer
+// of the LSM303DLHC.
+type LSM303DLHCAccelerometerRate byte
+
+const (
+	// LSM303DLHCAccelerometerRate1Hz is the 1Hz data rate.
+	LSM303DLHCAccelerometerRate1Hz LSM303DLHCAccelerometerRate = 0x10
+	// LSM303DLHCAccelerometerRate10Hz is the 10Hz data rate.
+	LSM303DLHCAccelerometerRate10Hz LSM303DLHCAccelerometerRate = 0x20
+	// LSM303DLHCAccelerometerRate25Hz is the 25Hz data rate.
+	LSM303DLHCAccelerometerRate25Hz LSM303DLHCAccelerometerRate = 0x30
+	// LSM303DLHCAccelerometerRate50Hz is the 50Hz data rate.
+	LSM303DLHCAccelerometerRate50Hz LSM303DLHCAccelerometerRate = 0x40
+	// LSM303DLHCAccelerometerRate100Hz is the 100Hz data rate.
+	LSM303DLHCAccelerometerRate100Hz LSM303DLHCAccelerometerRate = 0x50
+	// LSM303DLHCAccelerometerRate200Hz is the 200Hz data rate.
+	LSM303DLHCAccelerometerRate200Hz LSM303DLHCAccelerometerRate = 0x60
+	// LSM303DLHCAccelerometerRate400Hz is the 400Hz data rate.
+	LSM303DLHCAccelerometerRate400Hz LSM303DLHCAccelerometerRate = 0x70
+	// LSM303DLHCAccelerometerRate1344Hz is the 1344Hz data rate.
+	LSM303DLHCAccelerometerRate1344Hz LSM303DLHCAccelerometerRate = 0x90
+)
+
+// LSM303DLHCGain is the gain of the magnetometer of the LSM303DLHC, which
+// sets its range.
+type LSM303DLHCGain byte
+
+const (
+	// LSM303DLHCGain1_3 is the +-1.3 Gauss range.
+	LSM303DLHCGain1_3 LSM303DLHCGain = 0x20
+	// LSM303DLHCGain1_9 is the +-1.9 Gauss range.
+	LSM303DLHCGain1_9 LSM303DLHCGain = 0x40
+	// LSM303DLHCGain2_5 is the +-2.5 Gauss range.
+	LSM303DLHCGain2_5 LSM303DLHCGain = 0x60
+	// LSM303DLHCGain4_0 is the +-4.0 Gauss range.
+	LSM303DLHCGain4_0 LSM303DLHCGain = 0x80
+	// LSM303DLHCGain4_7 is the +-4.7 Gauss range.
+	LSM303DLHCGain4_7 LSM303DLHCGain = 0xA0
+	// LSM303DLHCGain5_6 is the +-5.6 Gauss range.
+	LSM303DLHCGain5_6 LSM303DLHCGain = 0xC0
+	// LSM303DLHCGain8_1 is the +-8.1 Gauss range.
+	LSM303DLHCGain8_1 LSM303DLHCGain = 0xE0
+)
+
+// lsm303dlhcLSBPerGauss are the LSB per Gauss of the gains, of the x and y
+// axis and of the z axis
+var lsm303dlhcLSBPerGauss = map[LSM303DLHCGain][2]float64{
+	LSM303DLHCGain1_3: {1100, 980},
+	LSM303DLHCGain1_9: {855, 760},
+	LSM303DLHCGain2_5: {670, 600},
+	LSM303DLHCGain4_0: {450, 400},
+	LSM303DLHCGain4_7: {400, 355},
+	LSM303DLHCGain5_6: {330, 295},
+	LSM303DLHCGain8_1: {230, 205},
+}
+
+// LSM303DLHCMagnetometerRate is the output data rate of the magnetometer of
+// the LSM303DLHC.
+type LSM303DLHCMagnetometerRate byte
+
+const (
+	// LSM303DLHCMagnetometerRate0_75Hz is the 0.75Hz data rate.
+	LSM303DLHCMagnetometerRate0_75Hz LSM303DLHCMagnetometerRate = 0x00
+	// LSM303DLHCMagnetometerRate1_5Hz is the 1.5Hz data rate.
+	LSM303DLHCMagnetometerRate1_5Hz LSM303DLHCMagnetometerRate = 0x04
+	// LSM303DLHCMagnetometerRate3Hz is the 3Hz data rate.
+	LSM303DLHCMagnetometerRate3Hz LSM303DLHCMagnetometerRate = 0x08
+	// LSM303DLHCMagnetometerRate7_5Hz is the 7.5Hz data rate.
+	LSM303DLHCMagnetometerRate7_5Hz LSM303DLHCMagnetometerRate = 0x0C
+	// LSM303DLHCMagnetometerRate15Hz is the 15Hz data rate.
+	LSM303DLHCMagnetometerRate15Hz LSM303DLHCMagnetometerRate = 0x10
+	// LSM303DLHCMagnetometerRate30Hz is the 30Hz data rate.
+	LSM303DLHCMagnetometerRate30Hz LSM303DLHCMagnetometerRate = 0x14
+	// LSM303DLHCMagnetometerRate75Hz is the 75Hz data rate.
+	LSM303DLHCMagnetometerRate75Hz LSM303DLHCMagnetometerRate = 0x18
+	// LSM303DLHCMagnetometerRate220Hz is the 220Hz data rate.
+	LSM303DLHCMagnetometerRate220Hz LSM303DLHCMagnetometerRate = 0x1C
+)
+
+// LSM303DLHCFIFOMode is the mode of the FIFO of the accelerometer of the
+// LSM303DLHC.
+type LSM303DLHCFIFOMode byte
+
+const (
+	// LSM303DLHCFIFOBypass bypasses the FIFO.
+	LSM303DLHCFIFOBypass LSM303DLHCFIFOMode = 0x00
+	// LSM303DLHCFIFOFill collects samples until the FIFO is full.
+	LSM303DLHCFIFOFill LSM303DLHCFIFOMode = 0x40
+	// LSM303DLHCFIFOStream keeps the latest samples, discarding the oldest.
+	LSM303DLHCFIFOStream LSM303DLHCFIFOMode = 0x80
+	// LSM303DLHCFIFOTrigger keeps the latest samples until the trigger
+	// interrupt, then collects samples until the FIFO is full.
+	LSM303DLHCFIFOTrigger LSM303DLHCFIFOMode = 0xC0
+)
+
+// LSM303DLHC interrupts signaled on the INT1 pin, which are or'ed together.
+const (
+	LSM303DLHCInterruptOverrun    = 0x02
+	LSM303DLHCInterruptWatermark  = 0x04
+	LSM303DLHCInterruptDataReady2 = 0x08
+	LSM303DLHCInterruptDataReady1 = 0x10
+	LSM303DLHCInterruptAOI2       = 0x20
+	LSM303DLHCInterruptAOI1       = 0x40
+	LSM303DLHCInterruptClick      = 0x80
+)
+
+// LSM303DLHCDriver is a driver for the LSM303DLHC 3-axis accelerometer and
+// 3-axis magnetometer, whose accelerometer is read at high resolution. The
+// address set by WithAddress is the one of the accelerometer, the
+// magnetometer is always at 0x1E.
+// Device datasheet: https://www.st.com/resource/en/datasheet/lsm303dlhc.pdf
+type LSM303DLHCDriver struct {
+	name          string
+	connector     Connector
+	connection    Connection
+	magConnection Connection
+	Config
+	accelRange    LSM303DLHCRange
+	accelRate     LSM303DLHCAccelerometerRate
+	gain          LSM303DLHCGain
+	magRate       LSM303DLHCMagnetometerRate
+	fifoMode      LSM303DLHCFIFOMode
+	fifoThreshold uint8
+	interrupts    byte
+}
+
+// NewLSM303DLHCDriver creates a new driver for the LSM303DLHC.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address of the accelerometer to use with this driver
+//		i2c.WithLSM303DLHCRange(LSM303DLHCRange):	measurement range of the accelerometer, +-2g by default
+//		i2c.WithLSM303DLHCAccelerometerRate(LSM303DLHCAccelerometerRate):	output data rate of the accelerometer, 100Hz by default
+//		i2c.WithLSM303DLHCGain(LSM303DLHCGain):	gain of the magnetometer, +-1.3 Gauss by default
+//		i2c.WithLSM303DLHCMagnetometerRate(LSM303DLHCMagnetometerRate):	output data rate of the magnetometer, 15Hz by default
+//		i2c.WithLSM303DLHCFIFO(LSM303DLHCFIFOMode, uint8):	FIFO mode and number of samples of the watermark
+//		i2c.WithLSM303DLHCInterrupts(byte):	interrupts which are signaled on INT1
+//
+func NewLSM303DLHCDriver(a Connector, options ...func(Config)) *LSM303DLHCDriver {
+	d := &LSM303DLHCDriver{
+		name:       gobot.DefaultName("LSM303DLHC"),
+		connector:  a,
+		Config:     NewConfig(),
+		accelRange: LSM303DLHCRange2g,
+		accelRate:  LSM303DLHCAccelerometerRate100Hz,
+		gain:       LSM303DLHCGain1_3,
+		magRate:    LSM303DLHCMagnetometerRate15Hz,
+		fifoMode:   LSM303DLHCFIFOBypass,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithLSM303DLHCRange option sets the measurement range of the
+// accelerometer of the LSM303DLHCDriver.
+func WithLSM303DLHCRange(val LSM303DLHCRange) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*LSM303DLHCDriver); ok {
+			d.accelRange = val
+		}
+	}
+}
+
+// WithLSM303DLHCAccelerometerRate option sets the output data rate of the
+// accelerometer of the LSM303DLHCDriver.
+func WithLSM303DLHCAccelerometerRate(val LSM303DLHCAccelerometerRate) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*LSM303DLHCDriver); ok {
+			d.accelRate = val
+		}
+	}
+}
+
+// WithLSM303DLHCGain option sets the gain of the magnetometer of the
+// LSM303DLHCDriver.
+func WithLSM303DLHCGain(val LSM303DLHCGain) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*LSM303DLHCDriver); ok {
+			d.gain = val
+		}
+	}
+}
+
+// WithLSM303DLHCMagnetometerRate option sets the output data rate of the
+// magnetometer of the LSM303DLHCDriver.
+func WithLSM303DLHCMagnetometerRate(val LSM303DLHCMagnetometerRate) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*LSM303DLHCDriver); ok {
+			d.magRate = val
+		}
+	}
+}
+
+// WithLSM303DLHCFIFO option sets the mode of the FIFO of the accelerometer
+// of the LSM303DLHCDriver and the number of samples in it, up to 31, at
+// which the watermark interrupt is triggered.
+func WithLSM303DLHCFIFO(mode LSM303DLHCFIFOMode, threshold uint8) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*LSM303DLHCDriver); ok {
+			d.fifoMode = mode
+			d.fifoThreshold = threshold
+		}
+	}
+}
+
+// WithLSM303DLHCInterrupts option sets the LSM303DLHCInterrupt* interrupts
+// of the LSM303DLHCDriver which are signaled on the INT1 pin.
+func WithLSM303DLHCInterrupts(int1 byte) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*LSM303DLHCDriver); ok {
+			d.interrupts = int1
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *LSM303DLHCDriver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *LSM303DLHCDriver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the device.
+func (d *LSM303DLHCDriver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start configures the accelerometer and the magnetometer of the
+// LSM303DLHC and starts measuring continuously.
+func (d *LSM303DLHCDriver) Start() (err error) {
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(lsm303dlhcAccelerometerAddress)
+
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
+		return
+	}
+	if d.magConnection, err = claimConnection(d.connector, d, lsm303dlhcMagnetometerAddress, bus); err != nil {
+		return
+	}
+
+	var fifo byte
+	if d.fifoMode != LSM303DLHCFIFOBypass {
+		fifo = lsm303dlhcFIFOEnable
+	}
+	for _, reg := range [][2]byte{
+		{lsm303dlhcRegisterCtrl1A, byte(d.accelRate) | lsm303dlhcEnableXYZ},
+		{lsm303dlhcRegisterCtrl3A, d.interrupts},
+		{lsm303dlhcRegisterCtrl4A, byte(d.accelRange) | lsm303dlhcHighResolution},
+		{lsm303dlhcRegisterCtrl5A, fifo},
+		{lsm303dlhcRegisterFIFOCtrlA, byte(d.fifoMode) | d.fifoThreshold&0x1f},
+	} {
+		if err = d.connection.WriteByteData(reg[0], reg[1]); err != nil {
+			return
+		}
+	}
+	for _, reg := range [][2]byte{
+		{lsm303dlhcRegisterCRAM, byte(d.magRate)},
+		{lsm303dlhcRegisterCRBM, byte(d.gain)},
+		{lsm303dlhcRegisterMRM, lsm303dlhcContinuous},
+	} {
+		if err = d.magConnection.WriteByteData(reg[0], reg[1]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Halt halts the device.
+func (d *LSM303DLHCDriver) Halt() (err error) {
+	releaseConnections(d.connector, d)
+	return
+}
+
+// AccelerometerXYZ returns the acceleration of the 3 axis in g.
+func (d *LSM303DLHCDriver) AccelerometerXYZ() (x float64, y float64, z float64, err error) {
+	data, err := d.read(d.connection, lsm303dlhcRegisterOutXLA)
+	if err != nil {
+		return
+	}
+	var rx, ry, rz int16
+	buf := bytes.NewBuffer(data)
+	binary.Read(buf, binary.LittleEndian, &rx)
+	binary.Read(buf, binary.LittleEndian, &ry)
+	binary.Read(buf, binary.LittleEndian, &rz)
+
+	// the 12 bit values are left-justified
+	scale := lsm303dlhcGPerLSB[d.accelRange]
+	return float64(rx>>4) * scale, float64(ry>>4) * scale, float64(rz>>4) * scale, nil
+}
+
+// ReadAcceleration returns the acceleration in meters per second squared. It
+// implements imu.Accelerometer.
+func (d *LSM303DLHCDriver) ReadAcceleration() (v imu.Vector, err error) {
+	x, y, z, err := d.AccelerometerXYZ()
+	if err != nil {
+		return
+	}
+	return imu.Vector{X: x, Y: y, Z: z}.Scale(imu.StandardGravity), nil
+}
+
+// MagnetometerXYZ returns the magnetic field of the 3 axis in Gauss.
+func (d *LSM303DLHCDriver) MagnetometerXYZ() (x float64, y float64, z float64, err error) {
+	data, err := d.read(d.magConnection, lsm303dlhcRegisterOutXM)
+	if err != nil {
+		return
+	}
+	// the axis are in the order X, Z, Y
+	var rx, ry, rz int16
+	buf := bytes.NewBuffer(data)
+	binary.Read(buf, binary.BigEndian, &rx)
+	binary.Read(buf, binary.BigEndian, &rz)
+	binary.Read(buf, binary.BigEndian, &ry)
+
+	lsb := lsm303dlhcLSBPerGauss[d.gain]
+	return float64(rx) / lsb[0], float64(ry) / lsb[0], float64(rz) / lsb[1], nil
+}
+
+// ReadMagneticField returns the magnetic field in tesla. It implements
+// imu.Magnetometer.
+func (d *LSM303DLHCDriver) ReadMagneticField() (v imu.Vector, err error) {
+	x, y, z, err := d.MagnetometerXYZ()
+	if err != nil {
+		return
+	}
+	return imu.Vector{X: x, Y: y, Z: z}.Scale(1e-4), nil
+}
+
+// FIFOEntries returns the number of samples in the FIFO of the
+// accelerometer.
+func (d *LSM303DLHCDriver) FIFOEntries() (entries int, err error) {
+	src, err := d.connection.ReadByteData(lsm303dlhcRegisterFIFOSrcA)
+	return int(src & 0x1f), err
+}
+
+// ReadFIFO reads all samples in the FIFO of the accelerometer and returns
+// their accelerations in meters per second squared, the oldest first.
+func (d *LSM303DLHCDriver) ReadFIFO() (samples []imu.Vector, err error) {
+	entries, err := d.FIFOEntries()
+	if err != nil {
+		return
+	}
+	for i := 0; i < entries; i++ {
+		v, err := d.ReadAcceleration()
+		if err != nil {
+			return samples, err
+		}
+		samples = append(samples, v)
+	}
+	return
+}
+
+// InterruptSource returns the source of the interrupt generator 1 of the
+// accelerometer, and clears it if it is latched.
+func (d *LSM303DLHCDriver) InterruptSource() (source byte, err error) {
+	return d.connection.ReadByteData(lsm303dlhcRegisterInt1SrcA)
+}
+
+// read reads the 6 bytes of the 3 axis starting at reg
+func (d *LSM303DLHCDriver) read(c Connection, reg byte) (data []byte, err error) {
+	if _, err = c.Write([]byte{reg}); err != nil {
+		return
+	}
+	data = make([]byte, 6)
+	n, err := c.Read(data)
+	if err != nil {
+		return
+	}
+	if n != len(data) {
+		err = ErrNotEnoughBytes
+	}
+	return
+}
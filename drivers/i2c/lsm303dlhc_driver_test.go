@@ -0,0 +1,229 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*LSM303DLHCDriver)(nil)
+
+var _ imu.Accelerometer = (*LSM303DLHCDriver)(nil)
+var _ imu.Magnetometer = (*LSM303DLHCDriver)(nil)
+
+// --------- HELPERS
+func initTestLSM303DLHCDriver() (driver *LSM303DLHCDriver) {
+	driver, _ = initTestLSM303DLHCDriverWithStubbedAdaptor()
+	return
+}
+
+func initTestLSM303DLHCDriverWithStubbedAdaptor() (*LSM303DLHCDriver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	return NewLSM303DLHCDriver(adaptor), adaptor
+}
+
+// --------- TESTS
+
+func TestNewLSM303DLHCDriver(t *testing.T) {
+	var d interface{} = NewLSM303DLHCDriver(newI2cTestAdaptor())
+	_, ok := d.(*LSM303DLHCDriver)
+	if !ok {
+		t.Errorf("NewLSM303DLHCDriver() should have returned a *LSM303DLHCDriver")
+	}
+}
+
+func TestLSM303DLHCDriver(t *testing.T) {
+	d := initTestLSM303DLHCDriver()
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "LSM303DLHC"), true)
+	d.SetName("mybot")
+	gobottest.Assert(t, d.Name(), "mybot")
+}
+
+func TestLSM303DLHCDriverOptions(t *testing.T) {
+	d := NewLSM303DLHCDriver(newI2cTestAdaptor(), WithBus(2),
+		WithLSM303DLHCRange(LSM303DLHCRange8g),
+		WithLSM303DLHCAccelerometerRate(LSM303DLHCAccelerometerRate400Hz),
+		WithLSM303DLHCGain(LSM303DLHCGain4_0),
+		WithLSM303DLHCMagnetometerRate(LSM303DLHCMagnetometerRate75Hz),
+		WithLSM303DLHCFIFO(LSM303DLHCFIFOStream, 10),
+		WithLSM303DLHCInterrupts(LSM303DLHCInterruptWatermark))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.accelRange, LSM303DLHCRange8g)
+	gobottest.Assert(t, d.accelRate, LSM303DLHCAccelerometerRate400Hz)
+	gobottest.Assert(t, d.gain, LSM303DLHCGain4_0)
+	gobottest.Assert(t, d.magRate, LSM303DLHCMagnetometerRate75Hz)
+	gobottest.Assert(t, d.fifoMode, LSM303DLHCFIFOStream)
+	gobottest.Assert(t, d.fifoThreshold, uint8(10))
+	gobottest.Assert(t, d.interrupts, byte(0x04))
+}
+
+func TestLSM303DLHCDriverStart(t *testing.T) {
+	d, adaptor := initTestLSM303DLHCDriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		0x20, 0x57,
+		0x22, 0x00,
+		0x23, 0x08,
+		0x24, 0x00,
+		0x2E, 0x00,
+		0x00, 0x10,
+		0x01, 0x20,
+		0x02, 0x00,
+	})
+
+	adaptor = newI2cTestAdaptor()
+	d = NewLSM303DLHCDriver(adaptor,
+		WithLSM303DLHCRange(LSM303DLHCRange16g),
+		WithLSM303DLHCAccelerometerRate(LSM303DLHCAccelerometerRate1344Hz),
+		WithLSM303DLHCGain(LSM303DLHCGain8_1),
+		WithLSM303DLHCMagnetometerRate(LSM303DLHCMagnetometerRate220Hz),
+		WithLSM303DLHCFIFO(LSM303DLHCFIFOFill, 16),
+		WithLSM303DLHCInterrupts(LSM303DLHCInterruptWatermark|LSM303DLHCInterruptOverrun))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		0x20, 0x97,
+		0x22, 0x06,
+		0x23, 0x38,
+		0x24, 0x40,
+		0x2E, 0x50,
+		0x00, 0x1C,
+		0x01, 0xE0,
+		0x02, 0x00,
+	})
+}
+
+func TestLSM303DLHCDriverStartConnectError(t *testing.T) {
+	d, adaptor := initTestLSM303DLHCDriverWithStubbedAdaptor()
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestLSM303DLHCDriverStartWriteError(t *testing.T) {
+	d, adaptor := initTestLSM303DLHCDriverWithStubbedAdaptor()
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, d.Start(), errors.New("write error"))
+}
+
+func TestLSM303DLHCDriverHalt(t *testing.T) {
+	d := initTestLSM303DLHCDriver()
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestLSM303DLHCDriverAccelerometerXYZ(t *testing.T) {
+	d, adaptor := initTestLSM303DLHCDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		buf := new(bytes.Buffer)
+		binary.Write(buf, binary.LittleEndian, int16(1000<<4))
+		binary.Write(buf, binary.LittleEndian, int16(-500<<4))
+		binary.Write(buf, binary.LittleEndian, int16(0))
+		copy(b, buf.Bytes())
+		return buf.Len(), nil
+	}
+
+	x, y, z, err := d.AccelerometerXYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, []float64{x, y, z}, []float64{1, -0.5, 0})
+
+	v, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, imu.Vector{X: imu.StandardGravity, Y: -0.5 * imu.StandardGravity, Z: 0})
+}
+
+func TestLSM303DLHCDriverMagnetometerXYZ(t *testing.T) {
+	d, adaptor := initTestLSM303DLHCDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		buf := new(bytes.Buffer)
+		binary.Write(buf, binary.BigEndian, int16(550))
+		binary.Write(buf, binary.BigEndian, int16(-980))
+		binary.Write(buf, binary.BigEndian, int16(1100))
+		copy(b, buf.Bytes())
+		return buf.Len(), nil
+	}
+
+	x, y, z, err := d.MagnetometerXYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, []float64{x, y, z}, []float64{0.5, 1, -1})
+
+	v, err := d.ReadMagneticField()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, imu.Vector{X: 0.5e-4, Y: 1e-4, Z: -1e-4})
+}
+
+func TestLSM303DLHCDriverXYZError(t *testing.T) {
+	d, adaptor := initTestLSM303DLHCDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, errors.New("read error"))
+	_, err = d.ReadMagneticField()
+	gobottest.Assert(t, err, errors.New("read error"))
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 3, nil
+	}
+	_, _, _, err = d.AccelerometerXYZ()
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	_, _, _, err = d.MagnetometerXYZ()
+	gobottest.Assert(t, err, errors.New("write error"))
+}
+
+func TestLSM303DLHCDriverReadFIFO(t *testing.T) {
+	d, adaptor := initTestLSM303DLHCDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		if len(b) == 1 {
+			b[0] = 0x83
+			return 1, nil
+		}
+		copy(b, []byte{0x00, 0x00, 0x00, 0x00, 0x80, 0x3e})
+		return 6, nil
+	}
+
+	entries, err := d.FIFOEntries()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, entries, 3)
+
+	samples, err := d.ReadFIFO()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(samples), 3)
+	gobottest.Assert(t, samples[2], imu.Vector{X: 0, Y: 0, Z: imu.StandardGravity})
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		if len(b) == 1 {
+			b[0] = 0x01
+			return 1, nil
+		}
+		return 0, errors.New("read error")
+	}
+	_, err = d.ReadFIFO()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestLSM303DLHCDriverInterruptSource(t *testing.T) {
+	d, adaptor := initTestLSM303DLHCDriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x42
+		return 1, nil
+	}
+	source, err := d.InterruptSource()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, source, byte(0x42))
+}
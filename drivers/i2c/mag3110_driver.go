@@ -0,0 +1,214 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+)
+
+const mag3110Address = 0x0E
+
+const (
+	mag3110RegisterStatus  = 0x00
+	mag3110RegisterOutX    = 0x01
+	mag3110RegisterDieTemp = 0x0F
+	mag3110RegisterCtrl1   = 0x10
+	mag3110RegisterCtrl2   = 0x11
+
+	mag3110Active        = 0x01
+	mag3110AutoReset     = 0x80
+	mag3110Raw           = 0x20
+	mag3110DataReady     = 0x08
+	mag3110TeslaPerCount = 1e-7
+)
+
+// MAG3110Rate is the output data rate of the MAG3110 at 16 times
+// oversampling, which is halved by each doubling of the oversampling.
+type MAG3110Rate byte
+
+const (
+	// MAG3110Rate80Hz is the 80Hz data rate.
+	MAG3110Rate80Hz MAG3110Rate = 0x00
+	// MAG3110Rate40Hz is the 40Hz data rate.
+	MAG3110Rate40Hz MAG3110Rate = 0x20
+	// MAG3110Rate20Hz is the 20Hz data rate.
+	MAG3110Rate20Hz MAG3110Rate = 0x40
+	// MAG3110Rate10Hz is the 10Hz data rate.
+	MAG3110Rate10Hz MAG3110Rate = 0x60
+	// MAG3110Rate5Hz is the 5Hz data rate.
+	MAG3110Rate5Hz MAG3110Rate = 0x80
+	// MAG3110Rate2_5Hz is the 2.5Hz data rate.
+	MAG3110Rate2_5Hz MAG3110Rate = 0xA0
+	// MAG3110Rate1_25Hz is the 1.25Hz data rate.
+	MAG3110Rate1_25Hz MAG3110Rate = 0xC0
+	// MAG3110Rate0_63Hz is the 0.63Hz data rate.
+	MAG3110Rate0_63Hz MAG3110Rate = 0xE0
+)
+
+// MAG3110Oversampling is the number of samples the MAG3110 takes per output.
+type MAG3110Oversampling byte
+
+const (
+	// MAG3110Oversampling16 takes 16 samples per output.
+	MAG3110Oversampling16 MAG3110Oversampling = 0x00
+	// MAG3110Oversampling32 takes 32 samples per output.
+	MAG3110Oversampling32 MAG3110Oversampling = 0x08
+	// MAG3110Oversampling64 takes 64 samples per output.
+	MAG3110Oversampling64 MAG3110Oversampling = 0x10
+	// MAG3110Oversampling128 takes 128 samples per output.
+	MAG3110Oversampling128 MAG3110Oversampling = 0x18
+)
+
+// MAG3110Driver is a driver for the MAG3110 3-axis digital magnetometer,
+// whose range is +-1000 microtesla. Its INT1 pin signals new data.
+// Device datasheet: https://www.nxp.com/docs/en/data-sheet/MAG3110.pdf
+type MAG3110Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	rate         MAG3110Rate
+	oversampling MAG3110Oversampling
+	raw          bool
+}
+
+// NewMAG3110Driver creates a new driver for the MAG3110.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithMAG3110Rate(MAG3110Rate):	output data rate, 10Hz by default
+//		i2c.WithMAG3110Oversampling(MAG3110Oversampling):	samples per output, 16 by default
+//		i2c.WithMAG3110Raw(bool):	output without the user offsets subtracted
+//
+func NewMAG3110Driver(a Connector, options ...func(Config)) *MAG3110Driver {
+	d := &MAG3110Driver{
+		name:         gobot.DefaultName("MAG3110"),
+		connector:    a,
+		Config:       NewConfig(),
+		rate:         MAG3110Rate10Hz,
+		oversampling: MAG3110Oversampling16,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithMAG3110Rate option sets the output data rate of the MAG3110Driver.
+func WithMAG3110Rate(val MAG3110Rate) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*MAG3110Driver); ok {
+			d.rate = val
+		}
+	}
+}
+
+// WithMAG3110Oversampling option sets the number of samples the
+// MAG3110Driver takes per output.
+func WithMAG3110Oversampling(val MAG3110Oversampling) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*MAG3110Driver); ok {
+			d.oversampling = val
+		}
+	}
+}
+
+// WithMAG3110Raw option sets whether the MAG3110Driver outputs the magnetic
+// field without the user offsets subtracted.
+func WithMAG3110Raw(val bool) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*MAG3110Driver); ok {
+			d.raw = val
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *MAG3110Driver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *MAG3110Driver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the device.
+func (d *MAG3110Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start configures the MAG3110 and starts measuring continuously.
+func (d *MAG3110Driver) Start() (err error) {
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(mag3110Address)
+
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
+		return
+	}
+
+	// the configuration is written in standby
+	if err = d.connection.WriteByteData(mag3110RegisterCtrl1, 0); err != nil {
+		return
+	}
+	var ctrl2 byte = mag3110AutoReset
+	if d.raw {
+		ctrl2 |= mag3110Raw
+	}
+	if err = d.connection.WriteByteData(mag3110RegisterCtrl2, ctrl2); err != nil {
+		return
+	}
+	return d.connection.WriteByteData(mag3110RegisterCtrl1, byte(d.rate)|byte(d.oversampling)|mag3110Active)
+}
+
+// Halt halts the device.
+func (d *MAG3110Driver) Halt() (err error) {
+	releaseConnections(d.connector, d)
+	return
+}
+
+// RawXYZ returns the raw magnetic field of the 3 axis, in 0.1 microtesla.
+func (d *MAG3110Driver) RawXYZ() (x int16, y int16, z int16, err error) {
+	if _, err = d.connection.Write([]byte{mag3110RegisterOutX}); err != nil {
+		return
+	}
+	data := make([]byte, 6)
+	n, err := d.connection.Read(data)
+	if err != nil {
+		return
+	}
+	if n != len(data) {
+		err = ErrNotEnoughBytes
+		return
+	}
+
+	buf := bytes.NewBuffer(data)
+	binary.Read(buf, binary.BigEndian, &x)
+	binary.Read(buf, binary.BigEndian, &y)
+	binary.Read(buf, binary.BigEndian, &z)
+	return
+}
+
+// ReadMagneticField returns the magnetic field in tesla. It implements
+// imu.Magnetometer.
+func (d *MAG3110Driver) ReadMagneticField() (v imu.Vector, err error) {
+	x, y, z, err := d.RawXYZ()
+	if err != nil {
+		return
+	}
+	return imu.Vector{X: float64(x), Y: float64(y), Z: float64(z)}.Scale(mag3110TeslaPerCount), nil
+}
+
+// Ready returns true when new data of all 3 axis is available.
+func (d *MAG3110Driver) Ready() (ready bool, err error) {
+	status, err := d.connection.ReadByteData(mag3110RegisterStatus)
+	return status&mag3110DataReady != 0, err
+}
+
+// Temperature returns the temperature of the die in degrees Celsius, which
+// is not calibrated.
+func (d *MAG3110Driver) Temperature() (temp int8, err error) {
+	val, err := d.connection.ReadByteData(mag3110RegisterDieTemp)
+	return int8(val), err
+}
@@ -0,0 +1,152 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*MAG3110Driver)(nil)
+
+var _ imu.Magnetometer = (*MAG3110Driver)(nil)
+
+// --------- HELPERS
+func initTestMAG3110Driver() (driver *MAG3110Driver) {
+	driver, _ = initTestMAG3110DriverWithStubbedAdaptor()
+	return
+}
+
+func initTestMAG3110DriverWithStubbedAdaptor() (*MAG3110Driver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	return NewMAG3110Driver(adaptor), adaptor
+}
+
+// --------- TESTS
+
+func TestNewMAG3110Driver(t *testing.T) {
+	var d interface{} = NewMAG3110Driver(newI2cTestAdaptor())
+	_, ok := d.(*MAG3110Driver)
+	if !ok {
+		t.Errorf("NewMAG3110Driver() should have returned a *MAG3110Driver")
+	}
+}
+
+func TestMAG3110Driver(t *testing.T) {
+	d := initTestMAG3110Driver()
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MAG3110"), true)
+	d.SetName("mybot")
+	gobottest.Assert(t, d.Name(), "mybot")
+}
+
+func TestMAG3110DriverOptions(t *testing.T) {
+	d := NewMAG3110Driver(newI2cTestAdaptor(), WithBus(2),
+		WithMAG3110Rate(MAG3110Rate80Hz),
+		WithMAG3110Oversampling(MAG3110Oversampling64),
+		WithMAG3110Raw(true))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.rate, MAG3110Rate80Hz)
+	gobottest.Assert(t, d.oversampling, MAG3110Oversampling64)
+	gobottest.Assert(t, d.raw, true)
+}
+
+func TestMAG3110DriverStart(t *testing.T) {
+	d, adaptor := initTestMAG3110DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x10, 0x00, 0x11, 0x80, 0x10, 0x61})
+
+	adaptor = newI2cTestAdaptor()
+	d = NewMAG3110Driver(adaptor,
+		WithMAG3110Rate(MAG3110Rate0_63Hz),
+		WithMAG3110Oversampling(MAG3110Oversampling128),
+		WithMAG3110Raw(true))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x10, 0x00, 0x11, 0xA0, 0x10, 0xF9})
+}
+
+func TestMAG3110DriverStartConnectError(t *testing.T) {
+	d, adaptor := initTestMAG3110DriverWithStubbedAdaptor()
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestMAG3110DriverStartWriteError(t *testing.T) {
+	d, adaptor := initTestMAG3110DriverWithStubbedAdaptor()
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, d.Start(), errors.New("write error"))
+}
+
+func TestMAG3110DriverHalt(t *testing.T) {
+	d := initTestMAG3110Driver()
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestMAG3110DriverXYZ(t *testing.T) {
+	d, adaptor := initTestMAG3110DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x01, 0xf4, 0xfe, 0x0c, 0x00, 0x00})
+		return 6, nil
+	}
+
+	x, y, z, err := d.RawXYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, []int16{x, y, z}, []int16{500, -500, 0})
+
+	scale := mag3110TeslaPerCount
+	v, err := d.ReadMagneticField()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, imu.Vector{X: 500 * scale, Y: -500 * scale, Z: 0})
+}
+
+func TestMAG3110DriverXYZError(t *testing.T) {
+	d, adaptor := initTestMAG3110DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err := d.ReadMagneticField()
+	gobottest.Assert(t, err, errors.New("read error"))
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 5, nil
+	}
+	_, _, _, err = d.RawXYZ()
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	_, _, _, err = d.RawXYZ()
+	gobottest.Assert(t, err, errors.New("write error"))
+}
+
+func TestMAG3110DriverReady(t *testing.T) {
+	d, adaptor := initTestMAG3110DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x0f
+		return 1, nil
+	}
+	ready, err := d.Ready()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, ready, true)
+}
+
+func TestMAG3110DriverTemperature(t *testing.T) {
+	d, adaptor := initTestMAG3110DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0xfb
+		return 1, nil
+	}
+	temp, err := d.Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temp, int8(-5))
+}
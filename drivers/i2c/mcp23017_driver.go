@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 )
 
 const mcp23017Address = 0x20
@@ -63,12 +65,20 @@ type MCP23017Config struct {
 }
 
 // MCP23017Driver contains the driver configuration parameters.
+//
+// It implements gpio.DigitalReader and gpio.DigitalWriter for the pins "A0"
+// to "B7", so that it can be the connection of gpio drivers.
 type MCP23017Driver struct {
 	name       string
 	connector  Connector
 	connection Connection
 	Config
-	MCPConf MCP23017Config
+	MCPConf   MCP23017Config
+	mutex     *sync.Mutex
+	inputs    uint16 // pins set as input by DigitalRead
+	outputs   uint16 // pins set as output by DigitalWrite
+	levels    uint16 // levels of the pins at the last read
+	interrupt expanderInterrupt
 	gobot.Commander
 	gobot.Eventer
 }
@@ -87,6 +97,8 @@ type MCP23017Driver struct {
 //		i2c.WithMCP23017Haen(int):	MCP23017 haen to use with this driver
 //		i2c.WithMCP23017Odr(int):	MCP23017 odr to use with this driver
 //		i2c.WithMCP23017Intpol(int):	MCP23017 intpol to use with this driver
+//		i2c.WithInterruptPin(gpio.DigitalReader, string):	pin the INTA output is connected to, which needs
+//			i2c.WithMCP23017Mirror(1) to signal changes of port B too
 //
 func NewMCP23017Driver(a Connector, options ...func(Config)) *MCP23017Driver {
 	m := &MCP23017Driver{
@@ -94,6 +106,7 @@ func NewMCP23017Driver(a Connector, options ...func(Config)) *MCP23017Driver {
 		connector: a,
 		Config:    NewConfig(),
 		MCPConf:   MCP23017Config{},
+		mutex:     &sync.Mutex{},
 		Commander: gobot.NewCommander(),
 		Eventer:   gobot.NewEventer(),
 	}
@@ -102,6 +115,9 @@ func NewMCP23017Driver(a Connector, options ...func(Config)) *MCP23017Driver {
 		option(m)
 	}
 
+	m.AddEvent(PinChange)
+	m.AddEvent(Error)
+
 	m.AddCommand("WriteGPIO", func(params map[string]interface{}) interface{} {
 		pin := params["pin"].(uint8)
 		val := params["val"].(uint8)
@@ -129,15 +145,31 @@ func (m *MCP23017Driver) SetName(n string) { m.name = n }
 // Connection returns the I2c connection.
 func (m *MCP23017Driver) Connection() gobot.Connection { return m.connector.(gobot.Connection) }
 
+// Connect implements the Connection interface, so that gpio drivers can
+// claim the pins of the expander. The expander itself is started as a driver.
+func (m *MCP23017Driver) Connect() (err error) { return }
+
+// Finalize implements the Connection interface
+func (m *MCP23017Driver) Finalize() (err error) { return }
+
 // Halt stops the driver.
-func (m *MCP23017Driver) Halt() (err error) { return }
+func (m *MCP23017Driver) Halt() (err error) {
+	m.interrupt.stop()
+	releaseConnections(m.connector, m)
+	return
+}
 
-// Start writes the device configuration.
+// Start writes the device configuration, and starts watching the interrupt
+// pin if one is set.
+//
+// Emits the Events:
+//	PinChange PinLevel - When an input changes, if an interrupt pin is set
+//	Error error - When the inputs can not be read after an interrupt
 func (m *MCP23017Driver) Start() (err error) {
 	bus := m.GetBusOrDefault(m.connector.GetDefaultBus())
 	address := m.GetAddressOrDefault(mcp23017Address)
 
-	m.connection, err = m.connector.GetConnection(address, bus)
+	m.connection, err = claimConnection(m.connector, m, address, bus)
 	if err != nil {
 		return err
 	}
@@ -147,9 +179,115 @@ func (m *MCP23017Driver) Start() (err error) {
 	if _, err := m.connection.Write([]uint8{ioconReg, ioconVal}); err != nil {
 		return err
 	}
+	if m.interrupt.enabled() {
+		m.interrupt.active = int(m.MCPConf.Intpol)
+		err = m.interrupt.watch(m.update, func(err error) { m.Publish(Error, err) })
+	}
 	return
 }
 
+// DigitalWrite writes the level to a pin "A0" to "B7", which is made an
+// output.
+func (m *MCP23017Driver) DigitalWrite(pin string, level byte) (err error) {
+	bit, err := expanderPin(pin, 2)
+	if err != nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	portStr, n := mcp23017Port(bit)
+	selectedPort := m.getPort(portStr)
+	if m.outputs&(1<<bit) == 0 {
+		if err = m.write(selectedPort.IODIR, n, 0); err != nil {
+			return
+		}
+		m.outputs |= 1 << bit
+		m.inputs &^= 1 << bit
+	}
+	if level != 0 {
+		level = 1
+	}
+	return m.write(selectedPort.OLAT, n, level)
+}
+
+// DigitalRead reads the level of a pin "A0" to "B7", which is made an input.
+// With an interrupt pin the level is the one read at the last interrupt.
+func (m *MCP23017Driver) DigitalRead(pin string) (val int, err error) {
+	bit, err := expanderPin(pin, 2)
+	if err != nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.inputs&(1<<bit) == 0 {
+		portStr, n := mcp23017Port(bit)
+		selectedPort := m.getPort(portStr)
+		if err = m.write(selectedPort.IODIR, n, 1); err != nil {
+			return
+		}
+		if m.interrupt.enabled() {
+			if err = m.write(selectedPort.GPINTEN, n, 1); err != nil {
+				return
+			}
+		}
+		m.inputs |= 1 << bit
+		m.outputs &^= 1 << bit
+	} else if m.interrupt.enabled() {
+		return int(m.levels>>bit) & 1, nil
+	}
+
+	if m.levels, err = m.readLevels(); err != nil {
+		return
+	}
+	return int(m.levels>>bit) & 1, nil
+}
+
+// update reads the inputs after an interrupt and publishes those which
+// changed
+func (m *MCP23017Driver) update() error {
+	m.mutex.Lock()
+	levels, err := m.readLevels()
+	if err != nil {
+		m.mutex.Unlock()
+		return err
+	}
+	changes := changedPins(m.levels, levels, m.inputs, 2)
+	m.levels = levels
+	m.mutex.Unlock()
+
+	for _, change := range changes {
+		m.Publish(PinChange, change)
+	}
+	return nil
+}
+
+// readLevels reads the GPIO registers of both ports
+func (m *MCP23017Driver) readLevels() (uint16, error) {
+	a, err := m.read(m.getPort("A").GPIO)
+	if err != nil {
+		return 0, err
+	}
+	b, err := m.read(m.getPort("B").GPIO)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(a) | uint16(b)<<8, nil
+}
+
+func (m *MCP23017Driver) setInterrupt(reader gpio.DigitalReader, pin string) {
+	m.interrupt.reader, m.interrupt.pin = reader, pin
+}
+
+// mcp23017Port returns the port and pin number of a bit of both ports
+func mcp23017Port(bit uint8) (string, uint8) {
+	if bit < 8 {
+		return "A", bit
+	}
+	return "B", bit - 8
+}
+
 // WriteGPIO writes a value to a gpio pin (0-7) and a port (A or B).
 func (m *MCP23017Driver) WriteGPIO(pin uint8, val uint8, portStr string) (err error) {
 	selectedPort := m.getPort(portStr)
@@ -6,12 +6,17 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*MCP23017Driver)(nil)
+var _ gobot.Connection = (*MCP23017Driver)(nil)
+var _ gpio.DigitalReader = (*MCP23017Driver)(nil)
+var _ gpio.DigitalWriter = (*MCP23017Driver)(nil)
 
 var pinValPort = map[string]interface{}{
 	"pin":  uint8(7),
@@ -453,3 +458,122 @@ func TestMCP23017DriverSetName(t *testing.T) {
 	d.SetName("TESTME")
 	gobottest.Assert(t, d.Name(), "TESTME")
 }
+
+// simulateMCP23017 makes the adaptor behave like an MCP23017 in bank 0, and
+// returns a function which sets a register, e.g. the levels of the inputs in
+// the GPIO registers
+func simulateMCP23017(a *i2cTestAdaptor) (regs *[0x16]byte, set func(reg uint8, val uint8)) {
+	regs = &[0x16]byte{}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, regs[:]), nil
+	}
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		if len(b) == 2 {
+			regs[b[0]] = b[1]
+		}
+		return len(b), nil
+	}
+	return regs, func(reg uint8, val uint8) {
+		a.mtx.Lock()
+		defer a.mtx.Unlock()
+		regs[reg] = val
+	}
+}
+
+func TestMCP23017DriverDigitalWrite(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithStubbedAdaptor(0)
+	regs, _ := simulateMCP23017(adaptor)
+	gobottest.Assert(t, mcp.Start(), nil)
+	regs[0x01] = 0xff
+
+	gobottest.Assert(t, mcp.DigitalWrite("B3", 1), nil)
+	gobottest.Assert(t, regs[0x01], uint8(0xf7))
+	gobottest.Assert(t, regs[0x15], uint8(0x08))
+	gobottest.Assert(t, mcp.DigitalWrite("b3", 0), nil)
+	gobottest.Assert(t, regs[0x15], uint8(0x00))
+	gobottest.Assert(t, mcp.DigitalWrite("C3", 0), ErrInvalidPin)
+}
+
+func TestMCP23017DriverDigitalRead(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithStubbedAdaptor(0)
+	regs, set := simulateMCP23017(adaptor)
+	gobottest.Assert(t, mcp.Start(), nil)
+
+	set(0x12, 0x04)
+	val, err := mcp.DigitalRead("A2")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, regs[0x00], uint8(0x04))
+	set(0x12, 0x00)
+	val, _ = mcp.DigitalRead("A2")
+	gobottest.Assert(t, val, 0)
+
+	set(0x13, 0x80)
+	val, _ = mcp.DigitalRead("B7")
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, regs[0x01], uint8(0x80))
+
+	_, err = mcp.DigitalRead("A9")
+	gobottest.Assert(t, err, ErrInvalidPin)
+}
+
+func TestMCP23017DriverInterrupt(t *testing.T) {
+	interrupt := &testInterruptPin{level: 1}
+	adaptor := newI2cTestAdaptor()
+	mcp := NewMCP23017Driver(adaptor, WithInterruptPin(interrupt, "7"), WithMCP23017Mirror(1))
+	regs, set := simulateMCP23017(adaptor)
+	gobottest.Assert(t, mcp.Start(), nil)
+	defer mcp.Halt()
+
+	val, err := mcp.DigitalRead("B1")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0)
+	gobottest.Assert(t, regs[0x05], uint8(0x02))
+
+	changes := make(chan PinLevel, 2)
+	mcp.On(PinChange, func(data interface{}) {
+		changes <- data.(PinLevel)
+	})
+
+	// inputs are only read at an interrupt
+	set(0x13, 0x02)
+	val, _ = mcp.DigitalRead("B1")
+	gobottest.Assert(t, val, 0)
+
+	interrupt.set(0)
+	select {
+	case change := <-changes:
+		gobottest.Assert(t, change, PinLevel{Pin: "B1", Level: 1})
+	case <-time.After(time.Second):
+		t.Errorf("MCP23017 Event \"PinChange\" was not published")
+	}
+	interrupt.set(1)
+	val, _ = mcp.DigitalRead("B1")
+	gobottest.Assert(t, val, 1)
+}
+
+func TestMCP23017DriverGpioDrivers(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithStubbedAdaptor(0)
+	regs, set := simulateMCP23017(adaptor)
+	gobottest.Assert(t, mcp.Start(), nil)
+
+	led := gpio.NewLedDriver(mcp, "A5")
+	gobottest.Assert(t, led.Start(), nil)
+	gobottest.Assert(t, led.On(), nil)
+	gobottest.Assert(t, regs[0x14], uint8(0x20))
+	gobottest.Refute(t, gpio.NewRelayDriver(mcp, "A5").Start(), nil)
+
+	set(0x12, 0x01)
+	button := gpio.NewButtonDriver(mcp, "A0")
+	pushed := make(chan bool, 1)
+	button.Once(gpio.ButtonPush, func(data interface{}) {
+		pushed <- true
+	})
+	gobottest.Assert(t, button.Start(), nil)
+	defer button.Halt()
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		t.Errorf("Button Event \"Push\" was not published")
+	}
+}
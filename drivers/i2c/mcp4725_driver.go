@@ -0,0 +1,181 @@
+package i2c
+
+import (
+	"errors"
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+const mcp4725Address = 0x62
+
+const (
+	mcp4725WriteDAC       = 0x40
+	mcp4725WriteDACEEPROM = 0x60
+	mcp4725Ready          = 0x80
+)
+
+// MCP4725Max is the highest value of the 12 bit MCP4725
+const MCP4725Max = 4095
+
+// ErrDACOutOfRange is the error resulting when a value beyond the resolution
+// of a DAC is written
+var ErrDACOutOfRange = errors.New("DAC value is out of range")
+
+// MCP4725Driver is a driver for the MCP4725 12 bit digital to analog
+// converter, whose output is 0 to the supply voltage.
+//
+// It implements aio.AnalogWriter, the pin is ignored as it has a single
+// output, so that it can be the connection of an aio.AnalogActuatorDriver.
+type MCP4725Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	mutex      *sync.Mutex
+	value      uint16
+	Config
+	gobot.Commander
+}
+
+// NewMCP4725Driver creates a new driver for the MCP4725. Its address is
+// 0x60 to 0x67, depending on the part and its A0 pin.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//
+// Adds the following API Commands:
+//	"Write" - See MCP4725Driver.Write, with the "val" param
+//	"WriteEEPROM" - See MCP4725Driver.WriteEEPROM, with the "val" param
+func NewMCP4725Driver(a Connector, options ...func(Config)) *MCP4725Driver {
+	m := &MCP4725Driver{
+		name:      gobot.DefaultName("MCP4725"),
+		connector: a,
+		mutex:     &sync.Mutex{},
+		Config:    NewConfig(),
+		Commander: gobot.NewCommander(),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	m.AddCommand("Write", func(params map[string]interface{}) interface{} {
+		val, _ := params["val"].(float64)
+		return m.Write(uint16(val))
+	})
+	m.AddCommand("WriteEEPROM", func(params map[string]interface{}) interface{} {
+		val, _ := params["val"].(float64)
+		return m.WriteEEPROM(uint16(val))
+	})
+
+	return m
+}
+
+// Name returns the name of the device.
+func (m *MCP4725Driver) Name() string { return m.name }
+
+// SetName sets the name of the device.
+func (m *MCP4725Driver) SetName(n string) { m.name = n }
+
+// Connection returns the connection of the device.
+func (m *MCP4725Driver) Connection() gobot.Connection { return m.connector.(gobot.Connection) }
+
+// Connect implements the Connection interface, so that aio drivers can claim
+// the output. The DAC itself is started as a driver.
+func (m *MCP4725Driver) Connect() (err error) { return }
+
+// Finalize implements the Connection interface
+func (m *MCP4725Driver) Finalize() (err error) { return }
+
+// Start initializes the MCP4725
+func (m *MCP4725Driver) Start() (err error) {
+	bus := m.GetBusOrDefault(m.connector.GetDefaultBus())
+	address := m.GetAddressOrDefault(mcp4725Address)
+
+	m.connection, err = claimConnection(m.connector, m, address, bus)
+	return
+}
+
+// Halt stops the device.
+func (m *MCP4725Driver) Halt() (err error) {
+	releaseConnections(m.connector, m)
+	return
+}
+
+// AnalogWrite writes the 0-4095 value to the output
+func (m *MCP4725Driver) AnalogWrite(pin string, value int) (err error) {
+	if value < 0 || value > MCP4725Max {
+		return ErrDACOutOfRange
+	}
+	return m.Write(uint16(value))
+}
+
+// Write writes the 0-4095 value to the output
+func (m *MCP4725Driver) Write(value uint16) (err error) {
+	if value > MCP4725Max {
+		return ErrDACOutOfRange
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, err = m.connection.Write([]byte{mcp4725WriteDAC, byte(value >> 4), byte(value << 4)}); err != nil {
+		return
+	}
+	m.value = value
+	return
+}
+
+// WriteEEPROM writes the 0-4095 value to the output and to the EEPROM, which
+// sets the output at power on. The EEPROM is written for up to 50
+// Milliseconds, see Ready.
+func (m *MCP4725Driver) WriteEEPROM(value uint16) (err error) {
+	if value > MCP4725Max {
+		return ErrDACOutOfRange
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, err = m.connection.Write([]byte{mcp4725WriteDACEEPROM, byte(value >> 4), byte(value << 4)}); err != nil {
+		return
+	}
+	m.value = value
+	return
+}
+
+// Value returns the value last written to the output
+func (m *MCP4725Driver) Value() uint16 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.value
+}
+
+// Read reads the value of the output and the one in the EEPROM
+func (m *MCP4725Driver) Read() (value uint16, eeprom uint16, err error) {
+	_, value, eeprom, err = m.read()
+	return
+}
+
+// Ready returns true when the EEPROM is not being written
+func (m *MCP4725Driver) Ready() (ready bool, err error) {
+	status, _, _, err := m.read()
+	return status&mcp4725Ready != 0, err
+}
+
+func (m *MCP4725Driver) read() (status byte, value uint16, eeprom uint16, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	buf := make([]byte, 5)
+	n, err := m.connection.Read(buf)
+	if err != nil {
+		return
+	}
+	if n != len(buf) {
+		err = ErrNotEnoughBytes
+		return
+	}
+	status = buf[0]
+	value = uint16(buf[1])<<4 | uint16(buf[2])>>4
+	eeprom = uint16(buf[3]&0x0f)<<8 | uint16(buf[4])
+	return
+}
@@ -0,0 +1,117 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/aio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*MCP4725Driver)(nil)
+var _ gobot.Connection = (*MCP4725Driver)(nil)
+var _ aio.AnalogWriter = (*MCP4725Driver)(nil)
+
+func initTestMCP4725DriverWithStubbedAdaptor() (*MCP4725Driver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	d := NewMCP4725Driver(adaptor)
+	d.Start()
+	return d, adaptor
+}
+
+func TestNewMCP4725Driver(t *testing.T) {
+	d := NewMCP4725Driver(newI2cTestAdaptor(), WithAddress(0x60))
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.GetAddressOrDefault(mcp4725Address), 0x60)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MCP4725"), true)
+	gobottest.Assert(t, d.Connect(), nil)
+	gobottest.Assert(t, d.Finalize(), nil)
+	d.SetName("dac")
+	gobottest.Assert(t, d.Name(), "dac")
+}
+
+func TestMCP4725DriverStart(t *testing.T) {
+	d, _ := initTestMCP4725DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Halt(), nil)
+
+	adaptor := newI2cTestAdaptor()
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, NewMCP4725Driver(adaptor).Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestMCP4725DriverWrite(t *testing.T) {
+	d, adaptor := initTestMCP4725DriverWithStubbedAdaptor()
+	written := []byte{}
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = append([]byte{}, b...)
+		return len(b), nil
+	}
+
+	gobottest.Assert(t, d.Write(0xabc), nil)
+	gobottest.Assert(t, written, []byte{0x40, 0xab, 0xc0})
+	gobottest.Assert(t, d.Value(), uint16(0xabc))
+	gobottest.Assert(t, d.WriteEEPROM(0x123), nil)
+	gobottest.Assert(t, written, []byte{0x60, 0x12, 0x30})
+	gobottest.Assert(t, d.AnalogWrite("0", 4095), nil)
+	gobottest.Assert(t, written, []byte{0x40, 0xff, 0xf0})
+
+	gobottest.Assert(t, d.Write(4096), ErrDACOutOfRange)
+	gobottest.Assert(t, d.WriteEEPROM(4096), ErrDACOutOfRange)
+	gobottest.Assert(t, d.AnalogWrite("0", -1), ErrDACOutOfRange)
+
+	gobottest.Assert(t, d.Command("Write")(map[string]interface{}{"val": 2048.0}), nil)
+	gobottest.Assert(t, written, []byte{0x40, 0x80, 0x00})
+	gobottest.Assert(t, d.Command("WriteEEPROM")(map[string]interface{}{"val": 1.0}), nil)
+	gobottest.Assert(t, written, []byte{0x60, 0x00, 0x10})
+
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, d.Write(1), errors.New("write error"))
+	gobottest.Assert(t, d.Value(), uint16(1))
+}
+
+func TestMCP4725DriverRead(t *testing.T) {
+	d, adaptor := initTestMCP4725DriverWithStubbedAdaptor()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0xc0, 0xab, 0xc0, 0x01, 0x23})
+		return 5, nil
+	}
+	value, eeprom, err := d.Read()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, value, uint16(0xabc))
+	gobottest.Assert(t, eeprom, uint16(0x123))
+	ready, err := d.Ready()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, ready, true)
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x40, 0, 0, 0, 0})
+		return 5, nil
+	}
+	ready, _ = d.Ready()
+	gobottest.Assert(t, ready, false)
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 2, nil
+	}
+	_, _, err = d.Read()
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+}
+
+func TestMCP4725DriverWithAnalogActuator(t *testing.T) {
+	d, adaptor := initTestMCP4725DriverWithStubbedAdaptor()
+	written := []byte{}
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = append([]byte{}, b...)
+		return len(b), nil
+	}
+	actuator := aio.NewAnalogActuatorDriver(d, "0")
+	actuator.Scaler = aio.AnalogActuatorLinearScaler(0, 3.3, 0, MCP4725Max)
+	gobottest.Assert(t, actuator.Start(), nil)
+	gobottest.Assert(t, actuator.Write(1.65), nil)
+	gobottest.Assert(t, d.Value(), uint16(2048))
+	gobottest.Assert(t, written, []byte{0x40, 0x80, 0x00})
+}
@@ -2,6 +2,7 @@ package i2c
 
 import (
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 )
 
 const mma7660Address = 0x4c
@@ -74,7 +75,7 @@ func (h *MMA7660Driver) Start() (err error) {
 	bus := h.GetBusOrDefault(h.connector.GetDefaultBus())
 	address := h.GetAddressOrDefault(mma7660Address)
 
-	h.connection, err = h.connector.GetConnection(address, bus)
+	h.connection, err = claimConnection(h.connector, h, address, bus)
 	if err != nil {
 		return err
 	}
@@ -95,13 +96,27 @@ func (h *MMA7660Driver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (h *MMA7660Driver) Halt() (err error) { return }
+func (h *MMA7660Driver) Halt() (err error) {
+	releaseConnections(h.connector, h)
+	return
+}
 
 // Acceleration returns the acceleration of the provided x, y, z
 func (h *MMA7660Driver) Acceleration(x, y, z float64) (ax, ay, az float64) {
 	return x / 21.0, y / 21.0, z / 21.0
 }
 
+// ReadAcceleration returns the acceleration in meters per second squared. It
+// implements imu.Accelerometer.
+func (h *MMA7660Driver) ReadAcceleration() (v imu.Vector, err error) {
+	x, y, z, err := h.XYZ()
+	if err != nil {
+		return
+	}
+	ax, ay, az := h.Acceleration(x, y, z)
+	return imu.Vector{X: ax, Y: ay, Z: az}.Scale(imu.StandardGravity), nil
+}
+
 // XYZ returns the raw x,y and z axis from the mma7660
 func (h *MMA7660Driver) XYZ() (x float64, y float64, z float64, err error) {
 	buf := []byte{0, 0, 0}
@@ -7,11 +7,14 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*MMA7660Driver)(nil)
 
+var _ imu.Accelerometer = (*MMA7660Driver)(nil)
+
 // --------- HELPERS
 func initTestMMA7660Driver() (driver *MMA7660Driver) {
 	driver, _ = initTestMMA7660DriverWithStubbedAdaptor()
@@ -111,6 +114,26 @@ func TestMMA7660DriverXYZ(t *testing.T) {
 	gobottest.Assert(t, z, 19.0)
 }
 
+func TestMMA7660DriverReadAcceleration(t *testing.T) {
+	d, adaptor := initTestMMA7660DriverWithStubbedAdaptor()
+	d.Start()
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x15, 0x00, 0x2b})
+		return 3, nil
+	}
+
+	v, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, imu.Vector{X: imu.StandardGravity, Y: 0, Z: -imu.StandardGravity})
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err = d.ReadAcceleration()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
 func TestMMA7660DriverXYZError(t *testing.T) {
 	d, adaptor := initTestMMA7660DriverWithStubbedAdaptor()
 	d.Start()
@@ -0,0 +1,241 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+)
+
+const mma8452Address = 0x1D
+
+const (
+	mma8452RegisterStatus     = 0x00
+	mma8452RegisterOutX       = 0x01
+	mma8452RegisterIntSource  = 0x0C
+	mma8452RegisterXYZDataCfg = 0x0E
+	mma8452RegisterCtrl1      = 0x2A
+	mma8452RegisterCtrl4      = 0x2D
+	mma8452RegisterCtrl5      = 0x2E
+
+	mma8452Active    = 0x01
+	mma8452DataReady = 0x08
+)
+
+// MMA8452Range is the measurement range of the MMA8452.
+type MMA8452Range byte
+
+const (
+	// MMA8452Range2g is the +-2g range.
+	MMA8452Range2g MMA8452Range = 0x00
+	// MMA8452Range4g is the +-4g range.
+	MMA8452Range4g MMA8452Range = 0x01
+	// MMA8452Range8g is the +-8g range.
+	MMA8452Range8g MMA8452Range = 0x02
+)
+
+// mma8452CountsPerG are the counts per g of the ranges
+var mma8452CountsPerG = map[MMA8452Range]float64{
+	MMA8452Range2g: 1024,
+	MMA8452Range4g: 512,
+	MMA8452Range8g: 256,
+}
+
+// MMA8452Rate is the output data rate of the MMA8452.
+type MMA8452Rate byte
+
+const (
+	// MMA8452Rate800Hz is the 800Hz data rate.
+	MMA8452Rate800Hz MMA8452Rate = 0x00
+	// MMA8452Rate400Hz is the 400Hz data rate.
+	MMA8452Rate400Hz MMA8452Rate = 0x08
+	// MMA8452Rate200Hz is the 200Hz data rate.
+	MMA8452Rate200Hz MMA8452Rate = 0x10
+	// MMA8452Rate100Hz is the 100Hz data rate.
+	MMA8452Rate100Hz MMA8452Rate = 0x18
+	// MMA8452Rate50Hz is the 50Hz data rate.
+	MMA8452Rate50Hz MMA8452Rate = 0x20
+	// MMA8452Rate12_5Hz is the 12.5Hz data rate.
+	MMA8452Rate12_5Hz MMA8452Rate = 0x28
+	// MMA8452Rate6_25Hz is the 6.25Hz data rate.
+	MMA8452Rate6_25Hz MMA8452Rate = 0x30
+	// MMA8452Rate1_56Hz is the 1.56Hz data rate.
+	MMA8452Rate1_56Hz MMA8452Rate = 0x38
+)
+
+// MMA8452 interrupts, which are or'ed together.
+const (
+	MMA8452InterruptDataReady   = 0x01
+	MMA8452InterruptMotion      = 0x04
+	MMA8452InterruptPulse       = 0x08
+	MMA8452InterruptOrientation = 0x10
+	MMA8452InterruptTransient   = 0x20
+	MMA8452InterruptAutoSleep   = 0x80
+)
+
+// MMA8452Driver is a driver for the MMA8452Q 3-axis 12-bit accelerometer. It
+// has no FIFO, unlike the MMA8451Q.
+// Device datasheet: https://www.nxp.com/docs/en/data-sheet/MMA8452Q.pdf
+type MMA8452Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	accelRange     MMA8452Range
+	rate           MMA8452Rate
+	interrupts     byte
+	interruptsInt1 byte
+}
+
+// NewMMA8452Driver creates a new driver for the MMA8452. Its address is
+// 0x1D, or 0x1C if its SA0 pin is low.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithMMA8452Range(MMA8452Range):	measurement range, +-2g by default
+//		i2c.WithMMA8452Rate(MMA8452Rate):	output data rate, 100Hz by default
+//		i2c.WithMMA8452Interrupts(byte, byte):	interrupts which are enabled and those of them routed to INT1
+//
+func NewMMA8452Driver(a Connector, options ...func(Config)) *MMA8452Driver {
+	d := &MMA8452Driver{
+		name:       gobot.DefaultName("MMA8452"),
+		connector:  a,
+		Config:     NewConfig(),
+		accelRange: MMA8452Range2g,
+		rate:       MMA8452Rate100Hz,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithMMA8452Range option sets the measurement range of the MMA8452Driver.
+func WithMMA8452Range(val MMA8452Range) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*MMA8452Driver); ok {
+			d.accelRange = val
+		}
+	}
+}
+
+// WithMMA8452Rate option sets the output data rate of the MMA8452Driver.
+func WithMMA8452Rate(val MMA8452Rate) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*MMA8452Driver); ok {
+			d.rate = val
+		}
+	}
+}
+
+// WithMMA8452Interrupts option enables the MMA8452Interrupt* interrupts of
+// the MMA8452Driver. Those of them also in int1 are signaled on the INT1
+// pin, the others on the INT2 pin.
+func WithMMA8452Interrupts(enable byte, int1 byte) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*MMA8452Driver); ok {
+			d.interrupts = enable
+			d.interruptsInt1 = int1
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *MMA8452Driver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *MMA8452Driver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the device.
+func (d *MMA8452Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start configures the MMA8452 and starts measuring.
+func (d *MMA8452Driver) Start() (err error) {
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(mma8452Address)
+
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
+		return
+	}
+
+	// the configuration is written in standby
+	for _, reg := range [][2]byte{
+		{mma8452RegisterCtrl1, 0},
+		{mma8452RegisterXYZDataCfg, byte(d.accelRange)},
+		{mma8452RegisterCtrl4, d.interrupts},
+		{mma8452RegisterCtrl5, d.interruptsInt1},
+		{mma8452RegisterCtrl1, byte(d.rate) | mma8452Active},
+	} {
+		if err = d.connection.WriteByteData(reg[0], reg[1]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Halt halts the device.
+func (d *MMA8452Driver) Halt() (err error) {
+	releaseConnections(d.connector, d)
+	return
+}
+
+// RawXYZ returns the raw 12-bit acceleration of the 3 axis.
+func (d *MMA8452Driver) RawXYZ() (x int16, y int16, z int16, err error) {
+	if _, err = d.connection.Write([]byte{mma8452RegisterOutX}); err != nil {
+		return
+	}
+	data := make([]byte, 6)
+	n, err := d.connection.Read(data)
+	if err != nil {
+		return
+	}
+	if n != len(data) {
+		err = ErrNotEnoughBytes
+		return
+	}
+
+	// the 12 bit values are left-justified
+	buf := bytes.NewBuffer(data)
+	binary.Read(buf, binary.BigEndian, &x)
+	binary.Read(buf, binary.BigEndian, &y)
+	binary.Read(buf, binary.BigEndian, &z)
+	return x >> 4, y >> 4, z >> 4, nil
+}
+
+// XYZ returns the acceleration of the 3 axis in g.
+func (d *MMA8452Driver) XYZ() (x float64, y float64, z float64, err error) {
+	rx, ry, rz, err := d.RawXYZ()
+	if err != nil {
+		return
+	}
+	counts := mma8452CountsPerG[d.accelRange]
+	return float64(rx) / counts, float64(ry) / counts, float64(rz) / counts, nil
+}
+
+// ReadAcceleration returns the acceleration in meters per second squared. It
+// implements imu.Accelerometer.
+func (d *MMA8452Driver) ReadAcceleration() (v imu.Vector, err error) {
+	x, y, z, err := d.XYZ()
+	if err != nil {
+		return
+	}
+	return imu.Vector{X: x, Y: y, Z: z}.Scale(imu.StandardGravity), nil
+}
+
+// Ready returns true when new data of all 3 axis is available.
+func (d *MMA8452Driver) Ready() (ready bool, err error) {
+	status, err := d.connection.ReadByteData(mma8452RegisterStatus)
+	return status&mma8452DataReady != 0, err
+}
+
+// InterruptSource returns the MMA8452Interrupt* interrupts which have
+// occurred.
+func (d *MMA8452Driver) InterruptSource() (source byte, err error) {
+	return d.connection.ReadByteData(mma8452RegisterIntSource)
+}
@@ -0,0 +1,182 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*MMA8452Driver)(nil)
+
+var _ imu.Accelerometer = (*MMA8452Driver)(nil)
+
+// --------- HELPERS
+func initTestMMA8452Driver() (driver *MMA8452Driver) {
+	driver, _ = initTestMMA8452DriverWithStubbedAdaptor()
+	return
+}
+
+func initTestMMA8452DriverWithStubbedAdaptor() (*MMA8452Driver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	return NewMMA8452Driver(adaptor), adaptor
+}
+
+// --------- TESTS
+
+func TestNewMMA8452Driver(t *testing.T) {
+	var d interface{} = NewMMA8452Driver(newI2cTestAdaptor())
+	_, ok := d.(*MMA8452Driver)
+	if !ok {
+		t.Errorf("NewMMA8452Driver() should have returned a *MMA8452Driver")
+	}
+}
+
+func TestMMA8452Driver(t *testing.T) {
+	d := initTestMMA8452Driver()
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MMA8452"), true)
+	d.SetName("mybot")
+	gobottest.Assert(t, d.Name(), "mybot")
+}
+
+func TestMMA8452DriverOptions(t *testing.T) {
+	d := NewMMA8452Driver(newI2cTestAdaptor(), WithBus(2),
+		WithMMA8452Range(MMA8452Range8g),
+		WithMMA8452Rate(MMA8452Rate800Hz),
+		WithMMA8452Interrupts(MMA8452InterruptDataReady|MMA8452InterruptPulse, MMA8452InterruptPulse))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.accelRange, MMA8452Range8g)
+	gobottest.Assert(t, d.rate, MMA8452Rate800Hz)
+	gobottest.Assert(t, d.interrupts, byte(0x09))
+	gobottest.Assert(t, d.interruptsInt1, byte(0x08))
+}
+
+func TestMMA8452DriverStart(t *testing.T) {
+	d, adaptor := initTestMMA8452DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		0x2A, 0x00,
+		0x0E, 0x00,
+		0x2D, 0x00,
+		0x2E, 0x00,
+		0x2A, 0x19,
+	})
+
+	adaptor = newI2cTestAdaptor()
+	d = NewMMA8452Driver(adaptor,
+		WithMMA8452Range(MMA8452Range4g),
+		WithMMA8452Rate(MMA8452Rate1_56Hz),
+		WithMMA8452Interrupts(MMA8452InterruptDataReady|MMA8452InterruptTransient, MMA8452InterruptDataReady))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		0x2A, 0x00,
+		0x0E, 0x01,
+		0x2D, 0x21,
+		0x2E, 0x01,
+		0x2A, 0x39,
+	})
+}
+
+func TestMMA8452DriverStartConnectError(t *testing.T) {
+	d, adaptor := initTestMMA8452DriverWithStubbedAdaptor()
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestMMA8452DriverStartWriteError(t *testing.T) {
+	d, adaptor := initTestMMA8452DriverWithStubbedAdaptor()
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, d.Start(), errors.New("write error"))
+}
+
+func TestMMA8452DriverHalt(t *testing.T) {
+	d := initTestMMA8452Driver()
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestMMA8452DriverXYZ(t *testing.T) {
+	d, adaptor := initTestMMA8452DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		// 1024, -512 and 0 left-justified
+		copy(b, []byte{0x40, 0x00, 0xe0, 0x00, 0x00, 0x00})
+		return 6, nil
+	}
+
+	rx, ry, rz, err := d.RawXYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, []int16{rx, ry, rz}, []int16{1024, -512, 0})
+
+	x, y, z, err := d.XYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, []float64{x, y, z}, []float64{1, -0.5, 0})
+
+	v, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, imu.Vector{X: imu.StandardGravity, Y: -0.5 * imu.StandardGravity, Z: 0})
+}
+
+func TestMMA8452DriverXYZRange(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	d := NewMMA8452Driver(adaptor, WithMMA8452Range(MMA8452Range8g))
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x40, 0x00, 0x00, 0x00, 0x00, 0x00})
+		return 6, nil
+	}
+	x, _, _, err := d.XYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, x, 4.0)
+}
+
+func TestMMA8452DriverXYZError(t *testing.T) {
+	d, adaptor := initTestMMA8452DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, errors.New("read error"))
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 1, nil
+	}
+	_, _, _, err = d.RawXYZ()
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	_, _, _, err = d.RawXYZ()
+	gobottest.Assert(t, err, errors.New("write error"))
+}
+
+func TestMMA8452DriverReady(t *testing.T) {
+	d, adaptor := initTestMMA8452DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x08
+		return 1, nil
+	}
+	ready, err := d.Ready()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, ready, true)
+}
+
+func TestMMA8452DriverInterruptSource(t *testing.T) {
+	d, adaptor := initTestMMA8452DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = MMA8452InterruptPulse
+		return 1, nil
+	}
+	source, err := d.InterruptSource()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, source, byte(0x08))
+}
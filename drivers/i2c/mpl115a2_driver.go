@@ -85,7 +85,10 @@ func (h *MPL115A2Driver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (h *MPL115A2Driver) Halt() (err error) { return }
+func (h *MPL115A2Driver) Halt() (err error) {
+	releaseConnections(h.connector, h)
+	return
+}
 
 // Pressure fetches the latest data from the MPL115A2, and returns the pressure
 func (h *MPL115A2Driver) Pressure() (p float32, err error) {
@@ -99,6 +102,21 @@ func (h *MPL115A2Driver) Temperature() (t float32, err error) {
 	return
 }
 
+// ReadPressure fetches the latest data from the MPL115A2, and returns the
+// pressure in pascals. It implements environment.Barometer.
+func (h *MPL115A2Driver) ReadPressure() (float64, error) {
+	p, err := h.Pressure()
+	// the pressure is in kPa
+	return float64(p) * 1000, err
+}
+
+// ReadTemperature fetches the latest data from the MPL115A2, and returns the
+// temperature in degrees Celsius. It implements environment.Thermometer.
+func (h *MPL115A2Driver) ReadTemperature() (float64, error) {
+	t, err := h.Temperature()
+	return float64(t), err
+}
+
 func (h *MPL115A2Driver) initialization() (err error) {
 	var coA0 int16
 	var coB1 int16
@@ -108,7 +126,7 @@ func (h *MPL115A2Driver) initialization() (err error) {
 	bus := h.GetBusOrDefault(h.connector.GetDefaultBus())
 	address := h.GetAddressOrDefault(mpl115a2Address)
 
-	h.connection, err = h.connector.GetConnection(address, bus)
+	h.connection, err = claimConnection(h.connector, h, address, bus)
 	if err != nil {
 		return err
 	}
@@ -6,11 +6,16 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*MPL115A2Driver)(nil)
 
+var _ environment.Thermometer = (*MPL115A2Driver)(nil)
+
+var _ environment.Barometer = (*MPL115A2Driver)(nil)
+
 // --------- HELPERS
 func initTestMPL115A2Driver() (driver *MPL115A2Driver) {
 	driver, _ = initTestMPL115A2DriverWithStubbedAdaptor()
@@ -85,6 +90,11 @@ func TestMPL115A2DriverReadData(t *testing.T) {
 	temp, _ := mpl.Temperature()
 	gobottest.Assert(t, press, float32(50.007942))
 	gobottest.Assert(t, temp, float32(116.58878))
+
+	pascal, _ := mpl.ReadPressure()
+	celsius, _ := mpl.ReadTemperature()
+	gobottest.Assert(t, pascal, float64(press)*1000)
+	gobottest.Assert(t, celsius, float64(temp))
 }
 
 func TestMPL115A2DriverReadDataError(t *testing.T) {
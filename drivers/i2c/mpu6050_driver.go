@@ -3,9 +3,11 @@ package i2c
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 )
 
 const mpu6050Address = 0x68
@@ -26,6 +28,13 @@ const MPU6050_ACCEL_FS_2 = 0x00
 const MPU6050_PWR1_SLEEP_BIT = 6
 const MPU6050_PWR1_ENABLE_BIT = 0
 
+// mpu6050AccelSensitivity is the LSB per g of the +-2g range
+const mpu6050AccelSensitivity = 16384
+
+// mpu6050GyroSensitivity is the LSB per degrees per second of the +-250
+// degrees per second range
+const mpu6050GyroSensitivity = 131
+
 type ThreeDData struct {
 	X int16
 	Y int16
@@ -89,7 +98,10 @@ func (h *MPU6050Driver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (h *MPU6050Driver) Halt() (err error) { return }
+func (h *MPU6050Driver) Halt() (err error) {
+	releaseConnections(h.connector, h)
+	return
+}
 
 // GetData fetches the latest data from the MPU6050
 func (h *MPU6050Driver) GetData() (err error) {
@@ -111,11 +123,31 @@ func (h *MPU6050Driver) GetData() (err error) {
 	return
 }
 
+// ReadAcceleration fetches the latest data and returns the acceleration in
+// meters per second squared. It implements imu.Accelerometer.
+func (h *MPU6050Driver) ReadAcceleration() (v imu.Vector, err error) {
+	if err = h.GetData(); err != nil {
+		return
+	}
+	scale := imu.StandardGravity / mpu6050AccelSensitivity
+	return threeDVector(h.Accelerometer, scale), nil
+}
+
+// ReadAngularVelocity fetches the latest data and returns the angular
+// velocity in radians per second. It implements imu.Gyroscope.
+func (h *MPU6050Driver) ReadAngularVelocity() (v imu.Vector, err error) {
+	if err = h.GetData(); err != nil {
+		return
+	}
+	scale := math.Pi / 180 / mpu6050GyroSensitivity
+	return threeDVector(h.Gyroscope, scale), nil
+}
+
 func (h *MPU6050Driver) initialize() (err error) {
 	bus := h.GetBusOrDefault(h.connector.GetDefaultBus())
 	address := h.GetAddressOrDefault(mpu6050Address)
 
-	h.connection, err = h.connector.GetConnection(address, bus)
+	h.connection, err = claimConnection(h.connector, h, address, bus)
 	if err != nil {
 		return err
 	}
@@ -162,3 +194,12 @@ func (h *MPU6050Driver) initialize() (err error) {
 func (h *MPU6050Driver) convertToCelsius() {
 	h.Temperature = (h.Temperature + 12412) / 340
 }
+
+// threeDVector returns the raw data scaled to a vector
+func threeDVector(d ThreeDData, scale float64) imu.Vector {
+	return imu.Vector{
+		X: float64(d.X) * scale,
+		Y: float64(d.Y) * scale,
+		Z: float64(d.Z) * scale,
+	}
+}
@@ -2,16 +2,22 @@ package i2c
 
 import (
 	"errors"
+	"math"
 	"strings"
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 // ensure that MPU6050Driver fulfills Gobot Driver interface
 var _ gobot.Driver = (*MPU6050Driver)(nil)
 
+// ensure that MPU6050Driver fulfills the imu sensor interfaces
+var _ imu.Accelerometer = (*MPU6050Driver)(nil)
+var _ imu.Gyroscope = (*MPU6050Driver)(nil)
+
 // --------- HELPERS
 func initTestMPU6050Driver() (driver *MPU6050Driver) {
 	driver, _ = initTestMPU6050DriverWithStubbedAdaptor()
@@ -106,6 +112,46 @@ func TestMPU6050DriverGetDataWriteError(t *testing.T) {
 	gobottest.Assert(t, mpu.GetData(), errors.New("write error"))
 }
 
+func TestMPU6050DriverReadAcceleration(t *testing.T) {
+	mpu, adaptor := initTestMPU6050DriverWithStubbedAdaptor()
+	mpu.Start()
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x40, 0x00, 0xc0, 0x00, 0x00, 0x00})
+		return 14, nil
+	}
+	v, err := mpu.ReadAcceleration()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, imu.Vector{X: imu.StandardGravity, Y: -imu.StandardGravity, Z: 0})
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err = mpu.ReadAcceleration()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestMPU6050DriverReadAngularVelocity(t *testing.T) {
+	mpu, adaptor := initTestMPU6050DriverWithStubbedAdaptor()
+	mpu.Start()
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b[8:], []byte{0x00, 0x83, 0x00, 0x00, 0xff, 0x7d})
+		return 14, nil
+	}
+	v, err := mpu.ReadAngularVelocity()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, math.Abs(v.X-math.Pi/180) < 1e-12, true)
+	gobottest.Assert(t, v.Y, 0.0)
+	gobottest.Assert(t, math.Abs(v.Z+math.Pi/180) < 1e-12, true)
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err = mpu.ReadAngularVelocity()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
 func TestMPU6050DriverSetName(t *testing.T) {
 	mpu := initTestMPU6050Driver()
 	mpu.SetName("TESTME")
@@ -1,7 +1,9 @@
 package i2c
 
 import (
+	"errors"
 	"strconv"
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -9,6 +11,9 @@ import (
 
 const pca9685Address = 0x40
 
+// pca9685DefaultFrequency is the PWM frequency in Hz after power on
+const pca9685DefaultFrequency = 200
+
 const (
 	PCA9685_MODE1        = 0x00
 	PCA9685_PRESCALE     = 0xFE
@@ -25,13 +30,52 @@ const (
 	PCA9685_ALLLED_OFF_H = 0xFD
 )
 
+const (
+	// pca9685AutoIncrement is the bit of the MODE1 register which makes the
+	// register address increment after each byte written
+	pca9685AutoIncrement = 0x20
+	// pca9685Full is the bit of the ON and OFF counts which turns a channel
+	// fully on, or off, which wins
+	pca9685Full = 0x1000
+	// pca9685Channels is the number of channels of each board
+	pca9685Channels = 16
+)
+
+// PCA9685PWM is the step at which the output of a channel turns on, and the
+// one at which it turns off, from 0 to 4095. The count 4096 turns the
+// channel fully on, or off, which wins.
+type PCA9685PWM struct {
+	On  uint16
+	Off uint16
+}
+
+var errPCA9685AnimationReplaced = errors.New("Animation was replaced")
+
+// pca9685Servo is the calibration of the servo at a channel
+type pca9685Servo struct {
+	min uint16 // pulse width at 0 degrees in microseconds
+	max uint16 // pulse width at 180 degrees in microseconds
+}
+
 // PCA9685Driver is a Gobot Driver for the PCA9685 16-channel 12-bit
 // PWM/Servo controller.
+//
+// It implements gpio.DigitalWriter, gpio.PwmWriter and gpio.ServoWriter for
+// the pins "0" to "15", so that it can be the connection of gpio drivers.
+// Boards chained behind it with WithPCA9685Chain continue the pins, e.g.
+// pins "16" to "31" are the channels of the second board.
 type PCA9685Driver struct {
-	name       string
-	connector  Connector
-	connection Connection
+	name        string
+	connector   Connector
+	connections []Connection // of the boards, in the order of their pins
+	chain       []int        // addresses of the boards chained behind
+	animator    *gobot.Animator
+	frequency   float32
+	mutex       *sync.Mutex
+	pwms        []PCA9685PWM // written to the channels
+	servos      map[int]pca9685Servo
 	Config
+	gobot.Commander
 }
 
 // NewPCA9685Driver creates a new driver with specified i2c interface
@@ -41,22 +85,74 @@ type PCA9685Driver struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithPCA9685Chain(...int):	addresses of the boards chained behind
 //
+// Adds the following API Commands:
+//	"Animate" - See PCA9685Driver.Animate and gobot.ParseAnimation, with the "channels" param
+//	"StopAnimation" - See PCA9685Driver.StopAnimation
+//	"SetServoCalibration" - See PCA9685Driver.SetServoCalibration, with the "pin", "min" and "max" params
 func NewPCA9685Driver(a Connector, options ...func(Config)) *PCA9685Driver {
 	p := &PCA9685Driver{
 		name:      gobot.DefaultName("PCA9685"),
 		connector: a,
 		Config:    NewConfig(),
+		Commander: gobot.NewCommander(),
+		frequency: pca9685DefaultFrequency,
+		mutex:     &sync.Mutex{},
+		servos:    map[int]pca9685Servo{},
 	}
 
 	for _, option := range options {
 		option(p)
 	}
 
-	// TODO: add commands for API
+	p.pwms = make([]PCA9685PWM, pca9685Channels*(1+len(p.chain)))
+	for i := range p.pwms {
+		p.pwms[i] = PCA9685PWM{Off: pca9685Full}
+	}
+
+	p.AddCommand("Animate", func(params map[string]interface{}) interface{} {
+		a, err := gobot.ParseAnimation(params)
+		if err != nil {
+			return err
+		}
+		channels := []int{}
+		list, _ := params["channels"].([]interface{})
+		for _, c := range list {
+			if ch, ok := c.(float64); ok {
+				channels = append(channels, int(ch))
+			}
+		}
+		return p.Animate(a, channels...)
+	})
+
+	p.AddCommand("StopAnimation", func(params map[string]interface{}) interface{} {
+		p.StopAnimation()
+		return nil
+	})
+
+	p.AddCommand("SetServoCalibration", func(params map[string]interface{}) interface{} {
+		pin, _ := params["pin"].(string)
+		min, _ := params["min"].(float64)
+		max, _ := params["max"].(float64)
+		return p.SetServoCalibration(pin, uint16(min), uint16(max))
+	})
+
+	// TODO: add more commands for API
 	return p
 }
 
+// WithPCA9685Chain option sets the addresses of the boards chained behind
+// the one at the address of the driver, whose channels continue its pins.
+// They share its bus and frequency.
+func WithPCA9685Chain(addresses ...int) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*PCA9685Driver); ok {
+			d.chain = addresses
+		}
+	}
+}
+
 // Name returns the Name for the Driver
 func (p *PCA9685Driver) Name() string { return p.name }
 
@@ -66,44 +162,148 @@ func (p *PCA9685Driver) SetName(n string) { p.name = n }
 // Connection returns the connection for the Driver
 func (p *PCA9685Driver) Connection() gobot.Connection { return p.connector.(gobot.Connection) }
 
-// Start initializes the pca9685
+// Start initializes the pca9685, and the boards chained behind it
 func (p *PCA9685Driver) Start() (err error) {
 	bus := p.GetBusOrDefault(p.connector.GetDefaultBus())
 	address := p.GetAddressOrDefault(pca9685Address)
 
-	p.connection, err = p.connector.GetConnection(address, bus)
-	if err != nil {
-		return err
-	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.connections = nil
+	for _, address := range append([]int{address}, p.chain...) {
+		connection, err := claimConnection(p.connector, p, address, bus)
+		if err != nil {
+			releaseConnections(p.connector, p)
+			return err
+		}
 
-	if _, err := p.connection.Write([]byte{PCA9685_MODE1, 0x00}); err != nil {
-		return err
-	}
+		if _, err := connection.Write([]byte{PCA9685_MODE1, pca9685AutoIncrement}); err != nil {
+			return err
+		}
 
-	if _, err := p.connection.Write([]byte{PCA9685_ALLLED_OFF_H, 0x10}); err != nil {
-		return err
+		if _, err := connection.Write([]byte{PCA9685_ALLLED_OFF_H, 0x10}); err != nil {
+			return err
+		}
+		p.connections = append(p.connections, connection)
 	}
 
+	for i := range p.pwms {
+		p.pwms[i] = PCA9685PWM{Off: pca9685Full}
+	}
 	return
 }
 
-// Halt stops the device
+// Halt turns all channels off and stops the device
 func (p *PCA9685Driver) Halt() (err error) {
-	_, err = p.connection.Write([]byte{PCA9685_ALLLED_OFF_H, 0x10})
+	defer releaseConnections(p.connector, p)
+	p.StopAnimation()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, connection := range p.connections {
+		if _, e := connection.Write([]byte{PCA9685_ALLLED_OFF_H, 0x10}); e != nil {
+			err = e
+		}
+	}
 	return
 }
 
 // SetPWM sets a specific channel to a pwm value from 0-4096
 func (p *PCA9685Driver) SetPWM(channel int, on uint16, off uint16) (err error) {
-	if _, err := p.connection.Write([]byte{byte(PCA9685_LED0_ON_L + 4*channel), byte(on), byte(on >> 8), byte(off), byte(off >> 8)}); err != nil {
-		return err
+	return p.SetPWMs(map[int]PCA9685PWM{channel: {On: on, Off: off}})
+}
+
+// SetPWMs sets several channels at once. The channels of each board are
+// written in one transfer, from the first to the last of them, so that
+// their outputs change at the same time.
+func (p *PCA9685Driver) SetPWMs(pwms map[int]PCA9685PWM) (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.setPWMs(pwms)
+}
+
+func (p *PCA9685Driver) setPWMs(pwms map[int]PCA9685PWM) (err error) {
+	for channel := range pwms {
+		if channel < 0 || channel >= len(p.pwms) {
+			return ErrInvalidPin
+		}
 	}
 
+	for board, connection := range p.connections {
+		first, last := -1, -1
+		for channel := range pwms {
+			if channel/pca9685Channels != board {
+				continue
+			}
+			if first < 0 || channel < first {
+				first = channel
+			}
+			if channel > last {
+				last = channel
+			}
+		}
+		if first < 0 {
+			continue
+		}
+
+		buf := []byte{byte(PCA9685_LED0_ON_L + 4*(first%pca9685Channels))}
+		for channel := first; channel <= last; channel++ {
+			pwm, ok := pwms[channel]
+			if !ok {
+				pwm = p.pwms[channel]
+			}
+			buf = append(buf, byte(pwm.On), byte(pwm.On>>8), byte(pwm.Off), byte(pwm.Off>>8))
+		}
+		if _, err = connection.Write(buf); err != nil {
+			return
+		}
+		for channel := first; channel <= last; channel++ {
+			if pwm, ok := pwms[channel]; ok {
+				p.pwms[channel] = pwm
+			}
+		}
+	}
+	return
+}
+
+// SetAllPWM sets all channels of all boards at once
+func (p *PCA9685Driver) SetAllPWM(on uint16, off uint16) (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for board, connection := range p.connections {
+		if _, err = connection.Write([]byte{PCA9685_ALLLED_ON_L, byte(on), byte(on >> 8), byte(off), byte(off >> 8)}); err != nil {
+			return
+		}
+		for i := 0; i < pca9685Channels; i++ {
+			p.pwms[board*pca9685Channels+i] = PCA9685PWM{On: on, Off: off}
+		}
+	}
 	return
 }
 
-// SetPWMFreq sets the PWM frequency in Hz
+// PWM returns the values last written to a channel
+func (p *PCA9685Driver) PWM(channel int) (pwm PCA9685PWM, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if channel < 0 || channel >= len(p.pwms) {
+		return pwm, ErrInvalidPin
+	}
+	return p.pwms[channel], nil
+}
+
+// SetPWMFreq sets the PWM frequency in Hz of all boards
 func (p *PCA9685Driver) SetPWMFreq(freq float32) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.frequency = freq
+	for _, connection := range p.connections {
+		if err := p.setPWMFreq(connection, freq); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PCA9685Driver) setPWMFreq(connection Connection, freq float32) error {
 	freq *= 0.9
 
 	var prescalevel float32 = 25000000
@@ -112,39 +312,52 @@ func (p *PCA9685Driver) SetPWMFreq(freq float32) error {
 	prescalevel -= 1
 	prescale := byte(prescalevel + 0.5)
 
-	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1)}); err != nil {
+	if _, err := connection.Write([]byte{byte(PCA9685_MODE1)}); err != nil {
 		return err
 	}
 	data := make([]byte, 1)
-	oldmode, err := p.connection.Read(data)
+	oldmode, err := connection.Read(data)
 	if err != nil {
 		return err
 	}
 
 	newmode := (oldmode & 0x7F) | 0x10
-	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), byte(newmode)}); err != nil {
+	if _, err := connection.Write([]byte{byte(PCA9685_MODE1), byte(newmode)}); err != nil {
 		return err
 	}
 
-	if _, err := p.connection.Write([]byte{byte(PCA9685_PRESCALE), prescale}); err != nil {
+	if _, err := connection.Write([]byte{byte(PCA9685_PRESCALE), prescale}); err != nil {
 		return err
 	}
 
-	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), byte(oldmode)}); err != nil {
+	if _, err := connection.Write([]byte{byte(PCA9685_MODE1), byte(oldmode)}); err != nil {
 		return err
 	}
 
 	time.Sleep(100 * time.Millisecond)
-	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), byte(oldmode | 0xa1)}); err != nil {
+	if _, err := connection.Write([]byte{byte(PCA9685_MODE1), byte(oldmode | 0xa1)}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// DigitalWrite turns the specified pin fully on for level 1 and fully off
+// for level 0
+func (p *PCA9685Driver) DigitalWrite(pin string, level byte) (err error) {
+	i, err := p.channel(pin)
+	if err != nil {
+		return
+	}
+	if level == 0 {
+		return p.SetPWM(i, 0, pca9685Full)
+	}
+	return p.SetPWM(i, pca9685Full, 0)
+}
+
 // PwmWrite writes a PWM signal to the specified pin
 func (p *PCA9685Driver) PwmWrite(pin string, val byte) (err error) {
-	i, err := strconv.Atoi(pin)
+	i, err := p.channel(pin)
 	if err != nil {
 		return
 	}
@@ -152,13 +365,110 @@ func (p *PCA9685Driver) PwmWrite(pin string, val byte) (err error) {
 	return p.SetPWM(i, 0, uint16(v))
 }
 
+// ServoPulseWrite writes a servo pulse of the width in microseconds to the
+// specified pin, at the frequency set by SetPWMFreq
+func (p *PCA9685Driver) ServoPulseWrite(pin string, us uint16) (err error) {
+	i, err := p.channel(pin)
+	if err != nil {
+		return
+	}
+	return p.SetPWM(i, 0, p.pulse(us))
+}
+
 // ServoWrite writes a servo signal to the specified pin.
-// Valid values are from 0-180.
+// Valid values are from 0-180, which are mapped to the pulse widths set by
+// SetServoCalibration for the pin.
 func (p *PCA9685Driver) ServoWrite(pin string, val byte) (err error) {
-	i, err := strconv.Atoi(pin)
+	i, err := p.channel(pin)
 	if err != nil {
 		return
 	}
+	p.mutex.Lock()
+	servo, ok := p.servos[i]
+	p.mutex.Unlock()
+	if ok {
+		us := gobot.ToScale(gobot.FromScale(float64(val), 0, 180), float64(servo.min), float64(servo.max))
+		return p.SetPWM(i, 0, p.pulse(uint16(us+0.5)))
+	}
 	v := gobot.ToScale(gobot.FromScale(float64(val), 0, 180), 200, 500)
 	return p.SetPWM(i, 0, uint16(v))
 }
+
+// SetServoCalibration sets the pulse widths in microseconds of the servo at
+// the specified pin at 0 and 180 degrees, to which ServoWrite maps the
+// angles, e.g. 500 and 2500. Without one the pulse widths are about 980 and
+// 2440 at 50 Hz.
+func (p *PCA9685Driver) SetServoCalibration(pin string, min uint16, max uint16) (err error) {
+	i, err := p.channel(pin)
+	if err != nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.servos[i] = pca9685Servo{min: min, max: max}
+	return
+}
+
+// Animate plays an animation of the duty cycle of the channels, with one
+// value per channel in each keyframe, replacing the animation which is
+// running. It is played at 50 frames per second, and the channels of each
+// frame are set at once.
+func (p *PCA9685Driver) Animate(a *gobot.Animation, channels ...int) error {
+	var animator *gobot.Animator
+	animator = gobot.NewAnimator(len(channels), func(values []float64) error {
+		pwms := map[int]PCA9685PWM{}
+		for i, channel := range channels {
+			v := gobot.ToScale(gobot.FromScale(values[i], 0, 255), 0, 4096)
+			pwms[channel] = PCA9685PWM{Off: uint16(v)}
+		}
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		// an animation which is replaced by a concurrent Animate stops
+		if p.animator != animator {
+			return errPCA9685AnimationReplaced
+		}
+		return p.setPWMs(pwms)
+	})
+
+	p.mutex.Lock()
+	running := p.animator
+	p.animator = animator
+	p.mutex.Unlock()
+
+	if running != nil {
+		running.Stop()
+	}
+	return animator.Play(a)
+}
+
+// StopAnimation stops the animation which is running
+func (p *PCA9685Driver) StopAnimation() {
+	p.mutex.Lock()
+	running := p.animator
+	p.animator = nil
+	p.mutex.Unlock()
+
+	if running != nil {
+		running.Stop()
+	}
+}
+
+// channel returns the channel of a pin
+func (p *PCA9685Driver) channel(pin string) (int, error) {
+	i, err := strconv.Atoi(pin)
+	if err != nil || i < 0 || i >= len(p.pwms) {
+		return 0, ErrInvalidPin
+	}
+	return i, nil
+}
+
+// pulse returns the off count of a servo pulse of the width in microseconds
+func (p *PCA9685Driver) pulse(us uint16) uint16 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	v := float32(us) * p.frequency * 4096 / 1000000
+	if v > 4095 {
+		v = 4095
+	}
+	return uint16(v + 0.5)
+}
@@ -3,7 +3,9 @@ package i2c
 import (
 	"errors"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/drivers/gpio"
@@ -13,9 +15,11 @@ import (
 // ensure that PCA9685Driver fulfills Gobot Driver interface
 var _ gobot.Driver = (*PCA9685Driver)(nil)
 
-// and also the PwmWriter and ServoWriter interfaces
+// and also the DigitalWriter, PwmWriter and ServoWriter interfaces
+var _ gpio.DigitalWriter = (*PCA9685Driver)(nil)
 var _ gpio.PwmWriter = (*PCA9685Driver)(nil)
 var _ gpio.ServoWriter = (*PCA9685Driver)(nil)
+var _ gpio.ServoPulseWriter = (*PCA9685Driver)(nil)
 
 // --------- HELPERS
 func initTestPCA9685Driver() (driver *PCA9685Driver) {
@@ -93,6 +97,68 @@ func TestPCA9685DriverSetPWMError(t *testing.T) {
 	gobottest.Assert(t, pca.SetPWM(0, 0, 256), errors.New("write error"))
 }
 
+func TestPCA9685DriverServoPulseWrite(t *testing.T) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	gobottest.Assert(t, pca.Start(), nil)
+
+	var written []byte
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = b
+		return len(b), nil
+	}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return len(b), nil
+	}
+	gobottest.Assert(t, pca.SetPWMFreq(50), nil)
+
+	// 1500us of a 20ms period is 307 of 4096 steps
+	gobottest.Assert(t, pca.ServoPulseWrite("1", 1500), nil)
+	gobottest.Assert(t, written, []byte{PCA9685_LED0_ON_L + 4, 0, 0, 0x33, 0x01})
+	gobottest.Refute(t, pca.ServoPulseWrite("x", 1500), nil)
+	gobottest.Assert(t, pca.ServoPulseWrite("16", 1500), ErrInvalidPin)
+}
+
+func TestPCA9685DriverAnimate(t *testing.T) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	gobottest.Assert(t, pca.Start(), nil)
+
+	written := make(chan []byte, 10)
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written <- b
+		return len(b), nil
+	}
+	a := gobot.NewFade([]float64{255, 0}, []float64{255, 0}, 0, nil)
+	gobottest.Assert(t, pca.Animate(a, 3, 4), nil)
+	gobottest.Assert(t, <-written, []byte{PCA9685_LED0_ON_L + 12, 0, 0, 0x00, 0x10, 0, 0, 0, 0})
+
+	gobottest.Refute(t, pca.Animate(a, 3), nil)
+	pca.StopAnimation()
+}
+
+func TestPCA9685DriverConcurrentAnimate(t *testing.T) {
+	pca, _ := initTestPCA9685DriverWithStubbedAdaptor()
+	gobottest.Assert(t, pca.Start(), nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(channel int) {
+			defer wg.Done()
+			pca.Animate(gobot.NewBreathing([]float64{255}, time.Second), channel)
+		}(i)
+	}
+	wg.Wait()
+	time.Sleep(10 * time.Millisecond)
+
+	// only the animation which was played last is running
+	pca.mutex.Lock()
+	animator := pca.animator
+	pca.mutex.Unlock()
+	gobottest.Assert(t, animator.Running(), true)
+	gobottest.Assert(t, pca.Halt(), nil)
+	gobottest.Assert(t, animator.Running(), false)
+}
+
 func TestPCA9685DriverSetPWMFreq(t *testing.T) {
 	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
 	gobottest.Assert(t, pca.Start(), nil)
@@ -129,3 +195,144 @@ func TestPCA9685DriverSetName(t *testing.T) {
 	pca.SetName("TESTME")
 	gobottest.Assert(t, pca.Name(), "TESTME")
 }
+
+func TestPCA9685DriverStartAutoIncrement(t *testing.T) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	gobottest.Assert(t, pca.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{PCA9685_MODE1, 0x20, PCA9685_ALLLED_OFF_H, 0x10})
+
+	pwm, err := pca.PWM(15)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, pwm, PCA9685PWM{On: 0, Off: 0x1000})
+	_, err = pca.PWM(16)
+	gobottest.Assert(t, err, ErrInvalidPin)
+}
+
+func TestPCA9685DriverDigitalWrite(t *testing.T) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	gobottest.Assert(t, pca.Start(), nil)
+
+	var written []byte
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = b
+		return len(b), nil
+	}
+	gobottest.Assert(t, pca.DigitalWrite("2", 1), nil)
+	gobottest.Assert(t, written, []byte{PCA9685_LED0_ON_L + 8, 0, 0x10, 0, 0})
+	gobottest.Assert(t, pca.DigitalWrite("2", 0), nil)
+	gobottest.Assert(t, written, []byte{PCA9685_LED0_ON_L + 8, 0, 0, 0, 0x10})
+	gobottest.Assert(t, pca.DigitalWrite("-1", 1), ErrInvalidPin)
+}
+
+func TestPCA9685DriverSetPWMs(t *testing.T) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	gobottest.Assert(t, pca.Start(), nil)
+
+	var written []byte
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = b
+		return len(b), nil
+	}
+	gobottest.Assert(t, pca.SetPWM(2, 0, 0x0100), nil)
+	gobottest.Assert(t, pca.SetPWMs(map[int]PCA9685PWM{1: {On: 0x10, Off: 0x0200}, 3: {Off: 0x0300}}), nil)
+	// the channel in between is written with its last values
+	gobottest.Assert(t, written, []byte{
+		PCA9685_LED0_ON_L + 4,
+		0x10, 0, 0, 0x02,
+		0, 0, 0, 0x01,
+		0, 0, 0, 0x03,
+	})
+	pwm, _ := pca.PWM(1)
+	gobottest.Assert(t, pwm, PCA9685PWM{On: 0x10, Off: 0x0200})
+
+	gobottest.Assert(t, pca.SetPWMs(map[int]PCA9685PWM{16: {}}), ErrInvalidPin)
+
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, pca.SetPWMs(map[int]PCA9685PWM{1: {Off: 0x0400}}), errors.New("write error"))
+	pwm, _ = pca.PWM(1)
+	gobottest.Assert(t, pwm, PCA9685PWM{On: 0x10, Off: 0x0200})
+}
+
+func TestPCA9685DriverSetAllPWM(t *testing.T) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	gobottest.Assert(t, pca.Start(), nil)
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pca.SetAllPWM(0, 0x0800), nil)
+	gobottest.Assert(t, adaptor.written, []byte{PCA9685_ALLLED_ON_L, 0, 0, 0, 0x08})
+	pwm, _ := pca.PWM(9)
+	gobottest.Assert(t, pwm, PCA9685PWM{Off: 0x0800})
+
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, pca.SetAllPWM(0, 0), errors.New("write error"))
+}
+
+func TestPCA9685DriverServoCalibration(t *testing.T) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	gobottest.Assert(t, pca.Start(), nil)
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return len(b), nil
+	}
+	gobottest.Assert(t, pca.SetPWMFreq(50), nil)
+
+	var written []byte
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = b
+		return len(b), nil
+	}
+	// uncalibrated servos keep the fixed mapping
+	gobottest.Assert(t, pca.ServoWrite("0", 90), nil)
+	gobottest.Assert(t, written, []byte{PCA9685_LED0_ON_L, 0, 0, 0x5E, 0x01})
+
+	gobottest.Assert(t, pca.SetServoCalibration("0", 500, 2500), nil)
+	// 1500us of a 20ms period is 307 of 4096 steps
+	gobottest.Assert(t, pca.ServoWrite("0", 90), nil)
+	gobottest.Assert(t, written, []byte{PCA9685_LED0_ON_L, 0, 0, 0x33, 0x01})
+	// 2500us is 512 steps
+	gobottest.Assert(t, pca.ServoWrite("0", 180), nil)
+	gobottest.Assert(t, written, []byte{PCA9685_LED0_ON_L, 0, 0, 0x00, 0x02})
+
+	gobottest.Assert(t, pca.Command("SetServoCalibration")(map[string]interface{}{"pin": "1", "min": 1000.0, "max": 2000.0}), nil)
+	gobottest.Assert(t, pca.ServoWrite("1", 90), nil)
+	gobottest.Assert(t, written, []byte{PCA9685_LED0_ON_L + 4, 0, 0, 0x33, 0x01})
+
+	gobottest.Assert(t, pca.SetServoCalibration("x", 500, 2500), ErrInvalidPin)
+}
+
+func TestPCA9685DriverChain(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	pca := NewPCA9685Driver(adaptor, WithPCA9685Chain(0x41))
+	gobottest.Assert(t, pca.Start(), nil)
+	gobottest.Assert(t, len(pca.connections), 2)
+
+	var written [][]byte
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = append(written, b)
+		return len(b), nil
+	}
+	gobottest.Assert(t, pca.SetPWMs(map[int]PCA9685PWM{15: {Off: 0x0100}, 16: {Off: 0x0200}}), nil)
+	gobottest.Assert(t, written, [][]byte{
+		{PCA9685_LED0_ON_L + 60, 0, 0, 0, 0x01},
+		{PCA9685_LED0_ON_L, 0, 0, 0, 0x02},
+	})
+
+	written = nil
+	gobottest.Assert(t, pca.DigitalWrite("31", 1), nil)
+	gobottest.Assert(t, written, [][]byte{{PCA9685_LED0_ON_L + 60, 0, 0x10, 0, 0}})
+	gobottest.Assert(t, pca.DigitalWrite("32", 1), ErrInvalidPin)
+
+	written = nil
+	gobottest.Assert(t, pca.Halt(), nil)
+	gobottest.Assert(t, len(written), 2)
+}
+
+func TestPCA9685DriverChainStartConnectError(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	pca := NewPCA9685Driver(adaptor, WithPCA9685Chain(0x41, 0x42))
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, pca.Start(), errors.New("Invalid i2c connection"))
+}
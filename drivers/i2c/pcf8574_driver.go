@@ -0,0 +1,222 @@
+package i2c
+
+import (
+	"sync"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+const pcf8574Address = 0x20
+
+// PCF8574Driver is a driver for the PCF8574 i2c port expander with 8 pins.
+//
+// It implements gpio.DigitalReader and gpio.DigitalWriter for the pins "0" to
+// "7", so that it can be the connection of gpio drivers.
+type PCF8574Driver struct {
+	*pcf857xDriver
+}
+
+// NewPCF8574Driver creates a new driver for the PCF8574. The PCF8574A has
+// its addresses from 0x38 instead of 0x20.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithInterruptPin(gpio.DigitalReader, string):	pin the INT output is connected to
+//
+func NewPCF8574Driver(a Connector, options ...func(Config)) *PCF8574Driver {
+	d := &PCF8574Driver{newPCF857xDriver(a, "PCF8574", 1)}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// pcf857xDriver drives the PCF8574 and PCF8575. Their pins are
+// quasi-bidirectional, a pin written high is only pulled up weakly and can be
+// read as an input.
+type pcf857xDriver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	ports     int
+	mutex     *sync.Mutex
+	latch     uint16 // levels written to the pins, high for inputs
+	inputs    uint16 // pins read by DigitalRead
+	levels    uint16 // levels of the pins at the last read
+	interrupt expanderInterrupt
+	gobot.Eventer
+}
+
+func newPCF857xDriver(a Connector, name string, ports int) *pcf857xDriver {
+	p := &pcf857xDriver{
+		name:      gobot.DefaultName(name),
+		connector: a,
+		Config:    NewConfig(),
+		ports:     ports,
+		mutex:     &sync.Mutex{},
+		latch:     0xffff,
+		Eventer:   gobot.NewEventer(),
+	}
+
+	p.AddEvent(PinChange)
+	p.AddEvent(Error)
+
+	return p
+}
+
+// Name returns the name of the device.
+func (p *pcf857xDriver) Name() string { return p.name }
+
+// SetName sets the name of the device.
+func (p *pcf857xDriver) SetName(n string) { p.name = n }
+
+// Connection returns the connection of the device.
+func (p *pcf857xDriver) Connection() gobot.Connection { return p.connector.(gobot.Connection) }
+
+// Connect implements the Connection interface, so that gpio drivers can
+// claim the pins of the expander. The expander itself is started as a driver.
+func (p *pcf857xDriver) Connect() (err error) { return }
+
+// Finalize implements the Connection interface
+func (p *pcf857xDriver) Finalize() (err error) { return }
+
+// Start sets all pins high, which makes them inputs, and starts watching the
+// interrupt pin if one is set.
+//
+// Emits the Events:
+//	PinChange PinLevel - When an input changes, if an interrupt pin is set
+//	Error error - When the inputs can not be read after an interrupt
+func (p *pcf857xDriver) Start() (err error) {
+	bus := p.GetBusOrDefault(p.connector.GetDefaultBus())
+	address := p.GetAddressOrDefault(pcf8574Address)
+
+	p.connection, err = claimConnection(p.connector, p, address, bus)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	p.latch = 0xffff
+	err = p.writeLatch()
+	if err == nil {
+		p.levels, err = p.readLevels()
+	}
+	p.mutex.Unlock()
+	if err != nil {
+		return
+	}
+
+	if p.interrupt.enabled() {
+		err = p.interrupt.watch(p.update, func(err error) { p.Publish(Error, err) })
+	}
+	return
+}
+
+// Halt stops watching the interrupt pin.
+func (p *pcf857xDriver) Halt() (err error) {
+	p.interrupt.stop()
+	releaseConnections(p.connector, p)
+	return
+}
+
+// DigitalWrite writes the level to the pin. A pin written high can be read
+// as an input again.
+func (p *pcf857xDriver) DigitalWrite(pin string, level byte) (err error) {
+	bit, err := expanderPin(pin, p.ports)
+	if err != nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if level == 0 {
+		p.latch &^= 1 << bit
+	} else {
+		p.latch |= 1 << bit
+	}
+	p.inputs &^= 1 << bit
+	return p.writeLatch()
+}
+
+// DigitalRead reads the level of the pin, which is written high first if it
+// was written low. With an interrupt pin the level is the one read at the
+// last interrupt.
+func (p *pcf857xDriver) DigitalRead(pin string) (val int, err error) {
+	bit, err := expanderPin(pin, p.ports)
+	if err != nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.inputs&(1<<bit) == 0 {
+		if p.latch&(1<<bit) == 0 {
+			p.latch |= 1 << bit
+			if err = p.writeLatch(); err != nil {
+				return
+			}
+		}
+		p.inputs |= 1 << bit
+	} else if p.interrupt.enabled() {
+		return int(p.levels>>bit) & 1, nil
+	}
+
+	if p.levels, err = p.readLevels(); err != nil {
+		return
+	}
+	return int(p.levels>>bit) & 1, nil
+}
+
+// update reads the inputs after an interrupt and publishes those which
+// changed
+func (p *pcf857xDriver) update() error {
+	p.mutex.Lock()
+	levels, err := p.readLevels()
+	if err != nil {
+		p.mutex.Unlock()
+		return err
+	}
+	changes := changedPins(p.levels, levels, p.inputs, p.ports)
+	p.levels = levels
+	p.mutex.Unlock()
+
+	for _, change := range changes {
+		p.Publish(PinChange, change)
+	}
+	return nil
+}
+
+func (p *pcf857xDriver) writeLatch() (err error) {
+	if p.ports == 1 {
+		return p.connection.WriteByte(byte(p.latch))
+	}
+	_, err = p.connection.Write([]byte{byte(p.latch), byte(p.latch >> 8)})
+	return
+}
+
+func (p *pcf857xDriver) readLevels() (uint16, error) {
+	if p.ports == 1 {
+		val, err := p.connection.ReadByte()
+		return uint16(val), err
+	}
+	buf := []byte{0, 0}
+	n, err := p.connection.Read(buf)
+	if err != nil {
+		return 0, err
+	}
+	if n != len(buf) {
+		return 0, ErrNotEnoughBytes
+	}
+	return uint16(buf[0]) | uint16(buf[1])<<8, nil
+}
+
+func (p *pcf857xDriver) setInterrupt(reader gpio.DigitalReader, pin string) {
+	p.interrupt.reader, p.interrupt.pin = reader, pin
+}
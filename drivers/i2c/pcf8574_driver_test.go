@@ -0,0 +1,149 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*PCF8574Driver)(nil)
+var _ gobot.Connection = (*PCF8574Driver)(nil)
+var _ gpio.DigitalReader = (*PCF8574Driver)(nil)
+var _ gpio.DigitalWriter = (*PCF8574Driver)(nil)
+
+// simulatePCF857x makes the adaptor behave like a PCF8574 or PCF8575 whose
+// pins are pulled low externally where inputs is 0, and returns the latch
+// and a function which sets the inputs
+func simulatePCF857x(a *i2cTestAdaptor) (latch *[]byte, set func(inputs ...byte)) {
+	latch = &[]byte{}
+	inputs := []byte{0xff, 0xff}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		for i := range b {
+			b[i] = inputs[i]
+			if i < len(*latch) {
+				b[i] &= (*latch)[i]
+			}
+		}
+		return len(b), nil
+	}
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		*latch = append([]byte{}, b...)
+		return len(b), nil
+	}
+	return latch, func(levels ...byte) {
+		a.mtx.Lock()
+		defer a.mtx.Unlock()
+		copy(inputs, levels)
+	}
+}
+
+func initTestPCF8574DriverWithStubbedAdaptor() (*PCF8574Driver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	return NewPCF8574Driver(adaptor), adaptor
+}
+
+func TestNewPCF8574Driver(t *testing.T) {
+	d := NewPCF8574Driver(newI2cTestAdaptor(), WithAddress(0x38))
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.GetAddressOrDefault(pcf8574Address), 0x38)
+	gobottest.Assert(t, d.Connect(), nil)
+	gobottest.Assert(t, d.Finalize(), nil)
+
+	d.SetName("expander")
+	gobottest.Assert(t, d.Name(), "expander")
+}
+
+func TestPCF8574DriverStart(t *testing.T) {
+	d, adaptor := initTestPCF8574DriverWithStubbedAdaptor()
+	latch, _ := simulatePCF857x(adaptor)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, *latch, []byte{0xff})
+	gobottest.Assert(t, d.Halt(), nil)
+
+	adaptor.Testi2cWriteImpl(func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	})
+	gobottest.Assert(t, d.Start(), errors.New("write error"))
+
+	d, adaptor = initTestPCF8574DriverWithStubbedAdaptor()
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestPCF8574DriverDigitalWrite(t *testing.T) {
+	d, adaptor := initTestPCF8574DriverWithStubbedAdaptor()
+	latch, _ := simulatePCF857x(adaptor)
+	gobottest.Assert(t, d.Start(), nil)
+
+	gobottest.Assert(t, d.DigitalWrite("3", 0), nil)
+	gobottest.Assert(t, *latch, []byte{0xf7})
+	gobottest.Assert(t, d.DigitalWrite("P6", 0), nil)
+	gobottest.Assert(t, *latch, []byte{0xb7})
+	gobottest.Assert(t, d.DigitalWrite("3", 1), nil)
+	gobottest.Assert(t, *latch, []byte{0xbf})
+	gobottest.Assert(t, d.DigitalWrite("8", 1), ErrInvalidPin)
+}
+
+func TestPCF8574DriverDigitalRead(t *testing.T) {
+	d, adaptor := initTestPCF8574DriverWithStubbedAdaptor()
+	latch, set := simulatePCF857x(adaptor)
+	gobottest.Assert(t, d.Start(), nil)
+
+	set(0xfe)
+	val, err := d.DigitalRead("0")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0)
+	val, _ = d.DigitalRead("1")
+	gobottest.Assert(t, val, 1)
+
+	// a pin written low is released before it is read
+	d.DigitalWrite("1", 0)
+	gobottest.Assert(t, *latch, []byte{0xfd})
+	val, _ = d.DigitalRead("1")
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, *latch, []byte{0xff})
+
+	adaptor.Testi2cReadImpl(func([]byte) (int, error) {
+		return 0, errors.New("read error")
+	})
+	_, err = d.DigitalRead("1")
+	gobottest.Assert(t, err, errors.New("read error"))
+	_, err = d.DigitalRead("Q")
+	gobottest.Assert(t, err, ErrInvalidPin)
+}
+
+func TestPCF8574DriverInterrupt(t *testing.T) {
+	interrupt := &testInterruptPin{level: 1}
+	adaptor := newI2cTestAdaptor()
+	d := NewPCF8574Driver(adaptor, WithInterruptPin(interrupt, "22"))
+	_, set := simulatePCF857x(adaptor)
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	val, _ := d.DigitalRead("4")
+	gobottest.Assert(t, val, 1)
+
+	changes := make(chan PinLevel, 2)
+	d.On(PinChange, func(data interface{}) {
+		changes <- data.(PinLevel)
+	})
+
+	set(0xef)
+	val, _ = d.DigitalRead("4")
+	gobottest.Assert(t, val, 1)
+
+	interrupt.set(0)
+	select {
+	case change := <-changes:
+		gobottest.Assert(t, change, PinLevel{Pin: "4", Level: 0})
+	case <-time.After(time.Second):
+		t.Errorf("PCF8574 Event \"PinChange\" was not published")
+	}
+	interrupt.set(1)
+	val, _ = d.DigitalRead("4")
+	gobottest.Assert(t, val, 0)
+}
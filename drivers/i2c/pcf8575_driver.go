@@ -0,0 +1,29 @@
+package i2c
+
+// PCF8575Driver is a driver for the PCF8575 i2c port expander with 16 pins.
+//
+// It implements gpio.DigitalReader and gpio.DigitalWriter for the pins "A0"
+// to "B7", which are P00 to P17 of the datasheet, so that it can be the
+// connection of gpio drivers.
+type PCF8575Driver struct {
+	*pcf857xDriver
+}
+
+// NewPCF8575Driver creates a new driver for the PCF8575.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithInterruptPin(gpio.DigitalReader, string):	pin the INT output is connected to
+//
+func NewPCF8575Driver(a Connector, options ...func(Config)) *PCF8575Driver {
+	d := &PCF8575Driver{newPCF857xDriver(a, "PCF8575", 2)}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
@@ -0,0 +1,42 @@
+package i2c
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*PCF8575Driver)(nil)
+var _ gobot.Connection = (*PCF8575Driver)(nil)
+
+func TestPCF8575Driver(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	d := NewPCF8575Driver(adaptor)
+	latch, set := simulatePCF857x(adaptor)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, *latch, []byte{0xff, 0xff})
+
+	gobottest.Assert(t, d.DigitalWrite("B2", 0), nil)
+	gobottest.Assert(t, *latch, []byte{0xff, 0xfb})
+	gobottest.Assert(t, d.DigitalWrite("8", 0), ErrInvalidPin)
+
+	set(0xff, 0x7f)
+	val, err := d.DigitalRead("B7")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0)
+	val, _ = d.DigitalRead("A7")
+	gobottest.Assert(t, val, 1)
+
+	relay := gpio.NewRelayDriver(d, "A0")
+	gobottest.Assert(t, relay.Start(), nil)
+	gobottest.Assert(t, relay.Off(), nil)
+	gobottest.Assert(t, *latch, []byte{0xfe, 0xfb})
+
+	adaptor.Testi2cReadImpl(func(b []byte) (int, error) {
+		return 1, nil
+	})
+	_, err = d.DigitalRead("B7")
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+}
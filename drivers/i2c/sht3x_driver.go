@@ -105,12 +105,15 @@ func (s *SHT3xDriver) Start() (err error) {
 	bus := s.GetBusOrDefault(s.connector.GetDefaultBus())
 	address := s.GetAddressOrDefault(s.sht3xAddress)
 
-	s.connection, err = s.connector.GetConnection(address, bus)
+	s.connection, err = claimConnection(s.connector, s, address, bus)
 	return
 }
 
 // Halt returns true if devices is halted successfully
-func (s *SHT3xDriver) Halt() (err error) { return }
+func (s *SHT3xDriver) Halt() (err error) {
+	releaseConnections(s.connector, s)
+	return
+}
 
 // SetAddress sets the address of the device
 func (s *SHT3xDriver) SetAddress(address int) { s.sht3xAddress = address }
@@ -197,6 +200,26 @@ func (s *SHT3xDriver) Sample() (temp float32, rh float32, err error) {
 	return
 }
 
+// ReadTemperature returns the temperature of one sample in degrees Celsius,
+// whatever the Units. It implements environment.Thermometer.
+func (s *SHT3xDriver) ReadTemperature() (float64, error) {
+	temp, _, err := s.Sample()
+	if err != nil {
+		return 0, err
+	}
+	if s.Units == "F" {
+		return (float64(temp) - 32) / 1.8, nil
+	}
+	return float64(temp), nil
+}
+
+// ReadHumidity returns the relative humidity of one sample. It implements
+// environment.Hygrometer.
+func (s *SHT3xDriver) ReadHumidity() (float64, error) {
+	_, rh, err := s.Sample()
+	return float64(rh), err
+}
+
 // getStatusRegister returns the device status register
 func (s *SHT3xDriver) getStatusRegister() (status uint16, err error) {
 	ret, err := s.sendCommandDelayGetResponse([]byte{0xf3, 0x2d}, nil, 1)
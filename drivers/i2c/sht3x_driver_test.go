@@ -2,15 +2,21 @@ package i2c
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*SHT3xDriver)(nil)
 
+var _ environment.Thermometer = (*SHT3xDriver)(nil)
+
+var _ environment.Hygrometer = (*SHT3xDriver)(nil)
+
 // --------- HELPERS
 func initTestSHT3xDriver() (driver *SHT3xDriver) {
 	driver, _ = initTestSHT3xDriverWithStubbedAdaptor()
@@ -109,6 +115,34 @@ func TestSHT3xDriverSampleNormal(t *testing.T) {
 	gobottest.Assert(t, temp, float32(185.9414))
 }
 
+func TestSHT3xDriverReadTemperatureHumidity(t *testing.T) {
+	sht3x, adaptor := initTestSHT3xDriverWithStubbedAdaptor()
+
+	gobottest.Assert(t, sht3x.Start(), nil)
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0xbe, 0xef, 0x92, 0xbe, 0xef, 0x92})
+		return 6, nil
+	}
+
+	celsius, err := sht3x.ReadTemperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, celsius, float64(float32(85.523003)))
+	rh, err := sht3x.ReadHumidity()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, rh, float64(float32(74.5845)))
+
+	// the temperature is in Celsius with the units in F
+	sht3x.Units = "F"
+	celsius, err = sht3x.ReadTemperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, fmt.Sprintf("%.2f", celsius), "85.52")
+
+	sht3x.Units = "K"
+	_, err = sht3x.ReadTemperature()
+	gobottest.Assert(t, err, ErrInvalidTemp)
+}
+
 func TestSHT3xDriverSampleBadCrc(t *testing.T) {
 	sht3x, adaptor := initTestSHT3xDriverWithStubbedAdaptor()
 
@@ -1,7 +1,12 @@
 package i2c
 
 import (
+	"image"
+	"image/color"
+	"time"
+
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 )
 
 const ssd1306I2CAddress = 0x3c
@@ -46,30 +51,42 @@ const ssd1306SetDisplayOffset = 0xD3
 const ssd1306SetPrechargePeriod = 0xD9
 const ssd1306SetVComDeselectLevel = 0xDB
 
-var ssd1306InitSequence []byte = []byte{
-	ssd1306SetDisplayNormal,
-	ssd1306SetDisplayOff,
-	ssd1306SetDisplayClock, 0x80, // the suggested ratio 0x80
-	ssd1306SetMultiplexRatio, 0x3F,
-	ssd1306SetDisplayOffset, 0x0, //no offset
-	ssd1306SetStartLine | 0x0, //SETSTARTLINE
-	ssd1306ChargePumpSetting, 0x14,
-	ssd1306SetMemoryAddressingMode, 0x00, //0x0 act like ks0108
-	ssd1306SetSegmentRemap0,
-	ssd1306SetComOutput0,
-	ssd1306SetComPins, 0x12, //COMSCANDEC
-	ssd1306SetContrast, 0xCF,
-	ssd1306SetPrechargePeriod, 0xF1,
-	ssd1306SetVComDeselectLevel, 0x40,
-	ssd1306DisplayOnResumeToRAM,
-	ssd1306SetDisplayNormal,
-	ssd1306StopScroll,
-	ssd1306SetSegmentRemap0,
-	ssd1306SetSegmentRemap127,
-	ssd1306SetComOutput8,
-	ssd1306SetMemoryAddressingMode, 0x00,
-	ssd1306SetContrast, 0xff,
-}
+const (
+	sh1106SetPageAddress   = 0xB0
+	sh1106SetLowColumn     = 0x00
+	sh1106SetHighColumn    = 0x10
+	sh1106SetDCDC          = 0xAD
+	sh1106ColumnRAMWidth   = 132
+	ssd1306ControlCommand  = 0x80
+	ssd1306ControlData     = 0x40
+	ssd1306ComPinsSequence = 0x02
+	ssd1306ComPinsAlt      = 0x12
+)
+
+// SSD1306Rotation is the clockwise rotation of the image on an SSD1306
+// display.
+type SSD1306Rotation int
+
+const (
+	// SSD1306Rotation0 shows the image upright.
+	SSD1306Rotation0 SSD1306Rotation = iota
+	// SSD1306Rotation90 turns the image by 90 degrees, it is as high as the
+	// display is wide.
+	SSD1306Rotation90
+	// SSD1306Rotation180 turns the image upside down.
+	SSD1306Rotation180
+	// SSD1306Rotation270 turns the image by 270 degrees, it is as high as the
+	// display is wide.
+	SSD1306Rotation270
+)
+
+// ssd1306ColorModel converts colors to the black or white of the display
+var ssd1306ColorModel = color.ModelFunc(func(c color.Color) color.Color {
+	if color.GrayModel.Convert(c).(color.Gray).Y >= 0x80 {
+		return color.White
+	}
+	return color.Black
+})
 
 // DisplayBuffer represents the display buffer intermediate memory
 type DisplayBuffer struct {
@@ -109,7 +126,10 @@ func (s *DisplayBuffer) Set(x, y, c int) {
 	}
 }
 
-// SSD1306Driver is a Gobot Driver for a SSD1306 Display
+// SSD1306Driver is a Gobot Driver for a SSD1306 Display, or a SH1106 one,
+// connected by I2C or SPI. It is a draw.Image, so it can be drawn on with
+// the image/draw package and DrawText, and Display sends the region changed
+// since the last Display to the display.
 type SSD1306Driver struct {
 	name       string
 	connector  Connector
@@ -117,10 +137,27 @@ type SSD1306Driver struct {
 	Config
 	gobot.Commander
 
-	Buffer *DisplayBuffer
+	Buffer   *DisplayBuffer
+	width    int
+	height   int
+	rotation SSD1306Rotation
+	sh1106   bool
+	spi      *ssd1306SPI
+	resetPin string
+	dirty    image.Rectangle
 }
 
-// NewSSD1306Driver creates a new SSD1306Driver.
+// ssd1306SPI is the 4-wire SPI bus of a SSD1306, which is driven by pins of
+// an adaptor
+type ssd1306SPI struct {
+	connection gpio.DigitalWriter
+	csPin      string
+	dcPin      string
+	clockPin   string
+	dataPin    string
+}
+
+// NewSSD1306Driver creates a new SSD1306Driver connected by I2C.
 //
 // Params:
 //        conn Connector - the Adaptor to use with this Driver
@@ -128,6 +165,10 @@ type SSD1306Driver struct {
 // Optional params:
 //        WithBus(int):    bus to use with this driver
 //        WithAddress(int):    address to use with this driver
+//        WithSSD1306DisplayWidth(int):    width of the display, 128 by default
+//        WithSSD1306DisplayHeight(int):    height of the display, 64 by default
+//        WithSSD1306Rotation(SSD1306Rotation):    rotation of the image
+//        WithSH1106():    the display has a SH1106 controller
 //
 func NewSSD1306Driver(a Connector, options ...func(Config)) *SSD1306Driver {
 	s := &SSD1306Driver{
@@ -135,13 +176,17 @@ func NewSSD1306Driver(a Connector, options ...func(Config)) *SSD1306Driver {
 		Commander: gobot.NewCommander(),
 		connector: a,
 		Config:    NewConfig(),
-		Buffer:    NewDisplayBuffer(ssd1306Width, ssd1306Height),
+		width:     ssd1306Width,
+		height:    ssd1306Height,
 	}
 
 	for _, option := range options {
 		option(s)
 	}
 
+	s.Buffer = NewDisplayBuffer(s.width, s.height)
+	s.dirty = image.Rect(0, 0, s.width, s.height)
+
 	s.AddCommand("Display", func(params map[string]interface{}) interface{} {
 		err := s.Display()
 		return map[string]interface{}{"err": err}
@@ -173,13 +218,100 @@ func NewSSD1306Driver(a Connector, options ...func(Config)) *SSD1306Driver {
 		y := int(params["y"].(int))
 		c := int(params["c"].(int))
 
-		s.Set(x, y, c)
+		if c == 0 {
+			s.Set(x, y, color.Black)
+		} else {
+			s.Set(x, y, color.White)
+		}
+		return nil
+	})
+
+	s.AddCommand("Text", func(params map[string]interface{}) interface{} {
+		x, _ := params["x"].(float64)
+		y, _ := params["y"].(float64)
+		text, _ := params["text"].(string)
+
+		DrawText(s, image.Pt(int(x), int(y)), text, Font5x7, color.White)
 		return nil
 	})
 
 	return s
 }
 
+// NewSSD1306SPIDriver creates a new SSD1306Driver connected by 4-wire SPI,
+// whose bus is driven by pins of an adaptor, given a DigitalWriter and the
+// pins connected to its CS, D/C, D0 (clock) and D1 (data) inputs.
+//
+// Optional params:
+//        WithSSD1306ResetPin(string):    pin connected to the RES input
+//        WithSSD1306DisplayWidth(int):    width of the display, 128 by default
+//        WithSSD1306DisplayHeight(int):    height of the display, 64 by default
+//        WithSSD1306Rotation(SSD1306Rotation):    rotation of the image
+//        WithSH1106():    the display has a SH1106 controller
+//
+func NewSSD1306SPIDriver(a gpio.DigitalWriter, csPin string, dcPin string, clockPin string, dataPin string, options ...func(Config)) *SSD1306Driver {
+	s := NewSSD1306Driver(nil, options...)
+	s.spi = &ssd1306SPI{
+		connection: a,
+		csPin:      csPin,
+		dcPin:      dcPin,
+		clockPin:   clockPin,
+		dataPin:    dataPin,
+	}
+	return s
+}
+
+// WithSSD1306DisplayWidth option sets the width of the display in pixels,
+// e.g. 96 for a 96x16 display.
+func WithSSD1306DisplayWidth(val int) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*SSD1306Driver); ok {
+			d.width = val
+		}
+	}
+}
+
+// WithSSD1306DisplayHeight option sets the height of the display in pixels,
+// e.g. 32 for a 128x32 display.
+func WithSSD1306DisplayHeight(val int) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*SSD1306Driver); ok {
+			d.height = val
+		}
+	}
+}
+
+// WithSSD1306Rotation option sets the clockwise rotation of the image on the
+// display.
+func WithSSD1306Rotation(val SSD1306Rotation) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*SSD1306Driver); ok {
+			d.rotation = val
+		}
+	}
+}
+
+// WithSSD1306ResetPin option sets the pin connected to the RES input of a
+// display connected by SPI, which is reset at Start.
+func WithSSD1306ResetPin(val string) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*SSD1306Driver); ok {
+			d.resetPin = val
+		}
+	}
+}
+
+// WithSH1106 option drives a display with a SH1106 controller, whose display
+// memory is 132 columns wide.
+func WithSH1106() func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*SSD1306Driver); ok {
+			d.sh1106 = true
+			d.name = gobot.DefaultName("SH1106")
+		}
+	}
+}
+
 // Name returns the Name for the Driver
 func (s *SSD1306Driver) Name() string { return s.name }
 
@@ -187,41 +319,144 @@ func (s *SSD1306Driver) Name() string { return s.name }
 func (s *SSD1306Driver) SetName(n string) { s.name = n }
 
 // Connection returns the connection for the Driver
-func (s *SSD1306Driver) Connection() gobot.Connection { return s.connector.(gobot.Connection) }
+func (s *SSD1306Driver) Connection() gobot.Connection {
+	if s.spi != nil {
+		return s.spi.connection.(gobot.Connection)
+	}
+	return s.connector.(gobot.Connection)
+}
 
 // Start starts the Driver up, and writes start command
 func (s *SSD1306Driver) Start() (err error) {
-	bus := s.GetBusOrDefault(s.connector.GetDefaultBus())
-	address := s.GetAddressOrDefault(ssd1306I2CAddress)
-
-	s.connection, err = s.connector.GetConnection(address, bus)
+	if s.spi != nil {
+		err = s.startSPI()
+	} else {
+		bus := s.GetBusOrDefault(s.connector.GetDefaultBus())
+		address := s.GetAddressOrDefault(ssd1306I2CAddress)
+		s.connection, err = claimConnection(s.connector, s, address, bus)
+	}
 	if err != nil {
 		return
 	}
 
-	s.Init()
-	s.On()
+	if err = s.Init(); err != nil {
+		return
+	}
+	return s.On()
+}
 
+// startSPI claims the pins of the SPI bus and resets the display
+func (s *SSD1306Driver) startSPI() (err error) {
+	pins := []string{s.spi.csPin, s.spi.dcPin, s.spi.clockPin, s.spi.dataPin}
+	if s.resetPin != "" {
+		pins = append(pins, s.resetPin)
+	}
+	if err = gobot.ClaimResources(s.Connection(), s, gobot.PinResource, pins...); err != nil {
+		return
+	}
+	if err = s.spi.connection.DigitalWrite(s.spi.csPin, 1); err != nil {
+		return
+	}
+	if err = s.spi.connection.DigitalWrite(s.spi.clockPin, 0); err != nil {
+		return
+	}
+	if s.resetPin == "" {
+		return
+	}
+	for _, level := range []byte{1, 0, 1} {
+		if err = s.spi.connection.DigitalWrite(s.resetPin, level); err != nil {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
 	return
 }
 
 // Halt returns true if device is halted successfully
-func (s *SSD1306Driver) Halt() (err error) { return nil }
+func (s *SSD1306Driver) Halt() (err error) {
+	if s.spi != nil {
+		gobot.ReleaseResources(s.Connection(), s)
+		return nil
+	}
+	releaseConnections(s.connector, s)
+	return nil
+}
 
 // Init turns display on
 func (s *SSD1306Driver) Init() (err error) {
-	s.Off()
-	s.commands(ssd1306InitSequence)
+	if err = s.Off(); err != nil {
+		return
+	}
+	if err = s.commands(s.initSequence()); err != nil {
+		return
+	}
+	if s.sh1106 {
+		// the SH1106 is addressed page by page
+		return
+	}
 
-	s.commands([]byte{ssd1306ColumnAddr, 0, // Start at 0,
+	if err = s.commands([]byte{ssd1306ColumnAddr, 0, // Start at 0,
 		byte(s.Buffer.Width) - 1, // End at last column (127?)
-	})
+	}); err != nil {
+		return
+	}
 
-	s.commands([]byte{ssd1306PageAddr, 0, // Start at 0,
+	return s.commands([]byte{ssd1306PageAddr, 0, // Start at 0,
 		(byte(s.Buffer.Height) / ssd1306PageSize) - 1, // End at page 7
 	})
+}
 
-	return nil
+// initSequence returns the commands which set up the controller for the
+// size of the display
+func (s *SSD1306Driver) initSequence() []byte {
+	comPins := byte(ssd1306ComPinsAlt)
+	if s.height != 64 {
+		// 128x32 and 96x16 displays use every other COM pin
+		comPins = ssd1306ComPinsSequence
+	}
+
+	if s.sh1106 {
+		return []byte{
+			ssd1306SetDisplayClock, 0x80,
+			ssd1306SetMultiplexRatio, byte(s.height - 1),
+			ssd1306SetDisplayOffset, 0x0,
+			ssd1306SetStartLine | 0x0,
+			sh1106SetDCDC, 0x8B, // DC-DC converter on
+			ssd1306SetSegmentRemap127,
+			ssd1306SetComOutput8,
+			ssd1306SetComPins, comPins,
+			ssd1306SetContrast, 0xCF,
+			ssd1306SetPrechargePeriod, 0x1F,
+			ssd1306SetVComDeselectLevel, 0x40,
+			ssd1306DisplayOnResumeToRAM,
+			ssd1306SetDisplayNormal,
+		}
+	}
+
+	return []byte{
+		ssd1306SetDisplayNormal,
+		ssd1306SetDisplayOff,
+		ssd1306SetDisplayClock, 0x80, // the suggested ratio 0x80
+		ssd1306SetMultiplexRatio, byte(s.height - 1),
+		ssd1306SetDisplayOffset, 0x0, //no offset
+		ssd1306SetStartLine | 0x0, //SETSTARTLINE
+		ssd1306ChargePumpSetting, 0x14,
+		ssd1306SetMemoryAddressingMode, 0x00, //0x0 act like ks0108
+		ssd1306SetSegmentRemap0,
+		ssd1306SetComOutput0,
+		ssd1306SetComPins, comPins,
+		ssd1306SetContrast, 0xCF,
+		ssd1306SetPrechargePeriod, 0xF1,
+		ssd1306SetVComDeselectLevel, 0x40,
+		ssd1306DisplayOnResumeToRAM,
+		ssd1306SetDisplayNormal,
+		ssd1306StopScroll,
+		ssd1306SetSegmentRemap0,
+		ssd1306SetSegmentRemap127,
+		ssd1306SetComOutput8,
+		ssd1306SetMemoryAddressingMode, 0x00,
+		ssd1306SetContrast, 0xff,
+	}
 }
 
 // On turns display on
@@ -237,12 +472,62 @@ func (s *SSD1306Driver) Off() (err error) {
 // Clear clears
 func (s *SSD1306Driver) Clear() (err error) {
 	s.Buffer.Clear()
+	s.dirty = image.Rect(0, 0, s.width, s.height)
 	return nil
 }
 
-// Set sets a pixel
-func (s *SSD1306Driver) Set(x, y, c int) {
-	s.Buffer.Set(x, y, c)
+// ColorModel returns the color model of the display, which turns a pixel on
+// for colors at least half as bright as white. It implements image.Image.
+func (s *SSD1306Driver) ColorModel() color.Model { return ssd1306ColorModel }
+
+// Bounds returns the bounds of the image, which are those of the display
+// turned by the rotation. It implements image.Image.
+func (s *SSD1306Driver) Bounds() image.Rectangle {
+	if s.rotation == SSD1306Rotation90 || s.rotation == SSD1306Rotation270 {
+		return image.Rect(0, 0, s.height, s.width)
+	}
+	return image.Rect(0, 0, s.width, s.height)
+}
+
+// At returns the color of a pixel, white if it is on. It implements
+// image.Image.
+func (s *SSD1306Driver) At(x, y int) color.Color {
+	if !image.Pt(x, y).In(s.Bounds()) {
+		return color.Black
+	}
+	x, y = s.rotate(x, y)
+	if s.Buffer.buffer[x+(y/ssd1306PageSize)*s.width]&(1<<uint(y%ssd1306PageSize)) != 0 {
+		return color.White
+	}
+	return color.Black
+}
+
+// Set sets a pixel, which is turned on by colors at least half as bright as
+// white. It implements draw.Image.
+func (s *SSD1306Driver) Set(x, y int, c color.Color) {
+	if !image.Pt(x, y).In(s.Bounds()) {
+		return
+	}
+	x, y = s.rotate(x, y)
+	if ssd1306ColorModel.Convert(c) == color.White {
+		s.Buffer.Set(x, y, 1)
+	} else {
+		s.Buffer.Set(x, y, 0)
+	}
+	s.dirty = s.dirty.Union(image.Rect(x, y, x+1, y+1))
+}
+
+// rotate returns the position on the display of a pixel of the image
+func (s *SSD1306Driver) rotate(x, y int) (int, int) {
+	switch s.rotation {
+	case SSD1306Rotation90:
+		return s.width - 1 - y, x
+	case SSD1306Rotation180:
+		return s.width - 1 - x, s.height - 1 - y
+	case SSD1306Rotation270:
+		return y, s.height - 1 - x
+	}
+	return x, y
 }
 
 // Reset sends the memory buffer to the display
@@ -259,25 +544,97 @@ func (s *SSD1306Driver) SetContrast(contrast byte) (err error) {
 	return
 }
 
-// Display sends the memory buffer to the display
+// Display sends the pages of the memory buffer which were changed since the
+// last Display to the display
 func (s *SSD1306Driver) Display() (err error) {
-	// Write the buffer
-	_, err = s.connection.Write(append([]byte{0x40}, s.Buffer.buffer...))
-	return err
+	if s.dirty.Empty() {
+		return nil
+	}
+	x0, x1 := s.dirty.Min.X, s.dirty.Max.X
+	p0, p1 := s.dirty.Min.Y/ssd1306PageSize, (s.dirty.Max.Y+ssd1306PageSize-1)/ssd1306PageSize
+
+	if s.sh1106 {
+		for page := p0; page < p1; page++ {
+			column := x0 + (sh1106ColumnRAMWidth-s.width)/2
+			if err = s.commands([]byte{
+				sh1106SetPageAddress | byte(page),
+				sh1106SetLowColumn | byte(column&0x0F),
+				sh1106SetHighColumn | byte(column>>4),
+			}); err != nil {
+				return
+			}
+			if err = s.data(s.Buffer.buffer[page*s.width+x0 : page*s.width+x1]); err != nil {
+				return
+			}
+		}
+	} else {
+		if err = s.commands([]byte{
+			ssd1306ColumnAddr, byte(x0), byte(x1 - 1),
+			ssd1306PageAddr, byte(p0), byte(p1 - 1),
+		}); err != nil {
+			return
+		}
+		var data []byte
+		for page := p0; page < p1; page++ {
+			data = append(data, s.Buffer.buffer[page*s.width+x0:page*s.width+x1]...)
+		}
+		if err = s.data(data); err != nil {
+			return
+		}
+	}
+
+	s.dirty = image.Rectangle{}
+	return nil
 }
 
 // command sends a unique command
 func (s *SSD1306Driver) command(b byte) (err error) {
-	_, err = s.connection.Write([]byte{0x80, b})
-	return
+	return s.commands([]byte{b})
 }
 
 // commands sends a command sequence
 func (s *SSD1306Driver) commands(commands []byte) (err error) {
+	if s.spi != nil {
+		return s.spi.write(0, commands)
+	}
 	var command []byte
 	for _, d := range commands {
-		command = append(command, []byte{0x80, d}...)
+		command = append(command, []byte{ssd1306ControlCommand, d}...)
 	}
 	_, err = s.connection.Write(command)
 	return
 }
+
+// data sends display data
+func (s *SSD1306Driver) data(data []byte) (err error) {
+	if s.spi != nil {
+		return s.spi.write(1, data)
+	}
+	_, err = s.connection.Write(append([]byte{ssd1306ControlData}, data...))
+	return
+}
+
+// write shifts the bytes into the display, most significant bit first, with
+// the D/C input low for commands and high for data
+func (b *ssd1306SPI) write(dc byte, data []byte) (err error) {
+	if err = b.connection.DigitalWrite(b.dcPin, dc); err != nil {
+		return
+	}
+	if err = b.connection.DigitalWrite(b.csPin, 0); err != nil {
+		return
+	}
+	for _, d := range data {
+		for bit := 7; bit >= 0; bit-- {
+			if err = b.connection.DigitalWrite(b.dataPin, (d>>uint(bit))&1); err != nil {
+				return
+			}
+			if err = b.connection.DigitalWrite(b.clockPin, 1); err != nil {
+				return
+			}
+			if err = b.connection.DigitalWrite(b.clockPin, 0); err != nil {
+				return
+			}
+		}
+	}
+	return b.connection.DigitalWrite(b.csPin, 1)
+}
@@ -3,8 +3,12 @@ package i2c
 import (
 	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"gobot.io/x/gobot"
@@ -13,6 +17,8 @@ import (
 
 var _ gobot.Driver = (*SSD1306Driver)(nil)
 
+var _ draw.Image = (*SSD1306Driver)(nil)
+
 func TestDisplayBuffer(t *testing.T) {
 
 	width := 128
@@ -239,3 +245,197 @@ func TestSSD1306DriverCommandsSet(t *testing.T) {
 	})
 	gobottest.Assert(t, s.Buffer.buffer[0], byte(1))
 }
+
+func TestSSD1306DriverImage(t *testing.T) {
+	s := initTestSSD1306Driver()
+	gobottest.Assert(t, s.Bounds(), image.Rect(0, 0, 128, 64))
+	gobottest.Assert(t, s.ColorModel().Convert(color.Gray{Y: 0x80}), color.Color(color.White))
+	gobottest.Assert(t, s.ColorModel().Convert(color.Gray{Y: 0x7F}), color.Color(color.Black))
+
+	s.Set(1, 9, color.White)
+	gobottest.Assert(t, s.Buffer.buffer[129], byte(0x02))
+	gobottest.Assert(t, s.At(1, 9), color.Color(color.White))
+	gobottest.Assert(t, s.At(1, 8), color.Color(color.Black))
+	s.Set(1, 9, color.RGBA{R: 0x20, A: 0xFF})
+	gobottest.Assert(t, s.Buffer.buffer[129], byte(0))
+
+	// pixels outside of the display are ignored
+	s.Set(128, 0, color.White)
+	s.Set(-1, 0, color.White)
+	gobottest.Assert(t, s.At(128, 0), color.Color(color.Black))
+
+	draw.Draw(s, image.Rect(0, 0, 2, 8), image.White, image.ZP, draw.Src)
+	gobottest.Assert(t, s.Buffer.buffer[0], byte(0xFF))
+	gobottest.Assert(t, s.Buffer.buffer[1], byte(0xFF))
+	gobottest.Assert(t, s.Buffer.buffer[2], byte(0))
+}
+
+func TestSSD1306DriverRotation(t *testing.T) {
+	tests := []struct {
+		rotation SSD1306Rotation
+		bounds   image.Rectangle
+		x, y     int
+	}{
+		{SSD1306Rotation0, image.Rect(0, 0, 128, 32), 1, 2},
+		{SSD1306Rotation90, image.Rect(0, 0, 32, 128), 125, 1},
+		{SSD1306Rotation180, image.Rect(0, 0, 128, 32), 126, 29},
+		{SSD1306Rotation270, image.Rect(0, 0, 32, 128), 2, 30},
+	}
+	for _, test := range tests {
+		s := NewSSD1306Driver(newI2cTestAdaptor(), WithSSD1306DisplayHeight(32), WithSSD1306Rotation(test.rotation))
+		gobottest.Assert(t, s.Bounds(), test.bounds)
+		s.Set(1, 2, color.White)
+		gobottest.Assert(t, s.Buffer.buffer[test.x+(test.y/8)*128], byte(1<<uint(test.y%8)))
+		gobottest.Assert(t, s.At(1, 2), color.Color(color.White))
+	}
+}
+
+func TestSSD1306DriverGeometry(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	s := NewSSD1306Driver(adaptor, WithSSD1306DisplayWidth(96), WithSSD1306DisplayHeight(16))
+	gobottest.Assert(t, s.Buffer.Size(), 192)
+	gobottest.Assert(t, s.Bounds(), image.Rect(0, 0, 96, 16))
+
+	var written [][]byte
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = append(written, append([]byte{}, b...))
+		return len(b), nil
+	}
+	gobottest.Assert(t, s.Start(), nil)
+	sequence := written[1]
+	gobottest.Assert(t, sequence[10:12], []byte{0x80, 0x0F}) // multiplex ratio
+	gobottest.Assert(t, sequence[32:34], []byte{0x80, 0x02}) // COM pins
+	gobottest.Assert(t, written[2], []byte{0x80, ssd1306ColumnAddr, 0x80, 0, 0x80, 95})
+	gobottest.Assert(t, written[3], []byte{0x80, ssd1306PageAddr, 0x80, 0, 0x80, 1})
+}
+
+func TestSSD1306DriverDisplayDirty(t *testing.T) {
+	s, adaptor := initTestSSD1306DriverWithStubbedAdaptor()
+	s.Start()
+
+	var written [][]byte
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = append(written, append([]byte{}, b...))
+		return len(b), nil
+	}
+
+	// the whole display at first
+	gobottest.Assert(t, s.Display(), nil)
+	gobottest.Assert(t, len(written), 2)
+	gobottest.Assert(t, written[0], []byte{0x80, ssd1306ColumnAddr, 0x80, 0, 0x80, 127, 0x80, ssd1306PageAddr, 0x80, 0, 0x80, 7})
+	gobottest.Assert(t, len(written[1]), 1025)
+
+	// nothing without changes
+	written = nil
+	gobottest.Assert(t, s.Display(), nil)
+	gobottest.Assert(t, len(written), 0)
+
+	// the pages and columns of the changed pixels
+	s.Set(3, 9, color.White)
+	s.Set(5, 17, color.White)
+	gobottest.Assert(t, s.Display(), nil)
+	gobottest.Assert(t, written[0], []byte{0x80, ssd1306ColumnAddr, 0x80, 3, 0x80, 5, 0x80, ssd1306PageAddr, 0x80, 1, 0x80, 2})
+	gobottest.Assert(t, written[1], []byte{0x40, 0x02, 0, 0, 0, 0, 0x02})
+
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	s.Clear()
+	gobottest.Assert(t, s.Display(), errors.New("write error"))
+}
+
+func TestSSD1306DriverSH1106(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	s := NewSSD1306Driver(adaptor, WithSH1106())
+	gobottest.Assert(t, strings.HasPrefix(s.Name(), "SH1106"), true)
+
+	var written [][]byte
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		written = append(written, append([]byte{}, b...))
+		return len(b), nil
+	}
+	gobottest.Assert(t, s.Start(), nil)
+	// off, the sequence and on without the addressing of the SSD1306
+	gobottest.Assert(t, len(written), 3)
+
+	written = nil
+	s.Display()
+	// every page is written on its own
+	gobottest.Assert(t, len(written), 16)
+	gobottest.Assert(t, written[0], []byte{0x80, sh1106SetPageAddress, 0x80, 0x02, 0x80, 0x10})
+	gobottest.Assert(t, len(written[1]), 129)
+
+	written = nil
+	s.Set(127, 63, color.White)
+	s.Display()
+	gobottest.Assert(t, written, [][]byte{
+		{0x80, sh1106SetPageAddress | 7, 0x80, 0x01, 0x80, 0x18},
+		{0x40, 0x80},
+	})
+}
+
+func TestSSD1306DriverText(t *testing.T) {
+	s := initTestSSD1306Driver()
+	s.Command("Text")(map[string]interface{}{"x": 2.0, "y": 0.0, "text": "!"})
+	// the column of the exclamation mark
+	gobottest.Assert(t, s.Buffer.buffer[4], byte(0x5F))
+}
+
+type ssd1306TestPins struct {
+	mutex    sync.Mutex
+	pins     map[string]byte
+	bits     int
+	value    byte
+	commands []byte
+	data     []byte
+	err      error
+}
+
+func (p *ssd1306TestPins) DigitalWrite(pin string, val byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	if pin == "clk" && val == 1 && p.pins["clk"] == 0 && p.pins["cs"] == 0 {
+		p.value = p.value<<1 | p.pins["mosi"]
+		if p.bits++; p.bits == 8 {
+			if p.pins["dc"] == 0 {
+				p.commands = append(p.commands, p.value)
+			} else {
+				p.data = append(p.data, p.value)
+			}
+			p.bits = 0
+		}
+	}
+	p.pins[pin] = val
+	return nil
+}
+
+func (p *ssd1306TestPins) Name() string          { return "pins" }
+func (p *ssd1306TestPins) SetName(n string)      {}
+func (p *ssd1306TestPins) Connect() (err error)  { return }
+func (p *ssd1306TestPins) Finalize() (err error) { return }
+
+func TestSSD1306SPIDriver(t *testing.T) {
+	pins := &ssd1306TestPins{pins: map[string]byte{}}
+	s := NewSSD1306SPIDriver(pins, "cs", "dc", "clk", "mosi", WithSSD1306ResetPin("res"), WithSSD1306DisplayHeight(32))
+	gobottest.Assert(t, s.Connection(), gobot.Connection(pins))
+	gobottest.Assert(t, s.Start(), nil)
+	gobottest.Assert(t, pins.pins["res"], byte(1))
+	gobottest.Assert(t, pins.pins["cs"], byte(1))
+	gobottest.Assert(t, pins.commands[0], byte(ssd1306SetDisplayOff))
+	gobottest.Assert(t, pins.commands[len(pins.commands)-1], byte(ssd1306SetDisplayOn))
+
+	pins.commands = nil
+	s.Set(0, 0, color.White)
+	s.Set(1, 1, color.White)
+	gobottest.Assert(t, s.Display(), nil)
+	gobottest.Assert(t, pins.commands, []byte{ssd1306ColumnAddr, 0, 127, ssd1306PageAddr, 0, 3})
+	gobottest.Assert(t, len(pins.data), 512)
+	gobottest.Assert(t, pins.data[:3], []byte{0x01, 0x02, 0})
+	gobottest.Assert(t, s.Halt(), nil)
+
+	pins.err = errors.New("write error")
+	gobottest.Assert(t, s.Start(), errors.New("write error"))
+}
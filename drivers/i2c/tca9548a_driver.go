@@ -0,0 +1,269 @@
+package i2c
+
+import (
+	"fmt"
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+const tca9548aAddress = 0x70
+
+// tca9548aChannels is the number of downstream channels of the multiplexer
+const tca9548aChannels = 8
+
+// TCA9548ADriver is a driver for the TCA9548A and PCA9548A i2c multiplexers
+// with 8 downstream channels, e.g. for several devices with the same address.
+//
+// It implements Connector with the channels as the buses 0 to 7, so that it
+// can be the connector of other i2c drivers, e.g.
+// NewBMP180Driver(mux, WithBus(3)) for a BMP180 at channel 3. The channel
+// of a device is selected before every transfer to it, and the transfers
+// through the multiplexer are serialized. The multiplexer has to be started
+// before the drivers of its devices.
+type TCA9548ADriver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	selected byte // channels of the control register, 0 for none
+	mutex    *sync.Mutex
+}
+
+// NewTCA9548ADriver creates a new driver for the multiplexer.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//
+func NewTCA9548ADriver(a Connector, options ...func(Config)) *TCA9548ADriver {
+	d := &TCA9548ADriver{
+		name:      gobot.DefaultName("TCA9548A"),
+		connector: a,
+		Config:    NewConfig(),
+		mutex:     &sync.Mutex{},
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// Name returns the name of the device.
+func (d *TCA9548ADriver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *TCA9548ADriver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the device.
+func (d *TCA9548ADriver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Connect implements the Connection interface, so that i2c drivers can
+// claim the addresses of the channels. The multiplexer itself is started as
+// a driver.
+func (d *TCA9548ADriver) Connect() (err error) { return }
+
+// Finalize implements the Connection interface
+func (d *TCA9548ADriver) Finalize() (err error) { return }
+
+// Start initializes the device with no channel selected.
+func (d *TCA9548ADriver) Start() (err error) {
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(tca9548aAddress)
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.connection, err = claimConnection(d.connector, d, address, bus)
+	if err != nil {
+		return
+	}
+	return d.selectChannels(0)
+}
+
+// Halt deselects all channels and stops the device.
+func (d *TCA9548ADriver) Halt() (err error) {
+	defer releaseConnections(d.connector, d)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.connection == nil {
+		return
+	}
+	return d.selectChannels(0)
+}
+
+// GetConnection returns a connection to the device at the address behind
+// the channel given as bus, from 0 to 7. It implements Connector.
+func (d *TCA9548ADriver) GetConnection(address int, bus int) (connection Connection, err error) {
+	if bus < 0 || bus >= tca9548aChannels {
+		return nil, fmt.Errorf("Bus number %d out of range", bus)
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.connection == nil {
+		return nil, ErrNotReady
+	}
+	upstream, err := d.connector.GetConnection(address, d.GetBusOrDefault(d.connector.GetDefaultBus()))
+	if err != nil {
+		return nil, err
+	}
+	return &tca9548aConnection{mux: d, channel: bus, connection: upstream}, nil
+}
+
+// GetDefaultBus returns the first channel. It implements Connector.
+func (d *TCA9548ADriver) GetDefaultBus() int { return 0 }
+
+// selectChannels writes the channels to the control register, unless they
+// are selected already
+func (d *TCA9548ADriver) selectChannels(channels byte) (err error) {
+	if channels == d.selected && channels != 0 {
+		return
+	}
+	if err = d.connection.WriteByte(channels); err != nil {
+		// the selection is unknown
+		d.selected = 0
+		return
+	}
+	d.selected = channels
+	return
+}
+
+// tca9548aConnection is a connection to a device behind a channel of a
+// TCA9548ADriver
+type tca9548aConnection struct {
+	mux        *TCA9548ADriver
+	channel    int
+	connection Connection // to the address on the bus of the multiplexer
+}
+
+// do selects the channel and calls f, while no other connection of the
+// multiplexer is used
+func (c *tca9548aConnection) do(f func() error) error {
+	c.mux.mutex.Lock()
+	defer c.mux.mutex.Unlock()
+	if c.mux.connection == nil {
+		return ErrNotReady
+	}
+	if err := c.mux.selectChannels(1 << uint(c.channel)); err != nil {
+		return err
+	}
+	return f()
+}
+
+// Read data from the device.
+func (c *tca9548aConnection) Read(data []byte) (read int, err error) {
+	err = c.do(func() (err error) {
+		read, err = c.connection.Read(data)
+		return
+	})
+	return
+}
+
+// Write data to the device.
+func (c *tca9548aConnection) Write(data []byte) (written int, err error) {
+	err = c.do(func() (err error) {
+		written, err = c.connection.Write(data)
+		return
+	})
+	return
+}
+
+// Close the connection to the device.
+func (c *tca9548aConnection) Close() error {
+	c.mux.mutex.Lock()
+	defer c.mux.mutex.Unlock()
+	return c.connection.Close()
+}
+
+// ReadByte reads a single byte from the device.
+func (c *tca9548aConnection) ReadByte() (val byte, err error) {
+	err = c.do(func() (err error) {
+		val, err = c.connection.ReadByte()
+		return
+	})
+	return
+}
+
+// ReadByteData reads a byte value for a register on the device.
+func (c *tca9548aConnection) ReadByteData(reg uint8) (val uint8, err error) {
+	err = c.do(func() (err error) {
+		val, err = c.connection.ReadByteData(reg)
+		return
+	})
+	return
+}
+
+// ReadWordData reads a word value for a register on the device.
+func (c *tca9548aConnection) ReadWordData(reg uint8) (val uint16, err error) {
+	err = c.do(func() (err error) {
+		val, err = c.connection.ReadWordData(reg)
+		return
+	})
+	return
+}
+
+// WriteByte writes a single byte to the device.
+func (c *tca9548aConnection) WriteByte(val byte) (err error) {
+	return c.do(func() error { return c.connection.WriteByte(val) })
+}
+
+// WriteByteData writes a byte value to a register on the device.
+func (c *tca9548aConnection) WriteByteData(reg uint8, val uint8) (err error) {
+	return c.do(func() error { return c.connection.WriteByteData(reg, val) })
+}
+
+// WriteWordData writes a word value to a register on the device.
+func (c *tca9548aConnection) WriteWordData(reg uint8, val uint16) (err error) {
+	return c.do(func() error { return c.connection.WriteWordData(reg, val) })
+}
+
+// WriteBlockData writes a block of bytes to a register on the device.
+func (c *tca9548aConnection) WriteBlockData(reg uint8, b []byte) (err error) {
+	return c.do(func() error { return c.connection.WriteBlockData(reg, b) })
+}
+
+// WriteRead writes w and then reads len(r) bytes into r as a single
+// transaction on the device.
+func (c *tca9548aConnection) WriteRead(w []byte, r []byte) (err error) {
+	ext, ok := c.connection.(I2cExtendedOperations)
+	if !ok {
+		return ErrNotSupported
+	}
+	return c.do(func() error { return ext.WriteRead(w, r) })
+}
+
+// ReadBlockData reads an SMBus block from a register on the device.
+func (c *tca9548aConnection) ReadBlockData(reg uint8, b []byte) (n int, err error) {
+	ext, ok := c.connection.(I2cExtendedOperations)
+	if !ok {
+		return 0, ErrNotSupported
+	}
+	err = c.do(func() (err error) {
+		n, err = ext.ReadBlockData(reg, b)
+		return
+	})
+	return
+}
+
+// ReadI2cBlockData reads a block of bytes starting at a register on the device.
+func (c *tca9548aConnection) ReadI2cBlockData(reg uint8, b []byte) (err error) {
+	ext, ok := c.connection.(I2cExtendedOperations)
+	if !ok {
+		return ErrNotSupported
+	}
+	return c.do(func() error { return ext.ReadI2cBlockData(reg, b) })
+}
+
+// SetPEC enables or disables packet error checking.
+func (c *tca9548aConnection) SetPEC(enable bool) (err error) {
+	ext, ok := c.connection.(I2cExtendedOperations)
+	if !ok {
+		return ErrNotSupported
+	}
+	c.mux.mutex.Lock()
+	defer c.mux.mutex.Unlock()
+	return ext.SetPEC(enable)
+}
@@ -0,0 +1,196 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*TCA9548ADriver)(nil)
+var _ gobot.Connection = (*TCA9548ADriver)(nil)
+var _ Connector = (*TCA9548ADriver)(nil)
+var _ I2cExtendedOperations = (*tca9548aConnection)(nil)
+
+func initTestTCA9548ADriverWithStubbedAdaptor() (*TCA9548ADriver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	d := NewTCA9548ADriver(adaptor)
+	d.Start()
+	adaptor.written = []byte{}
+	return d, adaptor
+}
+
+func TestNewTCA9548ADriver(t *testing.T) {
+	d := NewTCA9548ADriver(newI2cTestAdaptor(), WithAddress(0x71))
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "TCA9548A"), true)
+	gobottest.Assert(t, d.GetAddressOrDefault(tca9548aAddress), 0x71)
+	gobottest.Assert(t, d.GetDefaultBus(), 0)
+	gobottest.Assert(t, d.Connect(), nil)
+	gobottest.Assert(t, d.Finalize(), nil)
+
+	d.SetName("mux")
+	gobottest.Assert(t, d.Name(), "mux")
+}
+
+func TestTCA9548ADriverStart(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	d := NewTCA9548ADriver(adaptor)
+	_, err := d.GetConnection(0x77, 0)
+	gobottest.Assert(t, err, ErrNotReady)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x00})
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x00, 0x00})
+
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestTCA9548ADriverGetConnection(t *testing.T) {
+	d, adaptor := initTestTCA9548ADriverWithStubbedAdaptor()
+	_, err := d.GetConnection(0x77, 8)
+	gobottest.Assert(t, err, errors.New("Bus number 8 out of range"))
+	_, err = d.GetConnection(0x77, -1)
+	gobottest.Refute(t, err, nil)
+
+	adaptor.Testi2cConnectErr(true)
+	_, err = d.GetConnection(0x77, 0)
+	gobottest.Assert(t, err, errors.New("Invalid i2c connection"))
+}
+
+func TestTCA9548ADriverSelectsChannels(t *testing.T) {
+	d, adaptor := initTestTCA9548ADriverWithStubbedAdaptor()
+	c3, _ := d.GetConnection(0x77, 3)
+	c5, _ := d.GetConnection(0x77, 5)
+
+	_, err := c3.Write([]byte{0x01, 0x02})
+	gobottest.Assert(t, err, nil)
+	// the channel is selected already
+	gobottest.Assert(t, c3.WriteByte(0x03), nil)
+	gobottest.Assert(t, c5.WriteByteData(0x04, 0x05), nil)
+	gobottest.Assert(t, c3.WriteWordData(0x06, 0x0807), nil)
+	gobottest.Assert(t, c3.WriteBlockData(0x09, []byte{0x0A}), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		0x08, 0x01, 0x02,
+		0x03,
+		0x20, 0x04, 0x05,
+		0x08, 0x06, 0x07, 0x08,
+		0x09, 0x0A,
+	})
+
+	adaptor.written = []byte{}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		for i := range b {
+			b[i] = 0x42
+		}
+		return len(b), nil
+	}
+	buf := make([]byte, 2)
+	n, err := c5.Read(buf)
+	gobottest.Assert(t, n, 2)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, buf, []byte{0x42, 0x42})
+	val, err := c5.ReadByte()
+	gobottest.Assert(t, val, byte(0x42))
+	gobottest.Assert(t, err, nil)
+	val, err = c3.ReadByteData(0x01)
+	gobottest.Assert(t, val, byte(0x42))
+	gobottest.Assert(t, err, nil)
+	word, err := c3.ReadWordData(0x01)
+	gobottest.Assert(t, word, uint16(0x4242))
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x20, 0x08})
+
+	gobottest.Assert(t, c3.Close(), nil)
+}
+
+func TestTCA9548ADriverSelectError(t *testing.T) {
+	d, adaptor := initTestTCA9548ADriverWithStubbedAdaptor()
+	c, _ := d.GetConnection(0x77, 1)
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, c.WriteByte(0x01), errors.New("write error"))
+
+	// the channel is selected again after an error
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		return len(b), nil
+	}
+	adaptor.written = []byte{}
+	gobottest.Assert(t, c.WriteByte(0x01), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x02, 0x01})
+}
+
+func TestTCA9548ADriverHaltedConnection(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	d := NewTCA9548ADriver(adaptor)
+	d.Start()
+	c, _ := d.GetConnection(0x77, 1)
+	d.connection = nil
+	gobottest.Assert(t, c.WriteByte(0x01), ErrNotReady)
+}
+
+func TestTCA9548ADriverExtendedOperations(t *testing.T) {
+	d, _ := initTestTCA9548ADriverWithStubbedAdaptor()
+	c, _ := d.GetConnection(0x77, 1)
+	ext := c.(I2cExtendedOperations)
+	gobottest.Assert(t, ext.WriteRead([]byte{0x01}, make([]byte, 1)), ErrNotSupported)
+	_, err := ext.ReadBlockData(0x01, make([]byte, 1))
+	gobottest.Assert(t, err, ErrNotSupported)
+	gobottest.Assert(t, ext.ReadI2cBlockData(0x01, make([]byte, 1)), ErrNotSupported)
+	gobottest.Assert(t, ext.SetPEC(true), ErrNotSupported)
+
+	// WriteRead falls back to separate transfers
+	gobottest.Assert(t, WriteRead(c, []byte{0x01}, []byte{}), nil)
+}
+
+func TestTCA9548ADriverWithBus(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	mux := NewTCA9548ADriver(adaptor)
+	gobottest.Assert(t, mux.Start(), nil)
+
+	a := NewBlinkMDriver(mux, WithBus(2))
+	b := NewBlinkMDriver(mux, WithBus(6))
+	gobottest.Assert(t, a.Start(), nil)
+	gobottest.Assert(t, b.Start(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x00, 0x04, 'o', 0x40, 'o'})
+
+	// the same address on the same channel is claimed once
+	c := NewBlinkMDriver(mux, WithBus(2))
+	gobottest.Refute(t, c.Start(), nil)
+}
+
+func TestTCA9548ADriverConcurrentTransfers(t *testing.T) {
+	d, adaptor := initTestTCA9548ADriverWithStubbedAdaptor()
+	c1, _ := d.GetConnection(0x77, 3)
+	c2, _ := d.GetConnection(0x77, 5)
+
+	var wg sync.WaitGroup
+	for _, c := range []Connection{c1, c2} {
+		wg.Add(1)
+		go func(c Connection, channel byte) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				c.WriteByte(channel)
+			}
+		}(c, byte(c.(*tca9548aConnection).channel))
+	}
+	wg.Wait()
+
+	// every byte is written behind the channel it was written to
+	selected := byte(0)
+	for _, b := range adaptor.written {
+		switch b {
+		case 0x08, 0x20:
+			selected = b
+		default:
+			gobottest.Assert(t, byte(1)<<b, selected)
+		}
+	}
+}
@@ -234,7 +234,7 @@ func (d *TSL2561Driver) Start() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
 	address := d.GetAddressOrDefault(TSL2561AddressFloat)
 
-	if d.connection, err = d.connector.GetConnection(address, bus); err != nil {
+	if d.connection, err = claimConnection(d.connector, d, address, bus); err != nil {
 		return err
 	}
 
@@ -266,6 +266,7 @@ func (d *TSL2561Driver) Start() (err error) {
 
 // Halt stops the device
 func (d *TSL2561Driver) Halt() error {
+	releaseConnections(d.connector, d)
 	return nil
 }
 
@@ -89,7 +89,7 @@ func (w *WiichuckDriver) Start() (err error) {
 	bus := w.GetBusOrDefault(w.connector.GetDefaultBus())
 	address := w.GetAddressOrDefault(wiichuckAddress)
 
-	w.connection, err = w.connector.GetConnection(address, bus)
+	w.connection, err = claimConnection(w.connector, w, address, bus)
 	if err != nil {
 		return err
 	}
@@ -125,7 +125,10 @@ func (w *WiichuckDriver) Start() (err error) {
 }
 
 // Halt returns true if driver is halted successfully
-func (w *WiichuckDriver) Halt() (err error) { return }
+func (w *WiichuckDriver) Halt() (err error) {
+	releaseConnections(w.connector, w)
+	return
+}
 
 // Joystick returns the current value for the joystick
 func (w *WiichuckDriver) Joystick() map[string]float64 {
@@ -0,0 +1,265 @@
+package imu
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// AHRSDriver is an attitude and heading reference system, which fuses the
+// readings of a gyroscope, an accelerometer and optionally a magnetometer
+// into the orientation of the sensors at a fixed rate
+type AHRSDriver struct {
+	name          string
+	Accelerometer Accelerometer
+	Gyroscope     Gyroscope
+	// Magnetometer is nil without one, then the yaw drifts
+	Magnetometer Magnetometer
+	Fusion       Fusion
+	// GyroscopeBias is subtracted from the angular velocity, see
+	// CalibrateGyroscope
+	GyroscopeBias Vector
+	// MagnetometerCalibration corrects the magnetic field, see
+	// CalibrateMagnetometer
+	MagnetometerCalibration MagnetometerCalibration
+	interval                time.Duration
+	mutex                   *sync.Mutex
+	halt                    chan bool // nil while the loop is not running
+	last                    time.Time
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewAHRSDriver returns a new AHRSDriver with an update interval of 10
+// Milliseconds and a Madgwick filter given an Accelerometer and a Gyroscope,
+// e.g. both the same MPU6050.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the orientation is updated
+// 	Magnetometer: Magnetometer for the heading
+// 	Fusion: Filter which fuses the readings, e.g. NewMahonyFilter
+//
+// Adds the following API Commands:
+// 	"Orientation" - See AHRSDriver.Orientation
+// 	"EulerAngles" - See AHRSDriver.EulerAngles
+// 	"CalibrateGyroscope" - See AHRSDriver.CalibrateGyroscope, with the number of "samples"
+// 	"CalibrateMagnetometer" - See AHRSDriver.CalibrateMagnetometer, with the "duration" in milliseconds
+func NewAHRSDriver(accel Accelerometer, gyro Gyroscope, v ...interface{}) *AHRSDriver {
+	d := &AHRSDriver{
+		name:                    gobot.DefaultName("AHRS"),
+		Accelerometer:           accel,
+		Gyroscope:               gyro,
+		Fusion:                  NewMadgwickFilter(0.1),
+		MagnetometerCalibration: NoMagnetometerCalibration,
+		interval:                10 * time.Millisecond,
+		mutex:                   &sync.Mutex{},
+		Eventer:                 gobot.NewEventer(),
+		Commander:               gobot.NewCommander(),
+	}
+
+	for _, option := range v {
+		switch o := option.(type) {
+		case time.Duration:
+			d.interval = o
+		case Fusion:
+			d.Fusion = o
+		case Magnetometer:
+			d.Magnetometer = o
+		}
+	}
+
+	d.AddEvent(Orientation)
+	d.AddEvent(Euler)
+	d.AddEvent(Error)
+
+	d.AddCommand("Orientation", func(params map[string]interface{}) interface{} {
+		return d.Orientation()
+	})
+	d.AddCommand("EulerAngles", func(params map[string]interface{}) interface{} {
+		return d.EulerAngles()
+	})
+	d.AddCommand("CalibrateGyroscope", func(params map[string]interface{}) interface{} {
+		samples, _ := params["samples"].(float64)
+		bias, err := d.CalibrateGyroscope(int(samples))
+		if err != nil {
+			return err
+		}
+		return bias
+	})
+	d.AddCommand("CalibrateMagnetometer", func(params map[string]interface{}) interface{} {
+		ms, _ := params["duration"].(float64)
+		c, err := d.CalibrateMagnetometer(time.Duration(ms * float64(time.Millisecond)))
+		if err != nil {
+			return err
+		}
+		return c
+	})
+
+	return d
+}
+
+// Name returns the AHRSDrivers name
+func (d *AHRSDriver) Name() string { return d.name }
+
+// SetName sets the AHRSDrivers name
+func (d *AHRSDriver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the accelerometer, if it is a driver
+func (d *AHRSDriver) Connection() gobot.Connection {
+	if driver, ok := d.Accelerometer.(gobot.Driver); ok {
+		return driver.Connection()
+	}
+	return nil
+}
+
+// Start starts updating the orientation at the interval. The sensors are
+// drivers of their own.
+// Emits the Events:
+//	Orientation Quaternion - Event is emitted on every update with the orientation.
+//	Euler EulerAngles - Event is emitted on every update with the Euler angles of the orientation.
+//	Error error - Event is emitted on error reading the sensors.
+func (d *AHRSDriver) Start() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.halt != nil {
+		return
+	}
+	d.halt = make(chan bool)
+	d.last = time.Time{}
+	go d.loop(d.halt)
+	return
+}
+
+// Halt stops updating the orientation
+func (d *AHRSDriver) Halt() (err error) {
+	d.mutex.Lock()
+	halt := d.halt
+	d.halt = nil
+	d.mutex.Unlock()
+	if halt != nil {
+		halt <- true
+	}
+	return
+}
+
+func (d *AHRSDriver) loop(halt chan bool) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			q, err := d.Update()
+			if err != nil {
+				d.Publish(Error, err)
+				continue
+			}
+			d.Publish(Orientation, q)
+			d.Publish(Euler, q.Euler())
+		case <-halt:
+			return
+		}
+	}
+}
+
+// Update reads the sensors once, updates the orientation by the time since
+// the last update and returns it
+func (d *AHRSDriver) Update() (q Quaternion, err error) {
+	gyro, accel, mag, err := d.read()
+	if err != nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	now := time.Now()
+	dt := d.interval.Seconds()
+	if !d.last.IsZero() {
+		dt = now.Sub(d.last).Seconds()
+	}
+	d.last = now
+
+	gyro = gyro.Sub(d.GyroscopeBias)
+	if d.Magnetometer != nil {
+		mag = d.MagnetometerCalibration.Apply(mag)
+	}
+	return d.Fusion.Update(gyro, accel, mag, dt), nil
+}
+
+// Orientation returns the current orientation
+func (d *AHRSDriver) Orientation() Quaternion {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.Fusion.Orientation()
+}
+
+// EulerAngles returns the Euler angles of the current orientation
+func (d *AHRSDriver) EulerAngles() EulerAngles {
+	return d.Orientation().Euler()
+}
+
+// Yaw returns the heading in radians, counterclockwise from magnetic north or
+// from the heading at the start without a magnetometer
+func (d *AHRSDriver) Yaw() (float64, error) {
+	return d.EulerAngles().Yaw, nil
+}
+
+// CalibrateGyroscope reads the gyroscope the number of samples times at the
+// interval while it is at rest, and sets the GyroscopeBias to their mean
+func (d *AHRSDriver) CalibrateGyroscope(samples int) (bias Vector, err error) {
+	readings := []Vector{}
+	for i := 0; i < samples; i++ {
+		v, err := d.Gyroscope.ReadAngularVelocity()
+		if err != nil {
+			return bias, err
+		}
+		readings = append(readings, v)
+		time.Sleep(d.interval)
+	}
+	if bias, err = meanVector(readings); err != nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.GyroscopeBias = bias
+	return
+}
+
+// CalibrateMagnetometer reads the magnetometer at the interval for duration
+// while it is turned through all orientations, and sets the
+// MagnetometerCalibration of the readings
+func (d *AHRSDriver) CalibrateMagnetometer(duration time.Duration) (c MagnetometerCalibration, err error) {
+	if d.Magnetometer == nil {
+		return NoMagnetometerCalibration, ErrNoMagnetometer
+	}
+	readings := []Vector{}
+	for start := time.Now(); time.Since(start) < duration; time.Sleep(d.interval) {
+		v, err := d.Magnetometer.ReadMagneticField()
+		if err != nil {
+			return NoMagnetometerCalibration, err
+		}
+		readings = append(readings, v)
+	}
+	if c, err = NewMagnetometerCalibration(readings); err != nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.MagnetometerCalibration = c
+	return
+}
+
+// read reads the sensors, the field is the zero vector without a
+// magnetometer
+func (d *AHRSDriver) read() (gyro Vector, accel Vector, mag Vector, err error) {
+	if gyro, err = d.Gyroscope.ReadAngularVelocity(); err != nil {
+		return
+	}
+	if accel, err = d.Accelerometer.ReadAcceleration(); err != nil {
+		return
+	}
+	if d.Magnetometer != nil {
+		mag, err = d.Magnetometer.ReadMagneticField()
+	}
+	return
+}
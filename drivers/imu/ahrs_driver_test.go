@@ -0,0 +1,184 @@
+package imu
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*AHRSDriver)(nil)
+
+var _ gpio.YawSensor = (*AHRSDriver)(nil)
+
+type testSensor struct {
+	mutex  sync.Mutex
+	accel  Vector
+	gyro   Vector
+	fields []Vector
+	reads  int
+	err    error
+}
+
+func (s *testSensor) ReadAcceleration() (Vector, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.accel, s.err
+}
+
+func (s *testSensor) ReadAngularVelocity() (Vector, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.gyro, s.err
+}
+
+func (s *testSensor) ReadMagneticField() (Vector, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	field := s.fields[s.reads%len(s.fields)]
+	s.reads++
+	return field, s.err
+}
+
+func initTestAHRSDriver() (*AHRSDriver, *testSensor) {
+	s := &testSensor{
+		accel:  Vector{0, 0, StandardGravity},
+		fields: []Vector{earthField},
+	}
+	return NewAHRSDriver(s, s, s, time.Millisecond), s
+}
+
+func TestNewAHRSDriver(t *testing.T) {
+	s := &testSensor{}
+	d := NewAHRSDriver(s, s)
+	gobottest.Assert(t, d.interval, 10*time.Millisecond)
+	gobottest.Assert(t, d.Magnetometer, nil)
+	gobottest.Assert(t, d.Orientation(), Identity)
+	gobottest.Assert(t, d.Connection(), nil)
+	gobottest.Refute(t, d.Command("Orientation"), nil)
+	gobottest.Refute(t, d.Command("EulerAngles"), nil)
+	gobottest.Refute(t, d.Command("CalibrateGyroscope"), nil)
+	gobottest.Refute(t, d.Command("CalibrateMagnetometer"), nil)
+
+	mahony := NewMahonyFilter(1, 0)
+	d = NewAHRSDriver(s, s, s, mahony, 20*time.Millisecond)
+	gobottest.Assert(t, d.interval, 20*time.Millisecond)
+	gobottest.Assert(t, d.Fusion, Fusion(mahony))
+	gobottest.Assert(t, d.Magnetometer, Magnetometer(s))
+}
+
+func TestAHRSDriverSetName(t *testing.T) {
+	d, _ := initTestAHRSDriver()
+	d.SetName("mybot")
+	gobottest.Assert(t, d.Name(), "mybot")
+}
+
+func TestAHRSDriverStartHalt(t *testing.T) {
+	d, _ := initTestAHRSDriver()
+	orientation := make(chan Quaternion, 1)
+	euler := make(chan EulerAngles, 1)
+	d.Once(Orientation, func(data interface{}) {
+		orientation <- data.(Quaternion)
+	})
+	d.Once(Euler, func(data interface{}) {
+		euler <- data.(EulerAngles)
+	})
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Start(), nil)
+	select {
+	case <-orientation:
+	case <-time.After(time.Second):
+		t.Errorf("Orientation event was not published")
+	}
+	select {
+	case <-euler:
+	case <-time.After(time.Second):
+		t.Errorf("Euler event was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestAHRSDriverStartError(t *testing.T) {
+	d, s := initTestAHRSDriver()
+	s.err = errors.New("read error")
+	sem := make(chan error, 1)
+	d.Once(Error, func(data interface{}) {
+		sem <- data.(error)
+	})
+
+	gobottest.Assert(t, d.Start(), nil)
+	select {
+	case err := <-sem:
+		gobottest.Assert(t, err, s.err)
+	case <-time.After(time.Second):
+		t.Errorf("Error event was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestAHRSDriverUpdate(t *testing.T) {
+	d, s := initTestAHRSDriver()
+	s.gyro = Vector{0, 0, 0.5}
+	d.GyroscopeBias = Vector{0, 0, 0.5}
+	for i := 0; i < 100; i++ {
+		_, err := d.Update()
+		gobottest.Assert(t, err, nil)
+	}
+	e := d.EulerAngles()
+	assertNear(t, e.Roll, 0, 1e-6)
+	assertNear(t, e.Pitch, 0, 1e-6)
+	yaw, err := d.Yaw()
+	gobottest.Assert(t, err, nil)
+	assertNear(t, yaw, 0, 1e-6)
+
+	s.err = errors.New("read error")
+	_, err = d.Update()
+	gobottest.Assert(t, err, s.err)
+}
+
+func TestAHRSDriverCalibrateGyroscope(t *testing.T) {
+	d, s := initTestAHRSDriver()
+	s.gyro = Vector{0.25, -0.5, 0.125}
+	bias, err := d.CalibrateGyroscope(5)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, bias, s.gyro)
+	gobottest.Assert(t, d.GyroscopeBias, s.gyro)
+	gobottest.Assert(t, d.Command("CalibrateGyroscope")(map[string]interface{}{"samples": 2.0}), s.gyro)
+
+	_, err = d.CalibrateGyroscope(0)
+	gobottest.Assert(t, err, ErrNoSamples)
+
+	s.err = errors.New("read error")
+	_, err = d.CalibrateGyroscope(5)
+	gobottest.Assert(t, err, s.err)
+}
+
+func TestAHRSDriverCalibrateMagnetometer(t *testing.T) {
+	d, s := initTestAHRSDriver()
+	s.fields = []Vector{{1, 0, 0}, {-1, 2, 0}, {0, 1, 1}, {0, 1, -1}}
+	c, err := d.CalibrateMagnetometer(20 * time.Millisecond)
+	gobottest.Assert(t, err, nil)
+	assertNearVector(t, c.HardIron, Vector{0, 1, 0}, 1e-12)
+	gobottest.Assert(t, d.MagnetometerCalibration, c)
+
+	s.err = errors.New("read error")
+	_, err = d.CalibrateMagnetometer(20 * time.Millisecond)
+	gobottest.Assert(t, err, s.err)
+
+	d.Magnetometer = nil
+	_, err = d.CalibrateMagnetometer(20 * time.Millisecond)
+	gobottest.Assert(t, err, ErrNoMagnetometer)
+	gobottest.Assert(t, d.Command("CalibrateMagnetometer")(map[string]interface{}{"duration": 20.0}), ErrNoMagnetometer)
+}
+
+func TestAHRSDriverCommands(t *testing.T) {
+	d, _ := initTestAHRSDriver()
+	gobottest.Assert(t, d.Command("Orientation")(map[string]interface{}{}), Identity)
+	gobottest.Assert(t, d.Command("EulerAngles")(map[string]interface{}{}), EulerAngles{})
+}
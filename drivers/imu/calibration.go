@@ -0,0 +1,66 @@
+package imu
+
+import "math"
+
+// MagnetometerCalibration corrects the hard iron offset, a constant field of
+// magnetized parts near the sensor, and the soft iron distortion, which
+// stretches the field, of a magnetometer. The corrected field is
+// SoftIron * (field - HardIron).
+type MagnetometerCalibration struct {
+	HardIron Vector
+	SoftIron [3][3]float64
+}
+
+// NoMagnetometerCalibration leaves the field as it is
+var NoMagnetometerCalibration = MagnetometerCalibration{
+	SoftIron: [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+}
+
+// NewMagnetometerCalibration returns the calibration of a magnetometer from
+// samples taken while it was turned through all orientations. The hard iron
+// offset is the center of the samples, and the soft iron correction scales
+// every axis to the mean range of the axes.
+func NewMagnetometerCalibration(samples []Vector) (MagnetometerCalibration, error) {
+	if len(samples) == 0 {
+		return NoMagnetometerCalibration, ErrNoSamples
+	}
+	min, max := samples[0], samples[0]
+	for _, s := range samples[1:] {
+		min = Vector{math.Min(min.X, s.X), math.Min(min.Y, s.Y), math.Min(min.Z, s.Z)}
+		max = Vector{math.Max(max.X, s.X), math.Max(max.Y, s.Y), math.Max(max.Z, s.Z)}
+	}
+
+	c := NoMagnetometerCalibration
+	c.HardIron = max.Add(min).Scale(0.5)
+	r := max.Sub(min).Scale(0.5)
+	mean := (r.X + r.Y + r.Z) / 3
+	for i, ri := range []float64{r.X, r.Y, r.Z} {
+		if ri > 0 {
+			c.SoftIron[i][i] = mean / ri
+		}
+	}
+	return c, nil
+}
+
+// Apply returns the corrected field
+func (c MagnetometerCalibration) Apply(field Vector) Vector {
+	v := field.Sub(c.HardIron)
+	s := c.SoftIron
+	return Vector{
+		s[0][0]*v.X + s[0][1]*v.Y + s[0][2]*v.Z,
+		s[1][0]*v.X + s[1][1]*v.Y + s[1][2]*v.Z,
+		s[2][0]*v.X + s[2][1]*v.Y + s[2][2]*v.Z,
+	}
+}
+
+// meanVector returns the mean of the samples
+func meanVector(samples []Vector) (Vector, error) {
+	if len(samples) == 0 {
+		return Vector{}, ErrNoSamples
+	}
+	sum := Vector{}
+	for _, s := range samples {
+		sum = sum.Add(s)
+	}
+	return sum.Scale(1 / float64(len(samples))), nil
+}
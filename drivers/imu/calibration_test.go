@@ -0,0 +1,39 @@
+package imu
+
+import (
+	"math"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestMagnetometerCalibration(t *testing.T) {
+	_, err := NewMagnetometerCalibration(nil)
+	gobottest.Assert(t, err, ErrNoSamples)
+
+	// a sphere of radius 1 stretched to 2 along x and shifted
+	offset := Vector{3, -1, 0.5}
+	samples := []Vector{}
+	for i := 0; i < 360; i += 10 {
+		a := float64(i) * math.Pi / 180
+		samples = append(samples,
+			Vector{2 * math.Cos(a), math.Sin(a), 0}.Add(offset),
+			Vector{2 * math.Cos(a), 0, math.Sin(a)}.Add(offset))
+	}
+	c, err := NewMagnetometerCalibration(samples)
+	gobottest.Assert(t, err, nil)
+	assertNearVector(t, c.HardIron, offset, 1e-9)
+	for _, s := range samples {
+		assertNear(t, c.Apply(s).Norm(), 4.0/3, 1e-9)
+	}
+
+	gobottest.Assert(t, NoMagnetometerCalibration.Apply(Vector{1, 2, 3}), Vector{1, 2, 3})
+}
+
+func TestMeanVector(t *testing.T) {
+	_, err := meanVector(nil)
+	gobottest.Assert(t, err, ErrNoSamples)
+	mean, err := meanVector([]Vector{{1, 2, 3}, {3, 2, 1}})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, mean, Vector{2, 2, 2})
+}
@@ -0,0 +1,48 @@
+/*
+Package imu provides the interfaces shared by Gobot drivers for
+accelerometers, gyroscopes and magnetometers, and an AHRS driver which fuses
+their readings into an orientation.
+
+Installing:
+
+	go get -d -u gobot.io/x/gobot
+
+Example:
+
+	package main
+
+	import (
+		"fmt"
+		"time"
+
+		"gobot.io/x/gobot"
+		"gobot.io/x/gobot/drivers/i2c"
+		"gobot.io/x/gobot/drivers/imu"
+		"gobot.io/x/gobot/platforms/raspi"
+	)
+
+	func main() {
+		r := raspi.NewAdaptor()
+		mpu6050 := i2c.NewMPU6050Driver(r)
+		ahrs := imu.NewAHRSDriver(mpu6050, mpu6050, 20*time.Millisecond)
+
+		work := func() {
+			ahrs.CalibrateGyroscope(100)
+			ahrs.On(imu.Euler, func(data interface{}) {
+				fmt.Println("orientation", data)
+			})
+		}
+
+		robot := gobot.NewRobot("imuBot",
+			[]gobot.Connection{r},
+			[]gobot.Device{mpu6050, ahrs},
+			work,
+		)
+
+		robot.Start()
+	}
+
+For further information refer to imu README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/imu/README.md
+*/
+package imu // import "gobot.io/x/gobot/drivers/imu"
@@ -0,0 +1,212 @@
+package imu
+
+import "math"
+
+// Fusion estimates the orientation of a sensor from its angular velocity,
+// acceleration and, optionally, magnetic field
+type Fusion interface {
+	// Update integrates the readings of the last dt seconds and returns the
+	// orientation. Without a magnetometer the field is the zero vector, and
+	// the yaw drifts with the bias of the gyroscope.
+	Update(gyro Vector, accel Vector, mag Vector, dt float64) Quaternion
+	// Orientation returns the current orientation
+	Orientation() Quaternion
+	// Reset resets the orientation to the identity
+	Reset()
+}
+
+// MadgwickFilter is the gradient descent orientation filter of Sebastian
+// Madgwick
+type MadgwickFilter struct {
+	// Beta is the gain of the correction by the accelerometer and
+	// magnetometer, higher ones converge faster and follow noise more
+	Beta float64
+	q    Quaternion
+}
+
+// NewMadgwickFilter returns a new MadgwickFilter with the gain beta, e.g. 0.1
+func NewMadgwickFilter(beta float64) *MadgwickFilter {
+	return &MadgwickFilter{Beta: beta, q: Identity}
+}
+
+// Orientation returns the current orientation
+func (f *MadgwickFilter) Orientation() Quaternion { return f.q }
+
+// Reset resets the orientation to the identity
+func (f *MadgwickFilter) Reset() { f.q = Identity }
+
+// Update integrates the readings of the last dt seconds and returns the
+// orientation
+func (f *MadgwickFilter) Update(gyro Vector, accel Vector, mag Vector, dt float64) Quaternion {
+	q0, q1, q2, q3 := f.q.W, f.q.X, f.q.Y, f.q.Z
+	gx, gy, gz := gyro.X, gyro.Y, gyro.Z
+
+	// rate of change of the quaternion from the gyroscope
+	qDot0 := 0.5 * (-q1*gx - q2*gy - q3*gz)
+	qDot1 := 0.5 * (q0*gx + q2*gz - q3*gy)
+	qDot2 := 0.5 * (q0*gy - q1*gz + q3*gx)
+	qDot3 := 0.5 * (q0*gz + q1*gy - q2*gx)
+
+	if a, ok := accel.normalized(); ok {
+		ax, ay, az := a.X, a.Y, a.Z
+		var s0, s1, s2, s3 float64
+		if m, ok := mag.normalized(); ok {
+			mx, my, mz := m.X, m.Y, m.Z
+			_2q0mx := 2 * q0 * mx
+			_2q0my := 2 * q0 * my
+			_2q0mz := 2 * q0 * mz
+			_2q1mx := 2 * q1 * mx
+			_2q0 := 2 * q0
+			_2q1 := 2 * q1
+			_2q2 := 2 * q2
+			_2q3 := 2 * q3
+			_2q0q2 := 2 * q0 * q2
+			_2q2q3 := 2 * q2 * q3
+			q0q0 := q0 * q0
+			q0q1 := q0 * q1
+			q0q2 := q0 * q2
+			q0q3 := q0 * q3
+			q1q1 := q1 * q1
+			q1q2 := q1 * q2
+			q1q3 := q1 * q3
+			q2q2 := q2 * q2
+			q2q3 := q2 * q3
+			q3q3 := q3 * q3
+
+			// reference direction of the magnetic field of the earth
+			hx := mx*q0q0 - _2q0my*q3 + _2q0mz*q2 + mx*q1q1 + _2q1*my*q2 + _2q1*mz*q3 - mx*q2q2 - mx*q3q3
+			hy := _2q0mx*q3 + my*q0q0 - _2q0mz*q1 + _2q1mx*q2 - my*q1q1 + my*q2q2 + _2q2*mz*q3 - my*q3q3
+			_2bx := math.Sqrt(hx*hx + hy*hy)
+			_2bz := -_2q0mx*q2 + _2q0my*q1 + mz*q0q0 + _2q1mx*q3 - mz*q1q1 + _2q2*my*q3 - mz*q2q2 + mz*q3q3
+			_4bx := 2 * _2bx
+			_4bz := 2 * _2bz
+
+			// errors of the estimated directions of gravity and the field
+			fx := 2*q1q3 - _2q0q2 - ax
+			fy := 2*q0q1 + _2q2q3 - ay
+			fz := 1 - 2*q1q1 - 2*q2q2 - az
+			fmx := _2bx*(0.5-q2q2-q3q3) + _2bz*(q1q3-q0q2) - mx
+			fmy := _2bx*(q1q2-q0q3) + _2bz*(q0q1+q2q3) - my
+			fmz := _2bx*(q0q2+q1q3) + _2bz*(0.5-q1q1-q2q2) - mz
+
+			// gradient of the errors
+			s0 = -_2q2*fx + _2q1*fy - _2bz*q2*fmx + (-_2bx*q3+_2bz*q1)*fmy + _2bx*q2*fmz
+			s1 = _2q3*fx + _2q0*fy - 4*q1*fz + _2bz*q3*fmx + (_2bx*q2+_2bz*q0)*fmy + (_2bx*q3-_4bz*q1)*fmz
+			s2 = -_2q0*fx + _2q3*fy - 4*q2*fz + (-_4bx*q2-_2bz*q0)*fmx + (_2bx*q1+_2bz*q3)*fmy + (_2bx*q0-_4bz*q2)*fmz
+			s3 = _2q1*fx + _2q2*fy + (-_4bx*q3+_2bz*q1)*fmx + (-_2bx*q0+_2bz*q2)*fmy + _2bx*q1*fmz
+		} else {
+			_2q0 := 2 * q0
+			_2q1 := 2 * q1
+			_2q2 := 2 * q2
+			_2q3 := 2 * q3
+			_4q0 := 4 * q0
+			_4q1 := 4 * q1
+			_4q2 := 4 * q2
+			_8q1 := 8 * q1
+			_8q2 := 8 * q2
+			q0q0 := q0 * q0
+			q1q1 := q1 * q1
+			q2q2 := q2 * q2
+			q3q3 := q3 * q3
+
+			s0 = _4q0*q2q2 + _2q2*ax + _4q0*q1q1 - _2q1*ay
+			s1 = _4q1*q3q3 - _2q3*ax + 4*q0q0*q1 - _2q0*ay - _4q1 + _8q1*q1q1 + _8q1*q2q2 + _4q1*az
+			s2 = 4*q0q0*q2 + _2q0*ax + _4q2*q3q3 - _2q3*ay - _4q2 + _8q2*q1q1 + _8q2*q2q2 + _4q2*az
+			s3 = 4*q1q1*q3 - _2q1*ax + 4*q2q2*q3 - _2q2*ay
+		}
+
+		if n := math.Sqrt(s0*s0 + s1*s1 + s2*s2 + s3*s3); n > 0 {
+			qDot0 -= f.Beta * s0 / n
+			qDot1 -= f.Beta * s1 / n
+			qDot2 -= f.Beta * s2 / n
+			qDot3 -= f.Beta * s3 / n
+		}
+	}
+
+	f.q = Quaternion{q0 + qDot0*dt, q1 + qDot1*dt, q2 + qDot2*dt, q3 + qDot3*dt}.normalized()
+	return f.q
+}
+
+// MahonyFilter is the complementary orientation filter of Robert Mahony,
+// which corrects the gyroscope by a proportional and integral feedback of
+// the accelerometer and magnetometer
+type MahonyFilter struct {
+	// Kp is the proportional gain, e.g. 1
+	Kp float64
+	// Ki is the integral gain, which learns the bias of the gyroscope, e.g.
+	// 0.1 or 0 to disable it
+	Ki       float64
+	q        Quaternion
+	integral Vector
+}
+
+// NewMahonyFilter returns a new MahonyFilter with the proportional and
+// integral gains
+func NewMahonyFilter(kp float64, ki float64) *MahonyFilter {
+	return &MahonyFilter{Kp: kp, Ki: ki, q: Identity}
+}
+
+// Orientation returns the current orientation
+func (f *MahonyFilter) Orientation() Quaternion { return f.q }
+
+// Reset resets the orientation to the identity and the integral feedback
+func (f *MahonyFilter) Reset() {
+	f.q = Identity
+	f.integral = Vector{}
+}
+
+// Update integrates the readings of the last dt seconds and returns the
+// orientation
+func (f *MahonyFilter) Update(gyro Vector, accel Vector, mag Vector, dt float64) Quaternion {
+	q0, q1, q2, q3 := f.q.W, f.q.X, f.q.Y, f.q.Z
+
+	if a, ok := accel.normalized(); ok {
+		q0q0 := q0 * q0
+		q0q1 := q0 * q1
+		q0q2 := q0 * q2
+		q0q3 := q0 * q3
+		q1q1 := q1 * q1
+		q1q2 := q1 * q2
+		q1q3 := q1 * q3
+		q2q2 := q2 * q2
+		q2q3 := q2 * q3
+		q3q3 := q3 * q3
+
+		// estimated direction of gravity, half of it
+		v := Vector{q1q3 - q0q2, q0q1 + q2q3, q0q0 - 0.5 + q3q3}
+		// the error is the cross product of the measured and estimated
+		// directions
+		e := cross(a, v)
+
+		if m, ok := mag.normalized(); ok {
+			// reference direction of the magnetic field of the earth
+			hx := 2 * (m.X*(0.5-q2q2-q3q3) + m.Y*(q1q2-q0q3) + m.Z*(q1q3+q0q2))
+			hy := 2 * (m.X*(q1q2+q0q3) + m.Y*(0.5-q1q1-q3q3) + m.Z*(q2q3-q0q1))
+			bx := math.Sqrt(hx*hx + hy*hy)
+			bz := 2 * (m.X*(q1q3-q0q2) + m.Y*(q2q3+q0q1) + m.Z*(0.5-q1q1-q2q2))
+
+			// estimated direction of the field, half of it
+			w := Vector{
+				bx*(0.5-q2q2-q3q3) + bz*(q1q3-q0q2),
+				bx*(q1q2-q0q3) + bz*(q0q1+q2q3),
+				bx*(q0q2+q1q3) + bz*(0.5-q1q1-q2q2),
+			}
+			e = e.Add(cross(m, w))
+		}
+
+		if f.Ki > 0 {
+			f.integral = f.integral.Add(e.Scale(2 * f.Ki * dt))
+			gyro = gyro.Add(f.integral)
+		}
+		gyro = gyro.Add(e.Scale(2 * f.Kp))
+	}
+
+	g := gyro.Scale(0.5 * dt)
+	f.q = Quaternion{
+		q0 - q1*g.X - q2*g.Y - q3*g.Z,
+		q1 + q0*g.X + q2*g.Z - q3*g.Y,
+		q2 + q0*g.Y - q1*g.Z + q3*g.X,
+		q3 + q0*g.Z + q1*g.Y - q2*g.X,
+	}.normalized()
+	return f.q
+}
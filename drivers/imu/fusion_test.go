@@ -0,0 +1,80 @@
+package imu
+
+import (
+	"math"
+	"testing"
+)
+
+var _ Fusion = (*MadgwickFilter)(nil)
+var _ Fusion = (*MahonyFilter)(nil)
+
+// earthField is the magnetic field of the earth in tesla, to the north and
+// down
+var earthField = Vector{20e-6, 0, -45e-6}
+
+// sensorReadings returns the acceleration and field read by a sensor at
+// rest in the orientation
+func sensorReadings(orientation Quaternion) (accel Vector, mag Vector) {
+	toSensor := conjugate(orientation)
+	return toSensor.Rotate(Vector{0, 0, StandardGravity}), toSensor.Rotate(earthField)
+}
+
+func converge(f Fusion, accel Vector, mag Vector, steps int) EulerAngles {
+	for i := 0; i < steps; i++ {
+		f.Update(Vector{}, accel, mag, 0.01)
+	}
+	return f.Orientation().Euler()
+}
+
+func testFusion(t *testing.T, f Fusion) {
+	want := EulerAngles{Roll: 0.35, Pitch: -0.25, Yaw: 0.5}
+	accel, mag := sensorReadings(eulerQuaternion(want))
+	got := converge(f, accel, mag, 3000)
+	assertNear(t, got.Roll, want.Roll, 0.01)
+	assertNear(t, got.Pitch, want.Pitch, 0.01)
+	assertNear(t, got.Yaw, want.Yaw, 0.01)
+
+	// without a magnetometer the tilt is still found
+	f.Reset()
+	got = converge(f, accel, Vector{}, 3000)
+	assertNear(t, got.Roll, want.Roll, 0.01)
+	assertNear(t, got.Pitch, want.Pitch, 0.01)
+	f.Reset()
+	gotQ := f.Orientation()
+	if gotQ != Identity {
+		t.Errorf("%v should be reset to the identity", gotQ)
+	}
+}
+
+func testFusionGyroscope(t *testing.T, f Fusion) {
+	// turning counterclockwise at 1 rad/s for 1 second without correction
+	for i := 0; i < 100; i++ {
+		f.Update(Vector{0, 0, 1}, Vector{}, Vector{}, 0.01)
+	}
+	assertNear(t, f.Orientation().Euler().Yaw, 1, 1e-4)
+}
+
+func TestMadgwickFilter(t *testing.T) {
+	testFusion(t, NewMadgwickFilter(0.5))
+	testFusionGyroscope(t, NewMadgwickFilter(0.5))
+}
+
+func TestMahonyFilter(t *testing.T) {
+	testFusion(t, NewMahonyFilter(2, 0))
+	testFusionGyroscope(t, NewMahonyFilter(2, 0))
+}
+
+func TestMahonyFilterBias(t *testing.T) {
+	// the integral feedback learns the bias of the gyroscope
+	f := NewMahonyFilter(1, 0.5)
+	accel, mag := sensorReadings(Identity)
+	bias := Vector{0.02, -0.01, 0.03}
+	for i := 0; i < 20000; i++ {
+		f.Update(bias, accel, mag, 0.01)
+	}
+	e := f.Orientation().Euler()
+	assertNear(t, e.Roll, 0, 0.001)
+	assertNear(t, e.Pitch, 0, 0.001)
+	assertNear(t, e.Yaw, 0, 0.001)
+	assertNear(t, math.Abs(f.integral.X+bias.X), 0, 0.001)
+}
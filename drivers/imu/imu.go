@@ -0,0 +1,128 @@
+package imu
+
+import (
+	"errors"
+	"math"
+)
+
+// StandardGravity is the acceleration of gravity in m/s²
+const StandardGravity = 9.80665
+
+const (
+	// Error event
+	Error = "error"
+	// Orientation event
+	Orientation = "orientation"
+	// Euler event
+	Euler = "euler"
+)
+
+var (
+	// ErrNoData is the error resulting when a sensor is read which has not
+	// received any data yet
+	ErrNoData = errors.New("IMU sensor has no data yet")
+	// ErrNoSamples is the error resulting when a calibration has no samples
+	ErrNoSamples = errors.New("IMU calibration has no samples")
+	// ErrNoMagnetometer is the error resulting when the magnetometer of an
+	// AHRS without one is calibrated
+	ErrNoMagnetometer = errors.New("AHRS has no magnetometer")
+)
+
+// Accelerometer is a sensor of the acceleration in m/s², which includes
+// gravity, e.g. (0, 0, 9.81) when it lies flat at rest
+type Accelerometer interface {
+	ReadAcceleration() (Vector, error)
+}
+
+// Gyroscope is a sensor of the angular velocity in rad/s, counterclockwise
+// around its axes
+type Gyroscope interface {
+	ReadAngularVelocity() (Vector, error)
+}
+
+// Magnetometer is a sensor of the magnetic field in tesla
+type Magnetometer interface {
+	ReadMagneticField() (Vector, error)
+}
+
+// Vector is a three dimensional vector
+type Vector struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// Add returns the sum of the vectors
+func (v Vector) Add(w Vector) Vector { return Vector{v.X + w.X, v.Y + w.Y, v.Z + w.Z} }
+
+// Sub returns the difference of the vectors
+func (v Vector) Sub(w Vector) Vector { return Vector{v.X - w.X, v.Y - w.Y, v.Z - w.Z} }
+
+// Scale returns the vector multiplied by f
+func (v Vector) Scale(f float64) Vector { return Vector{v.X * f, v.Y * f, v.Z * f} }
+
+// Norm returns the length of the vector
+func (v Vector) Norm() float64 { return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z) }
+
+// normalized returns the vector of length 1 in the direction of v, or false
+// if v is 0
+func (v Vector) normalized() (Vector, bool) {
+	n := v.Norm()
+	if n == 0 {
+		return v, false
+	}
+	return v.Scale(1 / n), true
+}
+
+// Quaternion is a rotation, which describes the orientation of a sensor
+// relative to the earth
+type Quaternion struct {
+	W float64
+	X float64
+	Y float64
+	Z float64
+}
+
+// Identity is the quaternion of no rotation
+var Identity = Quaternion{W: 1}
+
+// normalized returns the quaternion of length 1 in the direction of q
+func (q Quaternion) normalized() Quaternion {
+	n := math.Sqrt(q.W*q.W + q.X*q.X + q.Y*q.Y + q.Z*q.Z)
+	if n == 0 {
+		return Identity
+	}
+	return Quaternion{q.W / n, q.X / n, q.Y / n, q.Z / n}
+}
+
+// EulerAngles are the angles in radians of an orientation, which is reached
+// from the reference by turning counterclockwise by Yaw around the z axis,
+// then by Pitch around the new y axis and then by Roll around the new x axis
+type EulerAngles struct {
+	Roll  float64
+	Pitch float64
+	Yaw   float64
+}
+
+// Euler returns the Euler angles of the quaternion
+func (q Quaternion) Euler() EulerAngles {
+	sinPitch := 2 * (q.W*q.Y - q.Z*q.X)
+	return EulerAngles{
+		Roll:  math.Atan2(2*(q.W*q.X+q.Y*q.Z), 1-2*(q.X*q.X+q.Y*q.Y)),
+		Pitch: math.Asin(math.Max(-1, math.Min(1, sinPitch))),
+		Yaw:   math.Atan2(2*(q.W*q.Z+q.X*q.Y), 1-2*(q.Y*q.Y+q.Z*q.Z)),
+	}
+}
+
+// Rotate returns the vector v in the frame of the sensor rotated into the
+// frame of the earth
+func (q Quaternion) Rotate(v Vector) Vector {
+	// v' = v + 2w(u×v) + 2u×(u×v) with u the vector part of q
+	u := Vector{q.X, q.Y, q.Z}
+	t := cross(u, v).Scale(2)
+	return v.Add(t.Scale(q.W)).Add(cross(u, t))
+}
+
+func cross(a, b Vector) Vector {
+	return Vector{a.Y*b.Z - a.Z*b.Y, a.Z*b.X - a.X*b.Z, a.X*b.Y - a.Y*b.X}
+}
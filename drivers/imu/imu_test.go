@@ -0,0 +1,71 @@
+package imu
+
+import (
+	"math"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+// eulerQuaternion returns the quaternion of the Euler angles
+func eulerQuaternion(e EulerAngles) Quaternion {
+	cr, sr := math.Cos(e.Roll/2), math.Sin(e.Roll/2)
+	cp, sp := math.Cos(e.Pitch/2), math.Sin(e.Pitch/2)
+	cy, sy := math.Cos(e.Yaw/2), math.Sin(e.Yaw/2)
+	return Quaternion{
+		W: cr*cp*cy + sr*sp*sy,
+		X: sr*cp*cy - cr*sp*sy,
+		Y: cr*sp*cy + sr*cp*sy,
+		Z: cr*cp*sy - sr*sp*cy,
+	}
+}
+
+func conjugate(q Quaternion) Quaternion {
+	return Quaternion{q.W, -q.X, -q.Y, -q.Z}
+}
+
+func assertNear(t *testing.T, a, b, tolerance float64) {
+	if math.Abs(a-b) > tolerance {
+		t.Helper()
+		t.Errorf("%v should be within %v of %v", a, tolerance, b)
+	}
+}
+
+func assertNearVector(t *testing.T, a, b Vector, tolerance float64) {
+	t.Helper()
+	assertNear(t, a.X, b.X, tolerance)
+	assertNear(t, a.Y, b.Y, tolerance)
+	assertNear(t, a.Z, b.Z, tolerance)
+}
+
+func TestVector(t *testing.T) {
+	v := Vector{1, 2, 2}
+	gobottest.Assert(t, v.Norm(), 3.0)
+	gobottest.Assert(t, v.Add(Vector{1, 1, 1}), Vector{2, 3, 3})
+	gobottest.Assert(t, v.Sub(Vector{1, 1, 1}), Vector{0, 1, 1})
+	gobottest.Assert(t, v.Scale(2), Vector{2, 4, 4})
+	n, ok := v.normalized()
+	gobottest.Assert(t, ok, true)
+	assertNear(t, n.Norm(), 1, 1e-12)
+	_, ok = Vector{}.normalized()
+	gobottest.Assert(t, ok, false)
+}
+
+func TestQuaternionEuler(t *testing.T) {
+	gobottest.Assert(t, Identity.Euler(), EulerAngles{})
+	e := EulerAngles{Roll: 0.3, Pitch: -0.2, Yaw: 2.5}
+	got := eulerQuaternion(e).Euler()
+	assertNear(t, got.Roll, e.Roll, 1e-12)
+	assertNear(t, got.Pitch, e.Pitch, 1e-12)
+	assertNear(t, got.Yaw, e.Yaw, 1e-12)
+	gobottest.Assert(t, Quaternion{}.normalized(), Identity)
+}
+
+func TestQuaternionRotate(t *testing.T) {
+	// a counterclockwise yaw of 90 degrees turns x into y
+	q := eulerQuaternion(EulerAngles{Yaw: math.Pi / 2})
+	assertNearVector(t, q.Rotate(Vector{1, 0, 0}), Vector{0, 1, 0}, 1e-12)
+	// a roll of 90 degrees turns y into z
+	q = eulerQuaternion(EulerAngles{Roll: math.Pi / 2})
+	assertNearVector(t, q.Rotate(Vector{0, 1, 0}), Vector{0, 0, 1}, 1e-12)
+}
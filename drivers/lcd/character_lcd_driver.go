@@ -0,0 +1,414 @@
+package lcd
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// CharacterLCDDriver is a driver for character LCDs with an HD44780
+// compatible controller, e.g. 16x2 or 20x4 ones, connected by a Bus. It
+// keeps a word wrapped text buffer, of which it shows as many lines as fit,
+// and scrolls texts longer than a row as marquees.
+type CharacterLCDDriver struct {
+	name     string
+	Bus      Bus
+	columns  int
+	rows     int
+	control  byte
+	row      int
+	lines    []string
+	top      int
+	marquees map[int]chan bool
+	mutex    *sync.Mutex
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewCharacterLCDDriver returns a new CharacterLCDDriver given a Bus, e.g. a
+// GPIOBus, an i2c.LCDBackpackDriver or an i2c.JHD1313M1Driver, and the size
+// of the display in characters.
+//
+// Adds the following API Commands:
+// 	"Clear" - See CharacterLCDDriver.Clear
+// 	"Home" - See CharacterLCDDriver.Home
+// 	"Write" - See CharacterLCDDriver.Write, with the "text"
+// 	"SetCursor" - See CharacterLCDDriver.SetCursor, with the "column" and "row"
+// 	"ShowCursor" - See CharacterLCDDriver.ShowCursor, with "on" true or false
+// 	"BlinkCursor" - See CharacterLCDDriver.BlinkCursor, with "on" true or false
+// 	"Print" - See CharacterLCDDriver.Print, with the "text"
+// 	"ScrollTo" - See CharacterLCDDriver.ScrollTo, with the "line"
+// 	"Marquee" - See CharacterLCDDriver.Marquee, with the "row", "text" and "interval" in milliseconds
+// 	"StopMarquee" - See CharacterLCDDriver.StopMarquee, with the "row"
+func NewCharacterLCDDriver(bus Bus, columns int, rows int) *CharacterLCDDriver {
+	d := &CharacterLCDDriver{
+		name:      gobot.DefaultName("CharacterLCD"),
+		Bus:       bus,
+		columns:   columns,
+		rows:      rows,
+		control:   displayOn,
+		marquees:  map[int]chan bool{},
+		mutex:     &sync.Mutex{},
+		Eventer:   gobot.NewEventer(),
+		Commander: gobot.NewCommander(),
+	}
+
+	d.AddEvent(Error)
+
+	d.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		return d.Clear()
+	})
+	d.AddCommand("Home", func(params map[string]interface{}) interface{} {
+		return d.Home()
+	})
+	d.AddCommand("Write", func(params map[string]interface{}) interface{} {
+		text, _ := params["text"].(string)
+		return d.Write(text)
+	})
+	d.AddCommand("SetCursor", func(params map[string]interface{}) interface{} {
+		column, _ := params["column"].(float64)
+		row, _ := params["row"].(float64)
+		return d.SetCursor(int(column), int(row))
+	})
+	d.AddCommand("ShowCursor", func(params map[string]interface{}) interface{} {
+		on, _ := params["on"].(bool)
+		return d.ShowCursor(on)
+	})
+	d.AddCommand("BlinkCursor", func(params map[string]interface{}) interface{} {
+		on, _ := params["on"].(bool)
+		return d.BlinkCursor(on)
+	})
+	d.AddCommand("Print", func(params map[string]interface{}) interface{} {
+		text, _ := params["text"].(string)
+		return d.Print(text)
+	})
+	d.AddCommand("ScrollTo", func(params map[string]interface{}) interface{} {
+		line, _ := params["line"].(float64)
+		return d.ScrollTo(int(line))
+	})
+	d.AddCommand("Marquee", func(params map[string]interface{}) interface{} {
+		row, _ := params["row"].(float64)
+		text, _ := params["text"].(string)
+		ms, _ := params["interval"].(float64)
+		return d.Marquee(int(row), text, time.Duration(ms*float64(time.Millisecond)))
+	})
+	d.AddCommand("StopMarquee", func(params map[string]interface{}) interface{} {
+		row, _ := params["row"].(float64)
+		d.StopMarquee(int(row))
+		return nil
+	})
+
+	return d
+}
+
+// Name returns the CharacterLCDDrivers name
+func (d *CharacterLCDDriver) Name() string { return d.name }
+
+// SetName sets the CharacterLCDDrivers name
+func (d *CharacterLCDDriver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the Bus, if it has one
+func (d *CharacterLCDDriver) Connection() gobot.Connection {
+	if bus, ok := d.Bus.(interface {
+		Connection() gobot.Connection
+	}); ok {
+		return bus.Connection()
+	}
+	return nil
+}
+
+// Size returns the number of columns and rows of the display
+func (d *CharacterLCDDriver) Size() (columns int, rows int) { return d.columns, d.rows }
+
+// Start initializes the controller and clears the display. A Bus which is a
+// driver is started on its own before.
+func (d *CharacterLCDDriver) Start() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err = d.Bus.Init(); err != nil {
+		return
+	}
+	var lines byte
+	if d.rows > 1 {
+		lines = twoLines
+	}
+	for _, command := range []byte{functionSet | lines, displayControl | d.control, entryModeSet | entryLeft} {
+		if err = d.Bus.WriteCommand(command); err != nil {
+			return
+		}
+	}
+	return d.clear()
+}
+
+// Halt stops the marquees
+func (d *CharacterLCDDriver) Halt() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.stopMarquees()
+	return
+}
+
+// Clear clears the display and the text buffer, and stops the marquees
+func (d *CharacterLCDDriver) Clear() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.stopMarquees()
+	d.lines = nil
+	d.top = 0
+	return d.clear()
+}
+
+// Home sets the cursor to the first column of the first row, and undoes the
+// shifts of the display
+func (d *CharacterLCDDriver) Home() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err = d.Bus.WriteCommand(returnHome); err != nil {
+		return
+	}
+	d.row = 0
+	time.Sleep(2 * time.Millisecond)
+	return
+}
+
+// SetCursor sets the cursor to a column and row, counted from 0
+func (d *CharacterLCDDriver) SetCursor(column int, row int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.setCursor(column, row)
+}
+
+// ShowCursor shows or hides the underline cursor
+func (d *CharacterLCDDriver) ShowCursor(on bool) error {
+	return d.setControl(cursorOn, on)
+}
+
+// BlinkCursor turns the blinking of the character at the cursor on or off
+func (d *CharacterLCDDriver) BlinkCursor(on bool) error {
+	return d.setControl(blinkOn, on)
+}
+
+// Display turns the display on or off, which keeps its content
+func (d *CharacterLCDDriver) Display(on bool) error {
+	return d.setControl(displayOn, on)
+}
+
+// Shift shifts the content of all rows by one character, to the right or to
+// the left
+func (d *CharacterLCDDriver) Shift(right bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	command := byte(cursorShift | displayMove)
+	if right {
+		command |= moveRight
+	}
+	return d.Bus.WriteCommand(command)
+}
+
+// Write writes the text at the cursor. A '\n' moves the cursor to the first
+// column of the next row.
+func (d *CharacterLCDDriver) Write(text string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.write(text)
+}
+
+// SetCustomChar sets one of the 8 CGRAM locations to a custom character of 8
+// rows of 5 pixels, e.g. one of i2c.CustomLCDChars, which is shown for the
+// byte of the location, from 0 to 7. The cursor is moved to the first
+// column of the first row.
+func (d *CharacterLCDDriver) SetCustomChar(location int, charMap [8]byte) (err error) {
+	if location < 0 || location > 7 {
+		return ErrInvalidCustomChar
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err = d.Bus.WriteCommand(setCGRAMAddr | byte(location<<3)); err != nil {
+		return
+	}
+	for _, row := range charMap {
+		if err = d.Bus.WriteData(row); err != nil {
+			return
+		}
+	}
+	return d.setCursor(0, 0)
+}
+
+// Print replaces the text buffer with the word wrapped text, and shows its
+// first lines. The marquees are stopped.
+func (d *CharacterLCDDriver) Print(text string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.stopMarquees()
+	d.lines = WordWrap(text, d.columns)
+	d.top = 0
+	return d.show()
+}
+
+// Lines returns the word wrapped lines of the text buffer
+func (d *CharacterLCDDriver) Lines() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return append([]string{}, d.lines...)
+}
+
+// ScrollTo shows the lines of the text buffer from a line on, which is at
+// most the number of lines which do not fit on the display
+func (d *CharacterLCDDriver) ScrollTo(line int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if line < 0 || line > len(d.lines)-d.rows && line > 0 {
+		return ErrInvalidPosition
+	}
+	d.top = line
+	return d.show()
+}
+
+// Marquee shows the text on a row, scrolling it by one character at every
+// interval if it is longer than the row
+func (d *CharacterLCDDriver) Marquee(row int, text string, interval time.Duration) (err error) {
+	if row < 0 || row >= d.rows {
+		return ErrInvalidPosition
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.stopMarquee(row)
+	if err = d.setCursor(0, row); err != nil {
+		return
+	}
+	if len(text) <= d.columns {
+		return d.write(d.pad(text))
+	}
+	if err = d.write(text[:d.columns]); err != nil {
+		return
+	}
+
+	halt := make(chan bool)
+	d.marquees[row] = halt
+	go func() {
+		// a gap separates the end of the text from its start
+		text := text + strings.Repeat(" ", 3)
+		for offset := 1; ; offset = (offset + 1) % len(text) {
+			select {
+			case <-time.After(interval):
+			case <-halt:
+				return
+			}
+			window := (text + text)[offset : offset+d.columns]
+			d.mutex.Lock()
+			select {
+			case <-halt:
+				// stopped while waiting for the lock
+				d.mutex.Unlock()
+				return
+			default:
+			}
+			err := d.setCursor(0, row)
+			if err == nil {
+				err = d.write(window)
+			}
+			d.mutex.Unlock()
+			if err != nil {
+				d.Publish(Error, err)
+			}
+		}
+	}()
+	return
+}
+
+// StopMarquee stops the marquee of a row, which keeps its text
+func (d *CharacterLCDDriver) StopMarquee(row int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.stopMarquee(row)
+}
+
+func (d *CharacterLCDDriver) stopMarquee(row int) {
+	if halt, ok := d.marquees[row]; ok {
+		close(halt)
+		delete(d.marquees, row)
+	}
+}
+
+func (d *CharacterLCDDriver) stopMarquees() {
+	for row := range d.marquees {
+		d.stopMarquee(row)
+	}
+}
+
+func (d *CharacterLCDDriver) clear() (err error) {
+	if err = d.Bus.WriteCommand(clearDisplay); err != nil {
+		return
+	}
+	d.row = 0
+	// clearing takes 1.52ms
+	time.Sleep(2 * time.Millisecond)
+	return
+}
+
+func (d *CharacterLCDDriver) setCursor(column int, row int) (err error) {
+	if column < 0 || column >= d.columns || row < 0 || row >= d.rows {
+		return ErrInvalidPosition
+	}
+	// the third and fourth rows continue the first and second ones
+	offsets := []int{0x00, 0x40, d.columns, 0x40 + d.columns}
+	if err = d.Bus.WriteCommand(setDDRAMAddr | byte(offsets[row]+column)); err != nil {
+		return
+	}
+	d.row = row
+	return
+}
+
+func (d *CharacterLCDDriver) setControl(flag byte, on bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	control := d.control &^ flag
+	if on {
+		control |= flag
+	}
+	if err := d.Bus.WriteCommand(displayControl | control); err != nil {
+		return err
+	}
+	d.control = control
+	return nil
+}
+
+func (d *CharacterLCDDriver) write(text string) (err error) {
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			if err = d.setCursor(0, (d.row+1)%d.rows); err != nil {
+				return
+			}
+			continue
+		}
+		if err = d.Bus.WriteData(text[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// show writes the lines of the text buffer from the top one to the rows
+func (d *CharacterLCDDriver) show() (err error) {
+	for row := 0; row < d.rows; row++ {
+		line := ""
+		if d.top+row < len(d.lines) {
+			line = d.lines[d.top+row]
+		}
+		if err = d.setCursor(0, row); err != nil {
+			return
+		}
+		if err = d.write(d.pad(line)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// pad fills the line up to a row with spaces
+func (d *CharacterLCDDriver) pad(line string) string {
+	return line + strings.Repeat(" ", d.columns-len(line))
+}
@@ -0,0 +1,257 @@
+package lcd
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*CharacterLCDDriver)(nil)
+
+type write struct {
+	b    byte
+	data bool
+}
+
+type testBus struct {
+	mutex   sync.Mutex
+	inited  bool
+	written []write
+	err     error
+}
+
+func (b *testBus) Init() error {
+	b.inited = true
+	return b.err
+}
+
+func (b *testBus) WriteCommand(command byte) error { return b.write(command, false) }
+
+func (b *testBus) WriteData(data byte) error { return b.write(data, true) }
+
+func (b *testBus) write(v byte, data bool) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.written = append(b.written, write{v, data})
+	return b.err
+}
+
+// text returns the data written since the last command
+func (b *testBus) text() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	text := ""
+	for _, w := range b.written {
+		if !w.data {
+			text = ""
+			continue
+		}
+		text += string(w.b)
+	}
+	return text
+}
+
+// reset forgets the written commands and data
+func (b *testBus) reset() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.written = nil
+}
+
+func initTestCharacterLCDDriver() (*CharacterLCDDriver, *testBus) {
+	b := &testBus{}
+	d := NewCharacterLCDDriver(b, 16, 2)
+	d.Start()
+	b.reset()
+	return d, b
+}
+
+func TestNewCharacterLCDDriver(t *testing.T) {
+	d := NewCharacterLCDDriver(&testBus{}, 20, 4)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "CharacterLCD"), true)
+	d.SetName("lcd")
+	gobottest.Assert(t, d.Name(), "lcd")
+	gobottest.Assert(t, d.Connection(), nil)
+	columns, rows := d.Size()
+	gobottest.Assert(t, columns, 20)
+	gobottest.Assert(t, rows, 4)
+	gobottest.Refute(t, d.Command("Print"), nil)
+	gobottest.Refute(t, d.Command("Marquee"), nil)
+
+	a := &testAdaptor{}
+	d = NewCharacterLCDDriver(NewGPIOBus(a, "rs", "e", "4", "5", "6", "7"), 16, 2)
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+}
+
+func TestCharacterLCDDriverStart(t *testing.T) {
+	b := &testBus{}
+	d := NewCharacterLCDDriver(b, 16, 2)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, b.inited, true)
+	gobottest.Assert(t, b.written, []write{{0x28, false}, {0x0C, false}, {0x06, false}, {0x01, false}})
+	gobottest.Assert(t, d.Halt(), nil)
+
+	b = &testBus{}
+	d = NewCharacterLCDDriver(b, 16, 1)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, b.written[0], write{0x20, false})
+
+	b.err = errors.New("write error")
+	gobottest.Assert(t, d.Start(), errors.New("write error"))
+}
+
+func TestCharacterLCDDriverCursor(t *testing.T) {
+	d, b := initTestCharacterLCDDriver()
+	gobottest.Assert(t, d.SetCursor(3, 1), nil)
+	gobottest.Assert(t, d.ShowCursor(true), nil)
+	gobottest.Assert(t, d.BlinkCursor(true), nil)
+	gobottest.Assert(t, d.ShowCursor(false), nil)
+	gobottest.Assert(t, d.Display(false), nil)
+	gobottest.Assert(t, d.Home(), nil)
+	gobottest.Assert(t, d.Clear(), nil)
+	gobottest.Assert(t, b.written, []write{
+		{0xC3, false}, {0x0E, false}, {0x0F, false}, {0x0D, false}, {0x09, false}, {0x02, false}, {0x01, false},
+	})
+
+	gobottest.Assert(t, d.SetCursor(16, 0), ErrInvalidPosition)
+	gobottest.Assert(t, d.SetCursor(0, 2), ErrInvalidPosition)
+	gobottest.Assert(t, d.SetCursor(-1, 0), ErrInvalidPosition)
+
+	b.err = errors.New("write error")
+	gobottest.Assert(t, d.ShowCursor(true), errors.New("write error"))
+	gobottest.Assert(t, d.Home(), errors.New("write error"))
+}
+
+func TestCharacterLCDDriverFourRows(t *testing.T) {
+	b := &testBus{}
+	d := NewCharacterLCDDriver(b, 20, 4)
+	d.Start()
+	b.reset()
+	gobottest.Assert(t, d.SetCursor(0, 2), nil)
+	gobottest.Assert(t, d.SetCursor(1, 3), nil)
+	gobottest.Assert(t, b.written, []write{{0x80 | 20, false}, {0x80 | 0x55, false}})
+}
+
+func TestCharacterLCDDriverWrite(t *testing.T) {
+	d, b := initTestCharacterLCDDriver()
+	gobottest.Assert(t, d.Write("hi\nyou"), nil)
+	gobottest.Assert(t, b.written, []write{
+		{'h', true}, {'i', true}, {0xC0, false}, {'y', true}, {'o', true}, {'u', true},
+	})
+
+	b.reset()
+	gobottest.Assert(t, d.Write("\n"), nil)
+	gobottest.Assert(t, b.written, []write{{0x80, false}})
+
+	b.err = errors.New("write error")
+	gobottest.Assert(t, d.Write("a"), errors.New("write error"))
+}
+
+func TestCharacterLCDDriverShift(t *testing.T) {
+	d, b := initTestCharacterLCDDriver()
+	gobottest.Assert(t, d.Shift(true), nil)
+	gobottest.Assert(t, d.Shift(false), nil)
+	gobottest.Assert(t, b.written, []write{{0x1C, false}, {0x18, false}})
+}
+
+func TestCharacterLCDDriverSetCustomChar(t *testing.T) {
+	d, b := initTestCharacterLCDDriver()
+	heart := [8]byte{0, 10, 31, 31, 31, 14, 4, 0}
+	gobottest.Assert(t, d.SetCustomChar(2, heart), nil)
+	gobottest.Assert(t, b.written[0], write{0x50, false})
+	gobottest.Assert(t, b.written[1], write{0, true})
+	gobottest.Assert(t, b.written[3], write{31, true})
+	gobottest.Assert(t, b.written[9], write{0x80, false})
+
+	gobottest.Assert(t, d.SetCustomChar(8, heart), ErrInvalidCustomChar)
+	gobottest.Assert(t, d.SetCustomChar(-1, heart), ErrInvalidCustomChar)
+
+	b.err = errors.New("write error")
+	gobottest.Assert(t, d.SetCustomChar(0, heart), errors.New("write error"))
+}
+
+func TestCharacterLCDDriverPrint(t *testing.T) {
+	d, b := initTestCharacterLCDDriver()
+	gobottest.Assert(t, d.Print("the quick brown fox jumps over the lazy dog"), nil)
+	gobottest.Assert(t, d.Lines(), []string{"the quick brown", "fox jumps over", "the lazy dog"})
+	gobottest.Assert(t, b.written[0], write{0x80, false})
+	gobottest.Assert(t, b.written[17], write{0xC0, false})
+	gobottest.Assert(t, b.text(), "fox jumps over  ")
+
+	gobottest.Assert(t, d.ScrollTo(1), nil)
+	gobottest.Assert(t, b.text(), "the lazy dog    ")
+	gobottest.Assert(t, d.ScrollTo(2), ErrInvalidPosition)
+	gobottest.Assert(t, d.ScrollTo(-1), ErrInvalidPosition)
+
+	gobottest.Assert(t, d.Print("short"), nil)
+	gobottest.Assert(t, d.ScrollTo(0), nil)
+	gobottest.Assert(t, d.ScrollTo(1), ErrInvalidPosition)
+	gobottest.Assert(t, b.text(), strings.Repeat(" ", 16))
+
+	gobottest.Assert(t, d.Clear(), nil)
+	gobottest.Assert(t, len(d.Lines()), 0)
+
+	b.err = errors.New("write error")
+	gobottest.Assert(t, d.Print("a"), errors.New("write error"))
+}
+
+func TestCharacterLCDDriverMarquee(t *testing.T) {
+	d, b := initTestCharacterLCDDriver()
+	gobottest.Assert(t, d.Marquee(1, "short", time.Millisecond), nil)
+	gobottest.Assert(t, b.text(), "short           ")
+	gobottest.Assert(t, len(d.marquees), 0)
+
+	gobottest.Assert(t, d.Marquee(0, "a long text for the first row", time.Millisecond), nil)
+	gobottest.Assert(t, b.text(), "a long text for ")
+	time.Sleep(20 * time.Millisecond)
+	d.StopMarquee(0)
+	gobottest.Assert(t, len(d.marquees), 0)
+	gobottest.Refute(t, b.text(), "a long text for ")
+
+	gobottest.Assert(t, d.Marquee(2, "a", time.Millisecond), ErrInvalidPosition)
+
+	gobottest.Assert(t, d.Marquee(1, "a long text for the second row", time.Millisecond), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, len(d.marquees), 0)
+}
+
+func TestCharacterLCDDriverMarqueeError(t *testing.T) {
+	d, b := initTestCharacterLCDDriver()
+	errs := make(chan interface{}, 1)
+	d.Once(Error, func(data interface{}) {
+		errs <- data
+	})
+	gobottest.Assert(t, d.Marquee(0, "a long text for the first row", time.Millisecond), nil)
+	b.mutex.Lock()
+	b.err = errors.New("write error")
+	b.mutex.Unlock()
+
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err, errors.New("write error"))
+	case <-time.After(time.Second):
+		t.Errorf("Error was not published")
+	}
+	d.Halt()
+}
+
+func TestCharacterLCDDriverCommands(t *testing.T) {
+	d, b := initTestCharacterLCDDriver()
+	gobottest.Assert(t, d.Command("Write")(map[string]interface{}{"text": "hi"}), nil)
+	gobottest.Assert(t, b.text(), "hi")
+	gobottest.Assert(t, d.Command("SetCursor")(map[string]interface{}{"column": 2.0, "row": 1.0}), nil)
+	gobottest.Assert(t, d.Command("ShowCursor")(map[string]interface{}{"on": true}), nil)
+	gobottest.Assert(t, d.Command("BlinkCursor")(map[string]interface{}{"on": true}), nil)
+	gobottest.Assert(t, d.Command("Print")(map[string]interface{}{"text": "a b c"}), nil)
+	gobottest.Assert(t, d.Lines(), []string{"a b c"})
+	gobottest.Assert(t, d.Command("ScrollTo")(map[string]interface{}{"line": 1.0}), ErrInvalidPosition)
+	gobottest.Assert(t, d.Command("Marquee")(map[string]interface{}{"row": 0.0, "text": "a long text for the first row", "interval": 1.0}), nil)
+	gobottest.Assert(t, d.Command("StopMarquee")(map[string]interface{}{"row": 0.0}), nil)
+	gobottest.Assert(t, d.Command("Home")(nil), nil)
+	gobottest.Assert(t, d.Command("Clear")(nil), nil)
+}
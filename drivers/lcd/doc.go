@@ -0,0 +1,45 @@
+/*
+Package lcd provides a driver for character LCDs with an HD44780 compatible
+controller, with a cursor, custom characters, a word wrapped text buffer and
+marquees, and the buses which connect it to the controller.
+
+Installing:
+
+	go get -d -u gobot.io/x/gobot
+
+Example:
+
+	package main
+
+	import (
+		"time"
+
+		"gobot.io/x/gobot"
+		"gobot.io/x/gobot/drivers/i2c"
+		"gobot.io/x/gobot/drivers/lcd"
+		"gobot.io/x/gobot/platforms/raspi"
+	)
+
+	func main() {
+		r := raspi.NewAdaptor()
+		backpack := i2c.NewLCDBackpackDriver(r)
+		display := lcd.NewCharacterLCDDriver(backpack, 16, 2)
+
+		work := func() {
+			display.Print("Hello, gophers!")
+			display.Marquee(1, "Gobot makes robots in Go", 300*time.Millisecond)
+		}
+
+		robot := gobot.NewRobot("lcdBot",
+			[]gobot.Connection{r},
+			[]gobot.Device{backpack, display},
+			work,
+		)
+
+		robot.Start()
+	}
+
+For further information refer to lcd README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/lcd/README.md
+*/
+package lcd // import "gobot.io/x/gobot/drivers/lcd"
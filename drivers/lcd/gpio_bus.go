@@ -0,0 +1,64 @@
+package lcd
+
+import (
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+// GPIOBus is the 4 bit Bus of a controller whose RS, E and D4 to D7 inputs
+// are connected to pins of an adaptor. Its RW input must be tied low.
+type GPIOBus struct {
+	connection gpio.DigitalWriter
+	RSPin      string
+	EPin       string
+	// DataPins are the pins connected to D4 to D7
+	DataPins [4]string
+}
+
+// NewGPIOBus returns a new GPIOBus given a DigitalWriter and the pins
+// connected to the RS, E and D4 to D7 inputs
+func NewGPIOBus(a gpio.DigitalWriter, rsPin string, ePin string, d4 string, d5 string, d6 string, d7 string) *GPIOBus {
+	return &GPIOBus{
+		connection: a,
+		RSPin:      rsPin,
+		EPin:       ePin,
+		DataPins:   [4]string{d4, d5, d6, d7},
+	}
+}
+
+// Connection returns the GPIOBus Connection
+func (b *GPIOBus) Connection() gobot.Connection { return b.connection.(gobot.Connection) }
+
+// Init puts the controller into the 4 bit mode
+func (b *GPIOBus) Init() (err error) {
+	if err = b.connection.DigitalWrite(b.EPin, 0); err != nil {
+		return
+	}
+	return InitFourBit(b)
+}
+
+// WriteCommand writes a command to the controller
+func (b *GPIOBus) WriteCommand(command byte) error { return WriteFourBit(b, command, false) }
+
+// WriteData writes data to the controller
+func (b *GPIOBus) WriteData(data byte) error { return WriteFourBit(b, data, true) }
+
+// WriteNibble writes the nibble to the D4 to D7 inputs and pulses E
+func (b *GPIOBus) WriteNibble(nibble byte, data bool) (err error) {
+	var rs byte
+	if data {
+		rs = 1
+	}
+	if err = b.connection.DigitalWrite(b.RSPin, rs); err != nil {
+		return
+	}
+	for i, pin := range b.DataPins {
+		if err = b.connection.DigitalWrite(pin, (nibble>>uint(i))&1); err != nil {
+			return
+		}
+	}
+	if err = b.connection.DigitalWrite(b.EPin, 1); err != nil {
+		return
+	}
+	return b.connection.DigitalWrite(b.EPin, 0)
+}
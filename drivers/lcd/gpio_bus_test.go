@@ -0,0 +1,71 @@
+package lcd
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ Bus = (*GPIOBus)(nil)
+var _ NibbleWriter = (*GPIOBus)(nil)
+
+type pinLevel struct {
+	pin   string
+	level byte
+}
+
+type testAdaptor struct {
+	name    string
+	written []pinLevel
+	err     error
+}
+
+func (a *testAdaptor) Name() string          { return a.name }
+func (a *testAdaptor) SetName(n string)      { a.name = n }
+func (a *testAdaptor) Connect() (err error)  { return }
+func (a *testAdaptor) Finalize() (err error) { return }
+
+func (a *testAdaptor) DigitalWrite(pin string, level byte) error {
+	a.written = append(a.written, pinLevel{pin, level})
+	return a.err
+}
+
+var _ gobot.Connection = (*testAdaptor)(nil)
+var _ gpio.DigitalWriter = (*testAdaptor)(nil)
+
+func TestGPIOBus(t *testing.T) {
+	a := &testAdaptor{}
+	b := NewGPIOBus(a, "rs", "e", "4", "5", "6", "7")
+	gobottest.Assert(t, b.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, b.DataPins, [4]string{"4", "5", "6", "7"})
+
+	gobottest.Assert(t, b.Init(), nil)
+	gobottest.Assert(t, a.written[0], pinLevel{"e", 0})
+	gobottest.Assert(t, len(a.written), 1+4*7)
+}
+
+func TestGPIOBusWriteNibble(t *testing.T) {
+	a := &testAdaptor{}
+	b := NewGPIOBus(a, "rs", "e", "4", "5", "6", "7")
+	gobottest.Assert(t, b.WriteNibble(0x5, true), nil)
+	gobottest.Assert(t, a.written, []pinLevel{
+		{"rs", 1}, {"4", 1}, {"5", 0}, {"6", 1}, {"7", 0}, {"e", 1}, {"e", 0},
+	})
+
+	a.written = nil
+	gobottest.Assert(t, b.WriteCommand(0x28), nil)
+	gobottest.Assert(t, a.written[0], pinLevel{"rs", 0})
+	gobottest.Assert(t, a.written[4], pinLevel{"7", 0})
+	gobottest.Assert(t, a.written[11], pinLevel{"7", 1})
+
+	a.written = nil
+	gobottest.Assert(t, b.WriteData('A'), nil)
+	gobottest.Assert(t, a.written[7], pinLevel{"rs", 1})
+
+	a.err = errors.New("write error")
+	gobottest.Assert(t, b.WriteNibble(0x5, true), errors.New("write error"))
+	gobottest.Assert(t, b.Init(), errors.New("write error"))
+}
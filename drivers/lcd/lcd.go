@@ -0,0 +1,127 @@
+package lcd
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// Error event
+	Error = "error"
+)
+
+var (
+	// ErrInvalidPosition is the error resulting when the cursor or a line is
+	// set outside of the display
+	ErrInvalidPosition = errors.New("Invalid LCD position")
+	// ErrInvalidCustomChar is the error resulting when a custom character is
+	// set outside of the 8 CGRAM locations
+	ErrInvalidCustomChar = errors.New("Invalid LCD custom character location")
+)
+
+// commands of the HD44780
+const (
+	clearDisplay   = 0x01
+	returnHome     = 0x02
+	entryModeSet   = 0x04
+	displayControl = 0x08
+	cursorShift    = 0x10
+	functionSet    = 0x20
+	setCGRAMAddr   = 0x40
+	setDDRAMAddr   = 0x80
+
+	entryLeft   = 0x02
+	displayOn   = 0x04
+	cursorOn    = 0x02
+	blinkOn     = 0x01
+	displayMove = 0x08
+	moveRight   = 0x04
+	twoLines    = 0x08
+	eightBits   = 0x03
+	fourBits    = 0x02
+)
+
+// Bus connects a character LCD to its HD44780 compatible controller
+type Bus interface {
+	// Init sets up the interface of the controller, e.g. the 4 bit mode,
+	// before the first command
+	Init() error
+	// WriteCommand writes a command to the controller
+	WriteCommand(command byte) error
+	// WriteData writes a character, or a row of a custom character, to the
+	// memory of the controller
+	WriteData(data byte) error
+}
+
+// NibbleWriter writes the 4 bits of a nibble to the D4 to D7 inputs of a
+// controller and pulses its E input, with the RS input low for commands and
+// high for data
+type NibbleWriter interface {
+	WriteNibble(nibble byte, data bool) error
+}
+
+// InitFourBit puts a controller whose D4 to D7 inputs are connected into the
+// 4 bit mode, whatever mode it was in
+func InitFourBit(w NibbleWriter) (err error) {
+	for _, step := range []struct {
+		nibble byte
+		delay  time.Duration
+	}{
+		{eightBits, 5 * time.Millisecond},
+		{eightBits, 100 * time.Microsecond},
+		{eightBits, 100 * time.Microsecond},
+		{fourBits, 100 * time.Microsecond},
+	} {
+		if err = w.WriteNibble(step.nibble, false); err != nil {
+			return
+		}
+		time.Sleep(step.delay)
+	}
+	return
+}
+
+// WriteFourBit writes a command, or data, to a controller in the 4 bit mode
+// as two nibbles, the upper one first
+func WriteFourBit(w NibbleWriter, b byte, data bool) (err error) {
+	if err = w.WriteNibble(b>>4, data); err != nil {
+		return
+	}
+	if err = w.WriteNibble(b&0x0F, data); err != nil {
+		return
+	}
+	// most commands take 37us
+	time.Sleep(50 * time.Microsecond)
+	return
+}
+
+// WordWrap breaks the text into lines of at most width characters between
+// words, and words longer than a line into pieces. Line breaks in the text
+// are kept.
+func WordWrap(text string, width int) []string {
+	lines := []string{}
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			for len(word) > width {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				lines = append(lines, word[:width])
+				word = word[width:]
+			}
+			switch {
+			case line == "":
+				line = word
+			case len(line)+1+len(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
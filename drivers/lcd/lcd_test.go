@@ -0,0 +1,52 @@
+package lcd
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type nibble struct {
+	nibble byte
+	data   bool
+}
+
+type testNibbleWriter struct {
+	written []nibble
+	err     error
+}
+
+func (w *testNibbleWriter) WriteNibble(n byte, data bool) error {
+	w.written = append(w.written, nibble{n, data})
+	return w.err
+}
+
+func TestInitFourBit(t *testing.T) {
+	w := &testNibbleWriter{}
+	gobottest.Assert(t, InitFourBit(w), nil)
+	gobottest.Assert(t, w.written, []nibble{{0x3, false}, {0x3, false}, {0x3, false}, {0x2, false}})
+
+	w = &testNibbleWriter{err: errors.New("write error")}
+	gobottest.Assert(t, InitFourBit(w), errors.New("write error"))
+	gobottest.Assert(t, len(w.written), 1)
+}
+
+func TestWriteFourBit(t *testing.T) {
+	w := &testNibbleWriter{}
+	gobottest.Assert(t, WriteFourBit(w, 0x28, false), nil)
+	gobottest.Assert(t, WriteFourBit(w, 'A', true), nil)
+	gobottest.Assert(t, w.written, []nibble{{0x2, false}, {0x8, false}, {0x4, true}, {0x1, true}})
+
+	w = &testNibbleWriter{err: errors.New("write error")}
+	gobottest.Assert(t, WriteFourBit(w, 0x28, false), errors.New("write error"))
+	gobottest.Assert(t, len(w.written), 1)
+}
+
+func TestWordWrap(t *testing.T) {
+	gobottest.Assert(t, WordWrap("", 8), []string{""})
+	gobottest.Assert(t, WordWrap("hello gopher world", 8), []string{"hello", "gopher", "world"})
+	gobottest.Assert(t, WordWrap("a b  c d", 3), []string{"a b", "c d"})
+	gobottest.Assert(t, WordWrap("hi\n\nthere", 8), []string{"hi", "", "there"})
+	gobottest.Assert(t, WordWrap("go abcdefghij", 4), []string{"go", "abcd", "efgh", "ij"})
+}
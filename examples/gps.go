@@ -0,0 +1,37 @@
+// +build example
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/platforms/gps"
+)
+
+func main() {
+	adaptor := gps.NewAdaptor(os.Args[1])
+	receiver := gps.NewDriver(adaptor)
+
+	work := func() {
+		receiver.On(gps.PositionEvent, func(data interface{}) {
+			p := data.(gps.Position)
+			fmt.Printf("%v lat: %.6f lon: %.6f alt: %.1fm\n", p.Time, p.Latitude, p.Longitude, p.Altitude)
+		})
+
+		receiver.On(gps.SatellitesEvent, func(data interface{}) {
+			fmt.Println("satellites in view:", len(data.([]gps.Satellite)))
+		})
+	}
+
+	robot := gobot.NewRobot("gpsBot",
+		[]gobot.Connection{adaptor},
+		[]gobot.Device{receiver},
+		work,
+	)
+
+	robot.Start()
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"image/color"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -20,9 +21,9 @@ func main() {
 		gobot.Every(1*time.Second, func() {
 			oled.Clear()
 			if stage {
-				for x := 0; x < oled.Buffer.Width; x += 5 {
-					for y := 0; y < oled.Buffer.Height; y++ {
-						oled.Set(x, y, 1)
+				for x := 0; x < oled.Bounds().Dx(); x += 5 {
+					for y := 0; y < oled.Bounds().Dy(); y++ {
+						oled.Set(x, y, color.White)
 					}
 				}
 			}
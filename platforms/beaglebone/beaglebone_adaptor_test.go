@@ -183,7 +183,7 @@ func TestBeagleboneConnectNoSlot(t *testing.T) {
 
 	a := NewAdaptor()
 	err := a.Connect()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/devices/platform/bone_capemgr/slots: No such file."), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/devices/platform/bone_capemgr/slots: file does not exist"), true)
 }
 
 func TestBeagleboneAnalogReadFileError(t *testing.T) {
@@ -196,7 +196,7 @@ func TestBeagleboneAnalogReadFileError(t *testing.T) {
 	a.Connect()
 
 	_, err := a.AnalogRead("P9_40")
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/bus/iio/devices/iio:device0/in_voltage1_raw: No such file."), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/bus/iio/devices/iio:device0/in_voltage1_raw: file does not exist"), true)
 }
 
 func TestBeagleboneDigitalPinDirectionFileError(t *testing.T) {
@@ -211,10 +211,10 @@ func TestBeagleboneDigitalPinDirectionFileError(t *testing.T) {
 	a.Connect()
 
 	err := a.DigitalWrite("P9_12", 1)
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio60/direction: No such file."), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio60/direction: file does not exist"), true)
 
 	err = a.Finalize()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/unexport: No such file."), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/unexport: file does not exist"), true)
 }
 
 func TestBeagleboneDigitalPinFinalizeFileError(t *testing.T) {
@@ -233,5 +233,5 @@ func TestBeagleboneDigitalPinFinalizeFileError(t *testing.T) {
 	gobottest.Assert(t, err, nil)
 
 	err = a.Finalize()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/unexport: No such file."), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/unexport: file does not exist"), true)
 }
@@ -1,9 +1,6 @@
 package beaglebone
 
-type pwmPinData struct {
-	channel int
-	path    string
-}
+import "gobot.io/x/gobot/platforms/linux"
 
 var pins = map[string]int{
 	// P8_1 - P8_2 GND
@@ -54,23 +51,45 @@ var pins = map[string]int{
 	"P9_31": 110,
 }
 
-var pwmPins = map[string]pwmPinData{
-	"P8_13": {path: "/sys/devices/platform/ocp/48304000.epwmss/48304200.pwm/pwm/pwmchip4", channel: 1},
-	"P8_19": {path: "/sys/devices/platform/ocp/48304000.epwmss/48304200.pwm/pwm/pwmchip4", channel: 0},
+var pwmPins = map[string]linux.PwmChannel{
+	"P8_13": {Path: "/sys/devices/platform/ocp/48304000.epwmss/48304200.pwm/pwm/pwmchip4", Channel: 1},
+	"P8_19": {Path: "/sys/devices/platform/ocp/48304000.epwmss/48304200.pwm/pwm/pwmchip4", Channel: 0},
+
+	"P9_14": {Path: "/sys/devices/platform/ocp/48302000.epwmss/48302200.pwm/pwm/pwmchip2", Channel: 0},
+	"P9_16": {Path: "/sys/devices/platform/ocp/48302000.epwmss/48302200.pwm/pwm/pwmchip2", Channel: 1},
+	"P9_21": {Path: "/sys/devices/platform/ocp/48300000.epwmss/48300200.pwm/pwm/pwmchip0", Channel: 1},
+	"P9_22": {Path: "/sys/devices/platform/ocp/48300000.epwmss/48300200.pwm/pwm/pwmchip0", Channel: 0},
+	//"P9_42": {Path: "", Channel: 0}, TODO: implement this pwm
+}
 
-	"P9_14": {path: "/sys/devices/platform/ocp/48302000.epwmss/48302200.pwm/pwm/pwmchip2", channel: 0},
-	"P9_16": {path: "/sys/devices/platform/ocp/48302000.epwmss/48302200.pwm/pwm/pwmchip2", channel: 1},
-	"P9_21": {path: "/sys/devices/platform/ocp/48300000.epwmss/48300200.pwm/pwm/pwmchip0", channel: 1},
-	"P9_22": {path: "/sys/devices/platform/ocp/48300000.epwmss/48300200.pwm/pwm/pwmchip0", channel: 0},
-	//"P9_42": {path: "", channel: 0}, TODO: implement this pwm
+var analogPins = map[string]int{
+	"P9_39": 0,
+	"P9_40": 1,
+	"P9_37": 2,
+	"P9_38": 3,
+	"P9_33": 4,
+	"P9_36": 5,
+	"P9_35": 6,
 }
 
-var analogPins = map[string]string{
-	"P9_39": "in_voltage0_raw",
-	"P9_40": "in_voltage1_raw",
-	"P9_37": "in_voltage2_raw",
-	"P9_38": "in_voltage3_raw",
-	"P9_33": "in_voltage4_raw",
-	"P9_36": "in_voltage5_raw",
-	"P9_35": "in_voltage6_raw",
+func newBoard() *linux.Board {
+	b := &linux.Board{
+		Name:          "Beaglebone",
+		Pins:          make(map[string]linux.Pin),
+		I2cBuses:      []int{0, 2},
+		DefaultI2cBus: 2,
+		PwmPeriod:     pwmDefaultPeriod,
+	}
+	for name, gpio := range pins {
+		b.Pins[name] = linux.Pin{Gpio: gpio}
+	}
+	for name, pwm := range pwmPins {
+		p := b.Pins[name]
+		p.Pwm = &linux.PwmChannel{Channel: pwm.Channel, Path: pwm.Path}
+		b.Pins[name] = p
+	}
+	for name, channel := range analogPins {
+		b.Pins[name] = linux.Pin{Gpio: -1, Analog: &linux.AnalogChannel{Device: 0, Channel: channel}}
+	}
+	return b
 }
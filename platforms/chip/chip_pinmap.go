@@ -1,176 +1,52 @@
 package chip
 
-var chipPins = map[string]sysfsPin{
+import "gobot.io/x/gobot/platforms/linux"
+
+var chipPins = map[string]linux.Pin{
 	"PWM0": {
-		pin:    34,
-		pwmPin: 0,
-	},
-	"AP-EINT3": {
-		pin:    35,
-		pwmPin: -1,
-	},
-	"TWI1-SCK": {
-		pin:    47,
-		pwmPin: -1,
-	},
-	"TWI1-SDA": {
-		pin:    48,
-		pwmPin: -1,
-	},
-	"TWI2-SCK": {
-		pin:    49,
-		pwmPin: -1,
-	},
-	"TWI2-SDA": {
-		pin:    50,
-		pwmPin: -1,
-	},
-	"LCD-D2": {
-		pin:    98,
-		pwmPin: -1,
-	},
-	"LCD-D3": {
-		pin:    99,
-		pwmPin: -1,
-	},
-	"LCD-D4": {
-		pin:    100,
-		pwmPin: -1,
-	},
-	"LCD-D5": {
-		pin:    101,
-		pwmPin: -1,
-	},
-	"LCD-D6": {
-		pin:    102,
-		pwmPin: -1,
-	},
-	"LCD-D7": {
-		pin:    103,
-		pwmPin: -1,
-	},
-	"LCD-D10": {
-		pin:    106,
-		pwmPin: -1,
-	},
-	"LCD-D11": {
-		pin:    107,
-		pwmPin: -1,
-	},
-	"LCD-D12": {
-		pin:    108,
-		pwmPin: -1,
-	},
-	"LCD-D13": {
-		pin:    109,
-		pwmPin: -1,
-	},
-	"LCD-D14": {
-		pin:    110,
-		pwmPin: -1,
-	},
-	"LCD-D15": {
-		pin:    111,
-		pwmPin: -1,
-	},
-	"LCD-D18": {
-		pin:    114,
-		pwmPin: -1,
-	},
-	"LCD-D19": {
-		pin:    115,
-		pwmPin: -1,
-	},
-	"LCD-D20": {
-		pin:    116,
-		pwmPin: -1,
-	},
-	"LCD-D21": {
-		pin:    117,
-		pwmPin: -1,
-	},
-	"LCD-D22": {
-		pin:    118,
-		pwmPin: -1,
-	},
-	"LCD-D23": {
-		pin:    119,
-		pwmPin: -1,
-	},
-	"LCD-CLK": {
-		pin:    120,
-		pwmPin: -1,
-	},
-	"LCD-DE": {
-		pin:    121,
-		pwmPin: -1,
-	},
-	"LCD-HSYNC": {
-		pin:    122,
-		pwmPin: -1,
-	},
-	"LCD-VSYNC": {
-		pin:    123,
-		pwmPin: -1,
-	},
-	"CSIPCK": {
-		pin:    128,
-		pwmPin: -1,
-	},
-	"CSICK": {
-		pin:    129,
-		pwmPin: -1,
-	},
-	"CSIHSYNC": {
-		pin:    130,
-		pwmPin: -1,
-	},
-	"CSIVSYNC": {
-		pin:    131,
-		pwmPin: -1,
-	},
-	"CSID0": {
-		pin:    132,
-		pwmPin: -1,
-	},
-	"CSID1": {
-		pin:    133,
-		pwmPin: -1,
-	},
-	"CSID2": {
-		pin:    134,
-		pwmPin: -1,
-	},
-	"CSID3": {
-		pin:    135,
-		pwmPin: -1,
-	},
-	"CSID4": {
-		pin:    136,
-		pwmPin: -1,
-	},
-	"CSID5": {
-		pin:    137,
-		pwmPin: -1,
-	},
-	"CSID6": {
-		pin:    138,
-		pwmPin: -1,
-	},
-	"CSID7": {
-		pin:    139,
-		pwmPin: -1,
-	},
-	"AP-EINT1": {
-		pin:    193,
-		pwmPin: -1,
-	},
-	"UART1-TX": {
-		pin:    195,
-		pwmPin: -1,
-	},
-	"UART1-RX": {
-		pin:    196,
-		pwmPin: -1,
-	},
+		Gpio: 34,
+		Pwm:  &linux.PwmChannel{Channel: 0, Polarity: "normal"},
+	},
+	"AP-EINT3":  {Gpio: 35},
+	"TWI1-SCK":  {Gpio: 47},
+	"TWI1-SDA":  {Gpio: 48},
+	"TWI2-SCK":  {Gpio: 49},
+	"TWI2-SDA":  {Gpio: 50},
+	"LCD-D2":    {Gpio: 98},
+	"LCD-D3":    {Gpio: 99},
+	"LCD-D4":    {Gpio: 100},
+	"LCD-D5":    {Gpio: 101},
+	"LCD-D6":    {Gpio: 102},
+	"LCD-D7":    {Gpio: 103},
+	"LCD-D10":   {Gpio: 106},
+	"LCD-D11":   {Gpio: 107},
+	"LCD-D12":   {Gpio: 108},
+	"LCD-D13":   {Gpio: 109},
+	"LCD-D14":   {Gpio: 110},
+	"LCD-D15":   {Gpio: 111},
+	"LCD-D18":   {Gpio: 114},
+	"LCD-D19":   {Gpio: 115},
+	"LCD-D20":   {Gpio: 116},
+	"LCD-D21":   {Gpio: 117},
+	"LCD-D22":   {Gpio: 118},
+	"LCD-D23":   {Gpio: 119},
+	"LCD-CLK":   {Gpio: 120},
+	"LCD-DE":    {Gpio: 121},
+	"LCD-HSYNC": {Gpio: 122},
+	"LCD-VSYNC": {Gpio: 123},
+	"CSIPCK":    {Gpio: 128},
+	"CSICK":     {Gpio: 129},
+	"CSIHSYNC":  {Gpio: 130},
+	"CSIVSYNC":  {Gpio: 131},
+	"CSID0":     {Gpio: 132},
+	"CSID1":     {Gpio: 133},
+	"CSID2":     {Gpio: 134},
+	"CSID3":     {Gpio: 135},
+	"CSID4":     {Gpio: 136},
+	"CSID5":     {Gpio: 137},
+	"CSID6":     {Gpio: 138},
+	"CSID7":     {Gpio: 139},
+	"AP-EINT1":  {Gpio: 193},
+	"UART1-TX":  {Gpio: 195},
+	"UART1-RX":  {Gpio: 196},
 }
@@ -53,9 +53,22 @@ const (
 	I2CModeRead              byte = 0x01
 	I2CModeContinuousRead    byte = 0x02
 	I2CModeStopReading       byte = 0x03
+	I2CTenBitMode            byte = 0x20
+	I2CAutoRestart           byte = 0x40
 	ServoConfig              byte = 0x70
+	EncoderData              byte = 0x61
+	EncoderAttach            byte = 0x00
+	EncoderReportPosition    byte = 0x01
+	EncoderReportPositions   byte = 0x02
+	EncoderResetPosition     byte = 0x03
+	EncoderReportAuto        byte = 0x04
+	EncoderDetach            byte = 0x05
 )
 
+// I2CTenBitAddress is or'ed into an address to use 10-bit addressing, it has
+// the same value as i2c.TenBitAddress
+const I2CTenBitAddress = 0x8000
+
 // Errors
 var (
 	ErrConnected = errors.New("client is already connected")
@@ -73,6 +86,8 @@ type Client struct {
 	ConnectTimeout  time.Duration
 	initFunc        func() error
 	initMutex       sync.Mutex
+	encoders        map[int]int
+	encoderMutex    sync.Mutex
 	gobot.Eventer
 }
 
@@ -92,6 +107,13 @@ type I2cReply struct {
 	Data     []byte
 }
 
+// EncoderPosition represents the position of an encoder in an EncoderData
+// message
+type EncoderPosition struct {
+	Encoder  int
+	Position int
+}
+
 // New returns a new Client
 func New() *Client {
 	c := &Client{
@@ -101,6 +123,7 @@ func New() *Client {
 		ConnectTimeout:  15 * time.Second,
 		pins:            []Pin{},
 		analogPins:      []int{},
+		encoders:        map[int]int{},
 		Eventer:         gobot.NewEventer(),
 	}
 
@@ -113,6 +136,7 @@ func New() *Client {
 		"AnalogMappingQuery",
 		"ProtocolVersion",
 		"I2cReply",
+		"EncoderData",
 		"StringData",
 		"Error",
 	} {
@@ -319,14 +343,24 @@ func (b *Client) ReportAnalog(pin int, state int) error {
 }
 
 // I2cRead reads numBytes from address once.
+// Addresses or'ed with I2CTenBitAddress are sent using 10-bit addressing.
 func (b *Client) I2cRead(address int, numBytes int) error {
-	return b.WriteSysex([]byte{I2CRequest, byte(address), (I2CModeRead << 3),
-		byte(numBytes) & 0x7F, (byte(numBytes) >> 7) & 0x7F})
+	return b.WriteSysex(append(i2cRequest(address, I2CModeRead<<3),
+		byte(numBytes)&0x7F, byte(numBytes>>7)&0x7F))
+}
+
+// I2cReadRegister reads numBytes from register of address once. A repeated
+// start is used between writing the register and reading, instead of a stop.
+func (b *Client) I2cReadRegister(address int, register int, numBytes int) error {
+	return b.WriteSysex(append(i2cRequest(address, I2CModeRead<<3|I2CAutoRestart),
+		byte(register)&0x7F, byte(register>>7)&0x7F,
+		byte(numBytes)&0x7F, byte(numBytes>>7)&0x7F))
 }
 
 // I2cWrite writes data to address.
+// Addresses or'ed with I2CTenBitAddress are sent using 10-bit addressing.
 func (b *Client) I2cWrite(address int, data []byte) error {
-	ret := []byte{I2CRequest, byte(address), (I2CModeWrite << 3)}
+	ret := i2cRequest(address, I2CModeWrite<<3)
 	for _, val := range data {
 		ret = append(ret, byte(val&0x7F))
 		ret = append(ret, byte((val>>7)&0x7F))
@@ -340,6 +374,52 @@ func (b *Client) I2cConfig(delay int) error {
 	return b.WriteSysex([]byte{I2CConfig, byte(delay & 0xFF), byte((delay >> 8) & 0xFF)})
 }
 
+// AttachEncoder attaches the quadrature encoder with the given number, from
+// 0 to 4, to its pins. It requires a firmware with the encoder feature,
+// such as ConfigurableFirmata.
+func (b *Client) AttachEncoder(encoder int, pinA int, pinB int) error {
+	return b.WriteSysex([]byte{EncoderData, EncoderAttach, byte(encoder), byte(pinA), byte(pinB)})
+}
+
+// DetachEncoder detaches the quadrature encoder with the given number
+func (b *Client) DetachEncoder(encoder int) error {
+	return b.WriteSysex([]byte{EncoderData, EncoderDetach, byte(encoder)})
+}
+
+// ReportEncoders enables or disables the reporting of the positions of all
+// attached encoders at the sampling interval
+func (b *Client) ReportEncoders(enable bool) error {
+	var state byte
+	if enable {
+		state = 1
+	}
+	return b.WriteSysex([]byte{EncoderData, EncoderReportAuto, state})
+}
+
+// ResetEncoder sets the position of the encoder with the given number to 0
+func (b *Client) ResetEncoder(encoder int) error {
+	b.encoderMutex.Lock()
+	b.encoders[encoder] = 0
+	b.encoderMutex.Unlock()
+	return b.WriteSysex([]byte{EncoderData, EncoderResetPosition, byte(encoder)})
+}
+
+// EncoderPosition returns the last reported position of the encoder with
+// the given number
+func (b *Client) EncoderPosition(encoder int) int {
+	b.encoderMutex.Lock()
+	defer b.encoderMutex.Unlock()
+	return b.encoders[encoder]
+}
+
+// i2cRequest returns the start of an I2CRequest sysex for the address
+func i2cRequest(address int, mode byte) []byte {
+	if address&I2CTenBitAddress != 0 {
+		mode |= I2CTenBitMode | byte(address>>7)&0x07
+	}
+	return []byte{I2CRequest, byte(address) & 0x7F, mode}
+}
+
 func (b *Client) togglePinReporting(pin int, state int, mode byte) error {
 	if state != 0 {
 		state = 1
@@ -497,6 +577,23 @@ func (b *Client) process() (err error) {
 				)
 			}
 			b.Publish(b.Event("I2cReply"), reply)
+		case EncoderData:
+			// every encoder is reported in 5 bytes, the number and the sign
+			// followed by the position in 4 bytes of 7 bits
+			positions := []EncoderPosition{}
+			b.encoderMutex.Lock()
+			for i := 2; i+5 < len(currentBuffer); i += 5 {
+				position := int(currentBuffer[i+1]) | int(currentBuffer[i+2])<<7 |
+					int(currentBuffer[i+3])<<14 | int(currentBuffer[i+4])<<21
+				if currentBuffer[i]&0x40 != 0 {
+					position = -position
+				}
+				encoder := int(currentBuffer[i] & 0x3F)
+				b.encoders[encoder] = position
+				positions = append(positions, EncoderPosition{Encoder: encoder, Position: position})
+			}
+			b.encoderMutex.Unlock()
+			b.Publish(b.Event("EncoderData"), positions)
 		case FirmwareQuery:
 			name := []byte{}
 			for _, val := range currentBuffer[4:(len(currentBuffer) - 1)] {
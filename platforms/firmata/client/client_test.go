@@ -287,6 +287,39 @@ func TestI2cRead(t *testing.T) {
 	gobottest.Assert(t, b.I2cRead(0x00, 10), nil)
 }
 
+func TestI2cReadRegister(t *testing.T) {
+	b := initTestFirmata()
+	b.setConnected(true)
+	writeDataMutex.Lock()
+	testWriteData.Reset()
+	writeDataMutex.Unlock()
+
+	gobottest.Assert(t, b.I2cReadRegister(0x1D, 0x80, 6), nil)
+	writeDataMutex.Lock()
+	gobottest.Assert(t, testWriteData.Bytes(), []byte{StartSysex, I2CRequest, 0x1D, 0x48, 0x00, 0x01, 0x06, 0x00, EndSysex})
+	writeDataMutex.Unlock()
+}
+
+func TestI2cTenBitAddress(t *testing.T) {
+	b := initTestFirmata()
+	b.setConnected(true)
+	writeDataMutex.Lock()
+	testWriteData.Reset()
+	writeDataMutex.Unlock()
+
+	gobottest.Assert(t, b.I2cWrite(0x2A5|I2CTenBitAddress, []byte{0x01}), nil)
+	writeDataMutex.Lock()
+	gobottest.Assert(t, testWriteData.Bytes(), []byte{StartSysex, I2CRequest, 0x25, 0x25, 0x01, 0x00, EndSysex})
+	testWriteData.Reset()
+	writeDataMutex.Unlock()
+
+	// 10-bit addresses below 0x80 are not taken for 7-bit ones
+	gobottest.Assert(t, b.I2cRead(0x05|I2CTenBitAddress, 1), nil)
+	writeDataMutex.Lock()
+	gobottest.Assert(t, testWriteData.Bytes(), []byte{StartSysex, I2CRequest, 0x05, 0x28, 0x01, 0x00, EndSysex})
+	writeDataMutex.Unlock()
+}
+
 func TestWriteSysex(t *testing.T) {
 	b := initTestFirmata()
 	b.setConnected(true)
@@ -465,3 +498,49 @@ func TestProcessSysexData(t *testing.T) {
 		t.Errorf("SysexResponse was not published")
 	}
 }
+
+func TestEncoders(t *testing.T) {
+	b := initTestFirmata()
+	b.setConnected(true)
+	writeDataMutex.Lock()
+	testWriteData.Reset()
+	writeDataMutex.Unlock()
+
+	gobottest.Assert(t, b.AttachEncoder(1, 2, 3), nil)
+	gobottest.Assert(t, b.ReportEncoders(true), nil)
+	gobottest.Assert(t, b.ResetEncoder(1), nil)
+	gobottest.Assert(t, b.DetachEncoder(1), nil)
+	writeDataMutex.Lock()
+	gobottest.Assert(t, testWriteData.Bytes(), []byte{
+		StartSysex, EncoderData, EncoderAttach, 1, 2, 3, EndSysex,
+		StartSysex, EncoderData, EncoderReportAuto, 1, EndSysex,
+		StartSysex, EncoderData, EncoderResetPosition, 1, EndSysex,
+		StartSysex, EncoderData, EncoderDetach, 1, EndSysex,
+	})
+	writeDataMutex.Unlock()
+}
+
+func TestProcessEncoderData(t *testing.T) {
+	sem := make(chan bool)
+	b := initTestFirmata()
+	b.setConnected(true)
+	// encoder 0 at 200 and encoder 1 at -3
+	SetTestReadData([]byte{240, 0x61, 0, 72, 1, 0, 0, 0x41, 3, 0, 0, 0, 247})
+
+	b.Once(b.Event("EncoderData"), func(data interface{}) {
+		gobottest.Assert(t, data, []EncoderPosition{
+			{Encoder: 0, Position: 200},
+			{Encoder: 1, Position: -3},
+		})
+		sem <- true
+	})
+
+	b.process()
+
+	select {
+	case <-sem:
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("EncoderData was not published")
+	}
+	gobottest.Assert(t, b.EncoderPosition(1), -3)
+}
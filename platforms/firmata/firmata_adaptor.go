@@ -1,17 +1,30 @@
 package firmata
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
+	"sync"
 	"time"
 
 	serial "go.bug.st/serial.v1"
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/drivers/i2c"
 	"gobot.io/x/gobot/platforms/firmata/client"
 )
 
+var errServoPulseTooShort = errors.New("Firmata servo pulses must be at least 544 microseconds")
+
+var errTooManyEncoders = errors.New("Firmata supports at most 5 encoders")
+
+// maxEncoders is the number of encoders of the encoder feature of Firmata
+const maxEncoders = 5
+
+// encoderMode is the pin mode of the encoder feature of Firmata
+const encoderMode = 0x09
+
 type firmataBoard interface {
 	Connect(io.ReadWriteCloser) error
 	Disconnect() error
@@ -22,9 +35,13 @@ type firmataBoard interface {
 	ReportDigital(int, int) error
 	DigitalWrite(int, int) error
 	I2cRead(int, int) error
+	I2cReadRegister(int, int, int) error
 	I2cWrite(int, []byte) error
 	I2cConfig(int) error
 	ServoConfig(int, int, int) error
+	AttachEncoder(int, int, int) error
+	ReportEncoders(bool) error
+	EncoderPosition(int) int
 	WriteSysex(data []byte) error
 	gobot.Eventer
 }
@@ -45,6 +62,8 @@ type Adaptor struct {
 	Board      firmataBoard
 	conn       io.ReadWriteCloser
 	PortOpener func(port string) (io.ReadWriteCloser, error)
+	encoders   map[string]int
+	encMutex   sync.Mutex
 	gobot.Eventer
 }
 
@@ -59,10 +78,11 @@ type Adaptor struct {
 // string port as a label to be displayed in the log and api.
 func NewAdaptor(args ...interface{}) *Adaptor {
 	f := &Adaptor{
-		name:  gobot.DefaultName("Firmata"),
-		port:  "",
-		conn:  nil,
-		Board: client.New(),
+		name:     gobot.DefaultName("Firmata"),
+		port:     "",
+		conn:     nil,
+		Board:    client.New(),
+		encoders: map[string]int{},
 		PortOpener: func(port string) (io.ReadWriteCloser, error) {
 			return serial.Open(port, &serial.Mode{BaudRate: 57600})
 		},
@@ -151,8 +171,37 @@ func (f *Adaptor) ServoWrite(pin string, angle byte) (err error) {
 	return
 }
 
+// ServoPulseWrite writes the pulse width in microseconds to the servo on the
+// specified pin. Firmata takes values below 544 as an angle, so shorter
+// pulses can not be written.
+func (f *Adaptor) ServoPulseWrite(pin string, us uint16) (err error) {
+	if us < 544 {
+		return errServoPulseTooShort
+	}
+
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return err
+	}
+
+	if f.Board.Pins()[p].Mode != client.Servo {
+		err = f.Board.SetPinMode(p, client.Servo)
+		if err != nil {
+			return err
+		}
+	}
+	err = f.Board.AnalogWrite(p, int(us))
+	return
+}
+
 // PwmWrite writes the 0-254 value to the specified pin
 func (f *Adaptor) PwmWrite(pin string, level byte) (err error) {
+	return f.AnalogWrite(pin, int(level))
+}
+
+// AnalogWrite writes the value to the PWM or DAC output of the specified
+// pin, in the resolution of the board
+func (f *Adaptor) AnalogWrite(pin string, level int) (err error) {
 	p, err := strconv.Atoi(pin)
 	if err != nil {
 		return err
@@ -164,7 +213,7 @@ func (f *Adaptor) PwmWrite(pin string, level byte) (err error) {
 			return err
 		}
 	}
-	err = f.Board.AnalogWrite(p, int(level))
+	err = f.Board.AnalogWrite(p, level)
 	return
 }
 
@@ -207,6 +256,58 @@ func (f *Adaptor) DigitalRead(pin string) (val int, err error) {
 	return f.Board.Pins()[p].Value, nil
 }
 
+// WatchDigitalPins calls handler whenever the board reports a new level of
+// one of the pins, which are made inputs, until stop is called. The board
+// reports the levels of all the inputs of a port when one of them changes,
+// and they are handled in the order of the reports. stop may be called more
+// than once.
+func (f *Adaptor) WatchDigitalPins(handler func(pin string, level int), pins ...string) (stop func(), err error) {
+	events := map[string]string{}
+	levels := map[string]int{}
+	for _, pin := range pins {
+		p, err := strconv.Atoi(pin)
+		if err != nil {
+			return nil, err
+		}
+		events[fmt.Sprintf("DigitalRead%v", p)] = pin
+	}
+
+	out := f.Board.Subscribe()
+	for _, pin := range pins {
+		if levels[pin], err = f.DigitalRead(pin); err != nil {
+			f.Board.Unsubscribe(out)
+			return nil, err
+		}
+	}
+
+	halt := make(chan bool)
+	done := make(chan bool)
+	var once sync.Once
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case evt := <-out:
+				pin, ok := events[evt.Name]
+				if !ok {
+					continue
+				}
+				if level := evt.Data.(int); level != levels[pin] {
+					levels[pin] = level
+					handler(pin, level)
+				}
+			case <-halt:
+				f.Board.Unsubscribe(out)
+				return
+			}
+		}
+	}()
+	return func() {
+		once.Do(func() { close(halt) })
+		<-done
+	}, nil
+}
+
 // AnalogRead retrieves value from analog pin.
 // Returns -1 if the response from the board has timed out
 func (f *Adaptor) AnalogRead(pin string) (val int, err error) {
@@ -231,10 +332,74 @@ func (f *Adaptor) AnalogRead(pin string) (val int, err error) {
 	return f.Board.Pins()[p].Value, nil
 }
 
+// EncoderRead retrieves the position of the quadrature encoder on the pins,
+// which is counted by the board. The encoder is attached on the first read,
+// which requires a firmware with the encoder feature such as
+// ConfigurableFirmata. It returns gpio.ErrEncoderReadUnsupported if the
+// board reported that the pins do not support the encoder feature.
+func (f *Adaptor) EncoderRead(pinA string, pinB string) (ticks int, err error) {
+	a, err := strconv.Atoi(pinA)
+	if err != nil {
+		return
+	}
+	b, err := strconv.Atoi(pinB)
+	if err != nil {
+		return
+	}
+	for _, pin := range []int{a, b} {
+		supported, err := f.supportsMode(pin, encoderMode)
+		if err != nil {
+			return 0, err
+		}
+		if !supported {
+			return 0, gpio.ErrEncoderReadUnsupported
+		}
+	}
+
+	f.encMutex.Lock()
+	defer f.encMutex.Unlock()
+	key := pinA + "/" + pinB
+	encoder, ok := f.encoders[key]
+	if !ok {
+		encoder = len(f.encoders)
+		if encoder >= maxEncoders {
+			return 0, errTooManyEncoders
+		}
+		if err = f.Board.AttachEncoder(encoder, a, b); err != nil {
+			return
+		}
+		if err = f.Board.ReportEncoders(true); err != nil {
+			return
+		}
+		f.encoders[key] = encoder
+	}
+
+	return f.Board.EncoderPosition(encoder), nil
+}
+
 func (f *Adaptor) WriteSysex(data []byte) error {
 	return f.Board.WriteSysex(data)
 }
 
+// supportsMode returns whether the pin supports the mode, which is assumed
+// if the board did not report the modes of the pin
+func (f *Adaptor) supportsMode(pin int, mode int) (bool, error) {
+	pins := f.Board.Pins()
+	if pin < 0 || pin >= len(pins) {
+		return false, fmt.Errorf("Invalid pin %d, the board has %d pins", pin, len(pins))
+	}
+	modes := pins[pin].SupportedModes
+	if len(modes) == 0 {
+		return true, nil
+	}
+	for _, m := range modes {
+		if m == mode {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // digitalPin converts pin number to digital mapping
 func (f *Adaptor) digitalPin(pin int) int {
 	return pin + 14
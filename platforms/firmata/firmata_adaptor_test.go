@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -22,8 +23,11 @@ var _ gobot.Adaptor = (*Adaptor)(nil)
 var _ gpio.DigitalReader = (*Adaptor)(nil)
 var _ gpio.DigitalWriter = (*Adaptor)(nil)
 var _ aio.AnalogReader = (*Adaptor)(nil)
+var _ aio.AnalogWriter = (*Adaptor)(nil)
 var _ gpio.PwmWriter = (*Adaptor)(nil)
 var _ gpio.ServoWriter = (*Adaptor)(nil)
+var _ gpio.EncoderReader = (*Adaptor)(nil)
+var _ gpio.DigitalPinWatcher = (*Adaptor)(nil)
 var _ i2c.Connector = (*Adaptor)(nil)
 var _ FirmataAdaptor = (*Adaptor)(nil)
 
@@ -54,7 +58,9 @@ func (readWriteCloser) Close() error {
 type mockFirmataBoard struct {
 	disconnectError error
 	gobot.Eventer
-	pins []client.Pin
+	pins     []client.Pin
+	encoders [][]int
+	i2cErr   error
 }
 
 func newMockFirmataBoard() *mockFirmataBoard {
@@ -78,16 +84,23 @@ func (m mockFirmataBoard) Disconnect() error {
 func (m mockFirmataBoard) Pins() []client.Pin {
 	return m.pins
 }
-func (mockFirmataBoard) AnalogWrite(int, int) error      { return nil }
-func (mockFirmataBoard) SetPinMode(int, int) error       { return nil }
-func (mockFirmataBoard) ReportAnalog(int, int) error     { return nil }
-func (mockFirmataBoard) ReportDigital(int, int) error    { return nil }
-func (mockFirmataBoard) DigitalWrite(int, int) error     { return nil }
-func (mockFirmataBoard) I2cRead(int, int) error          { return nil }
-func (mockFirmataBoard) I2cWrite(int, []byte) error      { return nil }
-func (mockFirmataBoard) I2cConfig(int) error             { return nil }
-func (mockFirmataBoard) ServoConfig(int, int, int) error { return nil }
-func (mockFirmataBoard) WriteSysex(data []byte) error    { return nil }
+func (mockFirmataBoard) AnalogWrite(int, int) error             { return nil }
+func (mockFirmataBoard) SetPinMode(int, int) error              { return nil }
+func (mockFirmataBoard) ReportAnalog(int, int) error            { return nil }
+func (mockFirmataBoard) ReportDigital(int, int) error           { return nil }
+func (mockFirmataBoard) DigitalWrite(int, int) error            { return nil }
+func (m *mockFirmataBoard) I2cRead(int, int) error              { return m.i2cErr }
+func (m *mockFirmataBoard) I2cReadRegister(int, int, int) error { return m.i2cErr }
+func (mockFirmataBoard) I2cWrite(int, []byte) error             { return nil }
+func (mockFirmataBoard) I2cConfig(int) error                    { return nil }
+func (mockFirmataBoard) ServoConfig(int, int, int) error        { return nil }
+func (mockFirmataBoard) WriteSysex(data []byte) error           { return nil }
+func (mockFirmataBoard) ReportEncoders(bool) error              { return nil }
+func (mockFirmataBoard) EncoderPosition(encoder int) int        { return 10 * encoder }
+func (m *mockFirmataBoard) AttachEncoder(encoder int, a int, b int) error {
+	m.encoders = append(m.encoders, []int{encoder, a, b})
+	return nil
+}
 
 func initTestAdaptor() *Adaptor {
 	a := NewAdaptor("/dev/null")
@@ -148,6 +161,57 @@ func TestAdaptorServoWriteBadPin(t *testing.T) {
 	gobottest.Refute(t, a.ServoWrite("xyz", 50), nil)
 }
 
+func TestAdaptorServoPulseWrite(t *testing.T) {
+	a := initTestAdaptor()
+	gobottest.Assert(t, a.ServoPulseWrite("1", 1500), nil)
+	gobottest.Assert(t, a.ServoPulseWrite("1", 500), errServoPulseTooShort)
+	gobottest.Refute(t, a.ServoPulseWrite("xyz", 1500), nil)
+}
+
+func TestAdaptorEncoderRead(t *testing.T) {
+	a := initTestAdaptor()
+	ticks, err := a.EncoderRead("2", "3")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, ticks, 0)
+	ticks, _ = a.EncoderRead("4", "5")
+	gobottest.Assert(t, ticks, 10)
+	// the encoder is attached once
+	ticks, _ = a.EncoderRead("2", "3")
+	gobottest.Assert(t, ticks, 0)
+	gobottest.Assert(t, a.Board.(*mockFirmataBoard).encoders, [][]int{{0, 2, 3}, {1, 4, 5}})
+
+	for _, pin := range []string{"6", "8", "10", "12"} {
+		_, err = a.EncoderRead(pin, "7")
+	}
+	gobottest.Assert(t, err, errTooManyEncoders)
+	_, err = a.EncoderRead("xyz", "7")
+	gobottest.Refute(t, err, nil)
+}
+
+func TestAdaptorEncoderReadBadPin(t *testing.T) {
+	a := initTestAdaptor()
+	n := len(a.Board.Pins())
+	_, err := a.EncoderRead("2", strconv.Itoa(n))
+	gobottest.Assert(t, err, fmt.Errorf("Invalid pin %d, the board has %d pins", n, n))
+	_, err = a.EncoderRead("-1", "2")
+	gobottest.Refute(t, err, nil)
+
+	a = NewAdaptor()
+	a.Board = &mockFirmataBoard{}
+	_, err = a.EncoderRead("2", "3")
+	gobottest.Assert(t, err, errors.New("Invalid pin 2, the board has 0 pins"))
+}
+
+func TestAdaptorEncoderReadUnsupported(t *testing.T) {
+	a := initTestAdaptor()
+	pins := a.Board.(*mockFirmataBoard).pins
+	pins[2].SupportedModes = []int{client.Input, client.Output, 0x09}
+	pins[3].SupportedModes = []int{client.Input, client.Output}
+	_, err := a.EncoderRead("2", "3")
+	gobottest.Assert(t, err, gpio.ErrEncoderReadUnsupported)
+	gobottest.Assert(t, len(a.Board.(*mockFirmataBoard).encoders), 0)
+}
+
 func TestAdaptorPwmWrite(t *testing.T) {
 	a := initTestAdaptor()
 	gobottest.Assert(t, a.PwmWrite("1", 50), nil)
@@ -158,6 +222,12 @@ func TestAdaptorPwmWriteBadPin(t *testing.T) {
 	gobottest.Refute(t, a.PwmWrite("xyz", 50), nil)
 }
 
+func TestAdaptorAnalogWrite(t *testing.T) {
+	a := initTestAdaptor()
+	gobottest.Assert(t, a.AnalogWrite("1", 1000), nil)
+	gobottest.Refute(t, a.AnalogWrite("xyz", 1000), nil)
+}
+
 func TestAdaptorDigitalWrite(t *testing.T) {
 	a := initTestAdaptor()
 	gobottest.Assert(t, a.DigitalWrite("1", 1), nil)
@@ -179,6 +249,42 @@ func TestAdaptorDigitalRead(t *testing.T) {
 	gobottest.Assert(t, val, 0)
 }
 
+func TestAdaptorWatchDigitalPins(t *testing.T) {
+	a := initTestAdaptor()
+	b := a.Board.(*mockFirmataBoard)
+	changes := make(chan string, 10)
+	stop, err := a.WatchDigitalPins(func(pin string, level int) {
+		changes <- fmt.Sprintf("%v=%v", pin, level)
+	}, "1", "2")
+	gobottest.Assert(t, err, nil)
+
+	// the levels of all the inputs of a port are reported
+	b.Publish("DigitalRead1", 1)
+	b.Publish("DigitalRead2", 1)
+	b.Publish("DigitalRead3", 1)
+	b.Publish("DigitalRead1", 0)
+	for _, change := range []string{"2=1", "1=0"} {
+		select {
+		case c := <-changes:
+			gobottest.Assert(t, c, change)
+		case <-time.After(100 * time.Millisecond):
+			t.Errorf("%v was not handled", change)
+		}
+	}
+
+	stop()
+	stop()
+	b.Publish("DigitalRead2", 0)
+	select {
+	case c := <-changes:
+		t.Errorf("%v was handled after stop", c)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	_, err = a.WatchDigitalPins(func(string, int) {}, "xyz")
+	gobottest.Refute(t, err, nil)
+}
+
 func TestAdaptorDigitalReadBadPin(t *testing.T) {
 	a := initTestAdaptor()
 	_, err := a.DigitalRead("xyz")
@@ -228,6 +334,37 @@ func TestAdaptorI2cRead(t *testing.T) {
 	gobottest.Assert(t, response, i)
 }
 
+func TestAdaptorI2cReadTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { i2cReplyTimeout = timeout }(i2cReplyTimeout)
+	i2cReplyTimeout = 10 * time.Millisecond
+	a := initTestAdaptor()
+	con, _ := a.GetConnection(0, 0)
+
+	_, err := con.Read([]byte{0})
+	gobottest.Assert(t, err, errI2cReplyTimeout)
+}
+
+func TestAdaptorI2cReadRequestError(t *testing.T) {
+	a := initTestAdaptor()
+	b := a.Board.(*mockFirmataBoard)
+	con, _ := a.GetConnection(0, 0)
+
+	b.i2cErr = errors.New("i2c error")
+	_, err := con.Read([]byte{0})
+	gobottest.Assert(t, err, errors.New("i2c error"))
+
+	// the failed read does not take the reply of the next one
+	b.i2cErr = nil
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		b.Publish(b.Event("I2cReply"), client.I2cReply{Data: []byte{100}})
+	}()
+	response := []byte{0}
+	_, err = con.Read(response)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, response, []byte{100})
+}
+
 func TestAdaptorI2cReadByte(t *testing.T) {
 	a := initTestAdaptor()
 	i := []byte{100}
@@ -346,3 +483,68 @@ func TestGetConnectionInvalidBus(t *testing.T) {
 	_, err := a.GetConnection(0x01, 99)
 	gobottest.Assert(t, err, errors.New("Invalid bus number 99, only 0 is supported"))
 }
+
+func TestAdaptorI2cWriteRead(t *testing.T) {
+	a := initTestAdaptor()
+	i := []byte{100, 101}
+	i2cReply := client.I2cReply{Data: i}
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		a.Board.Publish(a.Board.Event("I2cReply"), i2cReply)
+	}()
+
+	con, err := a.GetConnection(0, 0)
+	gobottest.Assert(t, err, nil)
+
+	response := []byte{0, 0}
+	err = i2c.WriteRead(con, []byte{0x01}, response)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, response, i)
+
+	ext := con.(i2c.I2cExtendedOperations)
+	gobottest.Assert(t, ext.WriteRead([]byte{0x01, 0x02}, response), errRegisterWriteRead)
+	gobottest.Assert(t, ext.SetPEC(true), i2c.ErrNotSupported)
+}
+
+func TestAdaptorI2cReadBlockData(t *testing.T) {
+	a := initTestAdaptor()
+	i2cReply := client.I2cReply{Data: []byte{2, 100, 101, 0}}
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		a.Board.Publish(a.Board.Event("I2cReply"), i2cReply)
+	}()
+
+	con, _ := a.GetConnection(0, 0)
+
+	response := []byte{0, 0, 0}
+	n, err := con.(i2c.I2cExtendedOperations).ReadBlockData(0x01, response)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 2)
+	gobottest.Assert(t, response[:n], []byte{100, 101})
+}
+
+func TestAdaptorI2cReadI2cBlockData(t *testing.T) {
+	a := initTestAdaptor()
+	i2cReply := client.I2cReply{Data: []byte{100, 101}}
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		a.Board.Publish(a.Board.Event("I2cReply"), i2cReply)
+		<-time.After(10 * time.Millisecond)
+		a.Board.Publish(a.Board.Event("I2cReply"), i2cReply)
+	}()
+
+	con, _ := a.GetConnection(0, 0)
+	ext := con.(i2c.I2cExtendedOperations)
+
+	response := []byte{0, 0}
+	gobottest.Assert(t, ext.ReadI2cBlockData(0x01, response), nil)
+	gobottest.Assert(t, response, []byte{100, 101})
+
+	gobottest.Assert(t, ext.ReadI2cBlockData(0x01, make([]byte, 3)), i2c.ErrNotEnoughBytes)
+}
+
+func TestAdaptorI2cTenBitAddress(t *testing.T) {
+	gobottest.Assert(t, NewFirmataI2cConnection(nil, 0x2A5|i2c.TenBitAddress).address, 0x2A5|client.I2CTenBitAddress)
+	gobottest.Assert(t, NewFirmataI2cConnection(nil, 0x05|i2c.TenBitAddress).address, 0x05|client.I2CTenBitAddress)
+	gobottest.Assert(t, NewFirmataI2cConnection(nil, 0x1D).address, 0x1D)
+}
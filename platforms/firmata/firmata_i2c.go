@@ -1,40 +1,126 @@
 package firmata
 
 import (
-	//	"gobot.io/x/gobot/drivers/i2c"
+	"errors"
+	"time"
+
+	"gobot.io/x/gobot/drivers/i2c"
 	"gobot.io/x/gobot/platforms/firmata/client"
 )
 
+var errRegisterWriteRead = errors.New("Firmata only supports combined transactions writing a single register byte")
+
+var errI2cReplyTimeout = errors.New("Firmata i2c reply timed out")
+
+// i2cReplyTimeout is how long a read waits for the reply of the board
+var i2cReplyTimeout = time.Second
+
 type firmataI2cConnection struct {
 	address int
 	adaptor *Adaptor
 }
 
 // NewFirmataI2cConnection creates an I2C connection to an I2C device at
-// the specified address
+// the specified address. Addresses or'ed with i2c.TenBitAddress use 10-bit
+// addressing.
 func NewFirmataI2cConnection(adaptor *Adaptor, address int) (connection *firmataI2cConnection) {
+	if address&i2c.TenBitAddress != 0 {
+		address = address&0x3FF | client.I2CTenBitAddress
+	} else {
+		address = address & 0x7F
+	}
 	return &firmataI2cConnection{adaptor: adaptor, address: address}
 }
 
 // Read tries to read a full buffer from the i2c device.
-// Returns an empty array if the response from the board has timed out.
+// Returns an error if the response from the board has timed out.
 func (c *firmataI2cConnection) Read(b []byte) (read int, err error) {
-	ret := make(chan []byte)
+	result, err := c.reply(func() error {
+		return c.adaptor.Board.I2cRead(c.address, len(b))
+	})
+	if err != nil {
+		return
+	}
+
+	copy(b, result)
+	read = len(result)
+	return
+}
 
-	if err = c.adaptor.Board.I2cRead(c.address, len(b)); err != nil {
+// WriteRead writes a register byte and then reads len(r) bytes into r, with
+// a repeated start between them.
+func (c *firmataI2cConnection) WriteRead(w []byte, r []byte) (err error) {
+	switch len(w) {
+	case 0:
+		_, err = c.Read(r)
 		return
+	case 1:
+		return c.readRegister(w[0], r)
 	}
+	return errRegisterWriteRead
+}
 
-	c.adaptor.Board.Once(c.adaptor.Board.Event("I2cReply"), func(data interface{}) {
-		ret <- data.(client.I2cReply).Data
+// ReadBlockData reads an SMBus block from the register. Firmata can not
+// read a block of unknown length, so len(b) bytes plus the length are read.
+func (c *firmataI2cConnection) ReadBlockData(reg uint8, b []byte) (n int, err error) {
+	buf := make([]byte, len(b)+1)
+	if err = c.readRegister(reg, buf); err != nil {
+		return
+	}
+
+	n = int(buf[0])
+	if n > len(b) {
+		n = len(b)
+	}
+	return copy(b, buf[1:n+1]), nil
+}
+
+// ReadI2cBlockData reads len(b) bytes starting at the register.
+func (c *firmataI2cConnection) ReadI2cBlockData(reg uint8, b []byte) (err error) {
+	return c.readRegister(reg, b)
+}
+
+// SetPEC is not supported by Firmata.
+func (c *firmataI2cConnection) SetPEC(enable bool) (err error) {
+	return i2c.ErrNotSupported
+}
+
+func (c *firmataI2cConnection) readRegister(reg uint8, b []byte) (err error) {
+	result, err := c.reply(func() error {
+		return c.adaptor.Board.I2cReadRegister(c.address, int(reg), len(b))
 	})
+	if err != nil {
+		return
+	}
+	if len(result) < len(b) {
+		return i2c.ErrNotEnoughBytes
+	}
 
-	result := <-ret
 	copy(b, result)
+	return
+}
 
-	read = len(result)
+// reply sends the read request and waits for the board to reply, for at
+// most i2cReplyTimeout
+func (c *firmataI2cConnection) reply(request func() error) ([]byte, error) {
+	out := c.adaptor.Board.Subscribe()
+	defer c.adaptor.Board.Unsubscribe(out)
 
-	return
+	if err := request(); err != nil {
+		return nil, err
+	}
+
+	timeout := time.After(i2cReplyTimeout)
+	for {
+		select {
+		case evt := <-out:
+			if evt.Name == c.adaptor.Board.Event("I2cReply") {
+				return evt.Data.(client.I2cReply).Data, nil
+			}
+		case <-timeout:
+			return nil, errI2cReplyTimeout
+		}
+	}
 }
 
 func (c *firmataI2cConnection) Write(data []byte) (written int, err error) {
@@ -0,0 +1,47 @@
+/*
+Package gps contains the Gobot adaptor and driver for NMEA 0183 GPS receivers.
+
+Installing:
+
+	go get gobot.io/x/gobot/platforms/gps
+
+Example:
+
+	package main
+
+	import (
+		"fmt"
+
+		"gobot.io/x/gobot"
+		"gobot.io/x/gobot/platforms/gps"
+	)
+
+	func main() {
+		adaptor := gps.NewAdaptor("/dev/ttyUSB0")
+		receiver := gps.NewDriver(adaptor)
+
+		work := func() {
+			receiver.SendPMTK(220, "1000")
+
+			receiver.On(gps.PositionEvent, func(data interface{}) {
+				p := data.(gps.Position)
+				fmt.Println("Position", p.Latitude, p.Longitude, p.Altitude)
+			})
+			receiver.On(gps.SatellitesEvent, func(data interface{}) {
+				fmt.Println("Satellites in view", len(data.([]gps.Satellite)))
+			})
+		}
+
+		robot := gobot.NewRobot("gpsBot",
+			[]gobot.Connection{adaptor},
+			[]gobot.Device{receiver},
+			work,
+		)
+
+		robot.Start()
+	}
+
+For further information refer to gps README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/gps/README.md
+*/
+package gps // import "gobot.io/x/gobot/platforms/gps"
@@ -0,0 +1,88 @@
+package gps
+
+import (
+	"io"
+
+	serial "go.bug.st/serial.v1"
+	"gobot.io/x/gobot"
+)
+
+// SerialReadWriter is the connection a GPS Driver reads sentences from and
+// writes configuration commands to.
+type SerialReadWriter interface {
+	gobot.Connection
+	io.ReadWriter
+}
+
+// Adaptor is a GPS receiver connected over a serial stream.
+type Adaptor struct {
+	name    string
+	port    string
+	sp      io.ReadWriteCloser
+	connect func(string) (io.ReadWriteCloser, error)
+}
+
+// NewAdaptor returns a new GPS Adaptor for the serial port, using the
+// NMEA 0183 default of 9600 baud unless another baud rate is given.
+func NewAdaptor(port string, baud ...int) *Adaptor {
+	mode := &serial.Mode{BaudRate: 9600}
+	if len(baud) > 0 {
+		mode.BaudRate = baud[0]
+	}
+
+	return &Adaptor{
+		name: gobot.DefaultName("GPS"),
+		port: port,
+		connect: func(port string) (io.ReadWriteCloser, error) {
+			return serial.Open(port, mode)
+		},
+	}
+}
+
+// NewStreamAdaptor returns a new GPS Adaptor which uses an already opened
+// connection, such as a TCP socket from a gpsd instance or a BLE serial port.
+func NewStreamAdaptor(rwc io.ReadWriteCloser) *Adaptor {
+	return &Adaptor{
+		name: gobot.DefaultName("GPS"),
+		connect: func(string) (io.ReadWriteCloser, error) {
+			return rwc, nil
+		},
+	}
+}
+
+// Name returns the Adaptor's name
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the Adaptor's name
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Port returns the Adaptor's port
+func (a *Adaptor) Port() string { return a.port }
+
+// Connect opens the connection to the GPS receiver
+func (a *Adaptor) Connect() (err error) {
+	sp, err := a.connect(a.Port())
+	if err != nil {
+		return err
+	}
+	a.sp = sp
+	return
+}
+
+// Finalize closes the connection to the GPS receiver
+func (a *Adaptor) Finalize() (err error) {
+	if a.sp != nil {
+		err = a.sp.Close()
+	}
+	return
+}
+
+// Read reads raw bytes from the GPS receiver
+func (a *Adaptor) Read(b []byte) (int, error) {
+	return a.sp.Read(b)
+}
+
+// Write writes raw bytes to the GPS receiver
+func (a *Adaptor) Write(b []byte) (int, error) {
+	return a.sp.Write(b)
+}
@@ -0,0 +1,71 @@
+package gps
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Adaptor = (*Adaptor)(nil)
+
+type testReadWriteCloser struct {
+	r       io.Reader
+	written bytes.Buffer
+	closed  bool
+}
+
+func newTestReadWriteCloser(lines ...string) *testReadWriteCloser {
+	return &testReadWriteCloser{r: strings.NewReader(strings.Join(lines, "\r\n") + "\r\n")}
+}
+
+func (t *testReadWriteCloser) Read(b []byte) (int, error)  { return t.r.Read(b) }
+func (t *testReadWriteCloser) Write(b []byte) (int, error) { return t.written.Write(b) }
+func (t *testReadWriteCloser) Close() error {
+	t.closed = true
+	return nil
+}
+
+func initTestAdaptor(lines ...string) (*Adaptor, *testReadWriteCloser) {
+	rwc := newTestReadWriteCloser(lines...)
+	a := NewStreamAdaptor(rwc)
+	a.Connect()
+	return a, rwc
+}
+
+func TestAdaptor(t *testing.T) {
+	a := NewAdaptor("/dev/ttyUSB0")
+	gobottest.Assert(t, a.Port(), "/dev/ttyUSB0")
+	gobottest.Assert(t, strings.HasPrefix(a.Name(), "GPS"), true)
+	a.SetName("NewName")
+	gobottest.Assert(t, a.Name(), "NewName")
+}
+
+func TestAdaptorConnect(t *testing.T) {
+	a, rwc := initTestAdaptor()
+	gobottest.Assert(t, a.Connect(), nil)
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, rwc.closed, true)
+
+	a = NewAdaptor("/dev/null")
+	a.connect = func(string) (io.ReadWriteCloser, error) {
+		return nil, errors.New("connect error")
+	}
+	gobottest.Assert(t, a.Connect(), errors.New("connect error"))
+	gobottest.Assert(t, a.Finalize(), nil)
+}
+
+func TestAdaptorReadWrite(t *testing.T) {
+	a, rwc := initTestAdaptor("hello")
+	b := make([]byte, 5)
+	n, err := a.Read(b)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, string(b[:n]), "hello")
+
+	a.Write([]byte("world"))
+	gobottest.Assert(t, rwc.written.String(), "world")
+}
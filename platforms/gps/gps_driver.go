@@ -0,0 +1,349 @@
+package gps
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// SentenceEvent event
+	SentenceEvent = "sentence"
+	// PositionEvent event
+	PositionEvent = "position"
+	// FixEvent event
+	FixEvent = "fix"
+	// SatellitesEvent event
+	SatellitesEvent = "satellites"
+	// ErrorEvent event
+	ErrorEvent = "error"
+)
+
+const knotsToKph = 1.852
+
+// Position is a geographic position reported by the receiver.
+// Latitude and Longitude are in decimal degrees, Altitude in meters above
+// mean sea level.
+type Position struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+	Altitude  float64
+}
+
+// Fix is the latest navigation solution, merged from all received sentences.
+type Fix struct {
+	Time             time.Time
+	Valid            bool
+	Latitude         float64
+	Longitude        float64
+	Altitude         float64
+	Quality          int
+	Type             int
+	SatellitesUsed   int
+	SatellitesInView int
+	PDOP             float64
+	HDOP             float64
+	VDOP             float64
+	SpeedKnots       float64
+	SpeedKph         float64
+	Course           float64
+}
+
+// Driver parses NMEA 0183 sentences received from a GPS receiver.
+type Driver struct {
+	name       string
+	connection SerialReadWriter
+	running    bool // between Start and Halt
+	reading    bool // while the reader is running
+	mutex      *sync.Mutex
+	fix        Fix
+	date       time.Time
+	satellites []Satellite
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewDriver creates a new GPS Driver.
+//
+// Adds the following API Commands:
+//	"Fix" - See Driver.Fix
+//	"SendSentence" - See Driver.SendSentence
+//
+// Adds the following events:
+//	"sentence" - every valid sentence received, as a Sentence
+//	"position" - a position from a GGA or valid RMC sentence, as a Position
+//	"fix" - the updated Fix after each GGA, RMC, GSA or VTG sentence
+//	"satellites" - the satellites in view, as a []Satellite, after a complete GSV group
+//	"error" - a read, checksum or decoding error
+func NewDriver(a SerialReadWriter) *Driver {
+	d := &Driver{
+		name:       gobot.DefaultName("GPS"),
+		connection: a,
+		mutex:      &sync.Mutex{},
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+
+	d.AddEvent(SentenceEvent)
+	d.AddEvent(PositionEvent)
+	d.AddEvent(FixEvent)
+	d.AddEvent(SatellitesEvent)
+	d.AddEvent(ErrorEvent)
+
+	d.AddCommand("Fix", func(params map[string]interface{}) interface{} {
+		return d.Fix()
+	})
+
+	d.AddCommand("SendSentence", func(params map[string]interface{}) interface{} {
+		body := params["body"].(string)
+		return d.SendSentence(body)
+	})
+
+	return d
+}
+
+// Name returns the Driver's name
+func (d *Driver) Name() string { return d.name }
+
+// SetName sets the Driver's name
+func (d *Driver) SetName(n string) { d.name = n }
+
+// Connection returns the Driver's connection
+func (d *Driver) Connection() gobot.Connection { return d.connection }
+
+// Start starts reading and decoding sentences from the receiver
+func (d *Driver) Start() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.running = true
+	if !d.reading {
+		// a reader which was halted but is still waiting for a line is
+		// kept, so that there is only one reader of the connection
+		d.reading = true
+		go d.read()
+	}
+	return
+}
+
+// Halt stops decoding sentences. The reader terminates with the next line,
+// or once the adaptor connection is closed.
+func (d *Driver) Halt() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.running = false
+	return
+}
+
+// read decodes the lines of the connection until the driver is halted
+func (d *Driver) read() {
+	r := bufio.NewReader(d.connection)
+	for {
+		line, err := r.ReadString('\n')
+		d.mutex.Lock()
+		running := d.running
+		d.reading = running && err == nil
+		d.mutex.Unlock()
+		if !running {
+			return
+		}
+		if strings.TrimSpace(line) != "" {
+			d.handleLine(line)
+		}
+		if err != nil {
+			d.Publish(ErrorEvent, err)
+			return
+		}
+	}
+}
+
+// Fix returns the latest navigation solution
+func (d *Driver) Fix() Fix {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.fix
+}
+
+// SendSentence sends a NMEA sentence body such as "PMTK220,1000" to the
+// receiver, adding the leading '$', the checksum and the line ending.
+func (d *Driver) SendSentence(body string) (err error) {
+	_, err = d.connection.Write([]byte(FormatSentence(body)))
+	return
+}
+
+// SendPMTK sends a MediaTek PMTK command, for example
+// SendPMTK(220, "1000") sets the position fix interval to one second.
+func (d *Driver) SendPMTK(packetType int, data ...string) (err error) {
+	body := fmt.Sprintf("PMTK%03d", packetType)
+	if len(data) > 0 {
+		body += "," + strings.Join(data, ",")
+	}
+	return d.SendSentence(body)
+}
+
+// SendUBX sends a u-blox UBX binary message with the given class, id
+// and payload, adding the sync chars, length and checksum.
+func (d *Driver) SendUBX(class byte, id byte, payload []byte) (err error) {
+	buf := new(bytes.Buffer)
+	buf.Write([]byte{0xB5, 0x62, class, id})
+	binary.Write(buf, binary.LittleEndian, uint16(len(payload)))
+	buf.Write(payload)
+
+	var ckA, ckB byte
+	for _, b := range buf.Bytes()[2:] {
+		ckA += b
+		ckB += ckA
+	}
+	buf.Write([]byte{ckA, ckB})
+
+	_, err = d.connection.Write(buf.Bytes())
+	return
+}
+
+// handleLine decodes a single line and publishes the resulting events
+func (d *Driver) handleLine(line string) {
+	s, err := ParseSentence(line)
+	if err != nil {
+		d.Publish(ErrorEvent, err)
+		return
+	}
+	d.Publish(SentenceEvent, s)
+
+	switch s.Type {
+	case "GGA":
+		g, err := ParseGGA(s)
+		if err != nil {
+			d.Publish(ErrorEvent, err)
+			return
+		}
+		d.handleGGA(g)
+	case "RMC":
+		r, err := ParseRMC(s)
+		if err != nil {
+			d.Publish(ErrorEvent, err)
+			return
+		}
+		d.handleRMC(r)
+	case "GSA":
+		g, err := ParseGSA(s)
+		if err != nil {
+			d.Publish(ErrorEvent, err)
+			return
+		}
+		d.handleGSA(g)
+	case "GSV":
+		g, err := ParseGSV(s)
+		if err != nil {
+			d.Publish(ErrorEvent, err)
+			return
+		}
+		d.handleGSV(g)
+	case "VTG":
+		v, err := ParseVTG(s)
+		if err != nil {
+			d.Publish(ErrorEvent, err)
+			return
+		}
+		d.handleVTG(v)
+	}
+}
+
+func (d *Driver) handleGGA(g GGA) {
+	d.mutex.Lock()
+	d.fix.Quality = g.Quality
+	d.fix.SatellitesUsed = g.Satellites
+	d.fix.HDOP = g.HDOP
+	d.fix.Valid = g.Quality > 0
+	if !d.date.IsZero() {
+		d.fix.Time = d.date.Add(g.Time)
+	}
+	if d.fix.Valid {
+		d.fix.Latitude = g.Latitude
+		d.fix.Longitude = g.Longitude
+		d.fix.Altitude = g.Altitude
+	}
+	fix := d.fix
+	d.mutex.Unlock()
+
+	if fix.Valid {
+		d.Publish(PositionEvent, Position{
+			Time:      fix.Time,
+			Latitude:  fix.Latitude,
+			Longitude: fix.Longitude,
+			Altitude:  fix.Altitude,
+		})
+	}
+	d.Publish(FixEvent, fix)
+}
+
+func (d *Driver) handleRMC(r RMC) {
+	d.mutex.Lock()
+	if !r.Time.IsZero() {
+		d.date = r.Time.Truncate(24 * time.Hour)
+		d.fix.Time = r.Time
+	}
+	d.fix.Valid = r.Valid
+	if r.Valid {
+		d.fix.Latitude = r.Latitude
+		d.fix.Longitude = r.Longitude
+		d.fix.SpeedKnots = r.SpeedKnots
+		d.fix.SpeedKph = r.SpeedKnots * knotsToKph
+		d.fix.Course = r.Course
+	}
+	fix := d.fix
+	d.mutex.Unlock()
+
+	if fix.Valid {
+		d.Publish(PositionEvent, Position{
+			Time:      fix.Time,
+			Latitude:  fix.Latitude,
+			Longitude: fix.Longitude,
+			Altitude:  fix.Altitude,
+		})
+	}
+	d.Publish(FixEvent, fix)
+}
+
+func (d *Driver) handleGSA(g GSA) {
+	d.mutex.Lock()
+	d.fix.Type = g.FixType
+	d.fix.PDOP = g.PDOP
+	d.fix.HDOP = g.HDOP
+	d.fix.VDOP = g.VDOP
+	fix := d.fix
+	d.mutex.Unlock()
+
+	d.Publish(FixEvent, fix)
+}
+
+func (d *Driver) handleGSV(g GSV) {
+	d.mutex.Lock()
+	if g.MessageNumber <= 1 {
+		d.satellites = nil
+	}
+	d.satellites = append(d.satellites, g.Satellites...)
+	d.fix.SatellitesInView = g.InView
+	sats := d.satellites
+	d.mutex.Unlock()
+
+	if g.MessageNumber >= g.TotalMessages {
+		d.Publish(SatellitesEvent, sats)
+	}
+}
+
+func (d *Driver) handleVTG(v VTG) {
+	d.mutex.Lock()
+	d.fix.Course = v.TrueCourse
+	d.fix.SpeedKnots = v.SpeedKnots
+	d.fix.SpeedKph = v.SpeedKph
+	fix := d.fix
+	d.mutex.Unlock()
+
+	d.Publish(FixEvent, fix)
+}
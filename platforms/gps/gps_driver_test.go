@@ -0,0 +1,132 @@
+package gps
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*Driver)(nil)
+
+func TestDriver(t *testing.T) {
+	a, _ := initTestAdaptor()
+	d := NewDriver(a)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "GPS"), true)
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	d.SetName("NewName")
+	gobottest.Assert(t, d.Name(), "NewName")
+}
+
+func TestDriverStart(t *testing.T) {
+	a, _ := initTestAdaptor(testGSA, testGSV1, testGSV2, testRMC, testGGA, testVTG)
+	d := NewDriver(a)
+
+	positions := make(chan Position, 10)
+	satellites := make(chan []Satellite, 10)
+	errs := make(chan error, 10)
+	d.On(PositionEvent, func(data interface{}) {
+		positions <- data.(Position)
+	})
+	d.On(SatellitesEvent, func(data interface{}) {
+		satellites <- data.([]Satellite)
+	})
+	d.On(ErrorEvent, func(data interface{}) {
+		errs <- data.(error)
+	})
+
+	gobottest.Assert(t, d.Start(), nil)
+
+	select {
+	case sats := <-satellites:
+		gobottest.Assert(t, len(sats), 8)
+		gobottest.Assert(t, sats[5].PRN, 19)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("satellites was not published")
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case p := <-positions:
+			gobottest.Assert(t, p.Latitude, 48+7.038/60)
+			gobottest.Assert(t, p.Time, time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC))
+		case <-time.After(100 * time.Millisecond):
+			t.Errorf("position was not published")
+		}
+	}
+
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err, io.EOF)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("error was not published")
+	}
+
+	fix := d.Fix()
+	gobottest.Assert(t, fix.Valid, true)
+	gobottest.Assert(t, fix.Altitude, 545.4)
+	gobottest.Assert(t, fix.Type, 3)
+	gobottest.Assert(t, fix.SatellitesUsed, 8)
+	gobottest.Assert(t, fix.SatellitesInView, 8)
+	gobottest.Assert(t, fix.VDOP, 2.1)
+	gobottest.Assert(t, fix.SpeedKph, 10.2)
+	gobottest.Assert(t, fix.Course, 54.7)
+
+	gobottest.Assert(t, d.Command("Fix")(nil), fix)
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestDriverHaltTwice(t *testing.T) {
+	a, _ := initTestAdaptor()
+	d := NewDriver(a)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestDriverChecksumError(t *testing.T) {
+	a, _ := initTestAdaptor("$GPGGA,123519,4807.038,N*00")
+	d := NewDriver(a)
+
+	errs := make(chan error, 10)
+	d.On(ErrorEvent, func(data interface{}) {
+		errs <- data.(error)
+	})
+	d.Start()
+
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err, ErrChecksumMismatch)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("error was not published")
+	}
+}
+
+func TestDriverSendSentence(t *testing.T) {
+	a, rwc := initTestAdaptor()
+	d := NewDriver(a)
+
+	gobottest.Assert(t, d.SendPMTK(220, "1000"), nil)
+	gobottest.Assert(t, rwc.written.String(), "$PMTK220,1000*1F\r\n")
+
+	rwc.written.Reset()
+	d.Command("SendSentence")(map[string]interface{}{"body": "PMTK220,1000"})
+	gobottest.Assert(t, rwc.written.String(), "$PMTK220,1000*1F\r\n")
+}
+
+func TestDriverSendUBX(t *testing.T) {
+	a, rwc := initTestAdaptor()
+	d := NewDriver(a)
+
+	// CFG-RATE: 1000ms measurement rate, 1 cycle, GPS time
+	gobottest.Assert(t, d.SendUBX(0x06, 0x08, []byte{0xE8, 0x03, 0x01, 0x00, 0x01, 0x00}), nil)
+	gobottest.Assert(t, rwc.written.Bytes(), []byte{
+		0xB5, 0x62, 0x06, 0x08, 0x06, 0x00, 0xE8, 0x03, 0x01, 0x00, 0x01, 0x00, 0x01, 0x39,
+	})
+}
@@ -0,0 +1,359 @@
+package gps
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSentence is the error returned when a line is not a NMEA 0183 sentence
+	ErrInvalidSentence = errors.New("invalid NMEA sentence")
+	// ErrChecksumMissing is the error returned when a NMEA sentence has no checksum
+	ErrChecksumMissing = errors.New("NMEA sentence checksum missing")
+	// ErrChecksumMismatch is the error returned when a NMEA sentence checksum is not valid
+	ErrChecksumMismatch = errors.New("NMEA sentence checksum mismatch")
+)
+
+// Sentence is a raw NMEA 0183 sentence split into its fields.
+type Sentence struct {
+	// Talker is the talker identifier such as "GP", "GN" or "GL"
+	Talker string
+	// Type is the sentence type such as "GGA" or "RMC"
+	Type string
+	// Fields holds the comma separated data fields following the address field
+	Fields []string
+	// Raw is the sentence as it was received, without line endings
+	Raw string
+}
+
+// GGA is the Global Positioning System Fix Data sentence.
+type GGA struct {
+	Time          time.Duration
+	Latitude      float64
+	Longitude     float64
+	Quality       int
+	Satellites    int
+	HDOP          float64
+	Altitude      float64
+	GeoidSep      float64
+	DGPSAge       float64
+	DGPSStationID string
+}
+
+// RMC is the Recommended Minimum Specific GNSS Data sentence.
+type RMC struct {
+	// Time is zero if the receiver does not know the date yet
+	Time       time.Time
+	Valid      bool
+	Latitude   float64
+	Longitude  float64
+	SpeedKnots float64
+	Course     float64
+	Variation  float64
+}
+
+// GSA is the GNSS DOP and Active Satellites sentence.
+type GSA struct {
+	Mode    string
+	FixType int
+	PRNs    []int
+	PDOP    float64
+	HDOP    float64
+	VDOP    float64
+}
+
+// GSV is the GNSS Satellites in View sentence.
+type GSV struct {
+	TotalMessages int
+	MessageNumber int
+	InView        int
+	Satellites    []Satellite
+}
+
+// VTG is the Course Over Ground and Ground Speed sentence.
+type VTG struct {
+	TrueCourse     float64
+	MagneticCourse float64
+	SpeedKnots     float64
+	SpeedKph       float64
+}
+
+// Satellite describes a single satellite reported by a GSV sentence.
+type Satellite struct {
+	PRN       int
+	Elevation int
+	Azimuth   int
+	SNR       int
+}
+
+// Checksum returns the NMEA checksum of the sentence body, that is all
+// characters between the leading '$' and the '*'.
+func Checksum(body string) byte {
+	var cs byte
+	for i := 0; i < len(body); i++ {
+		cs ^= body[i]
+	}
+	return cs
+}
+
+// FormatSentence builds a NMEA sentence with its checksum and line ending
+// from the body, for example "PMTK220,1000".
+func FormatSentence(body string) string {
+	return fmt.Sprintf("$%s*%02X\r\n", body, Checksum(body))
+}
+
+// ParseSentence verifies the checksum of a NMEA line and splits it into fields.
+func ParseSentence(line string) (s Sentence, err error) {
+	line = strings.TrimSpace(line)
+	if len(line) < 7 || (line[0] != '$' && line[0] != '!') {
+		return s, ErrInvalidSentence
+	}
+
+	star := strings.LastIndexByte(line, '*')
+	if star < 0 {
+		return s, ErrChecksumMissing
+	}
+	if len(line)-star != 3 {
+		return s, ErrInvalidSentence
+	}
+
+	cs, err := strconv.ParseUint(line[star+1:], 16, 8)
+	if err != nil {
+		return s, ErrInvalidSentence
+	}
+
+	body := line[1:star]
+	if Checksum(body) != byte(cs) {
+		return s, ErrChecksumMismatch
+	}
+
+	fields := strings.Split(body, ",")
+	address := fields[0]
+	if len(address) < 3 {
+		return s, ErrInvalidSentence
+	}
+
+	s.Raw = line
+	s.Fields = fields[1:]
+	if strings.HasPrefix(address, "P") {
+		// proprietary sentences have no talker identifier
+		s.Type = address
+		return
+	}
+	s.Talker = address[:2]
+	s.Type = address[2:]
+	return
+}
+
+// field returns the n-th field of the sentence or an empty string
+func (s Sentence) field(n int) string {
+	if n < len(s.Fields) {
+		return s.Fields[n]
+	}
+	return ""
+}
+
+// ParseGGA decodes a GGA sentence.
+func ParseGGA(s Sentence) (g GGA, err error) {
+	if s.Type != "GGA" {
+		return g, fmt.Errorf("expected GGA sentence, got %s", s.Type)
+	}
+	p := parser{s: s}
+	g.Time = p.timeOfDay(0)
+	g.Latitude = p.coordinate(1, 2)
+	g.Longitude = p.coordinate(3, 4)
+	g.Quality = p.int(5)
+	g.Satellites = p.int(6)
+	g.HDOP = p.float(7)
+	g.Altitude = p.float(8)
+	g.GeoidSep = p.float(10)
+	g.DGPSAge = p.float(12)
+	g.DGPSStationID = s.field(13)
+	return g, p.err
+}
+
+// ParseRMC decodes a RMC sentence.
+func ParseRMC(s Sentence) (r RMC, err error) {
+	if s.Type != "RMC" {
+		return r, fmt.Errorf("expected RMC sentence, got %s", s.Type)
+	}
+	p := parser{s: s}
+	tod := p.timeOfDay(0)
+	r.Valid = s.field(1) == "A"
+	r.Latitude = p.coordinate(2, 3)
+	r.Longitude = p.coordinate(4, 5)
+	r.SpeedKnots = p.float(6)
+	r.Course = p.float(7)
+	if date := p.date(8); !date.IsZero() {
+		r.Time = date.Add(tod)
+	}
+	r.Variation = p.float(9)
+	if s.field(10) == "W" {
+		r.Variation = -r.Variation
+	}
+	return r, p.err
+}
+
+// ParseGSA decodes a GSA sentence.
+func ParseGSA(s Sentence) (g GSA, err error) {
+	if s.Type != "GSA" {
+		return g, fmt.Errorf("expected GSA sentence, got %s", s.Type)
+	}
+	p := parser{s: s}
+	g.Mode = s.field(0)
+	g.FixType = p.int(1)
+	for i := 2; i < 14; i++ {
+		if s.field(i) != "" {
+			g.PRNs = append(g.PRNs, p.int(i))
+		}
+	}
+	g.PDOP = p.float(14)
+	g.HDOP = p.float(15)
+	g.VDOP = p.float(16)
+	return g, p.err
+}
+
+// ParseGSV decodes a GSV sentence.
+func ParseGSV(s Sentence) (g GSV, err error) {
+	if s.Type != "GSV" {
+		return g, fmt.Errorf("expected GSV sentence, got %s", s.Type)
+	}
+	p := parser{s: s}
+	g.TotalMessages = p.int(0)
+	g.MessageNumber = p.int(1)
+	g.InView = p.int(2)
+	// NMEA 4.1 appends a signal ID, which leaves a dangling field
+	for i := 3; i+3 < len(s.Fields); i += 4 {
+		if s.field(i) == "" {
+			continue
+		}
+		g.Satellites = append(g.Satellites, Satellite{
+			PRN:       p.int(i),
+			Elevation: p.int(i + 1),
+			Azimuth:   p.int(i + 2),
+			SNR:       p.int(i + 3),
+		})
+	}
+	return g, p.err
+}
+
+// ParseVTG decodes a VTG sentence.
+func ParseVTG(s Sentence) (v VTG, err error) {
+	if s.Type != "VTG" {
+		return v, fmt.Errorf("expected VTG sentence, got %s", s.Type)
+	}
+	p := parser{s: s}
+	v.TrueCourse = p.float(0)
+	v.MagneticCourse = p.float(2)
+	v.SpeedKnots = p.float(4)
+	v.SpeedKph = p.float(6)
+	return v, p.err
+}
+
+// parser decodes sentence fields and remembers the first error encountered.
+// Empty fields are not an error, receivers leave them blank without a fix.
+type parser struct {
+	s   Sentence
+	err error
+}
+
+func (p *parser) fail(n int, kind string) {
+	if p.err == nil {
+		p.err = fmt.Errorf("%s%s: invalid %s in field %d: %q",
+			p.s.Talker, p.s.Type, kind, n+1, p.s.field(n))
+	}
+}
+
+func (p *parser) int(n int) int {
+	f := p.s.field(n)
+	if f == "" {
+		return 0
+	}
+	v, err := strconv.Atoi(f)
+	if err != nil {
+		p.fail(n, "integer")
+	}
+	return v
+}
+
+func (p *parser) float(n int) float64 {
+	f := p.s.field(n)
+	if f == "" {
+		return 0
+	}
+	v, err := strconv.ParseFloat(f, 64)
+	if err != nil {
+		p.fail(n, "number")
+	}
+	return v
+}
+
+// coordinate decodes a ddmm.mmmm / dddmm.mmmm value and its hemisphere
+// field into signed decimal degrees.
+func (p *parser) coordinate(n, hemisphere int) float64 {
+	f := p.s.field(n)
+	if f == "" {
+		return 0
+	}
+	dot := strings.IndexByte(f, '.')
+	if dot < 0 {
+		dot = len(f)
+	}
+	if dot < 3 {
+		p.fail(n, "coordinate")
+		return 0
+	}
+	deg, err1 := strconv.ParseFloat(f[:dot-2], 64)
+	min, err2 := strconv.ParseFloat(f[dot-2:], 64)
+	if err1 != nil || err2 != nil {
+		p.fail(n, "coordinate")
+		return 0
+	}
+	v := deg + min/60
+	switch p.s.field(hemisphere) {
+	case "N", "E":
+	case "S", "W":
+		v = -v
+	default:
+		p.fail(hemisphere, "hemisphere")
+	}
+	return v
+}
+
+// timeOfDay decodes a hhmmss.ss UTC time field.
+func (p *parser) timeOfDay(n int) time.Duration {
+	f := p.s.field(n)
+	if f == "" {
+		return 0
+	}
+	if len(f) < 6 {
+		p.fail(n, "time")
+		return 0
+	}
+	h, err1 := strconv.Atoi(f[0:2])
+	m, err2 := strconv.Atoi(f[2:4])
+	sec, err3 := strconv.ParseFloat(f[4:], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		p.fail(n, "time")
+		return 0
+	}
+	return time.Duration(h)*time.Hour +
+		time.Duration(m)*time.Minute +
+		time.Duration(sec*float64(time.Second))
+}
+
+// date decodes a ddmmyy UTC date field.
+func (p *parser) date(n int) time.Time {
+	f := p.s.field(n)
+	if f == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse("020106", f)
+	if err != nil {
+		p.fail(n, "date")
+	}
+	return t
+}
@@ -0,0 +1,128 @@
+package gps
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+const (
+	testGGA  = "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47"
+	testRMC  = "$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A"
+	testGSA  = "$GPGSA,A,3,04,05,,09,12,,,24,,,,,2.5,1.3,2.1*39"
+	testGSV1 = "$GPGSV,2,1,08,01,40,083,46,02,17,308,41,12,07,344,39,14,22,228,45*75"
+	testGSV2 = "$GPGSV,2,2,08,15,30,050,47,19,10,100,,20,55,200,40,22,05,010,30*7B"
+	testVTG  = "$GPVTG,054.7,T,034.4,M,005.5,N,010.2,K*48"
+)
+
+func TestChecksum(t *testing.T) {
+	gobottest.Assert(t, Checksum("PMTK220,1000"), byte(0x1F))
+	gobottest.Assert(t, FormatSentence("PMTK220,1000"), "$PMTK220,1000*1F\r\n")
+}
+
+func TestParseSentence(t *testing.T) {
+	s, err := ParseSentence(testGGA + "\r\n")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, s.Talker, "GP")
+	gobottest.Assert(t, s.Type, "GGA")
+	gobottest.Assert(t, len(s.Fields), 14)
+	gobottest.Assert(t, s.Raw, testGGA)
+
+	s, err = ParseSentence("$PMTK220,1000*1F")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, s.Talker, "")
+	gobottest.Assert(t, s.Type, "PMTK220")
+}
+
+func TestParseSentenceError(t *testing.T) {
+	_, err := ParseSentence("GPGGA,123519*47")
+	gobottest.Assert(t, err, ErrInvalidSentence)
+
+	_, err = ParseSentence("$GPGGA,123519,4807.038,N")
+	gobottest.Assert(t, err, ErrChecksumMissing)
+
+	_, err = ParseSentence("$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48")
+	gobottest.Assert(t, err, ErrChecksumMismatch)
+
+	_, err = ParseSentence("$GPGGA,123519*4")
+	gobottest.Assert(t, err, ErrInvalidSentence)
+}
+
+func TestParseGGA(t *testing.T) {
+	s, _ := ParseSentence(testGGA)
+	g, err := ParseGGA(s)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, g.Time, 12*time.Hour+35*time.Minute+19*time.Second)
+	gobottest.Assert(t, g.Latitude, 48+7.038/60)
+	gobottest.Assert(t, g.Longitude, 11+31.0/60)
+	gobottest.Assert(t, g.Quality, 1)
+	gobottest.Assert(t, g.Satellites, 8)
+	gobottest.Assert(t, g.HDOP, 0.9)
+	gobottest.Assert(t, g.Altitude, 545.4)
+	gobottest.Assert(t, g.GeoidSep, 46.9)
+
+	_, err = ParseGGA(Sentence{Type: "RMC"})
+	gobottest.Refute(t, err, nil)
+}
+
+func TestParseGGAError(t *testing.T) {
+	s, err := ParseSentence("$GPGGA,123519,48x7.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*0F")
+	gobottest.Assert(t, err, nil)
+	_, err = ParseGGA(s)
+	gobottest.Assert(t, err.Error(), "GPGGA: invalid coordinate in field 2: \"48x7.038\"")
+}
+
+func TestParseRMC(t *testing.T) {
+	s, _ := ParseSentence(testRMC)
+	r, err := ParseRMC(s)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, r.Time, time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC))
+	gobottest.Assert(t, r.Valid, true)
+	gobottest.Assert(t, r.Latitude, 48+7.038/60)
+	gobottest.Assert(t, r.Longitude, 11+31.0/60)
+	gobottest.Assert(t, r.SpeedKnots, 22.4)
+	gobottest.Assert(t, r.Course, 84.4)
+	gobottest.Assert(t, r.Variation, -3.1)
+}
+
+func TestParseRMCNoDate(t *testing.T) {
+	s, _ := ParseSentence(FormatSentence("GPRMC,123519,V,,,,,,,,,"))
+	r, err := ParseRMC(s)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, r.Time.IsZero(), true)
+	gobottest.Assert(t, r.Valid, false)
+}
+
+func TestParseGSA(t *testing.T) {
+	s, _ := ParseSentence(testGSA)
+	g, err := ParseGSA(s)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, g.Mode, "A")
+	gobottest.Assert(t, g.FixType, 3)
+	gobottest.Assert(t, g.PRNs, []int{4, 5, 9, 12, 24})
+	gobottest.Assert(t, g.PDOP, 2.5)
+	gobottest.Assert(t, g.HDOP, 1.3)
+	gobottest.Assert(t, g.VDOP, 2.1)
+}
+
+func TestParseGSV(t *testing.T) {
+	s, _ := ParseSentence(testGSV1)
+	g, err := ParseGSV(s)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, g.TotalMessages, 2)
+	gobottest.Assert(t, g.MessageNumber, 1)
+	gobottest.Assert(t, g.InView, 8)
+	gobottest.Assert(t, len(g.Satellites), 4)
+	gobottest.Assert(t, g.Satellites[3], Satellite{PRN: 14, Elevation: 22, Azimuth: 228, SNR: 45})
+}
+
+func TestParseVTG(t *testing.T) {
+	s, _ := ParseSentence(testVTG)
+	v, err := ParseVTG(s)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v.TrueCourse, 54.7)
+	gobottest.Assert(t, v.MagneticCourse, 34.4)
+	gobottest.Assert(t, v.SpeedKnots, 5.5)
+	gobottest.Assert(t, v.SpeedKph, 10.2)
+}
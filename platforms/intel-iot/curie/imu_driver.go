@@ -2,8 +2,11 @@ package curie
 
 import (
 	"errors"
+	"math"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 	"gobot.io/x/gobot/platforms/firmata"
 )
 
@@ -18,6 +21,21 @@ const (
 	CURIE_IMU_READ_MOTION  = 0x06
 )
 
+// The Curie's accelerometer is read in its default range of +-2g, and its
+// gyroscope in its default range of +-2000 degrees per second.
+const (
+	curieAccelScale = imu.StandardGravity / 16384
+	curieGyroScale  = math.Pi / 180 / 16.4
+)
+
+// ErrReadTimeout is the error resulting when the Curie does not respond to a
+// read of the IMU in time
+var ErrReadTimeout = errors.New("Curie IMU read timed out")
+
+// readTimeout is how long ReadAcceleration and ReadAngularVelocity wait for
+// the response of the Curie
+var readTimeout = time.Second
+
 // AccelerometerData is what gets returned with the "Accelerometer" event.
 type AccelerometerData struct {
 	X int16
@@ -149,6 +167,47 @@ func (imu *IMUDriver) ReadMotion() error {
 	return imu.connection.WriteSysex([]byte{CURIE_IMU, CURIE_IMU_READ_MOTION})
 }
 
+// ReadAcceleration reads the accelerometer and waits for the response, and
+// returns the acceleration in meters per second squared. It implements
+// imu.Accelerometer.
+func (imu *IMUDriver) ReadAcceleration() (v imu.Vector, err error) {
+	data, err := imu.request("Accelerometer", imu.ReadAccelerometer)
+	if err != nil {
+		return
+	}
+	a := data.(*AccelerometerData)
+	return curieVector(a.X, a.Y, a.Z, curieAccelScale), nil
+}
+
+// ReadAngularVelocity reads the gyroscope and waits for the response, and
+// returns the angular velocity in radians per second. It implements
+// imu.Gyroscope.
+func (imu *IMUDriver) ReadAngularVelocity() (v imu.Vector, err error) {
+	data, err := imu.request("Gyroscope", imu.ReadGyroscope)
+	if err != nil {
+		return
+	}
+	g := data.(*GyroscopeData)
+	return curieVector(g.X, g.Y, g.Z, curieGyroScale), nil
+}
+
+// request calls read and waits for the event with its result
+func (imu *IMUDriver) request(event string, read func() error) (data interface{}, err error) {
+	result := make(chan interface{}, 1)
+	imu.Once(event, func(data interface{}) {
+		result <- data
+	})
+	if err = read(); err != nil {
+		return
+	}
+	select {
+	case data = <-result:
+	case <-time.After(readTimeout):
+		err = ErrReadTimeout
+	}
+	return
+}
+
 func (imu *IMUDriver) handleEvent(data []byte) (err error) {
 	if data[1] == CURIE_IMU {
 		switch data[2] {
@@ -192,6 +251,14 @@ func (imu *IMUDriver) handleEvent(data []byte) (err error) {
 	return
 }
 
+func curieVector(x, y, z int16, scale float64) imu.Vector {
+	return imu.Vector{
+		X: float64(x) * scale,
+		Y: float64(y) * scale,
+		Z: float64(z) * scale,
+	}
+}
+
 func parseAccelerometerData(data []byte) (*AccelerometerData, error) {
 	if len(data) < 9 {
 		return nil, errors.New("Invalid data")
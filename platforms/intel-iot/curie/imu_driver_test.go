@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 	"gobot.io/x/gobot/gobottest"
 
 	"gobot.io/x/gobot/platforms/firmata"
@@ -16,6 +19,9 @@ import (
 
 var _ gobot.Driver = (*IMUDriver)(nil)
 
+var _ imu.Accelerometer = (*IMUDriver)(nil)
+var _ imu.Gyroscope = (*IMUDriver)(nil)
+
 type readWriteCloser struct{}
 
 func (readWriteCloser) Write(p []byte) (int, error) {
@@ -67,16 +73,20 @@ func (m mockFirmataBoard) Disconnect() error {
 func (m mockFirmataBoard) Pins() []client.Pin {
 	return m.pins
 }
-func (mockFirmataBoard) AnalogWrite(int, int) error      { return nil }
-func (mockFirmataBoard) SetPinMode(int, int) error       { return nil }
-func (mockFirmataBoard) ReportAnalog(int, int) error     { return nil }
-func (mockFirmataBoard) ReportDigital(int, int) error    { return nil }
-func (mockFirmataBoard) DigitalWrite(int, int) error     { return nil }
-func (mockFirmataBoard) I2cRead(int, int) error          { return nil }
-func (mockFirmataBoard) I2cWrite(int, []byte) error      { return nil }
-func (mockFirmataBoard) I2cConfig(int) error             { return nil }
-func (mockFirmataBoard) ServoConfig(int, int, int) error { return nil }
-func (mockFirmataBoard) WriteSysex(data []byte) error    { return nil }
+func (mockFirmataBoard) AnalogWrite(int, int) error          { return nil }
+func (mockFirmataBoard) SetPinMode(int, int) error           { return nil }
+func (mockFirmataBoard) ReportAnalog(int, int) error         { return nil }
+func (mockFirmataBoard) ReportDigital(int, int) error        { return nil }
+func (mockFirmataBoard) DigitalWrite(int, int) error         { return nil }
+func (mockFirmataBoard) I2cRead(int, int) error              { return nil }
+func (mockFirmataBoard) I2cReadRegister(int, int, int) error { return nil }
+func (mockFirmataBoard) I2cWrite(int, []byte) error          { return nil }
+func (mockFirmataBoard) I2cConfig(int) error                 { return nil }
+func (mockFirmataBoard) ServoConfig(int, int, int) error     { return nil }
+func (mockFirmataBoard) AttachEncoder(int, int, int) error   { return nil }
+func (mockFirmataBoard) ReportEncoders(bool) error           { return nil }
+func (mockFirmataBoard) EncoderPosition(int) int             { return 0 }
+func (mockFirmataBoard) WriteSysex(data []byte) error        { return nil }
 
 func initTestIMUDriver() *IMUDriver {
 	a := firmata.NewAdaptor("/dev/null")
@@ -128,6 +138,44 @@ func TestIMUDriverReadAccelerometerData(t *testing.T) {
 	gobottest.Assert(t, result, &AccelerometerData{X: 1920, Y: 1920, Z: 1920})
 }
 
+func TestIMUDriverReadAcceleration(t *testing.T) {
+	d := initTestIMUDriver()
+	d.Start()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		d.handleEvent([]byte{0xF0, 0x11, 0x00, 0x00, 0x0f, 0x00, 0x0f, 0x00, 0x0f, 0xf7})
+	}()
+	v, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, nil)
+	want := 1920.0 / 16384 * imu.StandardGravity
+	gobottest.Assert(t, math.Abs(v.X-want) < 1e-9, true)
+	gobottest.Assert(t, math.Abs(v.Z-want) < 1e-9, true)
+}
+
+func TestIMUDriverReadAccelerationTimeout(t *testing.T) {
+	timeout := readTimeout
+	readTimeout = 10 * time.Millisecond
+	defer func() { readTimeout = timeout }()
+
+	d := initTestIMUDriver()
+	d.Start()
+	_, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, ErrReadTimeout)
+}
+
+func TestIMUDriverReadAngularVelocity(t *testing.T) {
+	d := initTestIMUDriver()
+	d.Start()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		d.handleEvent([]byte{0xF0, 0x11, 0x01, 0x00, 0x0f, 0x00, 0x0f, 0x00, 0x0f, 0xf7})
+	}()
+	v, err := d.ReadAngularVelocity()
+	gobottest.Assert(t, err, nil)
+	want := 1920.0 / 16.4 * math.Pi / 180
+	gobottest.Assert(t, math.Abs(v.Y-want) < 1e-9, true)
+}
+
 func TestIMUDriverReadGyroscope(t *testing.T) {
 	d := initTestIMUDriver()
 	d.Start()
@@ -27,6 +27,12 @@ type sysfsPin struct {
 }
 
 // Adaptor represents a Gobot Adaptor for an Intel Edison
+//
+// Unlike the other Linux boards it is not built on the linux.Adaptor. Using
+// a pin of the Arduino breakout board means setting its level shifter,
+// pull-up resistor and muxes through other gpios, depending on the
+// direction of the pin, and PWM pins also need their pinmux mode changed.
+// Such a setup sequence can not be described by a linux.Board.
 type Adaptor struct {
 	name        string
 	board       string
@@ -37,7 +43,6 @@ type Adaptor struct {
 	i2cBus      i2c.I2cDevice
 	connect     func(e *Adaptor) (err error)
 	writeFile   func(path string, data []byte) (i int, err error)
-	readFile    func(path string) ([]byte, error)
 	mutex       *sync.Mutex
 }
 
@@ -47,7 +52,6 @@ func NewAdaptor() *Adaptor {
 		name:      gobot.DefaultName("Edison"),
 		pinmap:    arduinoPinMap,
 		writeFile: writeFile,
-		readFile:  readFile,
 		mutex:     &sync.Mutex{},
 	}
 }
@@ -160,14 +164,12 @@ func (e *Adaptor) PwmWrite(pin string, val byte) (err error) {
 
 // AnalogRead returns value from analog reading of specified pin
 func (e *Adaptor) AnalogRead(pin string) (val int, err error) {
-	buf, err := e.readFile(
-		"/sys/bus/iio/devices/iio:device1/in_voltage" + pin + "_raw",
-	)
+	channel, err := strconv.Atoi(pin)
 	if err != nil {
 		return
 	}
 
-	val, err = strconv.Atoi(string(buf[0 : len(buf)-1]))
+	val, err = sysfs.NewIIODevice(1).ReadRaw(channel)
 
 	return val / 4, err
 }
@@ -440,22 +442,6 @@ func writeFile(path string, data []byte) (i int, err error) {
 	return file.Write(data)
 }
 
-func readFile(path string) ([]byte, error) {
-	file, err := sysfs.OpenFile(path, os.O_RDONLY, 0644)
-	defer file.Close()
-	if err != nil {
-		return make([]byte, 0), err
-	}
-
-	buf := make([]byte, 200)
-	var i int
-	i, err = file.Read(buf)
-	if i == 0 {
-		return buf, err
-	}
-	return buf[:i], err
-}
-
 // changePinMode writes pin mode to current_pinmux file
 func changePinMode(a *Adaptor, pin, mode string) (err error) {
 	_, err = a.writeFile(
@@ -123,7 +123,7 @@ func TestAdaptorArduinoSetupFail263(t *testing.T) {
 	delete(fs.Files, "/sys/class/gpio/gpio263/direction")
 
 	err := a.arduinoSetup()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio263/direction: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio263/direction: file does not exist"), true)
 }
 
 func TestAdaptorArduinoSetupFail240(t *testing.T) {
@@ -131,7 +131,7 @@ func TestAdaptorArduinoSetupFail240(t *testing.T) {
 	delete(fs.Files, "/sys/class/gpio/gpio240/direction")
 
 	err := a.arduinoSetup()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio240/direction: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio240/direction: file does not exist"), true)
 }
 
 func TestAdaptorArduinoSetupFail111(t *testing.T) {
@@ -139,7 +139,7 @@ func TestAdaptorArduinoSetupFail111(t *testing.T) {
 	delete(fs.Files, "/sys/kernel/debug/gpio_debug/gpio111/current_pinmux")
 
 	err := a.arduinoSetup()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/kernel/debug/gpio_debug/gpio111/current_pinmux: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/kernel/debug/gpio_debug/gpio111/current_pinmux: file does not exist"), true)
 }
 
 func TestAdaptorArduinoSetupFail131(t *testing.T) {
@@ -147,7 +147,7 @@ func TestAdaptorArduinoSetupFail131(t *testing.T) {
 	delete(fs.Files, "/sys/kernel/debug/gpio_debug/gpio131/current_pinmux")
 
 	err := a.arduinoSetup()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/kernel/debug/gpio_debug/gpio131/current_pinmux: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/kernel/debug/gpio_debug/gpio131/current_pinmux: file does not exist"), true)
 }
 
 func TestAdaptorArduinoI2CSetupFailTristate(t *testing.T) {
@@ -167,7 +167,7 @@ func TestAdaptorArduinoI2CSetupFail14(t *testing.T) {
 	delete(fs.Files, "/sys/class/gpio/gpio14/direction")
 
 	err := a.arduinoI2CSetup()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio14/direction: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio14/direction: file does not exist"), true)
 }
 
 func TestAdaptorArduinoI2CSetupUnexportFail(t *testing.T) {
@@ -177,7 +177,7 @@ func TestAdaptorArduinoI2CSetupUnexportFail(t *testing.T) {
 	delete(fs.Files, "/sys/class/gpio/unexport")
 
 	err := a.arduinoI2CSetup()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/unexport: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/unexport: file does not exist"), true)
 }
 
 func TestAdaptorArduinoI2CSetupFail236(t *testing.T) {
@@ -187,7 +187,7 @@ func TestAdaptorArduinoI2CSetupFail236(t *testing.T) {
 	delete(fs.Files, "/sys/class/gpio/gpio236/direction")
 
 	err := a.arduinoI2CSetup()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio236/direction: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/gpio/gpio236/direction: file does not exist"), true)
 }
 
 func TestAdaptorArduinoI2CSetupFail28(t *testing.T) {
@@ -197,7 +197,7 @@ func TestAdaptorArduinoI2CSetupFail28(t *testing.T) {
 	delete(fs.Files, "/sys/kernel/debug/gpio_debug/gpio28/current_pinmux")
 
 	err := a.arduinoI2CSetup()
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/kernel/debug/gpio_debug/gpio28/current_pinmux: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/kernel/debug/gpio_debug/gpio28/current_pinmux: file does not exist"), true)
 }
 
 func TestAdaptorConnectArduinoError(t *testing.T) {
@@ -293,7 +293,7 @@ func TestAdaptorDigitalPinInFileError(t *testing.T) {
 	a.Connect()
 
 	_, err := a.DigitalPin("13", "in")
-	gobottest.Assert(t, strings.Contains(err.Error(), "No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "file does not exist"), true)
 
 }
 
@@ -317,7 +317,7 @@ func TestAdaptorDigitalPinInResistorFileError(t *testing.T) {
 	a.Connect()
 
 	_, err := a.DigitalPin("13", "in")
-	gobottest.Assert(t, strings.Contains(err.Error(), "No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "file does not exist"), true)
 }
 
 func TestAdaptorDigitalPinInLevelShifterFileError(t *testing.T) {
@@ -340,7 +340,7 @@ func TestAdaptorDigitalPinInLevelShifterFileError(t *testing.T) {
 	a.Connect()
 
 	_, err := a.DigitalPin("13", "in")
-	gobottest.Assert(t, strings.Contains(err.Error(), "No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "file does not exist"), true)
 }
 
 func TestAdaptorDigitalPinInMuxFileError(t *testing.T) {
@@ -363,7 +363,7 @@ func TestAdaptorDigitalPinInMuxFileError(t *testing.T) {
 	a.Connect()
 
 	_, err := a.DigitalPin("13", "in")
-	gobottest.Assert(t, strings.Contains(err.Error(), "No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "file does not exist"), true)
 }
 
 func TestAdaptorDigitalWriteError(t *testing.T) {
@@ -435,7 +435,7 @@ func TestAdaptorPwmExportError(t *testing.T) {
 	a.Connect()
 
 	err := a.PwmWrite("5", 100)
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/pwm/pwmchip0/export: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/pwm/pwmchip0/export: file does not exist"), true)
 }
 
 func TestAdaptorPwmEnableError(t *testing.T) {
@@ -459,7 +459,7 @@ func TestAdaptorPwmEnableError(t *testing.T) {
 	a.Connect()
 
 	err := a.PwmWrite("5", 100)
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/pwm/pwmchip0/pwm1/enable: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/pwm/pwmchip0/pwm1/enable: file does not exist"), true)
 }
 
 func TestAdaptorPwmWritePinError(t *testing.T) {
@@ -500,11 +500,9 @@ func TestAdaptorAnalog(t *testing.T) {
 }
 
 func TestAdaptorAnalogError(t *testing.T) {
-	a, _ := initTestAdaptor()
+	a, fs := initTestAdaptor()
 
-	a.readFile = func(string) ([]byte, error) {
-		return nil, errors.New("read error")
-	}
+	fs.WithReadError = true
 	_, err := a.AnalogRead("0")
 	gobottest.Assert(t, err, errors.New("read error"))
 }
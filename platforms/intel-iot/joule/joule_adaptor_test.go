@@ -145,7 +145,7 @@ func TestAdaptorDigitalWriteError(t *testing.T) {
 	a, fs := initTestAdaptor()
 	fs.WithWriteError = true
 
-	err := a.DigitalWrite("13", 1)
+	err := a.DigitalWrite("J12_1", 1)
 	gobottest.Assert(t, err, errors.New("write error"))
 }
 
@@ -153,10 +153,18 @@ func TestAdaptorDigitalReadWriteError(t *testing.T) {
 	a, fs := initTestAdaptor()
 	fs.WithWriteError = true
 
-	_, err := a.DigitalRead("13")
+	_, err := a.DigitalRead("J12_1")
 	gobottest.Assert(t, err, errors.New("write error"))
 }
 
+func TestAdaptorDigitalWriteUnknownPin(t *testing.T) {
+	a, _ := initTestAdaptor()
+
+	// pins which are not on the header used to be taken as gpio 0
+	err := a.DigitalWrite("13", 1)
+	gobottest.Assert(t, err, errors.New("Not a valid pin"))
+}
+
 func TestAdaptorI2c(t *testing.T) {
 	a, _ := initTestAdaptor()
 
@@ -192,7 +200,7 @@ func TestAdaptorPwmPinExportError(t *testing.T) {
 	delete(fs.Files, "/sys/class/pwm/pwmchip0/export")
 
 	err := a.PwmWrite("J12_26", 100)
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/pwm/pwmchip0/export: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/pwm/pwmchip0/export: file does not exist"), true)
 }
 
 func TestAdaptorPwmPinEnableError(t *testing.T) {
@@ -201,5 +209,5 @@ func TestAdaptorPwmPinEnableError(t *testing.T) {
 	delete(fs.Files, "/sys/class/pwm/pwmchip0/pwm0/enable")
 
 	err := a.PwmWrite("J12_26", 100)
-	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/pwm/pwmchip0/pwm0/enable: No such file"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/pwm/pwmchip0/pwm0/enable: file does not exist"), true)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/veandco/go-sdl2/sdl"
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 )
 
 // Driver represents a joystick
@@ -19,6 +20,7 @@ type Driver struct {
 	config     joystickConfig
 	poll       func() sdl.Event
 	halt       chan bool
+	gestures   map[string]*gpio.ButtonGestures
 	gobot.Eventer
 }
 
@@ -61,6 +63,7 @@ func NewDriver(a *Adaptor, config string, v ...time.Duration) *Driver {
 		},
 		interval: 10 * time.Millisecond,
 		halt:     make(chan bool, 0),
+		gestures: make(map[string]*gpio.ButtonGestures),
 	}
 
 	if len(v) > 0 {
@@ -93,6 +96,10 @@ func (j *Driver) adaptor() *Adaptor {
 //	They will have the format:
 //		[button]_press
 //		[button]_release
+//		[button]_click
+//		[button]_double-click
+//		[button]_long-press
+//		[button]_hold
 //		[axis]
 func (j *Driver) Start() (err error) {
 	file, e := ioutil.ReadFile(j.configPath)
@@ -107,6 +114,9 @@ func (j *Driver) Start() (err error) {
 	for _, value := range j.config.Buttons {
 		j.AddEvent(fmt.Sprintf("%s_press", value.Name))
 		j.AddEvent(fmt.Sprintf("%s_release", value.Name))
+		for _, gesture := range []string{gpio.ButtonClick, gpio.ButtonDoubleClick, gpio.ButtonLongPress, gpio.ButtonHold} {
+			j.AddEvent(fmt.Sprintf("%s_%s", value.Name, gesture))
+		}
 	}
 	for _, value := range j.config.Axis {
 		j.AddEvent(value.Name)
@@ -135,9 +145,25 @@ func (j *Driver) Start() (err error) {
 // Halt stops joystick driver
 func (j *Driver) Halt() (err error) {
 	j.halt <- true
+	for _, g := range j.gestures {
+		g.Reset()
+	}
 	return
 }
 
+// Gestures returns the gesture thresholds of the named button
+func (j *Driver) Gestures(button string) *gpio.ButtonGestures {
+	if j.gestures[button] == nil {
+		j.gestures[button] = gpio.NewButtonGestures(func(event string, data interface{}) {
+			if event == gpio.ButtonPush || event == gpio.ButtonRelease {
+				return
+			}
+			j.Publish(j.Event(fmt.Sprintf("%s_%s", button, event)), data)
+		})
+	}
+	return j.gestures[button]
+}
+
 // HandleEvent publishes an specific event according to data received
 func (j *Driver) handleEvent(event sdl.Event) error {
 	switch data := event.(type) {
@@ -159,6 +185,7 @@ func (j *Driver) handleEvent(event sdl.Event) error {
 				j.Publish(j.Event(fmt.Sprintf("%s_press", button)), nil)
 			}
 			j.Publish(j.Event(fmt.Sprintf("%s_release", button)), nil)
+			j.Gestures(button).Update(data.State == 1, nil)
 		}
 	case *sdl.JoyHatEvent:
 		if data.Which == j.adaptor().joystick.InstanceID() {
@@ -0,0 +1,136 @@
+package linux
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// defaultPwmPeriod is the PWM period in nanoseconds used when a Board
+// does not specify one
+const defaultPwmPeriod = 10000000
+
+// Board describes the header pins and buses of a Linux single board computer.
+type Board struct {
+	// Name is the default name of adaptors created for this board
+	Name string `json:"name"`
+	// Pins maps header pin names to their gpio line, PWM and ADC channels
+	Pins map[string]Pin `json:"pins"`
+	// I2cBuses lists the valid /dev/i2c-N bus numbers
+	I2cBuses []int `json:"i2cBuses"`
+	// DefaultI2cBus is the bus used by i2c drivers unless configured otherwise
+	DefaultI2cBus int `json:"defaultI2cBus"`
+	// SpiBuses lists the /dev/spidevB.C buses and their chip selects
+	SpiBuses []SpiBus `json:"spiBuses,omitempty"`
+	// PwmPeriod is the PWM period in nanoseconds, 10ms if not set
+	PwmPeriod uint32 `json:"pwmPeriod,omitempty"`
+}
+
+// Pin describes a single header pin. A Gpio of -1 means the pin can not
+// be used as a digital pin.
+type Pin struct {
+	Gpio   int            `json:"gpio"`
+	Pwm    *PwmChannel    `json:"pwm,omitempty"`
+	Analog *AnalogChannel `json:"analog,omitempty"`
+}
+
+// PwmChannel describes a channel of a sysfs PWM chip.
+type PwmChannel struct {
+	// Chip is the number N of /sys/class/pwm/pwmchipN
+	Chip int `json:"chip"`
+	// Channel is the number of the PWM output of the chip
+	Channel int `json:"channel"`
+	// Path overrides the sysfs path of the PWM chip
+	Path string `json:"path,omitempty"`
+	// Polarity is set to "normal" or "inverted" before the channel is
+	// enabled, if the chip supports it
+	Polarity string `json:"polarity,omitempty"`
+}
+
+// AnalogChannel describes an Industrial I/O ADC channel.
+type AnalogChannel struct {
+	// Device is the number N of /sys/bus/iio/devices/iio:deviceN
+	Device int `json:"device"`
+	// Channel is the number N of in_voltageN_raw
+	Channel int `json:"channel"`
+}
+
+// SpiBus describes a SPI bus and the chip selects wired to the header.
+type SpiBus struct {
+	Bus         int   `json:"bus"`
+	ChipSelects []int `json:"chipSelects"`
+}
+
+// UnmarshalJSON decodes a Pin, defaulting to no gpio line when the
+// "gpio" field is missing.
+func (p *Pin) UnmarshalJSON(data []byte) error {
+	type pin Pin
+	v := pin{Gpio: -1}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*p = Pin(v)
+	return nil
+}
+
+// path returns the sysfs path of the PWM chip
+func (c PwmChannel) path() string {
+	if c.Path != "" {
+		return c.Path
+	}
+	return fmt.Sprintf("/sys/class/pwm/pwmchip%d", c.Chip)
+}
+
+// LoadBoard reads a JSON board description from a file.
+func LoadBoard(path string) (*Board, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBoard(data)
+}
+
+// ParseBoard decodes and validates a JSON board description.
+func ParseBoard(data []byte) (*Board, error) {
+	b := &Board{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, err
+	}
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Validate checks the board description for inconsistencies.
+func (b *Board) Validate() error {
+	if len(b.Pins) == 0 {
+		return fmt.Errorf("Board %q has no pins", b.Name)
+	}
+	if len(b.I2cBuses) > 0 && !b.hasI2cBus(b.DefaultI2cBus) {
+		return fmt.Errorf("Board %q default i2c bus %d is not one of its buses", b.Name, b.DefaultI2cBus)
+	}
+	for name, p := range b.Pins {
+		if p.Gpio < 0 && p.Pwm == nil && p.Analog == nil {
+			return fmt.Errorf("Board %q pin %s has no gpio, pwm or analog channel", b.Name, name)
+		}
+	}
+	return nil
+}
+
+// pwmPeriod returns the PWM period in nanoseconds
+func (b *Board) pwmPeriod() uint32 {
+	if b.PwmPeriod == 0 {
+		return defaultPwmPeriod
+	}
+	return b.PwmPeriod
+}
+
+func (b *Board) hasI2cBus(bus int) bool {
+	for _, n := range b.I2cBuses {
+		if n == bus {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package linux
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+const testBoardJSON = `{
+	"name": "TestBoard",
+	"i2cBuses": [0, 1],
+	"defaultI2cBus": 1,
+	"spiBuses": [{"bus": 0, "chipSelects": [0, 1]}],
+	"pins": {
+		"7":  {"gpio": 17},
+		"12": {"gpio": 18, "pwm": {"chip": 1, "channel": 2, "polarity": "inverted"}},
+		"A0": {"analog": {"device": 0, "channel": 3}}
+	}
+}`
+
+func TestParseBoard(t *testing.T) {
+	b, err := ParseBoard([]byte(testBoardJSON))
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, b.Name, "TestBoard")
+	gobottest.Assert(t, b.I2cBuses, []int{0, 1})
+	gobottest.Assert(t, b.DefaultI2cBus, 1)
+	gobottest.Assert(t, b.SpiBuses, []SpiBus{{Bus: 0, ChipSelects: []int{0, 1}}})
+	gobottest.Assert(t, b.pwmPeriod(), uint32(defaultPwmPeriod))
+
+	gobottest.Assert(t, b.Pins["7"], Pin{Gpio: 17})
+	gobottest.Assert(t, b.Pins["12"].Gpio, 18)
+	gobottest.Assert(t, *b.Pins["12"].Pwm, PwmChannel{Chip: 1, Channel: 2, Polarity: "inverted"})
+	gobottest.Assert(t, b.Pins["12"].Pwm.path(), "/sys/class/pwm/pwmchip1")
+	gobottest.Assert(t, b.Pins["A0"].Gpio, -1)
+	gobottest.Assert(t, *b.Pins["A0"].Analog, AnalogChannel{Device: 0, Channel: 3})
+}
+
+func TestParseBoardInvalidJSON(t *testing.T) {
+	_, err := ParseBoard([]byte(`{"name":`))
+	gobottest.Refute(t, err, nil)
+}
+
+func TestParseBoardInvalid(t *testing.T) {
+	_, err := ParseBoard([]byte(`{"name": "Empty"}`))
+	gobottest.Assert(t, err, errors.New(`Board "Empty" has no pins`))
+}
+
+func TestBoardValidate(t *testing.T) {
+	b := &Board{
+		Name:          "TestBoard",
+		Pins:          map[string]Pin{"1": {Gpio: 1}},
+		I2cBuses:      []int{0, 1},
+		DefaultI2cBus: 2,
+	}
+	gobottest.Assert(t, b.Validate(), errors.New(`Board "TestBoard" default i2c bus 2 is not one of its buses`))
+
+	b.DefaultI2cBus = 0
+	gobottest.Assert(t, b.Validate(), nil)
+
+	b.Pins["2"] = Pin{Gpio: -1}
+	gobottest.Assert(t, b.Validate(), errors.New(`Board "TestBoard" pin 2 has no gpio, pwm or analog channel`))
+}
+
+func TestPwmChannelPath(t *testing.T) {
+	c := PwmChannel{Channel: 1, Path: "/sys/devices/pwm"}
+	gobottest.Assert(t, c.path(), "/sys/devices/pwm")
+}
+
+func TestLoadBoard(t *testing.T) {
+	f, err := ioutil.TempFile("", "board")
+	gobottest.Assert(t, err, nil)
+	defer os.Remove(f.Name())
+	f.WriteString(testBoardJSON)
+	f.Close()
+
+	b, err := LoadBoard(f.Name())
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, b.Name, "TestBoard")
+
+	_, err = LoadBoard(f.Name() + ".missing")
+	gobottest.Refute(t, err, nil)
+}
@@ -0,0 +1,8 @@
+/*
+Package linux contains a generic Gobot adaptor for Linux single board
+computers, configured by a description of the board's header pins and buses.
+
+For further information refer to linux README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/linux/README.md
+*/
+package linux // import "gobot.io/x/gobot/platforms/linux"
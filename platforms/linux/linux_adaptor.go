@@ -0,0 +1,349 @@
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/sysfs"
+)
+
+var (
+	// ErrInvalidPin is the error resulting when a pin is not described by the board
+	ErrInvalidPin = errors.New("Not a valid pin")
+	// ErrNotDigitalPin is the error resulting when a pin has no gpio line
+	ErrNotDigitalPin = errors.New("Not a digital pin")
+	// ErrNotPWMPin is the error resulting when a pin has no PWM channel
+	ErrNotPWMPin = errors.New("Not a PWM pin")
+	// ErrNotAnalogPin is the error resulting when a pin has no ADC channel
+	ErrNotAnalogPin = errors.New("Not an analog pin")
+)
+
+// PWMPinner creates the PWMPinner for the channel of a header pin. Boards
+// which do not use the sysfs PWM interface provide their own.
+type PWMPinner func(pin string, channel PwmChannel, period uint32) (sysfs.PWMPinner, error)
+
+// Adaptor is a Gobot Adaptor for any Linux board described by a Board.
+type Adaptor struct {
+	name        string
+	board       *Board
+	digitalPins map[int]*sysfs.DigitalPin
+	pwmPins     map[PwmChannel]sysfs.PWMPinner
+	i2cBuses    map[int]i2c.I2cDevice
+	iioDevices  map[int]*sysfs.IIODevice
+	pwmPinner   PWMPinner
+	errNoPWM    error
+	errNoAnalog error
+	mutex       *sync.Mutex
+}
+
+// NewAdaptor creates a new Adaptor for the board.
+//
+// Optional params:
+//	linux.WithPWMPinner(f): creates the PWM pins using f instead of sysfs
+//	linux.WithPinErrors(pwm, analog): errors for pins without PWM or ADC channel
+func NewAdaptor(board *Board, options ...func(*Adaptor)) *Adaptor {
+	a := &Adaptor{
+		name:      gobot.DefaultName(board.Name),
+		pwmPinner: ExportPWMPin,
+		mutex:     &sync.Mutex{},
+	}
+
+	for _, option := range options {
+		option(a)
+	}
+
+	a.SetBoard(board)
+	return a
+}
+
+// WithPWMPinner sets the function used to create the PWM pins of the board.
+func WithPWMPinner(f PWMPinner) func(*Adaptor) {
+	return func(a *Adaptor) {
+		a.pwmPinner = f
+	}
+}
+
+// WithPinErrors sets the errors returned by the PWM and analog functions for
+// pins which are not PWM or analog pins of the board, in place of
+// ErrNotPWMPin and ErrNotAnalogPin. They are returned by those functions
+// for pins which are not on the board at all too, while DigitalPin still
+// returns ErrInvalidPin for them.
+func WithPinErrors(pwm error, analog error) func(*Adaptor) {
+	return func(a *Adaptor) {
+		a.errNoPWM = pwm
+		a.errNoAnalog = analog
+	}
+}
+
+// Name returns the name of the Adaptor
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the name of the Adaptor
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Board returns the description of the board
+func (a *Adaptor) Board() *Board { return a.board }
+
+// SetBoard changes the description of the board. Pins and buses already in
+// use are forgotten, so it should be called before the Adaptor is connected.
+func (a *Adaptor) SetBoard(board *Board) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.board = board
+	a.digitalPins = make(map[int]*sysfs.DigitalPin)
+	a.pwmPins = make(map[PwmChannel]sysfs.PWMPinner)
+	a.i2cBuses = make(map[int]i2c.I2cDevice)
+	a.iioDevices = make(map[int]*sysfs.IIODevice)
+}
+
+// Connect initializes the board
+func (a *Adaptor) Connect() (err error) {
+	return
+}
+
+// Finalize releases all i2c devices, exported digital and pwm pins and
+// stops any buffered analog capture.
+func (a *Adaptor) Finalize() (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for _, pin := range a.digitalPins {
+		if pin != nil {
+			if e := pin.Unexport(); e != nil {
+				err = multierror.Append(err, e)
+			}
+		}
+	}
+	for _, pin := range a.pwmPins {
+		if pin != nil {
+			if e := pin.Enable(false); e != nil {
+				err = multierror.Append(err, e)
+			}
+			if e := pin.Unexport(); e != nil {
+				err = multierror.Append(err, e)
+			}
+		}
+	}
+	for _, bus := range a.i2cBuses {
+		if bus != nil {
+			if e := bus.Close(); e != nil {
+				err = multierror.Append(err, e)
+			}
+		}
+	}
+	for _, dev := range a.iioDevices {
+		if dev.Buffered() {
+			if e := dev.StopBuffer(); e != nil {
+				err = multierror.Append(err, e)
+			}
+		}
+	}
+	return
+}
+
+// DigitalPin returns the exported digital pin, set to the given direction
+func (a *Adaptor) DigitalPin(pin string, dir string) (sysfsPin sysfs.DigitalPinner, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	p, err := a.translatePin(pin)
+	if err != nil {
+		return
+	}
+	if p.Gpio < 0 {
+		return nil, ErrNotDigitalPin
+	}
+
+	if a.digitalPins[p.Gpio] == nil {
+		a.digitalPins[p.Gpio] = sysfs.NewDigitalPin(p.Gpio)
+		if err = a.digitalPins[p.Gpio].Export(); err != nil {
+			return
+		}
+	}
+
+	if err = a.digitalPins[p.Gpio].Direction(dir); err != nil {
+		return
+	}
+
+	return a.digitalPins[p.Gpio], nil
+}
+
+// DigitalRead reads digital value from the specified pin
+func (a *Adaptor) DigitalRead(pin string) (val int, err error) {
+	sysfsPin, err := a.DigitalPin(pin, sysfs.IN)
+	if err != nil {
+		return
+	}
+	return sysfsPin.Read()
+}
+
+// DigitalWrite writes digital value to the specified pin
+func (a *Adaptor) DigitalWrite(pin string, val byte) (err error) {
+	sysfsPin, err := a.DigitalPin(pin, sysfs.OUT)
+	if err != nil {
+		return err
+	}
+	return sysfsPin.Write(int(val))
+}
+
+// PWMPin returns the exported and enabled PWM pin
+func (a *Adaptor) PWMPin(pin string) (sysfsPin sysfs.PWMPinner, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	p, err := a.translatePin(pin)
+	if err == nil && p.Pwm == nil {
+		err = ErrNotPWMPin
+	}
+	if err != nil {
+		if a.errNoPWM != nil {
+			err = a.errNoPWM
+		}
+		return nil, err
+	}
+
+	if a.pwmPins[*p.Pwm] == nil {
+		newPin, err := a.pwmPinner(pin, *p.Pwm, a.board.pwmPeriod())
+		if err != nil {
+			return nil, err
+		}
+		a.pwmPins[*p.Pwm] = newPin
+	}
+
+	return a.pwmPins[*p.Pwm], nil
+}
+
+// PwmWrite writes a PWM signal to the specified pin
+func (a *Adaptor) PwmWrite(pin string, val byte) (err error) {
+	pwmPin, err := a.PWMPin(pin)
+	if err != nil {
+		return
+	}
+	period, err := pwmPin.Period()
+	if err != nil {
+		return err
+	}
+	duty := gobot.FromScale(float64(val), 0, 255.0)
+	return pwmPin.SetDutyCycle(uint32(float64(period) * duty))
+}
+
+// ServoWrite writes a servo signal to the specified pin
+func (a *Adaptor) ServoWrite(pin string, angle byte) (err error) {
+	pwmPin, err := a.PWMPin(pin)
+	if err != nil {
+		return
+	}
+
+	// the duty cycle goes from 5% to 20% of the PwmPeriod of the board, so
+	// at the default 10ms period:
+	// 0.5 ms => -90
+	// 1.5 ms =>   0
+	// 2.0 ms =>  90
+	period := float64(a.board.pwmPeriod())
+	minDuty := 0.05 * period
+	maxDuty := 0.2 * period
+	duty := uint32(gobot.ToScale(gobot.FromScale(float64(angle), 0, 180), minDuty, maxDuty))
+	return pwmPin.SetDutyCycle(duty)
+}
+
+// AnalogRead returns the raw value of the ADC channel of the specified pin
+func (a *Adaptor) AnalogRead(pin string) (val int, err error) {
+	dev, channel, err := a.IIOChannel(pin)
+	if err != nil {
+		return
+	}
+	return dev.ReadRaw(channel)
+}
+
+// AnalogVoltage returns the voltage in millivolts of the ADC channel of the
+// specified pin, using the scale and offset reported by the IIO device
+func (a *Adaptor) AnalogVoltage(pin string) (mv float64, err error) {
+	dev, channel, err := a.IIOChannel(pin)
+	if err != nil {
+		return
+	}
+	return dev.ReadVoltage(channel)
+}
+
+// IIOChannel returns the Industrial I/O device and voltage channel of the
+// specified pin, e.g. to start a buffered capture
+func (a *Adaptor) IIOChannel(pin string) (dev *sysfs.IIODevice, channel int, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	p, err := a.translatePin(pin)
+	if err == nil && p.Analog == nil {
+		err = ErrNotAnalogPin
+	}
+	if err != nil {
+		if a.errNoAnalog != nil {
+			err = a.errNoAnalog
+		}
+		return nil, 0, err
+	}
+
+	if a.iioDevices[p.Analog.Device] == nil {
+		a.iioDevices[p.Analog.Device] = sysfs.NewIIODevice(p.Analog.Device)
+	}
+	return a.iioDevices[p.Analog.Device], p.Analog.Channel, nil
+}
+
+// GetConnection returns an i2c connection to a device on a specified bus.
+// Valid bus numbers are the I2cBuses of the board.
+func (a *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.board.hasI2cBus(bus) {
+		return nil, fmt.Errorf("Bus number %d out of range", bus)
+	}
+	if a.i2cBuses[bus] == nil {
+		a.i2cBuses[bus], err = sysfs.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", bus))
+	}
+	return i2c.NewConnection(a.i2cBuses[bus], address), err
+}
+
+// GetDefaultBus returns the default i2c bus for this board
+func (a *Adaptor) GetDefaultBus() int {
+	return a.board.DefaultI2cBus
+}
+
+func (a *Adaptor) translatePin(pin string) (p Pin, err error) {
+	p, ok := a.board.Pins[pin]
+	if !ok {
+		err = ErrInvalidPin
+	}
+	return
+}
+
+// ExportPWMPin exports and enables a sysfs PWM channel with the given period.
+// It is the default PWMPinner of the Adaptor.
+func ExportPWMPin(pin string, channel PwmChannel, period uint32) (sysfs.PWMPinner, error) {
+	newPin := sysfs.NewPWMPin(channel.Channel)
+	newPin.Path = channel.path()
+
+	if err := newPin.Export(); err != nil {
+		return nil, err
+	}
+	// polarity can only be changed while the pin is disabled
+	if channel.Polarity != "" {
+		if err := newPin.Enable(false); err != nil {
+			return nil, err
+		}
+		if err := newPin.InvertPolarity(channel.Polarity == "inverted"); err != nil {
+			return nil, err
+		}
+	}
+	if err := newPin.SetPeriod(period); err != nil {
+		return nil, err
+	}
+	if err := newPin.Enable(true); err != nil {
+		return nil, err
+	}
+	return newPin, nil
+}
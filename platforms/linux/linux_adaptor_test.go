@@ -0,0 +1,265 @@
+package linux
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/aio"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/sysfs"
+)
+
+// make sure that this Adaptor fullfills all the required interfaces
+var _ gobot.Adaptor = (*Adaptor)(nil)
+var _ gpio.DigitalReader = (*Adaptor)(nil)
+var _ gpio.DigitalWriter = (*Adaptor)(nil)
+var _ gpio.PwmWriter = (*Adaptor)(nil)
+var _ gpio.ServoWriter = (*Adaptor)(nil)
+var _ aio.AnalogReader = (*Adaptor)(nil)
+var _ sysfs.DigitalPinnerProvider = (*Adaptor)(nil)
+var _ sysfs.PWMPinnerProvider = (*Adaptor)(nil)
+var _ i2c.Connector = (*Adaptor)(nil)
+
+func testBoard() *Board {
+	return &Board{
+		Name:          "TestBoard",
+		I2cBuses:      []int{0, 1},
+		DefaultI2cBus: 1,
+		Pins: map[string]Pin{
+			"7":  {Gpio: 17},
+			"12": {Gpio: 18, Pwm: &PwmChannel{Chip: 0, Channel: 0, Polarity: "normal"}},
+			"A0": {Gpio: -1, Analog: &AnalogChannel{Device: 0, Channel: 1}},
+		},
+	}
+}
+
+func initTestAdaptor() (*Adaptor, *sysfs.MockFilesystem) {
+	a := NewAdaptor(testBoard())
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpio17/value",
+		"/sys/class/gpio/gpio17/direction",
+		"/sys/class/pwm/pwmchip0/export",
+		"/sys/class/pwm/pwmchip0/unexport",
+		"/sys/class/pwm/pwmchip0/pwm0/enable",
+		"/sys/class/pwm/pwmchip0/pwm0/period",
+		"/sys/class/pwm/pwmchip0/pwm0/duty_cycle",
+		"/sys/class/pwm/pwmchip0/pwm0/polarity",
+		"/sys/bus/iio/devices/iio:device0/in_voltage1_raw",
+		"/sys/bus/iio/devices/iio:device0/in_voltage_scale",
+		"/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_en",
+		"/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_index",
+		"/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_type",
+		"/sys/bus/iio/devices/iio:device0/buffer/length",
+		"/sys/bus/iio/devices/iio:device0/buffer/enable",
+		"/dev/iio:device0",
+		"/dev/i2c-1",
+	})
+
+	sysfs.SetFilesystem(fs)
+	sysfs.SetSyscall(&sysfs.MockSyscall{})
+	return a, fs
+}
+
+func TestAdaptorName(t *testing.T) {
+	a := NewAdaptor(testBoard())
+	gobottest.Assert(t, strings.HasPrefix(a.Name(), "TestBoard"), true)
+	a.SetName("NewName")
+	gobottest.Assert(t, a.Name(), "NewName")
+}
+
+func TestAdaptorSetBoard(t *testing.T) {
+	a := NewAdaptor(testBoard())
+	gobottest.Assert(t, a.Board().Name, "TestBoard")
+
+	b := testBoard()
+	b.DefaultI2cBus = 0
+	a.SetBoard(b)
+	gobottest.Assert(t, a.Board(), b)
+	gobottest.Assert(t, a.GetDefaultBus(), 0)
+}
+
+func TestAdaptorDigitalIO(t *testing.T) {
+	a, fs := initTestAdaptor()
+	a.Connect()
+
+	a.DigitalWrite("7", 1)
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio17/value"].Contents, "1")
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio17/direction"].Contents, "out")
+
+	fs.Files["/sys/class/gpio/gpio17/value"].Contents = "0"
+	i, err := a.DigitalRead("7")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, i, 0)
+
+	gobottest.Assert(t, a.DigitalWrite("99", 1), ErrInvalidPin)
+	gobottest.Assert(t, a.DigitalWrite("A0", 1), ErrNotDigitalPin)
+	gobottest.Assert(t, a.Finalize(), nil)
+}
+
+func TestAdaptorDigitalWriteError(t *testing.T) {
+	a, fs := initTestAdaptor()
+	fs.WithWriteError = true
+
+	err := a.DigitalWrite("7", 1)
+	gobottest.Assert(t, err, errors.New("write error"))
+}
+
+func TestAdaptorPwmWrite(t *testing.T) {
+	a, fs := initTestAdaptor()
+
+	err := a.PwmWrite("12", 100)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/export"].Contents, "0")
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm0/enable"].Contents, "1")
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm0/polarity"].Contents, "normal")
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm0/period"].Contents, "10000000")
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm0/duty_cycle"].Contents, "3921568")
+
+	err = a.ServoWrite("12", 0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm0/duty_cycle"].Contents, "500000")
+
+	err = a.ServoWrite("12", 180)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm0/duty_cycle"].Contents, "2000000")
+
+	gobottest.Assert(t, a.PwmWrite("99", 1), ErrInvalidPin)
+	gobottest.Assert(t, a.PwmWrite("7", 1), ErrNotPWMPin)
+	gobottest.Assert(t, a.ServoWrite("7", 1), ErrNotPWMPin)
+
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/unexport"].Contents, "0")
+}
+
+func TestAdaptorServoWritePwmPeriod(t *testing.T) {
+	a, fs := initTestAdaptor()
+	a.board.PwmPeriod = 20000000
+
+	gobottest.Assert(t, a.ServoWrite("12", 0), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm0/period"].Contents, "20000000")
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm0/duty_cycle"].Contents, "1000000")
+
+	gobottest.Assert(t, a.ServoWrite("12", 180), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm0/duty_cycle"].Contents, "4000000")
+}
+
+func TestAdaptorPwmWriteExportError(t *testing.T) {
+	a, fs := initTestAdaptor()
+	delete(fs.Files, "/sys/class/pwm/pwmchip0/export")
+
+	err := a.PwmWrite("12", 100)
+	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/class/pwm/pwmchip0/export: file does not exist"), true)
+}
+
+func TestAdaptorPWMPinner(t *testing.T) {
+	var channel PwmChannel
+	var period uint32
+	pin := sysfs.NewPWMPin(5)
+	a := NewAdaptor(testBoard(), WithPWMPinner(func(p string, c PwmChannel, per uint32) (sysfs.PWMPinner, error) {
+		channel = c
+		period = per
+		return pin, nil
+	}))
+
+	p, err := a.PWMPin("12")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, p, sysfs.PWMPinner(pin))
+	gobottest.Assert(t, channel, *testBoard().Pins["12"].Pwm)
+	gobottest.Assert(t, period, uint32(defaultPwmPeriod))
+
+	p2, _ := a.PWMPin("12")
+	gobottest.Assert(t, p2, p)
+}
+
+func TestAdaptorPinErrors(t *testing.T) {
+	errPWM := errors.New("no pwm")
+	errAnalog := errors.New("no analog")
+	a := NewAdaptor(testBoard(), WithPinErrors(errPWM, errAnalog))
+
+	_, err := a.PWMPin("99")
+	gobottest.Assert(t, err, errPWM)
+	_, err = a.PWMPin("7")
+	gobottest.Assert(t, err, errPWM)
+	_, err = a.AnalogRead("99")
+	gobottest.Assert(t, err, errAnalog)
+	_, err = a.AnalogRead("7")
+	gobottest.Assert(t, err, errAnalog)
+	_, err = a.DigitalPin("99", sysfs.IN)
+	gobottest.Assert(t, err, ErrInvalidPin)
+}
+
+func TestAdaptorAnalogRead(t *testing.T) {
+	a, fs := initTestAdaptor()
+
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage1_raw"].Contents = "1234\n"
+	val, err := a.AnalogRead("A0")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1234)
+
+	_, err = a.AnalogRead("99")
+	gobottest.Assert(t, err, ErrInvalidPin)
+
+	_, err = a.AnalogRead("7")
+	gobottest.Assert(t, err, ErrNotAnalogPin)
+
+	fs.WithReadError = true
+	_, err = a.AnalogRead("A0")
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestAdaptorAnalogVoltage(t *testing.T) {
+	a, fs := initTestAdaptor()
+
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage1_raw"].Contents = "1000\n"
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage_scale"].Contents = "0.439453125\n"
+	mv, err := a.AnalogVoltage("A0")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, mv, 439.453125)
+
+	_, err = a.AnalogVoltage("7")
+	gobottest.Assert(t, err, ErrNotAnalogPin)
+}
+
+func TestAdaptorIIOChannel(t *testing.T) {
+	a, fs := initTestAdaptor()
+
+	dev, channel, err := a.IIOChannel("A0")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, channel, 1)
+	gobottest.Assert(t, dev.Path, "/sys/bus/iio/devices/iio:device0")
+
+	dev2, _, _ := a.IIOChannel("A0")
+	gobottest.Assert(t, dev2, dev)
+
+	fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_index"].Contents = "0"
+	fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_type"].Contents = "le:u12/16>>0"
+	gobottest.Assert(t, dev.StartBuffer([]int{channel}, 16), nil)
+
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, dev.Buffered(), false)
+	gobottest.Assert(t, fs.Files["/sys/bus/iio/devices/iio:device0/buffer/enable"].Contents, "0")
+}
+
+func TestAdaptorI2c(t *testing.T) {
+	a, _ := initTestAdaptor()
+
+	con, err := a.GetConnection(0xff, 1)
+	gobottest.Assert(t, err, nil)
+
+	con.Write([]byte{0x00, 0x01})
+	data := []byte{42, 42}
+	con.Read(data)
+	gobottest.Assert(t, data, []byte{0x00, 0x01})
+
+	_, err = a.GetConnection(0xff, 2)
+	gobottest.Assert(t, err, errors.New("Bus number 2 out of range"))
+
+	gobottest.Assert(t, a.GetDefaultBus(), 1)
+	gobottest.Assert(t, a.Finalize(), nil)
+}
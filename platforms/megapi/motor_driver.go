@@ -3,6 +3,7 @@ package megapi
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
 	"sync"
 
 	"gobot.io/x/gobot"
@@ -74,6 +75,12 @@ func (m *MotorDriver) Speed(speed int16) error {
 	return nil
 }
 
+// SetPower sets the speed of the motor from -1 full backward to 1 full
+// forward
+func (m *MotorDriver) SetPower(power float64) error {
+	return m.Speed(int16(math.Round(math.Max(-1, math.Min(1, power)) * 255)))
+}
+
 // there is some sort of bug on the hardware such that you cannot
 // send the exact same speed to 2 different motors consecutively
 // hence we ensure we always alternate speeds
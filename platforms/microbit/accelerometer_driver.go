@@ -3,8 +3,10 @@ package microbit
 import (
 	"bytes"
 	"encoding/binary"
+	"sync"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 	"gobot.io/x/gobot/platforms/ble"
 )
 
@@ -12,6 +14,8 @@ import (
 type AccelerometerDriver struct {
 	name       string
 	connection gobot.Connection
+	mutex      *sync.Mutex
+	data       *AccelerometerData
 	gobot.Eventer
 }
 
@@ -43,6 +47,7 @@ func NewAccelerometerDriver(a ble.BLEConnector) *AccelerometerDriver {
 	n := &AccelerometerDriver{
 		name:       gobot.DefaultName("Microbit Accelerometer"),
 		connection: a,
+		mutex:      &sync.Mutex{},
 		Eventer:    gobot.NewEventer(),
 	}
 
@@ -81,6 +86,10 @@ func (b *AccelerometerDriver) Start() (err error) {
 			Y: float32(a.Y) / 1000.0,
 			Z: float32(a.Z) / 1000.0}
 
+		b.mutex.Lock()
+		b.data = result
+		b.mutex.Unlock()
+
 		b.Publish(b.Event(Accelerometer), result)
 	})
 
@@ -91,3 +100,15 @@ func (b *AccelerometerDriver) Start() (err error) {
 func (b *AccelerometerDriver) Halt() (err error) {
 	return
 }
+
+// ReadAcceleration returns the latest acceleration notified by the Microbit in
+// meters per second squared, or imu.ErrNoData before the first notification.
+// It implements imu.Accelerometer.
+func (b *AccelerometerDriver) ReadAcceleration() (v imu.Vector, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.data == nil {
+		return v, imu.ErrNoData
+	}
+	return imu.Vector{X: float64(b.data.X), Y: float64(b.data.Y), Z: float64(b.data.Z)}.Scale(imu.StandardGravity), nil
+}
@@ -1,16 +1,20 @@
 package microbit
 
 import (
+	"math"
 	"strings"
 	"testing"
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*AccelerometerDriver)(nil)
 
+var _ imu.Accelerometer = (*AccelerometerDriver)(nil)
+
 func initTestAccelerometerDriver() *AccelerometerDriver {
 	d := NewAccelerometerDriver(NewBleTestAdaptor())
 	return d
@@ -49,3 +53,16 @@ func TestAccelerometerDriverReadData(t *testing.T) {
 		t.Errorf("Microbit Event \"Accelerometer\" was not published")
 	}
 }
+
+func TestAccelerometerDriverReadAcceleration(t *testing.T) {
+	a := NewBleTestAdaptor()
+	d := NewAccelerometerDriver(a)
+	d.Start()
+	_, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, imu.ErrNoData)
+
+	a.TestReceiveNotification([]byte{0x22, 0x22, 0x23, 0x23, 0x24, 0x24}, nil)
+	v, err := d.ReadAcceleration()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, math.Abs(v.X-float64(float32(8.738))*imu.StandardGravity) < 1e-12, true)
+}
@@ -2,6 +2,7 @@ package microbit
 
 import (
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/platforms/ble"
 )
 
@@ -9,6 +10,10 @@ import (
 type ButtonDriver struct {
 	name       string
 	connection gobot.Connection
+	// GesturesA and GesturesB hold the thresholds of the gestures of
+	// button A and B
+	GesturesA *gpio.ButtonGestures
+	GesturesB *gpio.ButtonGestures
 	gobot.Eventer
 }
 
@@ -27,6 +32,10 @@ const (
 	ButtonB = "buttonB"
 )
 
+// gestures are published as the button event followed by the gesture,
+// e.g. "buttonA_double-click"
+var gestures = []string{gpio.ButtonClick, gpio.ButtonDoubleClick, gpio.ButtonLongPress, gpio.ButtonHold}
+
 // NewButtonDriver creates a Microbit ButtonDriver
 func NewButtonDriver(a ble.BLEConnector) *ButtonDriver {
 	n := &ButtonDriver{
@@ -35,8 +44,16 @@ func NewButtonDriver(a ble.BLEConnector) *ButtonDriver {
 		Eventer:    gobot.NewEventer(),
 	}
 
+	n.GesturesA = gpio.NewButtonGestures(n.gesture(ButtonA))
+	n.GesturesB = gpio.NewButtonGestures(n.gesture(ButtonB))
+
 	n.AddEvent(ButtonA)
 	n.AddEvent(ButtonB)
+	for _, button := range []string{ButtonA, ButtonB} {
+		for _, gesture := range gestures {
+			n.AddEvent(button + "_" + gesture)
+		}
+	}
 
 	return n
 }
@@ -56,21 +73,45 @@ func (b *ButtonDriver) adaptor() ble.BLEConnector {
 }
 
 // Start tells driver to get ready to do work
+//
+// Emits the Events:
+//	buttonA, buttonB []byte - On each notification of the button state
+//	buttonA_click, buttonA_double-click, buttonA_long-press, buttonA_hold and
+//	the same for buttonB - On the gestures of the button
 func (b *ButtonDriver) Start() (err error) {
 	// subscribe to button A notifications
 	b.adaptor().Subscribe(buttonACharacteristic, func(data []byte, e error) {
 		b.Publish(b.Event(ButtonA), data)
+		if len(data) > 0 {
+			b.GesturesA.Update(data[0] != 0, data)
+		}
 	})
 
 	// subscribe to button B notifications
 	b.adaptor().Subscribe(buttonBCharacteristic, func(data []byte, e error) {
 		b.Publish(b.Event(ButtonB), data)
+		if len(data) > 0 {
+			b.GesturesB.Update(data[0] != 0, data)
+		}
 	})
 
 	return
 }
 
-// Halt stops LED driver (void)
+// Halt stops the pending gestures of the buttons
 func (b *ButtonDriver) Halt() (err error) {
+	b.GesturesA.Reset()
+	b.GesturesB.Reset()
 	return
 }
+
+// gesture returns the handler which publishes the gestures of button,
+// the raw state of the button is already published as the button event
+func (b *ButtonDriver) gesture(button string) func(string, interface{}) {
+	return func(event string, data interface{}) {
+		if event == gpio.ButtonPush || event == gpio.ButtonRelease {
+			return
+		}
+		b.Publish(button+"_"+event, data)
+	}
+}
@@ -46,3 +46,23 @@ func TestButtonDriverReadData(t *testing.T) {
 		t.Errorf("Microbit Event \"ButtonB\" was not published")
 	}
 }
+
+func TestButtonDriverClick(t *testing.T) {
+	sem := make(chan bool, 0)
+	a := NewBleTestAdaptor()
+	d := NewButtonDriver(a)
+	d.GesturesB.DoubleClickTime = 0
+	d.Start()
+	d.On(ButtonB+"_click", func(data interface{}) {
+		sem <- true
+	})
+
+	a.TestReceiveNotification([]byte{1}, nil)
+	a.TestReceiveNotification([]byte{0}, nil)
+
+	select {
+	case <-sem:
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Microbit Event \"buttonB_click\" was not published")
+	}
+}
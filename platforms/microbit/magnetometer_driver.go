@@ -3,8 +3,10 @@ package microbit
 import (
 	"bytes"
 	"encoding/binary"
+	"sync"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 	"gobot.io/x/gobot/platforms/ble"
 )
 
@@ -12,6 +14,8 @@ import (
 type MagnetometerDriver struct {
 	name       string
 	connection gobot.Connection
+	mutex      *sync.Mutex
+	data       *MagnetometerData
 	gobot.Eventer
 }
 
@@ -43,6 +47,7 @@ func NewMagnetometerDriver(a ble.BLEConnector) *MagnetometerDriver {
 	n := &MagnetometerDriver{
 		name:       gobot.DefaultName("Microbit Magnetometer"),
 		connection: a,
+		mutex:      &sync.Mutex{},
 		Eventer:    gobot.NewEventer(),
 	}
 
@@ -81,6 +86,10 @@ func (b *MagnetometerDriver) Start() (err error) {
 			Y: float32(a.Y) / 1000.0,
 			Z: float32(a.Z) / 1000.0}
 
+		b.mutex.Lock()
+		b.data = result
+		b.mutex.Unlock()
+
 		b.Publish(b.Event(Magnetometer), result)
 	})
 
@@ -91,3 +100,15 @@ func (b *MagnetometerDriver) Start() (err error) {
 func (b *MagnetometerDriver) Halt() (err error) {
 	return
 }
+
+// ReadMagneticField returns the latest magnetic field notified by the
+// Microbit in tesla, or imu.ErrNoData before the first notification. It
+// implements imu.Magnetometer.
+func (b *MagnetometerDriver) ReadMagneticField() (v imu.Vector, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.data == nil {
+		return v, imu.ErrNoData
+	}
+	return imu.Vector{X: float64(b.data.X), Y: float64(b.data.Y), Z: float64(b.data.Z)}.Scale(1e-6), nil
+}
@@ -1,16 +1,20 @@
 package microbit
 
 import (
+	"math"
 	"strings"
 	"testing"
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*MagnetometerDriver)(nil)
 
+var _ imu.Magnetometer = (*MagnetometerDriver)(nil)
+
 func initTestMagnetometerDriver() *MagnetometerDriver {
 	d := NewMagnetometerDriver(NewBleTestAdaptor())
 	return d
@@ -49,3 +53,16 @@ func TestMagnetometerDriverReadData(t *testing.T) {
 		t.Errorf("Microbit Event \"Magnetometer\" was not published")
 	}
 }
+
+func TestMagnetometerDriverReadMagneticField(t *testing.T) {
+	a := NewBleTestAdaptor()
+	d := NewMagnetometerDriver(a)
+	d.Start()
+	_, err := d.ReadMagneticField()
+	gobottest.Assert(t, err, imu.ErrNoData)
+
+	a.TestReceiveNotification([]byte{0x22, 0x22, 0x23, 0x23, 0x24, 0x24}, nil)
+	v, err := d.ReadMagneticField()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, math.Abs(v.X-float64(float32(8.738))*1e-6) < 1e-12, true)
+}
@@ -87,11 +87,12 @@ func (s *Adaptor) AnalogRead(pin string) (val int, err error) {
 
 // PwmWrite writes in pin using analog write api
 func (s *Adaptor) PwmWrite(pin string, level byte) (err error) {
-	return s.AnalogWrite(pin, level)
+	return s.AnalogWrite(pin, int(level))
 }
 
-// AnalogWrite writes analog pin with specified level using Particle cloud api
-func (s *Adaptor) AnalogWrite(pin string, level byte) (err error) {
+// AnalogWrite writes analog pin with specified level using Particle cloud api.
+// PWM pins take 0-255, the DAC pins 0-4095.
+func (s *Adaptor) AnalogWrite(pin string, level int) (err error) {
 	params := url.Values{
 		"params":       {fmt.Sprintf("%v,%v", pin, level)},
 		"access_token": {s.AccessToken},
@@ -21,6 +21,7 @@ var _ gpio.DigitalReader = (*Adaptor)(nil)
 var _ gpio.DigitalWriter = (*Adaptor)(nil)
 var _ gpio.PwmWriter = (*Adaptor)(nil)
 var _ gpio.ServoWriter = (*Adaptor)(nil)
+var _ gpio.ServoPulseWriter = (*Adaptor)(nil)
 var _ sysfs.DigitalPinnerProvider = (*Adaptor)(nil)
 var _ sysfs.PWMPinnerProvider = (*Adaptor)(nil)
 var _ i2c.Connector = (*Adaptor)(nil)
@@ -55,7 +56,7 @@ Serial          : 000000003bc748ea
 	}
 	a := NewAdaptor()
 	gobottest.Assert(t, strings.HasPrefix(a.Name(), "RaspberryPi"), true)
-	gobottest.Assert(t, a.i2cDefaultBus, 1)
+	gobottest.Assert(t, a.GetDefaultBus(), 1)
 	gobottest.Assert(t, a.revision, "3")
 
 	readFile = func() ([]byte, error) {
@@ -66,7 +67,7 @@ Serial          : 000000003bc748ea
 `), nil
 	}
 	a = NewAdaptor()
-	gobottest.Assert(t, a.i2cDefaultBus, 1)
+	gobottest.Assert(t, a.GetDefaultBus(), 1)
 	gobottest.Assert(t, a.revision, "2")
 
 	readFile = func() ([]byte, error) {
@@ -77,7 +78,7 @@ Serial          : 000000003bc748ea
 `), nil
 	}
 	a = NewAdaptor()
-	gobottest.Assert(t, a.i2cDefaultBus, 0)
+	gobottest.Assert(t, a.GetDefaultBus(), 0)
 	gobottest.Assert(t, a.revision, "1")
 
 }
@@ -121,8 +122,13 @@ func TestAdaptorDigitalPWM(t *testing.T) {
 
 	gobottest.Assert(t, strings.Split(fs.Files["/dev/pi-blaster"].Contents, "\n")[0], "17=0.5")
 
+	gobottest.Assert(t, a.ServoPulseWrite("11", 1500), nil)
+
+	gobottest.Assert(t, strings.Split(fs.Files["/dev/pi-blaster"].Contents, "\n")[0], "17=0.15")
+
 	gobottest.Assert(t, a.PwmWrite("notexist", 1), errors.New("Not a valid pin"))
 	gobottest.Assert(t, a.ServoWrite("notexist", 1), errors.New("Not a valid pin"))
+	gobottest.Assert(t, a.ServoPulseWrite("notexist", 1500), errors.New("Not a valid pin"))
 }
 
 func TestAdaptorDigitalIO(t *testing.T) {
@@ -37,6 +37,8 @@ type SpheroDriver struct {
 	syncResponse    [][]uint8
 	packetChannel   chan *packet
 	responseChannel chan []uint8
+	// Animator plays the animations of the RGB LED
+	Animator *gobot.Animator
 	gobot.Eventer
 	gobot.Commander
 }
@@ -54,6 +56,8 @@ type SpheroDriver struct {
 // 	"SetStabilization" - See SpheroDriver.SetStabilization
 //  "SetDataStreaming" - See SpheroDriver.SetDataStreaming
 //  "SetRotationRate" - See SpheroDriver.SetRotationRate
+//	"Animate" - See SpheroDriver.Animate and gobot.ParseAnimation
+//	"StopAnimation" - See SpheroDriver.StopAnimation
 func NewSpheroDriver(a *Adaptor) *SpheroDriver {
 	s := &SpheroDriver{
 		name:            gobot.DefaultName("Sphero"),
@@ -68,6 +72,14 @@ func NewSpheroDriver(a *Adaptor) *SpheroDriver {
 	s.AddEvent(Collision)
 	s.AddEvent(SensorData)
 
+	// every frame is a packet over bluetooth, so animations are rendered
+	// at a lower frame rate
+	s.Animator = gobot.NewAnimator(3, func(values []float64) error {
+		s.SetRGB(gobot.AnimationByte(values[0]), gobot.AnimationByte(values[1]), gobot.AnimationByte(values[2]))
+		return nil
+	})
+	s.Animator.FrameRate = 10
+
 	s.AddCommand("SetRGB", func(params map[string]interface{}) interface{} {
 		r := uint8(params["r"].(float64))
 		g := uint8(params["g"].(float64))
@@ -88,6 +100,19 @@ func NewSpheroDriver(a *Adaptor) *SpheroDriver {
 		return nil
 	})
 
+	s.AddCommand("Animate", func(params map[string]interface{}) interface{} {
+		a, err := gobot.ParseAnimation(params)
+		if err != nil {
+			return err
+		}
+		return s.Animate(a)
+	})
+
+	s.AddCommand("StopAnimation", func(params map[string]interface{}) interface{} {
+		s.StopAnimation()
+		return nil
+	})
+
 	s.AddCommand("GetRGB", func(params map[string]interface{}) interface{} {
 		return s.GetRGB()
 	})
@@ -226,6 +251,7 @@ func (s *SpheroDriver) Start() (err error) {
 // Halt halts the SpheroDriver and sends a SpheroDriver.Stop command to the Sphero.
 // Returns true on successful halt.
 func (s *SpheroDriver) Halt() (err error) {
+	s.StopAnimation()
 	if s.adaptor().connected {
 		gobot.Every(10*time.Millisecond, func() {
 			s.Stop()
@@ -235,6 +261,17 @@ func (s *SpheroDriver) Halt() (err error) {
 	return
 }
 
+// Animate plays an animation of the color of the RGB LED, with red, green
+// and blue values per keyframe, replacing the animation which is running.
+func (s *SpheroDriver) Animate(a *gobot.Animation) error {
+	return s.Animator.Play(a)
+}
+
+// StopAnimation stops the animation which is running
+func (s *SpheroDriver) StopAnimation() {
+	s.Animator.Stop()
+}
+
 // SetRGB sets the Sphero to the given r, g, and b values
 func (s *SpheroDriver) SetRGB(r uint8, g uint8, b uint8) {
 	s.packetChannel <- s.craftPacket([]uint8{r, g, b, 0x01}, 0x02, 0x20)
@@ -1,116 +1,47 @@
 package tinkerboard
 
-var fixedPins = map[string]sysfsPin{
-	"7": {
-		pin:    17, // GPIO0_C1
-		pwmPin: -1,
-	},
-	"10": {
-		pin:    160, // GPIO5_B0
-		pwmPin: -1,
-	},
-	"8": {
-		pin:    161, // GPIO5_B1
-		pwmPin: -1,
-	},
-	"16": {
-		pin:    162, // GPIO5_B2
-		pwmPin: -1,
-	},
-	"18": {
-		pin:    163, // GPIO5_B3
-		pwmPin: -1,
-	},
-	"11": {
-		pin:    164, // GPIO5_B4
-		pwmPin: -1,
-	},
-	"29": {
-		pin:    165, // GPIO5_B5
-		pwmPin: -1,
-	},
-	"13": {
-		pin:    166, // GPIO5_B6
-		pwmPin: -1,
-	},
-	"15": {
-		pin:    167, // GPIO5_B7
-		pwmPin: -1,
-	},
-	"31": {
-		pin:    168, // GPIO5_C0
-		pwmPin: -1,
-	},
-	"22": {
-		pin:    171, // GPIO5_C3
-		pwmPin: -1,
-	},
-	"12": {
-		pin:    184, // GPIO5_A0
-		pwmPin: -1,
-	},
-	"35": {
-		pin:    185, // GPIO5_A1
-		pwmPin: -1,
-	},
-	"38": {
-		pin:    187, // GPIO5_A3
-		pwmPin: -1,
-	},
-	"40": {
-		pin:    188, // GPIO5_A4
-		pwmPin: -1,
-	},
-	"36": {
-		pin:    223, // GPIO5_A7
-		pwmPin: -1,
-	},
-	"37": {
-		pin:    224, // GPIO5_B0
-		pwmPin: -1,
-	},
-	"27": {
-		pin:    233, // GPIO5_C1
-		pwmPin: -1,
-	},
-	"28": {
-		pin:    234, // GPIO5_C2
-		pwmPin: -1,
-	},
+import "gobot.io/x/gobot/platforms/linux"
+
+var board = &linux.Board{
+	Name:          "Tinker Board",
+	Pins:          fixedPins,
+	I2cBuses:      []int{0, 1},
+	DefaultI2cBus: 1,
+}
+
+var fixedPins = map[string]linux.Pin{
+	"7":  {Gpio: 17},  // GPIO0_C1
+	"10": {Gpio: 160}, // GPIO5_B0
+	"8":  {Gpio: 161}, // GPIO5_B1
+	"16": {Gpio: 162}, // GPIO5_B2
+	"18": {Gpio: 163}, // GPIO5_B3
+	"11": {Gpio: 164}, // GPIO5_B4
+	"29": {Gpio: 165}, // GPIO5_B5
+	"13": {Gpio: 166}, // GPIO5_B6
+	"15": {Gpio: 167}, // GPIO5_B7
+	"31": {Gpio: 168}, // GPIO5_C0
+	"22": {Gpio: 171}, // GPIO5_C3
+	"12": {Gpio: 184}, // GPIO5_A0
+	"35": {Gpio: 185}, // GPIO5_A1
+	"38": {Gpio: 187}, // GPIO5_A3
+	"40": {Gpio: 188}, // GPIO5_A4
+	"36": {Gpio: 223}, // GPIO5_A7
+	"37": {Gpio: 224}, // GPIO5_B0
+	"27": {Gpio: 233}, // GPIO5_C1
+	"28": {Gpio: 234}, // GPIO5_C2
 	"33": {
-		pin:    238, // GPIO5_C6
-		pwmPin: 0,
+		Gpio: 238, // GPIO5_C6
+		Pwm:  &linux.PwmChannel{Channel: 0, Polarity: "normal"},
 	},
 	"32": {
-		pin:    239, // GPIO5_C7
-		pwmPin: 1,
-	},
-	"26": {
-		pin:    251, // GPIO5_A3
-		pwmPin: -1,
-	},
-	"3": {
-		pin:    252, // GPIO5_A4
-		pwmPin: -1,
-	},
-	"5": {
-		pin:    253, // GPIO5_A3
-		pwmPin: -1,
-	},
-	"23": {
-		pin:    254, // GPIO5_A6
-		pwmPin: -1,
-	},
-	"24": {
-		pin:    255, // GPIO5_A7
-		pwmPin: -1,
-	},
-	"21": {
-		pin:    256, // GPIO5_B0
-		pwmPin: -1,
-	},
-	"19": {
-		pin:    257, // GPIO5_B1
-		pwmPin: -1,
-	},
+		Gpio: 239, // GPIO5_C7
+		Pwm:  &linux.PwmChannel{Channel: 1, Polarity: "normal"},
+	},
+	"26": {Gpio: 251}, // GPIO5_A3
+	"3":  {Gpio: 252}, // GPIO5_A4
+	"5":  {Gpio: 253}, // GPIO5_A3
+	"23": {Gpio: 254}, // GPIO5_A6
+	"24": {Gpio: 255}, // GPIO5_A7
+	"21": {Gpio: 256}, // GPIO5_B0
+	"19": {Gpio: 257}, // GPIO5_B1
 }
@@ -0,0 +1,213 @@
+package gobot
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	// PinResource is a digital or analog pin of a connection
+	PinResource = "pin"
+	// I2cResource is a bus and address pair of a connection, e.g. "1:0x3c"
+	I2cResource = "i2c"
+	// PwmResource is the PWM output of a pin of a connection
+	PwmResource = "pwm"
+)
+
+// ResourceClaim is a resource of a connection in use by a driver.
+type ResourceClaim struct {
+	Kind  string `json:"kind"`
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+}
+
+// ResourceRegistry keeps track of the pins and i2c addresses of a connection
+// which are in use, so that two drivers can not be started on the same
+// resource.
+type ResourceRegistry struct {
+	claims  map[string]ResourceClaim
+	drivers map[string]Driver // drivers holding the claims of ClaimResources
+	mutex   *sync.Mutex
+}
+
+// registries holds the registries of the connections with claimed
+// resources, a registry is removed once all of its claims are released
+var (
+	registries      = make(map[Connection]*ResourceRegistry)
+	registriesMutex = &sync.Mutex{}
+)
+
+// NewResourceRegistry returns a new, empty ResourceRegistry
+func NewResourceRegistry() *ResourceRegistry {
+	return &ResourceRegistry{
+		claims:  make(map[string]ResourceClaim),
+		drivers: make(map[string]Driver),
+		mutex:   &sync.Mutex{},
+	}
+}
+
+// ResourceClaims returns the claimed resources of the connection, sorted by
+// kind and id.
+func ResourceClaims(c Connection) []ResourceClaim {
+	registriesMutex.Lock()
+	r := registries[c]
+	registriesMutex.Unlock()
+
+	if r == nil {
+		return []ResourceClaim{}
+	}
+	return r.Claims()
+}
+
+// Claim claims the resource for owner. It returns an error if the resource
+// is already claimed by another owner.
+func (r *ResourceRegistry) Claim(kind string, id string, owner string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := kind + ":" + id
+	if claim, ok := r.claims[key]; ok && claim.Owner != owner {
+		return fmt.Errorf("%s %s is already in use by %s", kind, id, claim.Owner)
+	}
+	r.claims[key] = ResourceClaim{Kind: kind, ID: id, Owner: owner}
+	return nil
+}
+
+// Release releases the resource if it is claimed by owner.
+func (r *ResourceRegistry) Release(kind string, id string, owner string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := kind + ":" + id
+	if claim, ok := r.claims[key]; ok && claim.Owner == owner {
+		delete(r.claims, key)
+	}
+}
+
+// ReleaseAll releases every resource claimed by owner.
+func (r *ResourceRegistry) ReleaseAll(owner string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key, claim := range r.claims {
+		if claim.Owner == owner {
+			delete(r.claims, key)
+		}
+	}
+}
+
+// Owner returns the owner of the resource, or "" if it is not claimed.
+func (r *ResourceRegistry) Owner(kind string, id string) string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.claims[kind+":"+id].Owner
+}
+
+// Claims returns the claimed resources, sorted by kind and id.
+func (r *ResourceRegistry) Claims() []ResourceClaim {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	claims := []ResourceClaim{}
+	for _, claim := range r.claims {
+		claims = append(claims, claim)
+	}
+	sort.Sort(byResource(claims))
+	return claims
+}
+
+type byResource []ResourceClaim
+
+func (s byResource) Len() int      { return len(s) }
+func (s byResource) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byResource) Less(i, j int) bool {
+	if s[i].Kind != s[j].Kind {
+		return s[i].Kind < s[j].Kind
+	}
+	return s[i].ID < s[j].ID
+}
+
+// ClaimResources claims every resource of the given kind and ids of the
+// connection for the driver. The claims are made in the name the driver has
+// at the time of its first claim, and they are held by the driver itself, so
+// that they are released by ReleaseResources even if the driver is renamed in
+// between, and another driver of the same name can not claim them. If any of
+// the resources is in use, the ones already claimed are released again and
+// the error is returned.
+func ClaimResources(c Connection, owner Driver, kind string, ids ...string) error {
+	registriesMutex.Lock()
+	defer registriesMutex.Unlock()
+
+	r := registries[c]
+	if r == nil {
+		r = NewResourceRegistry()
+		registries[c] = r
+	}
+	defer pruneRegistry(c, r)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	name := owner.Name()
+	for key, driver := range r.drivers {
+		if driver == owner {
+			name = r.claims[key].Owner
+			break
+		}
+	}
+
+	claimed := []string{}
+	for _, id := range ids {
+		key := kind + ":" + id
+		if claim, ok := r.claims[key]; ok {
+			if r.drivers[key] == owner {
+				continue
+			}
+			for _, key := range claimed {
+				delete(r.claims, key)
+				delete(r.drivers, key)
+			}
+			return fmt.Errorf("%s %s is already in use by %s", kind, id, claim.Owner)
+		}
+		r.claims[key] = ResourceClaim{Kind: kind, ID: id, Owner: name}
+		r.drivers[key] = owner
+		claimed = append(claimed, key)
+	}
+	return nil
+}
+
+// ReleaseResources releases every resource of the connection claimed by the
+// driver.
+func ReleaseResources(c Connection, owner Driver) {
+	registriesMutex.Lock()
+	defer registriesMutex.Unlock()
+
+	r := registries[c]
+	if r == nil {
+		return
+	}
+	defer pruneRegistry(c, r)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key, driver := range r.drivers {
+		if driver == owner {
+			delete(r.claims, key)
+			delete(r.drivers, key)
+		}
+	}
+}
+
+// pruneRegistry removes the registry of the connection if nothing of it is
+// claimed. registriesMutex has to be held.
+func pruneRegistry(c Connection, r *ResourceRegistry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.claims) == 0 {
+		delete(registries, c)
+	}
+}
@@ -0,0 +1,111 @@
+package gobot
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestResourceRegistryClaim(t *testing.T) {
+	r := NewResourceRegistry()
+	gobottest.Assert(t, r.Claim(PinResource, "13", "led"), nil)
+	gobottest.Assert(t, r.Claim(PinResource, "13", "led"), nil)
+	gobottest.Assert(t, r.Claim(PinResource, "13", "button"),
+		errors.New("pin 13 is already in use by led"))
+	gobottest.Assert(t, r.Claim(I2cResource, "13", "button"), nil)
+	gobottest.Assert(t, r.Owner(PinResource, "13"), "led")
+	gobottest.Assert(t, r.Owner(PinResource, "12"), "")
+}
+
+func TestResourceRegistryRelease(t *testing.T) {
+	r := NewResourceRegistry()
+	r.Claim(PinResource, "13", "led")
+	r.Release(PinResource, "13", "button")
+	gobottest.Assert(t, r.Owner(PinResource, "13"), "led")
+	r.Release(PinResource, "13", "led")
+	gobottest.Assert(t, r.Owner(PinResource, "13"), "")
+
+	r.Claim(PinResource, "1", "motor")
+	r.Claim(PinResource, "2", "motor")
+	r.Claim(PinResource, "3", "led")
+	r.ReleaseAll("motor")
+	gobottest.Assert(t, r.Claims(), []ResourceClaim{{Kind: PinResource, ID: "3", Owner: "led"}})
+}
+
+func TestResourceRegistryClaims(t *testing.T) {
+	r := NewResourceRegistry()
+	r.Claim(PinResource, "2", "b")
+	r.Claim(I2cResource, "1:0x3c", "oled")
+	r.Claim(PinResource, "1", "a")
+	gobottest.Assert(t, r.Claims(), []ResourceClaim{
+		{Kind: I2cResource, ID: "1:0x3c", Owner: "oled"},
+		{Kind: PinResource, ID: "1", Owner: "a"},
+		{Kind: PinResource, ID: "2", Owner: "b"},
+	})
+}
+
+func TestClaimResources(t *testing.T) {
+	a := newTestAdaptor("Connection1", "/dev/null")
+	led := newTestDriver(a, "led", "13")
+	rgb := newTestDriver(a, "rgb", "11")
+	gobottest.Assert(t, ClaimResources(a, led, PinResource, "13"), nil)
+	gobottest.Assert(t, ClaimResources(a, rgb, PinResource, "11", "12", "13"),
+		errors.New("pin 13 is already in use by led"))
+	gobottest.Assert(t, len(ResourceClaims(a)), 1)
+
+	ReleaseResources(a, led)
+	gobottest.Assert(t, ClaimResources(a, rgb, PinResource, "11", "12", "13"), nil)
+	gobottest.Assert(t, len(ResourceClaims(a)), 3)
+	ReleaseResources(a, rgb)
+	gobottest.Assert(t, len(ResourceClaims(a)), 0)
+	gobottest.Assert(t, registries[a], (*ResourceRegistry)(nil))
+}
+
+func TestReleaseResourcesRenamed(t *testing.T) {
+	a := newTestAdaptor("Connection1", "/dev/null")
+	led := newTestDriver(a, "led", "13")
+	gobottest.Assert(t, ClaimResources(a, led, PinResource, "13"), nil)
+	led.SetName("status")
+	gobottest.Assert(t, ClaimResources(a, led, PinResource, "12"), nil)
+	gobottest.Assert(t, ResourceClaims(a), []ResourceClaim{
+		{Kind: PinResource, ID: "12", Owner: "led"},
+		{Kind: PinResource, ID: "13", Owner: "led"},
+	})
+
+	ReleaseResources(a, led)
+	gobottest.Assert(t, len(ResourceClaims(a)), 0)
+	gobottest.Assert(t, registries[a], (*ResourceRegistry)(nil))
+}
+
+func TestClaimResourcesSameName(t *testing.T) {
+	a := newTestAdaptor("Connection1", "/dev/null")
+	led := newTestDriver(a, "led", "13")
+	other := newTestDriver(a, "led", "13")
+	gobottest.Assert(t, ClaimResources(a, led, PinResource, "13"), nil)
+	gobottest.Assert(t, ClaimResources(a, other, PinResource, "12", "13"),
+		errors.New("pin 13 is already in use by led"))
+	gobottest.Assert(t, ClaimResources(a, other, PwmResource, "13"), nil)
+
+	ReleaseResources(a, other)
+	gobottest.Assert(t, ResourceClaims(a), []ResourceClaim{{Kind: PinResource, ID: "13", Owner: "led"}})
+	ReleaseResources(a, led)
+	gobottest.Assert(t, registries[a], (*ResourceRegistry)(nil))
+}
+
+func TestConnectionToJSONClaims(t *testing.T) {
+	a := newTestAdaptor("Connection1", "/dev/null")
+	led := newTestDriver(a, "led", "13")
+	ClaimResources(a, led, PinResource, "13")
+	defer ReleaseResources(a, led)
+
+	json := NewJSONConnection(a)
+	gobottest.Assert(t, json.Claims, []ResourceClaim{{Kind: PinResource, ID: "13", Owner: "led"}})
+}
+
+func TestConnectionToJSONNoClaims(t *testing.T) {
+	a := newTestAdaptor("Connection1", "/dev/null")
+	json := NewJSONConnection(a)
+	gobottest.Assert(t, json.Claims, []ResourceClaim{})
+	gobottest.Assert(t, registries[a], (*ResourceRegistry)(nil))
+}
@@ -113,7 +113,7 @@ func (fs *MockFilesystem) OpenFile(name string, flag int, perm os.FileMode) (fil
 		f.Closed = false
 		return f, nil
 	}
-	return (*MockFile)(nil), &os.PathError{Err: errors.New(name + ": No such file.")}
+	return (*MockFile)(nil), &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
 }
 
 // Stat returns a generic FileInfo for all files in fs.Files.
@@ -145,7 +145,7 @@ func (fs *MockFilesystem) Stat(name string) (os.FileInfo, error) {
 		}
 	}
 
-	return nil, &os.PathError{Err: errors.New(name + ": No such file.")}
+	return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
 }
 
 // Add adds a new file to fs.Files given a name, and returns the newly created file
@@ -10,15 +10,24 @@ import (
 const (
 	// From  /usr/include/linux/i2c-dev.h:
 	// ioctl signals
-	I2C_SLAVE = 0x0703
-	I2C_FUNCS = 0x0705
-	I2C_SMBUS = 0x0720
+	I2C_SLAVE  = 0x0703
+	I2C_TENBIT = 0x0704
+	I2C_FUNCS  = 0x0705
+	I2C_RDWR   = 0x0707
+	I2C_PEC    = 0x0708
+	I2C_SMBUS  = 0x0720
 	// Read/write markers
 	I2C_SMBUS_READ  = 1
 	I2C_SMBUS_WRITE = 0
 
 	// From  /usr/include/linux/i2c.h:
+	// Message flags
+	I2C_M_RD  = 0x0001
+	I2C_M_TEN = 0x0010
 	// Adapter functionality
+	I2C_FUNC_I2C                    = 0x00000001
+	I2C_FUNC_10BIT_ADDR             = 0x00000002
+	I2C_FUNC_SMBUS_PEC              = 0x00000008
 	I2C_FUNC_SMBUS_READ_BYTE        = 0x00020000
 	I2C_FUNC_SMBUS_WRITE_BYTE       = 0x00040000
 	I2C_FUNC_SMBUS_READ_BYTE_DATA   = 0x00080000
@@ -27,6 +36,7 @@ const (
 	I2C_FUNC_SMBUS_WRITE_WORD_DATA  = 0x00400000
 	I2C_FUNC_SMBUS_READ_BLOCK_DATA  = 0x01000000
 	I2C_FUNC_SMBUS_WRITE_BLOCK_DATA = 0x02000000
+	I2C_FUNC_SMBUS_READ_I2C_BLOCK   = 0x04000000
 	// Transaction types
 	I2C_SMBUS_BYTE             = 1
 	I2C_SMBUS_BYTE_DATA        = 2
@@ -36,18 +46,44 @@ const (
 	I2C_SMBUS_I2C_BLOCK_BROKEN = 6
 	I2C_SMBUS_BLOCK_PROC_CALL  = 7 /* SMBus 2.0 */
 	I2C_SMBUS_I2C_BLOCK_DATA   = 8 /* SMBus 2.0 */
+	// Largest SMBus block
+	I2C_SMBUS_BLOCK_MAX = 32
+
+	// I2C_ADDR_TEN is or'ed into an address to select 10-bit addressing,
+	// it has the same value as i2c.TenBitAddress
+	I2C_ADDR_TEN = 0x8000
 )
 
 type i2cSmbusIoctlData struct {
 	readWrite byte
 	command   byte
 	size      uint32
-	data      uintptr
+	data      unsafe.Pointer
+}
+
+type i2cMsg struct {
+	addr  uint16
+	flags uint16
+	len   uint16
+	buf   unsafe.Pointer
+}
+
+type i2cRdwrIoctlData struct {
+	msgs  unsafe.Pointer
+	nmsgs uint32
+}
+
+// I2cMessage is a single message of a combined i2c transaction. Flags
+// may contain I2C_M_RD to read into Data instead of writing it.
+type I2cMessage struct {
+	Flags uint16
+	Data  []byte
 }
 
 type i2cDevice struct {
-	file  File
-	funcs uint64 // adapter functionality mask
+	file    File
+	funcs   uint64 // adapter functionality mask
+	address int
 }
 
 // NewI2cDevice returns an io.ReadWriteCloser with the proper ioctrl given
@@ -66,12 +102,7 @@ func NewI2cDevice(location string) (d *i2cDevice, err error) {
 }
 
 func (d *i2cDevice) queryFunctionality() (err error) {
-	_, _, errno := Syscall(
-		syscall.SYS_IOCTL,
-		d.file.Fd(),
-		I2C_FUNCS,
-		uintptr(unsafe.Pointer(&d.funcs)),
-	)
+	errno := ioctl(d.file.Fd(), I2C_FUNCS, unsafe.Pointer(&d.funcs))
 
 	if errno != 0 {
 		err = fmt.Errorf("Querying functionality failed with syscall.Errno %v", errno)
@@ -79,18 +110,57 @@ func (d *i2cDevice) queryFunctionality() (err error) {
 	return
 }
 
+// SetAddress sets the address of the device the following operations are
+// sent to. Addresses or'ed with I2C_ADDR_TEN use 10-bit addressing.
 func (d *i2cDevice) SetAddress(address int) (err error) {
+	tenBit := address&I2C_ADDR_TEN != 0
+	if tenBit && d.funcs&I2C_FUNC_10BIT_ADDR == 0 {
+		return fmt.Errorf("10-bit addressing not supported")
+	}
+	if tenBit != (d.address&I2C_ADDR_TEN != 0) {
+		var enable uintptr
+		if tenBit {
+			enable = 1
+		}
+		if _, _, errno := Syscall(syscall.SYS_IOCTL, d.file.Fd(), I2C_TENBIT, enable); errno != 0 {
+			return fmt.Errorf("Setting 10-bit addressing failed with syscall.Errno %v", errno)
+		}
+	}
+
+	slave := uintptr(byte(address))
+	if tenBit {
+		slave = uintptr(address & 0x3ff)
+	}
+
 	_, _, errno := Syscall(
 		syscall.SYS_IOCTL,
 		d.file.Fd(),
 		I2C_SLAVE,
-		uintptr(byte(address)),
+		slave,
 	)
 
 	if errno != 0 {
 		err = fmt.Errorf("Setting address failed with syscall.Errno %v", errno)
+		return
+	}
+
+	d.address = address
+	return
+}
+
+// SetPEC enables or disables SMBus packet error checking
+func (d *i2cDevice) SetPEC(enable bool) (err error) {
+	if d.funcs&I2C_FUNC_SMBUS_PEC == 0 {
+		return fmt.Errorf("SMBus packet error checking not supported")
 	}
 
+	var val uintptr
+	if enable {
+		val = 1
+	}
+	if _, _, errno := Syscall(syscall.SYS_IOCTL, d.file.Fd(), I2C_PEC, val); errno != 0 {
+		err = fmt.Errorf("Setting packet error checking failed with syscall.Errno %v", errno)
+	}
 	return
 }
 
@@ -104,7 +174,7 @@ func (d *i2cDevice) ReadByte() (val byte, err error) {
 	}
 
 	var data uint8
-	err = d.smbusAccess(I2C_SMBUS_READ, 0, I2C_SMBUS_BYTE, uintptr(unsafe.Pointer(&data)))
+	err = d.smbusAccess(I2C_SMBUS_READ, 0, I2C_SMBUS_BYTE, unsafe.Pointer(&data))
 	return data, err
 }
 
@@ -114,7 +184,7 @@ func (d *i2cDevice) ReadByteData(reg uint8) (val uint8, err error) {
 	}
 
 	var data uint8
-	err = d.smbusAccess(I2C_SMBUS_READ, reg, I2C_SMBUS_BYTE_DATA, uintptr(unsafe.Pointer(&data)))
+	err = d.smbusAccess(I2C_SMBUS_READ, reg, I2C_SMBUS_BYTE_DATA, unsafe.Pointer(&data))
 	return data, err
 }
 
@@ -124,7 +194,7 @@ func (d *i2cDevice) ReadWordData(reg uint8) (val uint16, err error) {
 	}
 
 	var data uint16
-	err = d.smbusAccess(I2C_SMBUS_READ, reg, I2C_SMBUS_WORD_DATA, uintptr(unsafe.Pointer(&data)))
+	err = d.smbusAccess(I2C_SMBUS_READ, reg, I2C_SMBUS_WORD_DATA, unsafe.Pointer(&data))
 	return data, err
 }
 
@@ -133,7 +203,7 @@ func (d *i2cDevice) WriteByte(val byte) (err error) {
 		return fmt.Errorf("SMBus write byte not supported")
 	}
 
-	err = d.smbusAccess(I2C_SMBUS_WRITE, val, I2C_SMBUS_BYTE, uintptr(0))
+	err = d.smbusAccess(I2C_SMBUS_WRITE, val, I2C_SMBUS_BYTE, nil)
 	return err
 }
 
@@ -143,7 +213,7 @@ func (d *i2cDevice) WriteByteData(reg uint8, val uint8) (err error) {
 	}
 
 	var data = val
-	err = d.smbusAccess(I2C_SMBUS_WRITE, reg, I2C_SMBUS_BYTE_DATA, uintptr(unsafe.Pointer(&data)))
+	err = d.smbusAccess(I2C_SMBUS_WRITE, reg, I2C_SMBUS_BYTE_DATA, unsafe.Pointer(&data))
 	return err
 }
 
@@ -153,7 +223,7 @@ func (d *i2cDevice) WriteWordData(reg uint8, val uint16) (err error) {
 	}
 
 	var data = val
-	err = d.smbusAccess(I2C_SMBUS_WRITE, reg, I2C_SMBUS_WORD_DATA, uintptr(unsafe.Pointer(&data)))
+	err = d.smbusAccess(I2C_SMBUS_WRITE, reg, I2C_SMBUS_WORD_DATA, unsafe.Pointer(&data))
 	return err
 }
 
@@ -179,6 +249,95 @@ func (d *i2cDevice) WriteBlockData(reg uint8, data []byte) (err error) {
 	return nil
 }
 
+// ReadBlockData reads an SMBus block from the register into b, the device
+// sends the length of the block
+func (d *i2cDevice) ReadBlockData(reg uint8, b []byte) (n int, err error) {
+	if d.funcs&I2C_FUNC_SMBUS_READ_BLOCK_DATA == 0 {
+		return 0, fmt.Errorf("SMBus read block data not supported")
+	}
+
+	// the first byte holds the length of the block, and the last the PEC
+	var data [I2C_SMBUS_BLOCK_MAX + 2]byte
+	if err = d.smbusAccess(I2C_SMBUS_READ, reg, I2C_SMBUS_BLOCK_DATA, unsafe.Pointer(&data[0])); err != nil {
+		return
+	}
+
+	n = int(data[0])
+	if n > I2C_SMBUS_BLOCK_MAX {
+		n = I2C_SMBUS_BLOCK_MAX
+	}
+	return copy(b, data[1:n+1]), nil
+}
+
+// ReadI2cBlockData reads len(b) bytes starting at the register
+func (d *i2cDevice) ReadI2cBlockData(reg uint8, b []byte) (err error) {
+	if d.funcs&I2C_FUNC_SMBUS_READ_I2C_BLOCK == 0 {
+		return fmt.Errorf("SMBus read I2C block data not supported")
+	}
+	if len(b) > I2C_SMBUS_BLOCK_MAX {
+		return fmt.Errorf("Reading blocks larger than 32 bytes (%v) not supported", len(b))
+	}
+
+	var data [I2C_SMBUS_BLOCK_MAX + 2]byte
+	data[0] = byte(len(b))
+	if err = d.smbusAccess(I2C_SMBUS_READ, reg, I2C_SMBUS_I2C_BLOCK_DATA, unsafe.Pointer(&data[0])); err != nil {
+		return
+	}
+
+	copy(b, data[1:len(b)+1])
+	return nil
+}
+
+// WriteRead writes w and then reads len(r) bytes into r, with a repeated
+// start instead of a stop condition between them
+func (d *i2cDevice) WriteRead(w []byte, r []byte) (err error) {
+	return d.Transfer([]I2cMessage{
+		{Data: w},
+		{Flags: I2C_M_RD, Data: r},
+	})
+}
+
+// Transfer sends the messages to the current address as a single combined
+// transaction, using repeated starts between them
+func (d *i2cDevice) Transfer(msgs []I2cMessage) (err error) {
+	if d.funcs&I2C_FUNC_I2C == 0 {
+		return fmt.Errorf("I2C combined transactions not supported")
+	}
+	if len(msgs) == 0 {
+		return
+	}
+
+	addr, flags := uint16(byte(d.address)), uint16(0)
+	if d.address&I2C_ADDR_TEN != 0 {
+		addr, flags = uint16(d.address&0x3ff), I2C_M_TEN
+	}
+
+	raw := make([]i2cMsg, len(msgs))
+	for i, msg := range msgs {
+		raw[i] = i2cMsg{
+			addr:  addr,
+			flags: msg.Flags | flags,
+			len:   uint16(len(msg.Data)),
+		}
+		if len(msg.Data) > 0 {
+			raw[i].buf = unsafe.Pointer(&msg.Data[0])
+		}
+	}
+
+	rdwr := &i2cRdwrIoctlData{
+		msgs:  unsafe.Pointer(&raw[0]),
+		nmsgs: uint32(len(raw)),
+	}
+
+	errno := ioctl(d.file.Fd(), I2C_RDWR, unsafe.Pointer(rdwr))
+
+	if errno != 0 {
+		return fmt.Errorf("Transfer failed with syscall.Errno %v", errno)
+	}
+
+	return nil
+}
+
 // Read implements the io.ReadWriteCloser method by direct I2C read operations.
 func (d *i2cDevice) Read(b []byte) (n int, err error) {
 	return d.file.Read(b)
@@ -189,7 +348,7 @@ func (d *i2cDevice) Write(b []byte) (n int, err error) {
 	return d.file.Write(b)
 }
 
-func (d *i2cDevice) smbusAccess(readWrite byte, command byte, size uint32, data uintptr) error {
+func (d *i2cDevice) smbusAccess(readWrite byte, command byte, size uint32, data unsafe.Pointer) error {
 	smbus := &i2cSmbusIoctlData{
 		readWrite: readWrite,
 		command:   command,
@@ -197,12 +356,7 @@ func (d *i2cDevice) smbusAccess(readWrite byte, command byte, size uint32, data
 		data:      data,
 	}
 
-	_, _, errno := Syscall(
-		syscall.SYS_IOCTL,
-		d.file.Fd(),
-		I2C_SMBUS,
-		uintptr(unsafe.Pointer(smbus)),
-	)
+	errno := ioctl(d.file.Fd(), I2C_SMBUS, unsafe.Pointer(smbus))
 
 	if errno != 0 {
 		return fmt.Errorf("Failed with syscall.Errno %v", errno)
@@ -5,6 +5,7 @@ import (
 	"os"
 	"syscall"
 	"testing"
+	"unsafe"
 
 	"gobot.io/x/gobot/drivers/i2c"
 	"gobot.io/x/gobot/gobottest"
@@ -49,7 +50,7 @@ func TestNewI2cDevice(t *testing.T) {
 	SetSyscall(&MockSyscall{})
 
 	i, err := NewI2cDevice(os.DevNull)
-	gobottest.Assert(t, err.Error(), " : /dev/null: No such file.")
+	gobottest.Assert(t, err.Error(), "open /dev/null: file does not exist")
 
 	fs = NewMockFilesystem([]string{
 		"/dev/i2c-1",
@@ -347,3 +348,188 @@ func TestNewI2cDeviceWrite(t *testing.T) {
 	gobottest.Assert(t, n, len(buf))
 	gobottest.Assert(t, err, nil)
 }
+
+func TestNewI2cDeviceSetAddressTenBit(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/dev/i2c-1",
+	})
+	SetFilesystem(fs)
+
+	var calls [][2]uintptr
+	SetSyscall(&MockSyscall{
+		Impl: func(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
+			calls = append(calls, [2]uintptr{a2, a3})
+			return 0, 0, 0
+		},
+	})
+
+	i, err := NewI2cDevice("/dev/i2c-1")
+	gobottest.Assert(t, err, nil)
+	calls = nil
+
+	err = i.SetAddress(0x2A5 | I2C_ADDR_TEN)
+	gobottest.Assert(t, err.Error(), "10-bit addressing not supported")
+
+	i.funcs = I2C_FUNC_10BIT_ADDR
+	gobottest.Assert(t, i.SetAddress(0x2A5|I2C_ADDR_TEN), nil)
+	gobottest.Assert(t, calls, [][2]uintptr{{I2C_TENBIT, 1}, {I2C_SLAVE, 0x2A5}})
+
+	calls = nil
+	gobottest.Assert(t, i.SetAddress(0x1D), nil)
+	gobottest.Assert(t, calls, [][2]uintptr{{I2C_TENBIT, 0}, {I2C_SLAVE, 0x1D}})
+
+	calls = nil
+	gobottest.Assert(t, i.SetAddress(0x1E), nil)
+	gobottest.Assert(t, calls, [][2]uintptr{{I2C_SLAVE, 0x1E}})
+}
+
+func TestNewI2cDeviceSetPEC(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/dev/i2c-1",
+	})
+	SetFilesystem(fs)
+
+	var pec uintptr
+	SetSyscall(&MockSyscall{
+		Impl: func(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
+			if a2 == I2C_PEC {
+				pec = a3
+			}
+			return 0, 0, 0
+		},
+	})
+
+	i, _ := NewI2cDevice("/dev/i2c-1")
+	var _ i2c.I2cExtendedOperations = i
+
+	gobottest.Assert(t, i.SetPEC(true).Error(), "SMBus packet error checking not supported")
+
+	i.funcs = I2C_FUNC_SMBUS_PEC
+	gobottest.Assert(t, i.SetPEC(true), nil)
+	gobottest.Assert(t, pec, uintptr(1))
+	gobottest.Assert(t, i.SetPEC(false), nil)
+	gobottest.Assert(t, pec, uintptr(0))
+}
+
+func TestNewI2cDeviceReadBlockData(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/dev/i2c-1",
+	})
+	SetFilesystem(fs)
+
+	SetSyscall(&MockSyscall{
+		IoctlImpl: func(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno) {
+			if request == I2C_SMBUS {
+				smbus := (*i2cSmbusIoctlData)(arg)
+				if smbus.size == I2C_SMBUS_BLOCK_DATA && smbus.command == 0x10 {
+					data := (*[I2C_SMBUS_BLOCK_MAX + 2]byte)(smbus.data)
+					copy(data[:], []byte{3, 0xA, 0xB, 0xC})
+				}
+			}
+			return 0
+		},
+	})
+
+	i, _ := NewI2cDevice("/dev/i2c-1")
+
+	_, err := i.ReadBlockData(0x10, make([]byte, 8))
+	gobottest.Assert(t, err.Error(), "SMBus read block data not supported")
+
+	i.funcs = I2C_FUNC_SMBUS_READ_BLOCK_DATA
+	buf := make([]byte, 8)
+	n, err := i.ReadBlockData(0x10, buf)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 3)
+	gobottest.Assert(t, buf[:n], []byte{0xA, 0xB, 0xC})
+}
+
+func TestNewI2cDeviceReadI2cBlockData(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/dev/i2c-1",
+	})
+	SetFilesystem(fs)
+
+	SetSyscall(&MockSyscall{
+		IoctlImpl: func(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno) {
+			if request == I2C_SMBUS {
+				smbus := (*i2cSmbusIoctlData)(arg)
+				if smbus.size == I2C_SMBUS_I2C_BLOCK_DATA {
+					data := (*[I2C_SMBUS_BLOCK_MAX + 2]byte)(smbus.data)
+					for j := byte(1); j <= data[0]; j++ {
+						data[j] = smbus.command + j
+					}
+				}
+			}
+			return 0
+		},
+	})
+
+	i, _ := NewI2cDevice("/dev/i2c-1")
+
+	err := i.ReadI2cBlockData(0x10, make([]byte, 4))
+	gobottest.Assert(t, err.Error(), "SMBus read I2C block data not supported")
+
+	i.funcs = I2C_FUNC_SMBUS_READ_I2C_BLOCK
+	err = i.ReadI2cBlockData(0x10, make([]byte, 33))
+	gobottest.Assert(t, err.Error(), "Reading blocks larger than 32 bytes (33) not supported")
+
+	buf := make([]byte, 4)
+	gobottest.Assert(t, i.ReadI2cBlockData(0x10, buf), nil)
+	gobottest.Assert(t, buf, []byte{0x11, 0x12, 0x13, 0x14})
+}
+
+func TestNewI2cDeviceTransfer(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/dev/i2c-1",
+	})
+	SetFilesystem(fs)
+
+	var msgs []i2cMsg
+	SetSyscall(&MockSyscall{
+		IoctlImpl: func(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno) {
+			if request == I2C_RDWR {
+				rdwr := (*i2cRdwrIoctlData)(arg)
+				msgs = nil
+				for j := uintptr(0); j < uintptr(rdwr.nmsgs); j++ {
+					msg := (*i2cMsg)(unsafe.Pointer(uintptr(rdwr.msgs) + j*unsafe.Sizeof(i2cMsg{})))
+					msgs = append(msgs, *msg)
+				}
+				// fill the read buffer
+				r := (*[2]byte)(msgs[1].buf)
+				r[0], r[1] = 0x12, 0x34
+			}
+			return 0
+		},
+	})
+
+	i, _ := NewI2cDevice("/dev/i2c-1")
+
+	r := make([]byte, 2)
+	gobottest.Assert(t, i.WriteRead([]byte{0x0F}, r).Error(), "I2C combined transactions not supported")
+
+	i.funcs = I2C_FUNC_I2C | I2C_FUNC_10BIT_ADDR
+	i.SetAddress(0x1D)
+	gobottest.Assert(t, i.WriteRead([]byte{0x0F}, r), nil)
+	gobottest.Assert(t, r, []byte{0x12, 0x34})
+	gobottest.Assert(t, len(msgs), 2)
+	gobottest.Assert(t, msgs[0].addr, uint16(0x1D))
+	gobottest.Assert(t, msgs[0].flags, uint16(0))
+	gobottest.Assert(t, msgs[0].len, uint16(1))
+	gobottest.Assert(t, msgs[1].flags, uint16(I2C_M_RD))
+	gobottest.Assert(t, msgs[1].len, uint16(2))
+
+	i.SetAddress(0x2A5 | I2C_ADDR_TEN)
+	gobottest.Assert(t, i.Transfer([]I2cMessage{{Data: []byte{0x01}}, {Flags: I2C_M_RD, Data: r}}), nil)
+	gobottest.Assert(t, msgs[0].addr, uint16(0x2A5))
+	gobottest.Assert(t, msgs[0].flags, uint16(I2C_M_TEN))
+	gobottest.Assert(t, msgs[1].flags, uint16(I2C_M_RD|I2C_M_TEN))
+
+	gobottest.Assert(t, i.Transfer(nil), nil)
+
+	SetSyscall(&MockSyscall{
+		Impl: func(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
+			return 0, 0, 1
+		},
+	})
+	gobottest.Refute(t, i.WriteRead([]byte{0x0F}, r), nil)
+}
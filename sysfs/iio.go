@@ -0,0 +1,338 @@
+package sysfs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IIOPATH default linux Industrial I/O devices path
+const IIOPATH = "/sys/bus/iio/devices"
+
+var (
+	errBufferNotEnabled = errors.New("iio buffer has not been enabled")
+	errBufferEnabled    = errors.New("iio buffer is already enabled")
+)
+
+// IIODevice is an Industrial I/O device, such as an ADC, exposed by the
+// kernel as /sys/bus/iio/devices/iio:deviceN and /dev/iio:deviceN
+type IIODevice struct {
+	// Path is the sysfs directory of the device
+	Path string
+	// DevPath is the character device buffered scans are read from
+	DevPath string
+
+	buffer   File
+	channels []int
+	scan     []iioScanElement
+	scanSize int
+}
+
+// IIOScanType describes how a channel is stored in a buffered scan.
+// It is read from scan_elements/in_voltageN_type, e.g. "le:u12/16>>4".
+type IIOScanType struct {
+	BigEndian   bool
+	Signed      bool
+	RealBits    uint
+	StorageBits uint
+	Shift       uint
+}
+
+type iioScanElement struct {
+	channel int
+	index   int
+	offset  int
+	typ     IIOScanType
+}
+
+type byIndex []iioScanElement
+
+func (s byIndex) Len() int           { return len(s) }
+func (s byIndex) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byIndex) Less(i, j int) bool { return s[i].index < s[j].index }
+
+// NewIIODevice returns an IIODevice given the device number N of iio:deviceN
+func NewIIODevice(device int) *IIODevice {
+	return &IIODevice{
+		Path:    fmt.Sprintf("%s/iio:device%d", IIOPATH, device),
+		DevPath: fmt.Sprintf("/dev/iio:device%d", device),
+	}
+}
+
+// ReadRaw reads the raw value of a voltage channel
+func (d *IIODevice) ReadRaw(channel int) (val int, err error) {
+	buf, err := d.read(fmt.Sprintf("in_voltage%d_raw", channel))
+	if err != nil {
+		return
+	}
+	return strconv.Atoi(buf)
+}
+
+// Scale returns the factor which converts a raw value of the channel to
+// millivolts. A channel scale takes precedence over the device wide scale,
+// and 1 is returned if the device has neither.
+func (d *IIODevice) Scale(channel int) (float64, error) {
+	return d.readFloat(1, fmt.Sprintf("in_voltage%d_scale", channel), "in_voltage_scale")
+}
+
+// Offset returns the value added to a raw value of the channel before it is
+// scaled, or 0 if the device does not provide one.
+func (d *IIODevice) Offset(channel int) (float64, error) {
+	return d.readFloat(0, fmt.Sprintf("in_voltage%d_offset", channel), "in_voltage_offset")
+}
+
+// ReadVoltage reads a voltage channel and returns its value in millivolts,
+// computed as (raw + offset) * scale
+func (d *IIODevice) ReadVoltage(channel int) (mv float64, err error) {
+	raw, err := d.ReadRaw(channel)
+	if err != nil {
+		return
+	}
+	offset, err := d.Offset(channel)
+	if err != nil {
+		return
+	}
+	scale, err := d.Scale(channel)
+	if err != nil {
+		return
+	}
+	return (float64(raw) + offset) * scale, nil
+}
+
+// ScanType returns how the channel is stored in a buffered scan
+func (d *IIODevice) ScanType(channel int) (t IIOScanType, err error) {
+	buf, err := d.read(fmt.Sprintf("scan_elements/in_voltage%d_type", channel))
+	if err != nil {
+		return
+	}
+	return ParseIIOScanType(buf)
+}
+
+// SetTrigger selects the trigger which starts each buffered scan,
+// e.g. "sysfstrig0" or "hrtimer0"
+func (d *IIODevice) SetTrigger(name string) error {
+	return d.write("trigger/current_trigger", name)
+}
+
+// StartBuffer enables the channels for buffered capture, sets the length of
+// the kernel buffer in scans and opens the character device for reading.
+func (d *IIODevice) StartBuffer(channels []int, length int) (err error) {
+	if d.buffer != nil {
+		return errBufferEnabled
+	}
+
+	scan := []iioScanElement{}
+	for _, ch := range channels {
+		e := iioScanElement{channel: ch}
+		buf, err := d.read(fmt.Sprintf("scan_elements/in_voltage%d_index", ch))
+		if err != nil {
+			return err
+		}
+		if e.index, err = strconv.Atoi(buf); err != nil {
+			return err
+		}
+		if e.typ, err = d.ScanType(ch); err != nil {
+			return err
+		}
+		if err = d.write(fmt.Sprintf("scan_elements/in_voltage%d_en", ch), "1"); err != nil {
+			return err
+		}
+		scan = append(scan, e)
+	}
+
+	// elements are stored in index order, each aligned to its own size, and
+	// the scan is padded to the alignment of the largest one
+	sort.Sort(byIndex(scan))
+	size, largest := 0, 1
+	for i := range scan {
+		bytes := int(scan[i].typ.StorageBits / 8)
+		if bytes > 0 && size%bytes != 0 {
+			size += bytes - size%bytes
+		}
+		scan[i].offset = size
+		size += bytes
+		if bytes > largest {
+			largest = bytes
+		}
+	}
+	if size%largest != 0 {
+		size += largest - size%largest
+	}
+
+	if err = d.write("buffer/length", strconv.Itoa(length)); err != nil {
+		return
+	}
+	if err = d.write("buffer/enable", "1"); err != nil {
+		return
+	}
+
+	buffer, err := OpenFile(d.DevPath, os.O_RDONLY, 0644)
+	if err != nil {
+		d.write("buffer/enable", "0")
+		return
+	}
+
+	d.buffer = buffer
+	d.channels = channels
+	d.scan = scan
+	d.scanSize = size
+	return
+}
+
+// ReadScan blocks until the next buffered scan is available and returns the
+// raw values of the channels, in the order they were passed to StartBuffer
+func (d *IIODevice) ReadScan() (vals []int, err error) {
+	if d.buffer == nil {
+		return nil, errBufferNotEnabled
+	}
+
+	buf := make([]byte, d.scanSize)
+	if _, err = io.ReadFull(d.buffer, buf); err != nil {
+		return
+	}
+
+	values := make(map[int]int, len(d.scan))
+	for _, e := range d.scan {
+		values[e.channel] = e.typ.decode(buf[e.offset : e.offset+int(e.typ.StorageBits/8)])
+	}
+	for _, ch := range d.channels {
+		vals = append(vals, values[ch])
+	}
+	return
+}
+
+// StopBuffer disables buffered capture and the channels enabled for it
+func (d *IIODevice) StopBuffer() (err error) {
+	if d.buffer == nil {
+		return errBufferNotEnabled
+	}
+
+	d.buffer.Close()
+	d.buffer = nil
+	if err = d.write("buffer/enable", "0"); err != nil {
+		return
+	}
+	for _, e := range d.scan {
+		if err = d.write(fmt.Sprintf("scan_elements/in_voltage%d_en", e.channel), "0"); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Buffered returns true if buffered capture has been started
+func (d *IIODevice) Buffered() bool {
+	return d.buffer != nil
+}
+
+// ParseIIOScanType parses the [be|le]:[s|u]bits/storagebits[>>shift]
+// format of a scan element type
+func ParseIIOScanType(s string) (t IIOScanType, err error) {
+	var endian, sign string
+	s = strings.TrimSpace(s)
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || len(parts[1]) < 1 {
+		return t, fmt.Errorf("Invalid iio scan type %q", s)
+	}
+	endian, sign = parts[0], parts[1][:1]
+	if endian != "le" && endian != "be" || sign != "s" && sign != "u" {
+		return t, fmt.Errorf("Invalid iio scan type %q", s)
+	}
+
+	bits := parts[1][1:]
+	shift := "0"
+	if i := strings.Index(bits, ">>"); i >= 0 {
+		bits, shift = bits[:i], bits[i+2:]
+	}
+	sizes := strings.SplitN(bits, "/", 2)
+	if len(sizes) != 2 {
+		return t, fmt.Errorf("Invalid iio scan type %q", s)
+	}
+
+	realBits, err1 := strconv.ParseUint(sizes[0], 10, 8)
+	storageBits, err2 := strconv.ParseUint(sizes[1], 10, 8)
+	shiftBits, err3 := strconv.ParseUint(shift, 10, 8)
+	if err1 != nil || err2 != nil || err3 != nil || storageBits%8 != 0 || storageBits > 64 {
+		return t, fmt.Errorf("Invalid iio scan type %q", s)
+	}
+
+	return IIOScanType{
+		BigEndian:   endian == "be",
+		Signed:      sign == "s",
+		RealBits:    uint(realBits),
+		StorageBits: uint(storageBits),
+		Shift:       uint(shiftBits),
+	}, nil
+}
+
+// decode extracts the value of a scan element from its storage bytes
+func (t IIOScanType) decode(b []byte) int {
+	var v uint64
+	for i := range b {
+		if t.BigEndian {
+			v = v<<8 | uint64(b[i])
+		} else {
+			v |= uint64(b[i]) << (8 * uint(i))
+		}
+	}
+
+	v >>= t.Shift
+	if t.RealBits < 64 {
+		v &= 1<<t.RealBits - 1
+		if t.Signed && v&(1<<(t.RealBits-1)) != 0 {
+			return int(int64(v) - 1<<t.RealBits)
+		}
+	}
+	return int(int64(v))
+}
+
+func (d *IIODevice) read(name string) (string, error) {
+	file, err := OpenFile(d.Path+"/"+name, os.O_RDONLY, 0644)
+	defer file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 64)
+	n, err := file.Read(buf)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(string(buf[:n])), nil
+}
+
+func (d *IIODevice) write(name string, data string) error {
+	file, err := OpenFile(d.Path+"/"+name, os.O_WRONLY, 0644)
+	defer file.Close()
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteString(data)
+	return err
+}
+
+// readFloat reads the first of the named attributes the device provides,
+// returning def if it provides none of them
+func (d *IIODevice) readFloat(def float64, names ...string) (float64, error) {
+	for _, name := range names {
+		file, err := OpenFile(d.Path+"/"+name, os.O_RDONLY, 0644)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		buf := make([]byte, 64)
+		n, err := file.Read(buf)
+		file.Close()
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		return strconv.ParseFloat(strings.TrimSpace(string(buf[:n])), 64)
+	}
+	return def, nil
+}
@@ -0,0 +1,200 @@
+package sysfs
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func initTestIIODevice() (*IIODevice, *MockFilesystem) {
+	fs := NewMockFilesystem([]string{
+		"/sys/bus/iio/devices/iio:device0/in_voltage0_raw",
+		"/sys/bus/iio/devices/iio:device0/in_voltage1_raw",
+		"/sys/bus/iio/devices/iio:device0/in_voltage_scale",
+		"/sys/bus/iio/devices/iio:device0/in_voltage1_scale",
+		"/sys/bus/iio/devices/iio:device0/in_voltage1_offset",
+		"/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage0_en",
+		"/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage0_index",
+		"/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage0_type",
+		"/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_en",
+		"/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_index",
+		"/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_type",
+		"/sys/bus/iio/devices/iio:device0/buffer/length",
+		"/sys/bus/iio/devices/iio:device0/buffer/enable",
+		"/sys/bus/iio/devices/iio:device0/trigger/current_trigger",
+		"/dev/iio:device0",
+	})
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage_scale"].Contents = "0.5\n"
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage1_scale"].Contents = "0.25\n"
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage1_offset"].Contents = "-100\n"
+	fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage0_index"].Contents = "0\n"
+	fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage0_type"].Contents = "le:u12/16>>0\n"
+	fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_index"].Contents = "1\n"
+	fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_type"].Contents = "be:s12/16>>4\n"
+
+	SetFilesystem(fs)
+	return NewIIODevice(0), fs
+}
+
+func TestIIODevice(t *testing.T) {
+	d, _ := initTestIIODevice()
+	gobottest.Assert(t, d.Path, "/sys/bus/iio/devices/iio:device0")
+	gobottest.Assert(t, d.DevPath, "/dev/iio:device0")
+}
+
+func TestIIODeviceReadRaw(t *testing.T) {
+	d, fs := initTestIIODevice()
+
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage0_raw"].Contents = "1023\n"
+	val, err := d.ReadRaw(0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1023)
+
+	_, err = d.ReadRaw(5)
+	gobottest.Refute(t, err, nil)
+
+	fs.WithReadError = true
+	_, err = d.ReadRaw(0)
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestIIODeviceScaleOffset(t *testing.T) {
+	d, fs := initTestIIODevice()
+
+	scale, err := d.Scale(0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, scale, 0.5)
+
+	scale, _ = d.Scale(1)
+	gobottest.Assert(t, scale, 0.25)
+
+	offset, err := d.Offset(0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, offset, 0.0)
+
+	offset, _ = d.Offset(1)
+	gobottest.Assert(t, offset, -100.0)
+
+	delete(fs.Files, "/sys/bus/iio/devices/iio:device0/in_voltage_scale")
+	scale, _ = d.Scale(0)
+	gobottest.Assert(t, scale, 1.0)
+}
+
+// deniedFilesystem denies opening one of the files of a MockFilesystem
+type deniedFilesystem struct {
+	*MockFilesystem
+	denied string
+}
+
+func (fs *deniedFilesystem) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	if name == fs.denied {
+		return (*MockFile)(nil), &os.PathError{Op: "open", Path: name, Err: os.ErrPermission}
+	}
+	return fs.MockFilesystem.OpenFile(name, flag, perm)
+}
+
+func TestIIODeviceScaleOpenError(t *testing.T) {
+	d, fs := initTestIIODevice()
+	SetFilesystem(&deniedFilesystem{MockFilesystem: fs, denied: "/sys/bus/iio/devices/iio:device0/in_voltage1_scale"})
+	defer SetFilesystem(fs)
+
+	_, err := d.Scale(1)
+	gobottest.Assert(t, os.IsPermission(err), true)
+
+	// only attributes which do not exist fall back to the next one
+	scale, err := d.Scale(0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, scale, 0.5)
+}
+
+func TestIIODeviceReadVoltage(t *testing.T) {
+	d, fs := initTestIIODevice()
+
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage0_raw"].Contents = "1000\n"
+	mv, err := d.ReadVoltage(0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, mv, 500.0)
+
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage1_raw"].Contents = "1000\n"
+	mv, err = d.ReadVoltage(1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, mv, 225.0)
+
+	fs.WithReadError = true
+	_, err = d.ReadVoltage(0)
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestIIODeviceBuffer(t *testing.T) {
+	d, fs := initTestIIODevice()
+
+	gobottest.Assert(t, d.SetTrigger("sysfstrig0"), nil)
+	gobottest.Assert(t, fs.Files["/sys/bus/iio/devices/iio:device0/trigger/current_trigger"].Contents, "sysfstrig0")
+
+	_, err := d.ReadScan()
+	gobottest.Assert(t, err, errBufferNotEnabled)
+
+	gobottest.Assert(t, d.StartBuffer([]int{1, 0}, 64), nil)
+	gobottest.Assert(t, d.Buffered(), true)
+	gobottest.Assert(t, fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage0_en"].Contents, "1")
+	gobottest.Assert(t, fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage1_en"].Contents, "1")
+	gobottest.Assert(t, fs.Files["/sys/bus/iio/devices/iio:device0/buffer/length"].Contents, "64")
+	gobottest.Assert(t, fs.Files["/sys/bus/iio/devices/iio:device0/buffer/enable"].Contents, "1")
+	gobottest.Assert(t, d.StartBuffer([]int{0}, 64), errBufferEnabled)
+
+	// channel 0 is 0x0123 little endian, channel 1 is -2 big endian shifted by 4
+	fs.Files["/dev/iio:device0"].Contents = string([]byte{0x23, 0x01, 0xff, 0xe0})
+	vals, err := d.ReadScan()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, vals, []int{-2, 0x123})
+
+	gobottest.Assert(t, d.StopBuffer(), nil)
+	gobottest.Assert(t, d.Buffered(), false)
+	gobottest.Assert(t, fs.Files["/sys/bus/iio/devices/iio:device0/buffer/enable"].Contents, "0")
+	gobottest.Assert(t, fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage0_en"].Contents, "0")
+	gobottest.Assert(t, d.StopBuffer(), errBufferNotEnabled)
+}
+
+func TestIIODeviceBufferMixedSizes(t *testing.T) {
+	d, fs := initTestIIODevice()
+	fs.Files["/sys/bus/iio/devices/iio:device0/scan_elements/in_voltage0_type"].Contents = "le:s24/32>>0\n"
+
+	gobottest.Assert(t, d.StartBuffer([]int{0, 1}, 64), nil)
+	defer d.StopBuffer()
+	// a 32 bit and a 16 bit element are padded to the 32 bit alignment
+	gobottest.Assert(t, d.scanSize, 8)
+
+	// channel 0 is -5 little endian, channel 1 is 0x012 big endian shifted by 4
+	fs.Files["/dev/iio:device0"].Contents = string([]byte{0xfb, 0xff, 0xff, 0x00, 0x01, 0x20, 0x00, 0x00})
+	vals, err := d.ReadScan()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, vals, []int{-5, 0x12})
+}
+
+func TestIIODeviceStartBufferError(t *testing.T) {
+	d, fs := initTestIIODevice()
+
+	gobottest.Refute(t, d.StartBuffer([]int{3}, 64), nil)
+
+	delete(fs.Files, "/dev/iio:device0")
+	gobottest.Refute(t, d.StartBuffer([]int{0}, 64), nil)
+	gobottest.Assert(t, d.Buffered(), false)
+	gobottest.Assert(t, fs.Files["/sys/bus/iio/devices/iio:device0/buffer/enable"].Contents, "0")
+}
+
+func TestParseIIOScanType(t *testing.T) {
+	st, err := ParseIIOScanType("le:s24/32>>8")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, st, IIOScanType{Signed: true, RealBits: 24, StorageBits: 32, Shift: 8})
+
+	st, err = ParseIIOScanType("be:u10/16")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, st, IIOScanType{BigEndian: true, RealBits: 10, StorageBits: 16})
+
+	for _, s := range []string{"", "le", "xx:u12/16", "le:x12/16", "le:u12", "le:u12/12", "le:u12/16>>x"} {
+		_, err = ParseIIOScanType(s)
+		gobottest.Refute(t, err, nil)
+	}
+}
@@ -2,6 +2,7 @@ package sysfs
 
 import (
 	"syscall"
+	"unsafe"
 )
 
 // SystemCaller represents a Syscall
@@ -15,6 +16,15 @@ type NativeSyscall struct{}
 // MockSyscall represents the mock Syscall
 type MockSyscall struct {
 	Impl func(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno)
+	// IoctlImpl is called for the ioctls whose argument is a pointer, so
+	// that it can be inspected, instead of Impl
+	IoctlImpl func(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno)
+}
+
+// pointerIoctler is a SystemCaller which takes the argument of ioctls as a
+// pointer
+type pointerIoctler interface {
+	Ioctl(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno)
 }
 
 var sys SystemCaller = &NativeSyscall{}
@@ -29,11 +39,26 @@ func Syscall(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
 	return sys.Syscall(trap, a1, a2, a3)
 }
 
+// ioctl calls an ioctl whose argument is a pointer
+func ioctl(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno) {
+	if s, ok := sys.(pointerIoctler); ok {
+		return s.Ioctl(fd, request, arg)
+	}
+	_, _, err = sys.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg))
+	return
+}
+
 // Syscall calls syscall.Syscall
 func (sys *NativeSyscall) Syscall(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
 	return syscall.Syscall(trap, a1, a2, a3)
 }
 
+// Ioctl calls syscall.Syscall with the pointer as uintptr
+func (sys *NativeSyscall) Ioctl(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno) {
+	_, _, err = syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg))
+	return
+}
+
 // Syscall implements the SystemCaller interface
 func (sys *MockSyscall) Syscall(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
 	if sys.Impl != nil {
@@ -41,3 +66,12 @@ func (sys *MockSyscall) Syscall(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err s
 	}
 	return 0, 0, 0
 }
+
+// Ioctl calls IoctlImpl, or Impl with the pointer as uintptr without it
+func (sys *MockSyscall) Ioctl(fd, request uintptr, arg unsafe.Pointer) (err syscall.Errno) {
+	if sys.IoctlImpl != nil {
+		return sys.IoctlImpl(fd, request, arg)
+	}
+	_, _, err = sys.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg))
+	return
+}